@@ -12,6 +12,7 @@ import (
 	"github.com/frain-dev/convoy/api/policies"
 	portalapi "github.com/frain-dev/convoy/api/portal-api"
 	"github.com/frain-dev/convoy/api/public"
+	scimapi "github.com/frain-dev/convoy/api/scim"
 	"github.com/frain-dev/convoy/api/types"
 	"github.com/frain-dev/convoy/database/postgres"
 	"github.com/frain-dev/convoy/internal/pkg/metrics"
@@ -84,6 +85,9 @@ func (a *ApplicationHandler) BuildRoutes() *chi.Mux {
 		ingestRouter.Post("/{maskID}", a.IngestEvent)
 	})
 
+	// Public signing keys.
+	router.Get("/projects/{projectID}/.well-known/jwks.json", a.GetProjectJWKS)
+
 	// Public API.
 	publicAPI := &public.PublicHandler{A: a.A}
 	router.Mount("/api", publicAPI.BuildRoutes())
@@ -94,6 +98,10 @@ func (a *ApplicationHandler) BuildRoutes() *chi.Mux {
 	portalAPI := &portalapi.PortalLinkHandler{A: a.A}
 	router.Mount("/portal-api", portalAPI.BuildRoutes())
 
+	// SCIM provisioning API.
+	scimAPI := &scimapi.SCIMHandler{A: a.A}
+	router.Mount("/scim/v2", scimAPI.BuildRoutes())
+
 	router.Handle("/queue/monitoring/*", a.A.Queue.(*redisqueue.RedisQueue).Monitor())
 	router.Handle("/metrics", promhttp.HandlerFor(metrics.Reg(), promhttp.HandlerOpts{}))
 	router.HandleFunc("/*", reactRootHandler)
@@ -183,6 +191,8 @@ func (a *ApplicationHandler) RegisterDashboardRoutes(r *chi.Mux) {
 	r.Method(PUT, "/ui/organisations/{orgID}/members/{memberID}", uiMiddlewares.HandlerFunc(dh.UpdateOrganisationMember))
 	r.Method(DELETE, "/ui/organisations/{orgID}/members/{memberID}", uiMiddlewares.HandlerFunc(dh.DeleteOrganisationMember))
 
+	r.Method(POST, "/ui/organisations/{orgID}/scim/token", uiMiddlewares.HandlerFunc(dh.GenerateSCIMToken))
+
 	r.Method(POST, "/ui/organisations/{orgID}/projects", uiMiddlewares.HandlerFunc(dh.CreateProject))
 	r.Method(GET, "/ui/organisations/{orgID}/projects", uiMiddlewaresWithPagination.HandlerFunc(dh.GetProjects))
 	r.Method(GET, "/ui/organisations/{orgID}/projects/{projectID}", uiMiddlewares.HandlerFunc(dh.GetProject))
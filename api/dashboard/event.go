@@ -39,11 +39,12 @@ func (a *DashboardHandler) CreateEndpointEvent(w http.ResponseWriter, r *http.Re
 	}
 
 	ce := services.CreateEventService{
-		EndpointRepo: postgres.NewEndpointRepo(a.A.DB),
-		EventRepo:    postgres.NewEventRepo(a.A.DB),
-		Queue:        a.A.Queue,
-		NewMessage:   &newMessage,
-		Project:      project,
+		EndpointRepo:  postgres.NewEndpointRepo(a.A.DB),
+		EventRepo:     postgres.NewEventRepo(a.A.DB),
+		EventTypeRepo: postgres.NewEventTypeRepo(a.A.DB),
+		Queue:         a.A.Queue,
+		NewMessage:    &newMessage,
+		Project:       project,
 	}
 
 	event, err := ce.Run(r.Context())
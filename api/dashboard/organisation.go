@@ -134,3 +134,35 @@ func (a *DashboardHandler) DeleteOrganisation(w http.ResponseWriter, r *http.Req
 
 	_ = render.Render(w, r, util.NewServerResponse("Organisation deleted successfully", nil, http.StatusOK))
 }
+
+// GenerateSCIMToken creates the token the organisation's identity provider
+// authenticates to the SCIM API with, replacing any previous token.
+func (a *DashboardHandler) GenerateSCIMToken(w http.ResponseWriter, r *http.Request) {
+	org, err := a.retrieveOrganisation(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	if err = a.A.Authz.Authorize(r.Context(), "organisation.manage", org); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("Unauthorized", http.StatusForbidden))
+		return
+	}
+
+	ss := services.NewSCIMService(
+		postgres.NewSCIMRepo(a.A.DB),
+		postgres.NewOrgRepo(a.A.DB),
+		postgres.NewOrgMemberRepo(a.A.DB),
+		postgres.NewUserRepo(a.A.DB),
+		postgres.NewProjectRepo(a.A.DB),
+	)
+
+	token, err := ss.GenerateToken(r.Context(), org)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	resp := models.SCIMTokenResponse{Token: token}
+	_ = render.Render(w, r, util.NewServerResponse("SCIM token generated successfully", resp, http.StatusCreated))
+}
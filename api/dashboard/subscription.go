@@ -219,6 +219,17 @@ func (a *DashboardHandler) TestSubscriptionFilter(w http.ResponseWriter, r *http
 		return
 	}
 
+	if test.Expression != "" {
+		isValid, err := test.TestExpression()
+		if err != nil {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+			return
+		}
+
+		_ = render.Render(w, r, util.NewServerResponse("Subscriptions filter validated successfully", isValid, http.StatusCreated))
+		return
+	}
+
 	subRepo := postgres.NewSubscriptionRepo(a.A.DB)
 	isBodyValid, err := subRepo.TestSubscriptionFilter(r.Context(), test.Request.Body, test.Schema.Body)
 	if err != nil {
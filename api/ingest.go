@@ -18,6 +18,7 @@ import (
 	"github.com/frain-dev/convoy/pkg/httpheader"
 	"github.com/frain-dev/convoy/pkg/verifier"
 	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/services"
 	"github.com/frain-dev/convoy/util"
 	"github.com/frain-dev/convoy/worker/task"
 	"github.com/go-chi/render"
@@ -59,6 +60,8 @@ func (a *ApplicationHandler) IngestEvent(w http.ResponseWriter, r *http.Request)
 			v = verifier.NewTwitterVerifier(verifierConfig.HMac.Secret)
 		case datastore.ShopifySourceProvider:
 			v = verifier.NewShopifyVerifier(verifierConfig.HMac.Secret)
+		case datastore.StandardWebhooksSourceProvider:
+			v = verifier.NewStandardWebhooksVerifier(verifierConfig.HMac.Secret)
 		default:
 			_ = render.Render(w, r, util.NewErrorResponse("Provider type undefined",
 				http.StatusBadRequest))
@@ -147,6 +150,14 @@ func (a *ApplicationHandler) IngestEvent(w http.ResponseWriter, r *http.Request)
 		payload = []byte("{}")
 	}
 
+	// ingested events are typed by the source's mask id, so that's the
+	// catalog entry their payload is validated against.
+	err = services.ValidateEventSchema(r.Context(), postgres.NewEventTypeRepo(a.A.DB), g, maskID, payload)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
 	// 3.2 On success
 	// Attach Source to Event.
 	// Write Event to the Ingestion Queue.
@@ -154,6 +165,7 @@ func (a *ApplicationHandler) IngestEvent(w http.ResponseWriter, r *http.Request)
 		UID:              ulid.Make().String(),
 		EventType:        datastore.EventType(maskID),
 		SourceID:         source.UID,
+		Source:           &datastore.Source{UID: source.UID, Name: source.Name},
 		ProjectID:        source.ProjectID,
 		Raw:              string(payload),
 		Data:             payload,
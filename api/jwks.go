@@ -0,0 +1,45 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/frain-dev/convoy/database/postgres"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/services"
+	"github.com/frain-dev/convoy/util"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// GetProjectJWKS serves the public keys of a project's active signing keys.
+// It is unauthenticated so receivers can fetch it to verify the detached
+// JWS sent with every delivery, the kid in the JWS names the key to use.
+func (a *ApplicationHandler) GetProjectJWKS(w http.ResponseWriter, r *http.Request) {
+	project, err := postgres.NewProjectRepo(a.A.DB).FetchProjectByID(r.Context(), chi.URLParam(r, "projectID"))
+	if err != nil {
+		if errors.Is(err, datastore.ErrProjectNotFound) {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+			return
+		}
+
+		_ = render.Render(w, r, util.NewErrorResponse("error retrieving project", http.StatusBadRequest))
+		return
+	}
+
+	keys, err := postgres.NewSigningKeyRepo(a.A.DB).LoadActiveSigningKeys(r.Context(), project.UID)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("an error occurred while fetching signing keys", http.StatusInternalServerError))
+		return
+	}
+
+	jwks, err := services.BuildJWKS(keys)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	// keys are rotated with an overlap, so a short cache is safe.
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	render.JSON(w, r, jwks)
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"net/http"
+
+	"github.com/frain-dev/convoy/datastore"
+	m "github.com/frain-dev/convoy/internal/pkg/middleware"
+)
+
+type QueryListDeadLetter struct {
+	// A list of endpoint IDs to filter by
+	EndpointIDs []string `json:"endpointId"`
+	// The event type to filter by
+	EventType string `json:"eventType"`
+	SearchParams
+	Pageable
+}
+
+type QueryListDeadLetterResponse struct {
+	*datastore.DeadLetterFilter
+}
+
+func (ql *QueryListDeadLetter) Transform(r *http.Request) (*QueryListDeadLetterResponse, error) {
+	searchParams, err := getSearchParams(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QueryListDeadLetterResponse{
+		DeadLetterFilter: &datastore.DeadLetterFilter{
+			EndpointIDs:  getEndpointIDs(r),
+			EventType:    r.URL.Query().Get("eventType"),
+			Pageable:     m.GetPageableFromContext(r.Context()),
+			SearchParams: searchParams,
+		},
+	}, nil
+}
+
+type QueryBatchDeadLetter struct {
+	// A list of endpoint IDs to filter by
+	EndpointIDs []string `json:"endpointId"`
+	// The event type to filter by
+	EventType string `json:"eventType"`
+	SearchParams
+}
+
+type QueryBatchDeadLetterResponse struct {
+	*datastore.DeadLetterFilter
+}
+
+func (qb *QueryBatchDeadLetter) Transform(r *http.Request) (*QueryBatchDeadLetterResponse, error) {
+	searchParams, err := getSearchParams(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QueryBatchDeadLetterResponse{
+		DeadLetterFilter: &datastore.DeadLetterFilter{
+			EndpointIDs:  getEndpointIDs(r),
+			EventType:    r.URL.Query().Get("eventType"),
+			Pageable:     defaultPageable,
+			SearchParams: searchParams,
+		},
+	}, nil
+}
+
+type DeadLetterResponse struct {
+	*datastore.DeadLetter
+}
@@ -1,15 +1,20 @@
 package models
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/frain-dev/convoy/datastore"
 	m "github.com/frain-dev/convoy/internal/pkg/middleware"
+	"github.com/frain-dev/convoy/net"
+	"github.com/frain-dev/convoy/pkg/compress"
 	"github.com/frain-dev/convoy/util"
 	"net/http"
 	"strings"
 )
 
 type CreateEndpoint struct {
-	URL                string `json:"url" valid:"required~please provide a url for your endpoint"`
+	URL                string `json:"url"`
 	Secret             string `json:"secret"`
 	OwnerID            string `json:"owner_id"`
 	Description        string `json:"description"`
@@ -23,16 +28,40 @@ type CreateEndpoint struct {
 	RateLimit         int                     `json:"rate_limit"`
 	RateLimitDuration string                  `json:"rate_limit_duration"`
 	Authentication    *EndpointAuthentication `json:"authentication"`
+	OrderedDelivery   bool                    `json:"ordered_delivery"`
+	PartitionKeyPath  string                  `json:"partition_key_path"`
+
+	// Kind defaults to http, other kinds publish their events to the
+	// queue in Destination instead of posting them to URL
+	Kind        datastore.EndpointKind `json:"kind" valid:"optional,in(http|sqs|google|kafka|amqp)~unsupported endpoint kind"`
+	Destination *EndpointDestination   `json:"destination"`
+
+	// ContentEncoding compresses the requests sent to an http endpoint
+	ContentEncoding compress.Encoding `json:"content_encoding" valid:"optional,in(gzip|zstd)~unsupported content encoding"`
+
 	// Deprecated but necessary for backward compatibility
 	AppID string
 }
 
 func (cE *CreateEndpoint) Validate() error {
-	return util.Validate(cE)
+	err := util.Validate(cE)
+	if err != nil {
+		return err
+	}
+
+	if util.IsStringEmpty(string(cE.Kind)) || cE.Kind == datastore.HTTPEndpointKind {
+		if util.IsStringEmpty(cE.URL) {
+			return errors.New("url:please provide a url for your endpoint")
+		}
+
+		return nil
+	}
+
+	return validateQueueEndpoint(cE.Authentication)
 }
 
 type UpdateEndpoint struct {
-	URL                string  `json:"url" valid:"required~please provide a url for your endpoint"`
+	URL                string  `json:"url"`
 	Secret             string  `json:"secret"`
 	OwnerID            string  `json:"owner_id"`
 	Description        string  `json:"description"`
@@ -46,10 +75,44 @@ type UpdateEndpoint struct {
 	RateLimit         int                     `json:"rate_limit"`
 	RateLimitDuration string                  `json:"rate_limit_duration"`
 	Authentication    *EndpointAuthentication `json:"authentication"`
+	OrderedDelivery   *bool                   `json:"ordered_delivery"`
+	PartitionKeyPath  *string                 `json:"partition_key_path"`
+
+	// Kind and Destination are kept when kind isn't set, the
+	// destination is kept when only the kind is set
+	Kind        datastore.EndpointKind `json:"kind" valid:"optional,in(http|sqs|google|kafka|amqp)~unsupported endpoint kind"`
+	Destination *EndpointDestination   `json:"destination"`
+
+	// ContentEncoding replaces the endpoint's content encoding, an empty
+	// string turns compression off
+	ContentEncoding *compress.Encoding `json:"content_encoding"`
 }
 
 func (uE *UpdateEndpoint) Validate() error {
-	return util.Validate(uE)
+	err := util.Validate(uE)
+	if err != nil {
+		return err
+	}
+
+	if uE.ContentEncoding != nil && !uE.ContentEncoding.IsValid() {
+		return errors.New("content_encoding:unsupported content encoding")
+	}
+
+	if util.IsStringEmpty(string(uE.Kind)) || uE.Kind == datastore.HTTPEndpointKind {
+		return nil
+	}
+
+	return validateQueueEndpoint(uE.Authentication)
+}
+
+// validateQueueEndpoint rejects authentication, which only applies to
+// http requests, on endpoints that publish to a queue.
+func validateQueueEndpoint(auth *EndpointAuthentication) error {
+	if auth != nil && !util.IsStringEmpty(string(auth.Type)) {
+		return errors.New("authentication:authentication is only supported for http endpoints")
+	}
+
+	return nil
 }
 
 type DynamicEndpoint struct {
@@ -99,8 +162,10 @@ func (q *QueryListEndpoint) Transform(r *http.Request) *QueryListEndpointRespons
 }
 
 type EndpointAuthentication struct {
-	Type   datastore.EndpointAuthenticationType `json:"type,omitempty" valid:"optional,in(api_key)~unsupported authentication type"`
+	Type   datastore.EndpointAuthenticationType `json:"type,omitempty" valid:"optional,in(api_key|mtls|oauth2)~unsupported authentication type"`
 	ApiKey *ApiKey                              `json:"api_key"`
+	MTLS   *MTLSClientCert                      `json:"mtls"`
+	OAuth2 *OAuth2                              `json:"oauth2"`
 }
 
 func (ea *EndpointAuthentication) Transform() *datastore.EndpointAuthentication {
@@ -111,9 +176,215 @@ func (ea *EndpointAuthentication) Transform() *datastore.EndpointAuthentication
 	return &datastore.EndpointAuthentication{
 		Type:   ea.Type,
 		ApiKey: ea.ApiKey.transform(),
+		MTLS:   ea.MTLS.transform(),
+		OAuth2: ea.OAuth2.transform(),
+	}
+}
+
+// ValidateEndpointAuthentication checks the authentication of an endpoint
+// created or updated through the API or a dynamic event, it returns nil
+// when no authentication type is set.
+func ValidateEndpointAuthentication(auth *datastore.EndpointAuthentication) (*datastore.EndpointAuthentication, error) {
+	if auth != nil && !util.IsStringEmpty(string(auth.Type)) {
+		if err := util.Validate(auth); err != nil {
+			return nil, err
+		}
+
+		if auth == nil && auth.Type == datastore.APIKeyAuthentication {
+			return nil, util.NewServiceError(http.StatusBadRequest, errors.New("api key field is required"))
+		}
+
+		switch auth.Type {
+		case datastore.MTLSAuthentication:
+			if auth.MTLS == nil {
+				return nil, util.NewServiceError(http.StatusBadRequest, errors.New("mtls field is required"))
+			}
+
+			_, err := net.ClientTLSConfig(auth.MTLS.ClientCert, auth.MTLS.ClientKey, auth.MTLS.CACert)
+			if err != nil {
+				return nil, util.NewServiceError(http.StatusBadRequest, fmt.Errorf("invalid mtls client certificate: %v", err))
+			}
+		case datastore.OAuth2Authentication:
+			if auth.OAuth2 == nil {
+				return nil, util.NewServiceError(http.StatusBadRequest, errors.New("oauth2 field is required"))
+			}
+		}
+
+		return auth, nil
+	}
+
+	return nil, nil
+}
+
+// EncryptEndpointAuthentication encrypts the mtls client key and oauth2
+// client secret of auth with encryptionKey before they are stored, see
+// util.Encrypt. An encryption key must be configured to store them.
+func EncryptEndpointAuthentication(auth *datastore.EndpointAuthentication, encryptionKey string) error {
+	if auth == nil || (auth.MTLS == nil && auth.OAuth2 == nil) {
+		return nil
+	}
+
+	if util.IsStringEmpty(encryptionKey) {
+		return util.NewServiceError(http.StatusBadRequest, errors.New("an encryption key must be configured to store mtls and oauth2 credentials"))
+	}
+
+	if auth.MTLS != nil {
+		key, err := util.Encrypt([]byte(auth.MTLS.ClientKey), encryptionKey)
+		if err != nil {
+			return err
+		}
+
+		auth.MTLS.ClientKey = key
+	}
+
+	if auth.OAuth2 != nil {
+		secret, err := util.Encrypt([]byte(auth.OAuth2.ClientSecret), encryptionKey)
+		if err != nil {
+			return err
+		}
+
+		auth.OAuth2.ClientSecret = secret
+	}
+
+	return nil
+}
+
+type MTLSClientCert struct {
+	// PEM encoded client certificate presented to the endpoint
+	ClientCert string `json:"client_cert" valid:"required~please provide a client certificate"`
+
+	// PEM encoded private key of the client certificate
+	ClientKey string `json:"client_key" valid:"required~please provide a client key"`
+
+	// PEM encoded CA certificate used to verify the endpoint instead of the system roots
+	CACert string `json:"ca_cert"`
+}
+
+func (mc *MTLSClientCert) transform() *datastore.MTLSClientCert {
+	if mc == nil {
+		return nil
+	}
+
+	return &datastore.MTLSClientCert{
+		ClientCert: mc.ClientCert,
+		ClientKey:  mc.ClientKey,
+		CACert:     mc.CACert,
+	}
+}
+
+type OAuth2 struct {
+	// Token endpoint of the authorization server
+	TokenURL string `json:"token_url" valid:"required~please provide a token url,url~please provide a valid token url"`
+
+	ClientID     string `json:"client_id" valid:"required~please provide a client id"`
+	ClientSecret string `json:"client_secret" valid:"required~please provide a client secret"`
+
+	// Space separated list of scopes to request
+	Scope string `json:"scope"`
+}
+
+func (o *OAuth2) transform() *datastore.OAuth2 {
+	if o == nil {
+		return nil
+	}
+
+	return &datastore.OAuth2{
+		TokenURL:     o.TokenURL,
+		ClientID:     o.ClientID,
+		ClientSecret: o.ClientSecret,
+		Scope:        o.Scope,
+	}
+}
+
+type EndpointDestination struct {
+	Sqs    *SQSPubSubConfig         `json:"sqs"`
+	Google *GooglePubSubTopicConfig `json:"google"`
+	Kafka  *KafkaTopicConfig        `json:"kafka"`
+	Amqp   *AmqpExchangeConfig      `json:"amqp"`
+}
+
+func (ed *EndpointDestination) Transform() *datastore.EndpointDestination {
+	if ed == nil {
+		return nil
+	}
+
+	return &datastore.EndpointDestination{
+		Sqs:    ed.Sqs.transform(),
+		Google: ed.Google.transform(),
+		Kafka:  ed.Kafka.transform(),
+		Amqp:   ed.Amqp.transform(),
+	}
+}
+
+type KafkaTopicConfig struct {
+	Brokers []string         `json:"brokers"`
+	Topic   string           `json:"topic"`
+	TLS     bool             `json:"tls"`
+	CACert  string           `json:"ca_cert"`
+	SASL    *KafkaSASLConfig `json:"sasl"`
+}
+
+func (kc *KafkaTopicConfig) transform() *datastore.KafkaTopicConfig {
+	if kc == nil {
+		return nil
+	}
+
+	return &datastore.KafkaTopicConfig{
+		Brokers: kc.Brokers,
+		Topic:   kc.Topic,
+		TLS:     kc.TLS,
+		CACert:  kc.CACert,
+		SASL:    kc.SASL.transform(),
+	}
+}
+
+type AmqpExchangeConfig struct {
+	// URL is the broker's amqp:// or amqps:// url
+	URL        string `json:"url"`
+	Exchange   string `json:"exchange"`
+	RoutingKey string `json:"routing_key"`
+}
+
+func (ac *AmqpExchangeConfig) transform() *datastore.AmqpExchangeConfig {
+	if ac == nil {
+		return nil
+	}
+
+	return &datastore.AmqpExchangeConfig{
+		URL:        ac.URL,
+		Exchange:   ac.Exchange,
+		RoutingKey: ac.RoutingKey,
 	}
 }
 
 type EndpointResponse struct {
 	*datastore.Endpoint
 }
+
+// MarshalJSON leaves the mtls client key and oauth2 client secret of the
+// endpoint out of the response.
+func (e EndpointResponse) MarshalJSON() ([]byte, error) {
+	if e.Endpoint == nil {
+		return []byte("null"), nil
+	}
+
+	endpoint := *e.Endpoint
+	if auth := endpoint.Authentication; auth != nil {
+		redacted := *auth
+		if auth.MTLS != nil {
+			mtls := *auth.MTLS
+			mtls.ClientKey = ""
+			redacted.MTLS = &mtls
+		}
+
+		if auth.OAuth2 != nil {
+			oauth2 := *auth.OAuth2
+			oauth2.ClientSecret = ""
+			redacted.OAuth2 = &oauth2
+		}
+
+		endpoint.Authentication = &redacted
+	}
+
+	return json.Marshal(&endpoint)
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/compress"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCreateEndpoint_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint *CreateEndpoint
+		wantErr  string
+	}{
+		{
+			name:     "should_pass_validation",
+			endpoint: &CreateEndpoint{Name: "endpoint", URL: "https://example.com"},
+		},
+		{
+			name:     "should_require_url_for_http_endpoints",
+			endpoint: &CreateEndpoint{Name: "endpoint", Kind: datastore.HTTPEndpointKind},
+			wantErr:  "url:please provide a url for your endpoint",
+		},
+		{
+			name:     "should_not_require_url_for_queue_endpoints",
+			endpoint: &CreateEndpoint{Name: "endpoint", Kind: datastore.SqsEndpointKind},
+		},
+		{
+			name:     "should_reject_unsupported_kind",
+			endpoint: &CreateEndpoint{Name: "endpoint", URL: "https://example.com", Kind: "grpc"},
+			wantErr:  "kind:unsupported endpoint kind",
+		},
+		{
+			name: "should_reject_authentication_for_queue_endpoints",
+			endpoint: &CreateEndpoint{
+				Name: "endpoint",
+				Kind: datastore.KafkaEndpointKind,
+				Authentication: &EndpointAuthentication{
+					Type:   datastore.APIKeyAuthentication,
+					ApiKey: &ApiKey{HeaderName: "x-api-key", HeaderValue: "secret"},
+				},
+			},
+			wantErr: "authentication:authentication is only supported for http endpoints",
+		},
+		{
+			name:     "should_accept_content_encoding",
+			endpoint: &CreateEndpoint{Name: "endpoint", URL: "https://example.com", ContentEncoding: compress.Zstd},
+		},
+		{
+			name:     "should_reject_unsupported_content_encoding",
+			endpoint: &CreateEndpoint{Name: "endpoint", URL: "https://example.com", ContentEncoding: "br"},
+			wantErr:  "content_encoding:unsupported content encoding",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.endpoint.Validate()
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -1,12 +1,17 @@
 package models
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/frain-dev/convoy/datastore"
 	m "github.com/frain-dev/convoy/internal/pkg/middleware"
 	"github.com/frain-dev/convoy/util"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -20,10 +25,100 @@ type CreateEvent struct {
 	Data           json.RawMessage   `json:"data" valid:"required~please provide your data"`
 	CustomHeaders  map[string]string `json:"custom_headers"`
 	IdempotencyKey string            `json:"idempotency_key"`
+
+	Schedule
 }
 
 func (e *CreateEvent) Validate() error {
-	return util.Validate(e)
+	err := util.Validate(e)
+	if err != nil {
+		return err
+	}
+
+	return e.Schedule.Validate()
+}
+
+// MaxBulkEvents is the most events a single bulk request can create.
+const MaxBulkEvents = 1000
+
+type BulkEventResult struct {
+	// Index is the position of the event in the request
+	Index   int    `json:"index"`
+	EventID string `json:"event_id,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type BulkEventResponse struct {
+	Created int               `json:"created"`
+	Failed  int               `json:"failed"`
+	Results []BulkEventResult `json:"results"`
+}
+
+var ErrTooManyBulkEvents = fmt.Errorf("a bulk request cannot have more than %d events", MaxBulkEvents)
+
+// ParseBulkEvents reads the events of a bulk request, the body is either a
+// JSON array or, when sent as application/x-ndjson, one event per line.
+// Events are left undecoded so each one can fail on its own.
+func ParseBulkEvents(r *http.Request) ([]json.RawMessage, error) {
+	if strings.Contains(r.Header.Get("Content-Type"), "ndjson") {
+		return readNDJSONEvents(r.Body)
+	}
+
+	return readJSONEvents(r.Body)
+}
+
+func readJSONEvents(body io.Reader) ([]json.RawMessage, error) {
+	decoder := json.NewDecoder(body)
+
+	t, err := decoder.Token()
+	if err != nil || t != json.Delim('[') {
+		return nil, errors.New("body must be a JSON array of events")
+	}
+
+	var events []json.RawMessage
+	for decoder.More() {
+		if len(events) == MaxBulkEvents {
+			return nil, ErrTooManyBulkEvents
+		}
+
+		var event json.RawMessage
+		if err := decoder.Decode(&event); err != nil {
+			return nil, errors.New("body contains badly-formed JSON")
+		}
+
+		events = append(events, event)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, errors.New("body contains badly-formed JSON")
+	}
+
+	return events, nil
+}
+
+func readNDJSONEvents(body io.Reader) ([]json.RawMessage, error) {
+	reader := bufio.NewReader(body)
+
+	var events []json.RawMessage
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			if len(events) == MaxBulkEvents {
+				return nil, ErrTooManyBulkEvents
+			}
+
+			events = append(events, line)
+		}
+
+		if errors.Is(err, io.EOF) {
+			return events, nil
+		}
+	}
 }
 
 type DynamicEvent struct {
@@ -33,7 +128,12 @@ type DynamicEvent struct {
 }
 
 func (de *DynamicEvent) Validate() error {
-	return util.Validate(de)
+	err := util.Validate(de)
+	if err != nil {
+		return err
+	}
+
+	return de.Event.Schedule.Validate()
 }
 
 type SearchParams struct {
@@ -83,10 +183,17 @@ type DynamicEventStub struct {
 	Data           json.RawMessage   `json:"data" valid:"required~please provide your data"`
 	CustomHeaders  map[string]string `json:"custom_headers"`
 	IdempotencyKey string            `json:"idempotency_key"`
+
+	Schedule
 }
 
 func (ds *DynamicEventStub) Validate() error {
-	return util.Validate(ds)
+	err := util.Validate(ds)
+	if err != nil {
+		return err
+	}
+
+	return ds.Schedule.Validate()
 }
 
 type FanoutEvent struct {
@@ -98,10 +205,114 @@ type FanoutEvent struct {
 	Data           json.RawMessage   `json:"data" valid:"required~please provide your data"`
 	CustomHeaders  map[string]string `json:"custom_headers"`
 	IdempotencyKey string            `json:"idempotency_key"`
+
+	Schedule
 }
 
 func (fe *FanoutEvent) Validate() error {
-	return util.Validate(fe)
+	err := util.Validate(fe)
+	if err != nil {
+		return err
+	}
+
+	return fe.Schedule.Validate()
+}
+
+// Schedule holds an event's deliveries until a later time, either an
+// absolute time or a delay from when the event is created can be set.
+// It also sets when the event expires, after which its deliveries are
+// discarded instead of being sent or retried.
+type Schedule struct {
+	// DeliverAt is when the event should be sent to its endpoints
+	DeliverAt *time.Time `json:"deliver_at,omitempty"`
+
+	// Delay is how long after it's created the event should be sent,
+	// it's a duration like 90s, 30m or 24h
+	Delay string `json:"delay,omitempty"`
+
+	// ExpiresAt is when the event's deliveries stop being sent
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// TTL is how long after its delivery time the event stays worth
+	// sending, it's a duration like 90s, 30m or 24h
+	TTL string `json:"ttl,omitempty"`
+}
+
+func (s *Schedule) Validate() error {
+	_, err := s.ExpiryTime(time.Now())
+	return err
+}
+
+// DeliveryTime returns when an event created at now should be delivered,
+// it's the zero time when the event isn't scheduled.
+func (s *Schedule) DeliveryTime(now time.Time) (time.Time, error) {
+	if s.DeliverAt != nil && !util.IsStringEmpty(s.Delay) {
+		return time.Time{}, errors.New("only one of deliver_at and delay can be set")
+	}
+
+	if s.DeliverAt != nil {
+		if !s.DeliverAt.After(now) {
+			return time.Time{}, errors.New("deliver_at must be in the future")
+		}
+
+		return *s.DeliverAt, nil
+	}
+
+	if !util.IsStringEmpty(s.Delay) {
+		delay, err := time.ParseDuration(s.Delay)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid delay: %s", s.Delay)
+		}
+
+		if delay <= 0 {
+			return time.Time{}, errors.New("delay must be greater than zero")
+		}
+
+		return now.Add(delay), nil
+	}
+
+	return time.Time{}, nil
+}
+
+// ExpiryTime returns when an event created at now expires, it's the zero
+// time when the event never expires. A ttl counts from the event's
+// delivery time so scheduled events don't expire while they're held.
+func (s *Schedule) ExpiryTime(now time.Time) (time.Time, error) {
+	deliverAt, err := s.DeliveryTime(now)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if deliverAt.IsZero() {
+		deliverAt = now
+	}
+
+	if s.ExpiresAt != nil && !util.IsStringEmpty(s.TTL) {
+		return time.Time{}, errors.New("only one of expires_at and ttl can be set")
+	}
+
+	if s.ExpiresAt != nil {
+		if !s.ExpiresAt.After(deliverAt) {
+			return time.Time{}, errors.New("expires_at must be after the event's delivery time")
+		}
+
+		return *s.ExpiresAt, nil
+	}
+
+	if !util.IsStringEmpty(s.TTL) {
+		ttl, err := time.ParseDuration(s.TTL)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid ttl: %s", s.TTL)
+		}
+
+		if ttl <= 0 {
+			return time.Time{}, errors.New("ttl must be greater than zero")
+		}
+
+		return deliverAt.Add(ttl), nil
+	}
+
+	return time.Time{}, nil
 }
 
 type EventResponse struct {
@@ -0,0 +1,205 @@
+package models
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule_DeliveryTime(t *testing.T) {
+	now := time.Now()
+	deliverAt := now.Add(24 * time.Hour)
+	past := now.Add(-time.Minute)
+
+	tests := []struct {
+		name       string
+		schedule   Schedule
+		want       time.Time
+		wantErrMsg string
+	}{
+		{
+			name:     "should_not_schedule_by_default",
+			schedule: Schedule{},
+			want:     time.Time{},
+		},
+		{
+			name:     "should_deliver_at_time",
+			schedule: Schedule{DeliverAt: &deliverAt},
+			want:     deliverAt,
+		},
+		{
+			name:     "should_deliver_after_delay",
+			schedule: Schedule{Delay: "90m"},
+			want:     now.Add(90 * time.Minute),
+		},
+		{
+			name:       "should_error_for_deliver_at_and_delay",
+			schedule:   Schedule{DeliverAt: &deliverAt, Delay: "1h"},
+			wantErrMsg: "only one of deliver_at and delay can be set",
+		},
+		{
+			name:       "should_error_for_deliver_at_in_the_past",
+			schedule:   Schedule{DeliverAt: &past},
+			wantErrMsg: "deliver_at must be in the future",
+		},
+		{
+			name:       "should_error_for_invalid_delay",
+			schedule:   Schedule{Delay: "tomorrow"},
+			wantErrMsg: "invalid delay: tomorrow",
+		},
+		{
+			name:       "should_error_for_negative_delay",
+			schedule:   Schedule{Delay: "-5m"},
+			wantErrMsg: "delay must be greater than zero",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.schedule.DeliveryTime(now)
+			if tt.wantErrMsg != "" {
+				require.EqualError(t, err, tt.wantErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSchedule_ExpiryTime(t *testing.T) {
+	now := time.Now()
+	deliverAt := now.Add(time.Hour)
+	expiresAt := now.Add(30 * time.Minute)
+
+	tests := []struct {
+		name       string
+		schedule   Schedule
+		want       time.Time
+		wantErrMsg string
+	}{
+		{
+			name:     "should_not_expire_by_default",
+			schedule: Schedule{},
+			want:     time.Time{},
+		},
+		{
+			name:     "should_expire_at_time",
+			schedule: Schedule{ExpiresAt: &expiresAt},
+			want:     expiresAt,
+		},
+		{
+			name:     "should_expire_after_ttl",
+			schedule: Schedule{TTL: "5m"},
+			want:     now.Add(5 * time.Minute),
+		},
+		{
+			name:     "should_count_ttl_from_delivery_time",
+			schedule: Schedule{Delay: "1h", TTL: "5m"},
+			want:     deliverAt.Add(5 * time.Minute),
+		},
+		{
+			name:       "should_error_for_expires_at_and_ttl",
+			schedule:   Schedule{ExpiresAt: &expiresAt, TTL: "5m"},
+			wantErrMsg: "only one of expires_at and ttl can be set",
+		},
+		{
+			name:       "should_error_for_expires_at_before_delivery",
+			schedule:   Schedule{DeliverAt: &deliverAt, ExpiresAt: &expiresAt},
+			wantErrMsg: "expires_at must be after the event's delivery time",
+		},
+		{
+			name:       "should_error_for_invalid_ttl",
+			schedule:   Schedule{TTL: "soon"},
+			wantErrMsg: "invalid ttl: soon",
+		},
+		{
+			name:       "should_error_for_negative_ttl",
+			schedule:   Schedule{TTL: "-1m"},
+			wantErrMsg: "ttl must be greater than zero",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.schedule.ExpiryTime(now)
+			if tt.wantErrMsg != "" {
+				require.EqualError(t, err, tt.wantErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseBulkEvents(t *testing.T) {
+	tooMany := strings.Repeat(`{"event_type":"a"},`, MaxBulkEvents) + `{"event_type":"a"}`
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        []string
+		wantErrMsg  string
+	}{
+		{
+			name:        "should_read_json_array",
+			contentType: "application/json",
+			body:        `[{"event_type":"a"}, {"event_type":"b"}, "not an event"]`,
+			want:        []string{`{"event_type":"a"}`, `{"event_type":"b"}`, `"not an event"`},
+		},
+		{
+			name:        "should_read_ndjson",
+			contentType: "application/x-ndjson",
+			body:        "{\"event_type\":\"a\"}\n\n{\"event_type\":\n{\"event_type\":\"b\"}",
+			want:        []string{`{"event_type":"a"}`, `{"event_type":`, `{"event_type":"b"}`},
+		},
+		{
+			name:        "should_error_for_body_that_is_not_an_array",
+			contentType: "application/json",
+			body:        `{"event_type":"a"}`,
+			wantErrMsg:  "body must be a JSON array of events",
+		},
+		{
+			name:        "should_error_for_malformed_array",
+			contentType: "application/json",
+			body:        `[{"event_type":"a"}, {"event_type":`,
+			wantErrMsg:  "body contains badly-formed JSON",
+		},
+		{
+			name:        "should_error_for_too_many_events",
+			contentType: "application/json",
+			body:        "[" + tooMany + "]",
+			wantErrMsg:  ErrTooManyBulkEvents.Error(),
+		},
+		{
+			name:        "should_error_for_too_many_ndjson_events",
+			contentType: "application/x-ndjson",
+			body:        strings.ReplaceAll(tooMany, "},", "}\n"),
+			wantErrMsg:  ErrTooManyBulkEvents.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/events/batch", strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", tt.contentType)
+
+			events, err := ParseBulkEvents(r)
+			if tt.wantErrMsg != "" {
+				require.EqualError(t, err, tt.wantErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, len(tt.want), len(events))
+			for i := range tt.want {
+				require.Equal(t, tt.want[i], string(events[i]))
+			}
+		})
+	}
+}
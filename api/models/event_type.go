@@ -0,0 +1,42 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+)
+
+type CreateEventType struct {
+	// Name is what events of this type are sent with, e.g. user.created
+	Name string `json:"name" valid:"required~please provide a name"`
+
+	Description string `json:"description"`
+	Version     string `json:"version"`
+
+	// JSONSchema is the schema the data of events of this type must match,
+	// draft 2020-12 is assumed when $schema isn't set
+	JSONSchema json.RawMessage `json:"json_schema" swaggertype:"object"`
+}
+
+func (ce *CreateEventType) Validate() error {
+	return util.Validate(ce)
+}
+
+type UpdateEventType struct {
+	Description *string         `json:"description"`
+	Version     *string         `json:"version"`
+	JSONSchema  json.RawMessage `json:"json_schema" swaggertype:"object"`
+
+	// Deprecated types stay in the catalog and are still validated, they
+	// are flagged so new subscriptions can avoid them
+	Deprecated *bool `json:"deprecated"`
+}
+
+func (ue *UpdateEventType) Validate() error {
+	return util.Validate(ue)
+}
+
+type EventTypeResponse struct {
+	*datastore.ProjectEventType
+}
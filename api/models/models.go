@@ -14,6 +14,12 @@ type Organisation struct {
 	CustomDomain string `json:"custom_domain" bson:"custom_domain"`
 }
 
+// SCIMTokenResponse holds a newly generated SCIM token, it can't be
+// retrieved again.
+type SCIMTokenResponse struct {
+	Token string `json:"token"`
+}
+
 type OrganisationInvite struct {
 	InviteeEmail string    `json:"invitee_email" valid:"required~please provide a valid invitee email,email"`
 	Role         auth.Role `json:"role" bson:"role"`
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"errors"
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/util"
@@ -16,7 +17,16 @@ type CreateProject struct {
 }
 
 func (cP *CreateProject) Validate() error {
-	return util.Validate(cP)
+	err := util.Validate(cP)
+	if err != nil {
+		return err
+	}
+
+	if cP.Config != nil {
+		return cP.Config.Strategy.validate()
+	}
+
+	return nil
 }
 
 type UpdateProject struct {
@@ -28,19 +38,29 @@ type UpdateProject struct {
 }
 
 func (uP *UpdateProject) Validate() error {
-	return util.Validate(uP)
+	err := util.Validate(uP)
+	if err != nil {
+		return err
+	}
+
+	if uP.Config != nil {
+		return uP.Config.Strategy.validate()
+	}
+
+	return nil
 }
 
 type ProjectConfig struct {
-	MaxIngestSize            uint64                        `json:"max_payload_read_size"`
-	ReplayAttacks            bool                          `json:"replay_attacks_prevention_enabled"`
-	IsRetentionPolicyEnabled bool                          `json:"retention_policy_enabled"`
-	DisableEndpoint          bool                          `json:"disable_endpoint"`
-	RetentionPolicy          *RetentionPolicyConfiguration `json:"retention_policy"`
-	RateLimit                *RateLimitConfiguration       `json:"ratelimit"`
-	Strategy                 *StrategyConfiguration        `json:"strategy"`
-	Signature                *SignatureConfiguration       `json:"signature"`
-	MetaEvent                *MetaEventConfiguration       `json:"meta_event"`
+	MaxIngestSize             uint64                        `json:"max_payload_read_size"`
+	ReplayAttacks             bool                          `json:"replay_attacks_prevention_enabled"`
+	IsRetentionPolicyEnabled  bool                          `json:"retention_policy_enabled"`
+	DisableEndpoint           bool                          `json:"disable_endpoint"`
+	RetentionPolicy           *RetentionPolicyConfiguration `json:"retention_policy"`
+	RateLimit                 *RateLimitConfiguration       `json:"ratelimit"`
+	Strategy                  *StrategyConfiguration        `json:"strategy"`
+	Signature                 *SignatureConfiguration       `json:"signature"`
+	MetaEvent                 *MetaEventConfiguration       `json:"meta_event"`
+	IsSchemaValidationEnabled bool                          `json:"schema_validation_enabled"`
 }
 
 func (pc *ProjectConfig) Transform() *datastore.ProjectConfig {
@@ -49,15 +69,16 @@ func (pc *ProjectConfig) Transform() *datastore.ProjectConfig {
 	}
 
 	return &datastore.ProjectConfig{
-		MaxIngestSize:            pc.MaxIngestSize,
-		ReplayAttacks:            pc.ReplayAttacks,
-		IsRetentionPolicyEnabled: pc.IsRetentionPolicyEnabled,
-		DisableEndpoint:          pc.DisableEndpoint,
-		RetentionPolicy:          pc.RetentionPolicy.transform(),
-		RateLimit:                pc.RateLimit.Transform(),
-		Strategy:                 pc.Strategy.transform(),
-		Signature:                pc.Signature.transform(),
-		MetaEvent:                pc.MetaEvent.transform(),
+		MaxIngestSize:             pc.MaxIngestSize,
+		ReplayAttacks:             pc.ReplayAttacks,
+		IsRetentionPolicyEnabled:  pc.IsRetentionPolicyEnabled,
+		DisableEndpoint:           pc.DisableEndpoint,
+		RetentionPolicy:           pc.RetentionPolicy.transform(),
+		RateLimit:                 pc.RateLimit.Transform(),
+		Strategy:                  pc.Strategy.transform(),
+		Signature:                 pc.Signature.transform(),
+		MetaEvent:                 pc.MetaEvent.transform(),
+		IsSchemaValidationEnabled: pc.IsSchemaValidationEnabled,
 	}
 }
 
@@ -87,9 +108,23 @@ func (rc *RateLimitConfiguration) Transform() *datastore.RateLimitConfiguration
 }
 
 type StrategyConfiguration struct {
-	Type       string `json:"type" valid:"optional~please provide a valid strategy type, in(linear|exponential)~unsupported strategy type"`
+	Type       string `json:"type" valid:"optional~please provide a valid strategy type, in(linear|exponential|custom)~unsupported strategy type"`
 	Duration   uint64 `json:"duration" valid:"optional~please provide a valid duration in seconds,int"`
 	RetryCount uint64 `json:"retry_count" valid:"optional~please provide a valid retry count,int"`
+
+	// MaxDuration caps the exponential backoff interval, in seconds
+	MaxDuration     uint64        `json:"max_duration" valid:"optional~please provide a valid max duration in seconds,int"`
+	Jitter          string        `json:"jitter" valid:"optional,in(none|full|decorrelated)~unsupported jitter type"`
+	Intervals       pq.Int64Array `json:"intervals"`
+	HonorRetryAfter bool          `json:"honor_retry_after"`
+}
+
+func (sc *StrategyConfiguration) validate() error {
+	if sc == nil {
+		return nil
+	}
+
+	return validateRetrySchedule(datastore.StrategyProvider(sc.Type), sc.Duration, sc.MaxDuration, datastore.JitterType(sc.Jitter), sc.Intervals)
 }
 
 func (sc *StrategyConfiguration) transform() *datastore.StrategyConfiguration {
@@ -98,15 +133,50 @@ func (sc *StrategyConfiguration) transform() *datastore.StrategyConfiguration {
 	}
 
 	return &datastore.StrategyConfiguration{
-		Type:       datastore.StrategyProvider(sc.Type),
-		Duration:   sc.Duration,
-		RetryCount: sc.RetryCount,
+		Type:            datastore.StrategyProvider(sc.Type),
+		Duration:        sc.Duration,
+		RetryCount:      sc.RetryCount,
+		MaxDuration:     sc.MaxDuration,
+		Jitter:          datastore.JitterType(sc.Jitter),
+		Intervals:       sc.Intervals,
+		HonorRetryAfter: sc.HonorRetryAfter,
 	}
 }
 
+// validateRetrySchedule checks the fields that only make sense for some strategy types.
+func validateRetrySchedule(strategy datastore.StrategyProvider, duration, maxDuration uint64, jitter datastore.JitterType, intervals []int64) error {
+	hasJitter := !util.IsStringEmpty(string(jitter)) && jitter != datastore.NoJitter
+	if strategy != datastore.CustomStrategyProvider && duration == 0 && (hasJitter || maxDuration > 0) {
+		return errors.New("please provide a duration greater than zero to use jitter or a max duration")
+	}
+
+	switch strategy {
+	case datastore.CustomStrategyProvider:
+		if len(intervals) == 0 {
+			return errors.New("please provide the retry intervals for the custom strategy")
+		}
+
+		for _, interval := range intervals {
+			if interval <= 0 {
+				return errors.New("retry intervals must be greater than zero")
+			}
+		}
+	case datastore.ExponentialStrategyProvider:
+		if maxDuration > 0 && maxDuration < duration {
+			return errors.New("max duration cannot be less than the duration")
+		}
+	}
+
+	return nil
+}
+
 type SignatureConfiguration struct {
-	Header   config.SignatureHeaderProvider `json:"header,omitempty" valid:"required~please provide a valid signature header"`
-	Versions []SignatureVersion             `json:"versions"`
+	Header config.SignatureHeaderProvider `json:"header,omitempty" valid:"required~please provide a valid signature header"`
+
+	// Scheme selects how requests are signed, either convoy or standard_webhooks.
+	// The header is ignored by the standard_webhooks scheme.
+	Scheme   datastore.SignatureScheme `json:"scheme,omitempty" valid:"optional,in(convoy|standard_webhooks)~unsupported signature scheme"`
+	Versions []SignatureVersion        `json:"versions"`
 }
 
 func (sc *SignatureConfiguration) transform() *datastore.SignatureConfiguration {
@@ -114,7 +184,7 @@ func (sc *SignatureConfiguration) transform() *datastore.SignatureConfiguration
 		return nil
 	}
 
-	s := &datastore.SignatureConfiguration{Header: sc.Header}
+	s := &datastore.SignatureConfiguration{Header: sc.Header, Scheme: sc.Scheme}
 	for _, version := range sc.Versions {
 		s.Versions = append(s.Versions, datastore.SignatureVersion{
 			UID:       version.UID,
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+)
+
+type RotateSigningKey struct {
+	// Algorithm of the new key, either EdDSA (Ed25519) or PS256 (RSA-PSS).
+	// Defaults to EdDSA
+	Algorithm datastore.SigningKeyAlgorithm `json:"algorithm" valid:"optional,in(EdDSA|PS256)~unsupported signing key algorithm"`
+
+	// Expiration is the number of hours the current key remains in the
+	// project's JWKS after the rotation
+	Expiration int `json:"expiration"`
+}
+
+func (rs *RotateSigningKey) Validate() error {
+	return util.Validate(rs)
+}
+
+type SigningKeyResponse struct {
+	*datastore.SigningKey
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/frain-dev/convoy/datastore"
@@ -95,7 +96,8 @@ func validateSourceForProvider(newSource *CreateSource) error {
 	switch newSource.Provider {
 	case datastore.GithubSourceProvider,
 		datastore.ShopifySourceProvider,
-		datastore.TwitterSourceProvider:
+		datastore.TwitterSourceProvider,
+		datastore.StandardWebhooksSourceProvider:
 		verifierConfig := newSource.Verifier
 		if verifierConfig.HMac == nil || verifierConfig.HMac.Secret == "" {
 			return fmt.Errorf("hmac secret is required for %s source", newSource.Provider)
@@ -249,10 +251,15 @@ func (ak *ApiKey) transform() *datastore.ApiKey {
 }
 
 type PubSubConfig struct {
-	Type    datastore.PubSubType `json:"type"`
-	Workers int                  `json:"workers"`
-	Sqs     *SQSPubSubConfig     `json:"sqs"`
-	Google  *GooglePubSubConfig  `json:"google"`
+	Type       datastore.PubSubType    `json:"type"`
+	Workers    int                     `json:"workers"`
+	Sqs        *SQSPubSubConfig        `json:"sqs"`
+	Google     *GooglePubSubConfig     `json:"google"`
+	Kafka      *KafkaPubSubConfig      `json:"kafka"`
+	Amqp       *AmqpPubSubConfig       `json:"amqp"`
+	Nats       *NatsPubSubConfig       `json:"nats"`
+	Mapping    *PubSubMessageMapping   `json:"mapping"`
+	DeadLetter *PubSubDeadLetterConfig `json:"dead_letter"`
 }
 
 func (pc *PubSubConfig) Transform() *datastore.PubSubConfig {
@@ -261,10 +268,15 @@ func (pc *PubSubConfig) Transform() *datastore.PubSubConfig {
 	}
 
 	return &datastore.PubSubConfig{
-		Type:    pc.Type,
-		Workers: pc.Workers,
-		Sqs:     pc.Sqs.transform(),
-		Google:  pc.Google.transform(),
+		Type:       pc.Type,
+		Workers:    pc.Workers,
+		Sqs:        pc.Sqs.transform(),
+		Google:     pc.Google.transform(),
+		Kafka:      pc.Kafka.transform(),
+		Amqp:       pc.Amqp.transform(),
+		Nats:       pc.Nats.transform(),
+		Mapping:    pc.Mapping.Transform(),
+		DeadLetter: pc.DeadLetter.transform(),
 	}
 }
 
@@ -306,6 +318,179 @@ func (gc *GooglePubSubConfig) transform() *datastore.GooglePubSubConfig {
 	}
 }
 
+type KafkaPubSubConfig struct {
+	Brokers         []string         `json:"brokers"`
+	Topic           string           `json:"topic"`
+	ConsumerGroupID string           `json:"consumer_group_id"`
+	TLS             bool             `json:"tls"`
+	CACert          string           `json:"ca_cert"`
+	SASL            *KafkaSASLConfig `json:"sasl"`
+}
+
+func (kc *KafkaPubSubConfig) transform() *datastore.KafkaPubSubConfig {
+	if kc == nil {
+		return nil
+	}
+
+	return &datastore.KafkaPubSubConfig{
+		Brokers:         kc.Brokers,
+		Topic:           kc.Topic,
+		ConsumerGroupID: kc.ConsumerGroupID,
+		TLS:             kc.TLS,
+		CACert:          kc.CACert,
+		SASL:            kc.SASL.transform(),
+	}
+}
+
+type KafkaSASLConfig struct {
+	Mechanism datastore.KafkaSASLMechanism `json:"mechanism"`
+	Username  string                       `json:"username"`
+	Password  string                       `json:"password"`
+}
+
+func (ks *KafkaSASLConfig) transform() *datastore.KafkaSASLConfig {
+	if ks == nil {
+		return nil
+	}
+
+	return &datastore.KafkaSASLConfig{
+		Mechanism: ks.Mechanism,
+		Username:  ks.Username,
+		Password:  ks.Password,
+	}
+}
+
+type AmqpPubSubConfig struct {
+	URL      string `json:"url"`
+	Queue    string `json:"queue"`
+	Prefetch int    `json:"prefetch"`
+}
+
+func (ac *AmqpPubSubConfig) transform() *datastore.AmqpPubSubConfig {
+	if ac == nil {
+		return nil
+	}
+
+	return &datastore.AmqpPubSubConfig{
+		URL:      ac.URL,
+		Queue:    ac.Queue,
+		Prefetch: ac.Prefetch,
+	}
+}
+
+type NatsPubSubConfig struct {
+	URL      string `json:"url"`
+	Stream   string `json:"stream"`
+	Consumer string `json:"consumer"`
+	Subject  string `json:"subject"`
+	Prefetch int    `json:"prefetch"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Token    string `json:"token"`
+}
+
+func (nc *NatsPubSubConfig) transform() *datastore.NatsPubSubConfig {
+	if nc == nil {
+		return nil
+	}
+
+	return &datastore.NatsPubSubConfig{
+		URL:      nc.URL,
+		Stream:   nc.Stream,
+		Consumer: nc.Consumer,
+		Subject:  nc.Subject,
+		Prefetch: nc.Prefetch,
+		Username: nc.Username,
+		Password: nc.Password,
+		Token:    nc.Token,
+	}
+}
+
+type PubSubMessageMapping struct {
+	EventType      string            `json:"event_type"`
+	EndpointID     string            `json:"endpoint_id"`
+	OwnerID        string            `json:"owner_id"`
+	IdempotencyKey string            `json:"idempotency_key"`
+	Data           string            `json:"data"`
+	Headers        map[string]string `json:"headers"`
+}
+
+func (pm *PubSubMessageMapping) Transform() *datastore.PubSubMessageMapping {
+	if pm == nil {
+		return nil
+	}
+
+	return &datastore.PubSubMessageMapping{
+		EventType:      pm.EventType,
+		EndpointID:     pm.EndpointID,
+		OwnerID:        pm.OwnerID,
+		IdempotencyKey: pm.IdempotencyKey,
+		Data:           pm.Data,
+		Headers:        pm.Headers,
+	}
+}
+
+type PubSubDeadLetterConfig struct {
+	Type            datastore.PubSubDeadLetterType `json:"type"`
+	MaxRedeliveries int                            `json:"max_redeliveries"`
+	Sqs             *SQSPubSubConfig               `json:"sqs"`
+	Google          *GooglePubSubTopicConfig       `json:"google"`
+}
+
+func (dc *PubSubDeadLetterConfig) transform() *datastore.PubSubDeadLetterConfig {
+	if dc == nil {
+		return nil
+	}
+
+	return &datastore.PubSubDeadLetterConfig{
+		Type:            dc.Type,
+		MaxRedeliveries: dc.MaxRedeliveries,
+		Sqs:             dc.Sqs.transform(),
+		Google:          dc.Google.transform(),
+	}
+}
+
+type GooglePubSubTopicConfig struct {
+	TopicID        string `json:"topic_id"`
+	ServiceAccount []byte `json:"service_account"`
+	ProjectID      string `json:"project_id"`
+}
+
+func (gc *GooglePubSubTopicConfig) transform() *datastore.GooglePubSubTopicConfig {
+	if gc == nil {
+		return nil
+	}
+
+	return &datastore.GooglePubSubTopicConfig{
+		TopicID:        gc.TopicID,
+		ServiceAccount: gc.ServiceAccount,
+		ProjectID:      gc.ProjectID,
+	}
+}
+
+type TestPubSubMapping struct {
+	Mapping    *PubSubMessageMapping `json:"mapping"`
+	Payload    json.RawMessage       `json:"payload" valid:"required~please provide a sample payload"`
+	Attributes map[string]string     `json:"attributes"`
+}
+
+func (tm *TestPubSubMapping) Validate() error {
+	return util.Validate(tm)
+}
+
+type TestPubSubMappingResponse struct {
+	EventType      string            `json:"event_type"`
+	EndpointID     string            `json:"endpoint_id"`
+	OwnerID        string            `json:"owner_id"`
+	IdempotencyKey string            `json:"idempotency_key"`
+	Data           json.RawMessage   `json:"data"`
+	CustomHeaders  map[string]string `json:"custom_headers"`
+}
+
+type SourceDeadLetterResponse struct {
+	*datastore.SourceDeadLetter
+}
+
 type SourceResponse struct {
 	*datastore.Source
 }
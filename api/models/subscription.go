@@ -1,8 +1,14 @@
 package models
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/frain-dev/convoy/datastore"
 	m "github.com/frain-dev/convoy/internal/pkg/middleware"
+	"github.com/frain-dev/convoy/pkg/expression"
+	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/frain-dev/convoy/pkg/transform"
 	"github.com/frain-dev/convoy/util"
 	"github.com/lib/pq"
 	"net/http"
@@ -19,10 +25,38 @@ type CreateSubscription struct {
 	RetryConfig     *RetryConfiguration     `json:"retry_config,omitempty"`
 	FilterConfig    *FilterConfiguration    `json:"filter_config,omitempty"`
 	RateLimitConfig *RateLimitConfiguration `json:"rate_limit_config,omitempty"`
+	BatchConfig     *BatchConfiguration     `json:"batch_config,omitempty"`
+
+	// Function is a javascript transform(request) run on each delivery
+	Function string `json:"function,omitempty"`
+
+	// TTL is how long the subscription's deliveries stay worth sending,
+	// it's a duration like 90s, 30m or 24h
+	TTL string `json:"ttl,omitempty"`
 }
 
 func (cs *CreateSubscription) Validate() error {
-	return util.Validate(cs)
+	err := util.Validate(cs)
+	if err != nil {
+		return err
+	}
+
+	err = cs.RetryConfig.validate()
+	if err != nil {
+		return err
+	}
+
+	err = cs.BatchConfig.validate()
+	if err != nil {
+		return err
+	}
+
+	_, err = SubscriptionTTL(cs.TTL)
+	if err != nil {
+		return err
+	}
+
+	return validateFunction(cs.Function)
 }
 
 type UpdateSubscription struct {
@@ -35,10 +69,78 @@ type UpdateSubscription struct {
 	RetryConfig     *RetryConfiguration     `json:"retry_config,omitempty"`
 	FilterConfig    *FilterConfiguration    `json:"filter_config,omitempty"`
 	RateLimitConfig *RateLimitConfiguration `json:"rate_limit_config,omitempty"`
+
+	// BatchConfig replaces the subscription's batch configuration,
+	// a max_count of 0 turns batching off.
+	BatchConfig *BatchConfiguration `json:"batch_config,omitempty"`
+
+	// Function replaces the subscription's transform function, an
+	// empty string removes it.
+	Function *string `json:"function,omitempty"`
+
+	// TTL replaces the subscription's ttl, an empty string removes it.
+	TTL *string `json:"ttl,omitempty"`
 }
 
 func (us *UpdateSubscription) Validate() error {
-	return util.Validate(us)
+	err := util.Validate(us)
+	if err != nil {
+		return err
+	}
+
+	// a retry config update is partial, its schedule is checked once it
+	// has been merged into the subscription's, see ValidateRetryConfig
+	if us.RetryConfig != nil {
+		_, _, err = us.RetryConfig.durations()
+		if err != nil {
+			return err
+		}
+	}
+
+	err = us.BatchConfig.validate()
+	if err != nil {
+		return err
+	}
+
+	if us.TTL != nil {
+		_, err = SubscriptionTTL(*us.TTL)
+		if err != nil {
+			return err
+		}
+	}
+
+	if us.Function != nil {
+		return validateFunction(*us.Function)
+	}
+
+	return nil
+}
+
+// SubscriptionTTL converts a subscription's ttl to seconds, an empty
+// ttl is 0 and means the subscription's deliveries never expire.
+func SubscriptionTTL(ttl string) (uint64, error) {
+	if util.IsStringEmpty(ttl) {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(ttl)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ttl: %s", ttl)
+	}
+
+	if d < time.Second {
+		return 0, errors.New("ttl must be at least one second")
+	}
+
+	return uint64(d.Seconds()), nil
+}
+
+func validateFunction(function string) error {
+	if util.IsStringEmpty(function) {
+		return nil
+	}
+
+	return transform.Validate(function)
 }
 
 type QueryListSubscription struct {
@@ -73,6 +175,67 @@ type FilterSchema struct {
 type TestFilter struct {
 	Request FilterSchema `json:"request"`
 	Schema  FilterSchema `json:"schema"`
+
+	// Expression is a CEL filter expression, it is tested against the
+	// request instead of the schema when it is set
+	Expression string `json:"expression,omitempty"`
+
+	// EventType and Source describe the event the expression is tested
+	// against
+	EventType string           `json:"event_type,omitempty"`
+	Source    TestFilterSource `json:"source"`
+}
+
+type TestFilterSource struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// TestExpression evaluates the expression against the request, a request
+// the expression fails to evaluate against doesn't match it.
+func (tf *TestFilter) TestExpression() (bool, error) {
+	p, err := expression.Compile(tf.Expression)
+	if err != nil {
+		return false, fmt.Errorf("invalid filter expression: %w", err)
+	}
+
+	headers := map[string][]string{}
+	if h, ok := tf.Request.Headers.(map[string]interface{}); ok {
+		for k, v := range h {
+			headers[k] = []string{fmt.Sprintf("%v", v)}
+		}
+	}
+
+	matched, err := p.Eval(&expression.Input{
+		Body:       tf.Request.Body,
+		Headers:    headers,
+		EventType:  tf.EventType,
+		SourceID:   tf.Source.ID,
+		SourceName: tf.Source.Name,
+	})
+	if err != nil {
+		return false, nil
+	}
+
+	return matched, nil
+}
+
+type TestFunction struct {
+	Payload  json.RawMessage       `json:"payload" valid:"required~please provide a sample payload"`
+	Headers  httpheader.HTTPHeader `json:"headers"`
+	Path     string                `json:"path"`
+	Function string                `json:"function" valid:"required~please provide a function"`
+}
+
+func (tf *TestFunction) Validate() error {
+	return util.Validate(tf)
+}
+
+type TestFunctionResponse struct {
+	Payload json.RawMessage       `json:"payload"`
+	Headers httpheader.HTTPHeader `json:"headers"`
+	Path    string                `json:"path"`
+	Log     []string              `json:"log"`
 }
 
 type AlertConfiguration struct {
@@ -96,6 +259,62 @@ type RetryConfiguration struct {
 	Duration        string                     `json:"duration,omitempty" valid:"duration~please provide a valid time duration"`
 	IntervalSeconds uint64                     `json:"interval_seconds" valid:"int~please provide a valid interval seconds"`
 	RetryCount      uint64                     `json:"retry_count" valid:"int~please provide a valid retry count"`
+
+	// MaxDuration caps the exponential backoff interval, e.g. 1h
+	MaxDuration string               `json:"max_duration,omitempty" valid:"duration~please provide a valid max duration"`
+	Jitter      datastore.JitterType `json:"jitter,omitempty" valid:"optional,in(none|full|decorrelated)~unsupported jitter type"`
+	Intervals   pq.Int64Array        `json:"intervals,omitempty"`
+
+	// HonorRetryAfter is a pointer so an update that leaves it
+	// out doesn't turn it off.
+	HonorRetryAfter *bool `json:"honor_retry_after,omitempty"`
+}
+
+func (rc *RetryConfiguration) validate() error {
+	if rc == nil {
+		return nil
+	}
+
+	duration, maxDuration, err := rc.durations()
+	if err != nil {
+		return err
+	}
+
+	return validateRetrySchedule(rc.Type, duration, maxDuration, rc.Jitter, rc.Intervals)
+}
+
+// ValidateRetryConfig checks a subscription's retry schedule once an
+// update has been merged into it.
+func ValidateRetryConfig(rc *datastore.RetryConfiguration) error {
+	if rc == nil {
+		return nil
+	}
+
+	return validateRetrySchedule(rc.Type, rc.Duration, rc.MaxDuration, rc.Jitter, rc.Intervals)
+}
+
+func (rc *RetryConfiguration) durations() (uint64, uint64, error) {
+	duration := rc.IntervalSeconds
+	if !util.IsStringEmpty(rc.Duration) {
+		interval, err := time.ParseDuration(rc.Duration)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		duration = uint64(interval.Seconds())
+	}
+
+	var maxDuration uint64
+	if !util.IsStringEmpty(rc.MaxDuration) {
+		d, err := time.ParseDuration(rc.MaxDuration)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		maxDuration = uint64(d.Seconds())
+	}
+
+	return duration, maxDuration, nil
 }
 
 func (rc *RetryConfiguration) Transform() (*datastore.RetryConfiguration, error) {
@@ -103,7 +322,23 @@ func (rc *RetryConfiguration) Transform() (*datastore.RetryConfiguration, error)
 		return nil, nil
 	}
 
-	strategyConfig := &datastore.RetryConfiguration{Type: rc.Type, RetryCount: rc.RetryCount}
+	strategyConfig := &datastore.RetryConfiguration{
+		Type:            rc.Type,
+		RetryCount:      rc.RetryCount,
+		Jitter:          rc.Jitter,
+		Intervals:       rc.Intervals,
+		HonorRetryAfter: rc.HonorRetryAfter != nil && *rc.HonorRetryAfter,
+	}
+
+	if !util.IsStringEmpty(rc.MaxDuration) {
+		maxDuration, err := time.ParseDuration(rc.MaxDuration)
+		if err != nil {
+			return nil, err
+		}
+
+		strategyConfig.MaxDuration = uint64(maxDuration.Seconds())
+	}
+
 	if !util.IsStringEmpty(rc.Duration) {
 		interval, err := time.ParseDuration(rc.Duration)
 		if err != nil {
@@ -118,9 +353,54 @@ func (rc *RetryConfiguration) Transform() (*datastore.RetryConfiguration, error)
 	return strategyConfig, nil
 }
 
+type BatchConfiguration struct {
+	// MaxCount is the most deliveries sent in one batch
+	MaxCount uint64 `json:"max_count"`
+
+	// MaxBytes caps the combined payload size of a batch, 0 means no limit
+	MaxBytes uint64 `json:"max_bytes"`
+
+	// MaxWait is how long a delivery may wait for its batch to fill up, e.g. 30s
+	MaxWait string `json:"max_wait,omitempty" valid:"duration~please provide a valid batch max wait"`
+}
+
+func (bc *BatchConfiguration) validate() error {
+	if bc == nil || bc.MaxCount == 0 {
+		return nil
+	}
+
+	maxWait, err := time.ParseDuration(bc.MaxWait)
+	if err != nil {
+		return errors.New("please provide a valid batch max wait")
+	}
+
+	if maxWait < time.Second {
+		return errors.New("batch max wait must be at least 1s")
+	}
+
+	return nil
+}
+
+func (bc *BatchConfiguration) Transform() *datastore.BatchConfiguration {
+	if bc == nil || bc.MaxCount == 0 {
+		return nil
+	}
+
+	maxWait, _ := time.ParseDuration(bc.MaxWait)
+	return &datastore.BatchConfiguration{
+		MaxCount: bc.MaxCount,
+		MaxBytes: bc.MaxBytes,
+		MaxWait:  uint64(maxWait.Seconds()),
+	}
+}
+
 type FilterConfiguration struct {
 	EventTypes pq.StringArray `json:"event_types"`
 	Filter     FS             `json:"filter"`
+
+	// Expression is a CEL expression used instead of Filter e.g.
+	// body.amount > 100 && headers['x-tier'] == 'gold'
+	Expression string `json:"expression,omitempty"`
 }
 
 func (fc *FilterConfiguration) Transform() *datastore.FilterConfiguration {
@@ -134,6 +414,7 @@ func (fc *FilterConfiguration) Transform() *datastore.FilterConfiguration {
 			Headers: fc.Filter.Headers,
 			Body:    fc.Filter.Body,
 		},
+		Expression: fc.Expression,
 	}
 }
 
@@ -41,10 +41,11 @@ func (a *PortalLinkHandler) CreateEndpointEvent(w http.ResponseWriter, r *http.R
 	}
 
 	ce := services.CreateEventService{
-		EndpointRepo: postgres.NewEndpointRepo(a.A.DB),
-		Queue:        a.A.Queue,
-		NewMessage:   &newMessage,
-		Project:      project,
+		EndpointRepo:  postgres.NewEndpointRepo(a.A.DB),
+		EventTypeRepo: postgres.NewEventTypeRepo(a.A.DB),
+		Queue:         a.A.Queue,
+		NewMessage:    &newMessage,
+		Project:       project,
 	}
 
 	event, err := ce.Run(r.Context())
@@ -0,0 +1,37 @@
+package portalapi
+
+import (
+	"net/http"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/database/postgres"
+	"github.com/frain-dev/convoy/datastore"
+	m "github.com/frain-dev/convoy/internal/pkg/middleware"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/util"
+	"github.com/go-chi/render"
+)
+
+// GetEventTypes lists the project's event type catalog so customers can
+// pick the types their subscriptions filter on.
+func (a *PortalLinkHandler) GetEventTypes(w http.ResponseWriter, r *http.Request) {
+	project, err := a.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	pageable := m.GetPageableFromContext(r.Context())
+	eventTypes, paginationData, err := postgres.NewEventTypeRepo(a.A.DB).LoadEventTypesPaged(r.Context(), project.UID, pageable)
+	if err != nil {
+		log.FromContext(r.Context()).WithError(err).Error("an error occurred while fetching event types")
+		_ = render.Render(w, r, util.NewErrorResponse("an error occurred while fetching event types", http.StatusInternalServerError))
+		return
+	}
+
+	resp := models.NewListResponse(eventTypes, func(eventType datastore.ProjectEventType) models.EventTypeResponse {
+		return models.EventTypeResponse{ProjectEventType: &eventType}
+	})
+	_ = render.Render(w, r, util.NewServerResponse("Event types fetched successfully",
+		pagedResponse{Content: resp, Pagination: &paginationData}, http.StatusOK))
+}
@@ -81,6 +81,8 @@ func (a *PortalLinkHandler) BuildRoutes() http.Handler {
 		})
 	})
 
+	router.With(middleware.Pagination).Get("/event-types", a.GetEventTypes)
+
 	router.Get("/project", a.GetProject)
 	router.Post("/flags", flipt.BatchEvaluate)
 
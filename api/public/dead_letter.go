@@ -0,0 +1,239 @@
+package public
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/database/postgres"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/services"
+	"github.com/frain-dev/convoy/util"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// GetDeadLettersPaged
+// @Summary List all dead letters
+// @Description This endpoint fetches the event deliveries that exhausted their retries, with pagination
+// @Tags Dead Letters
+// @Accept  json
+// @Produce  json
+// @Param projectID path string true "Project ID"
+// @Param request query models.QueryListDeadLetter false "Query Params"
+// @Success 200 {object} util.ServerResponse{data=pagedResponse{content=[]models.DeadLetterResponse}}
+// @Failure 400,401,404 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /v1/projects/{projectID}/dead-letters [get]
+func (a *PublicHandler) GetDeadLettersPaged(w http.ResponseWriter, r *http.Request) {
+	var q *models.QueryListDeadLetter
+	data, err := q.Transform(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	project, err := a.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	deadLetters, paginationData, err := postgres.NewDeadLetterRepo(a.A.DB).LoadDeadLettersPaged(r.Context(), project.UID, data.DeadLetterFilter)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("an error occurred while fetching dead letters", http.StatusInternalServerError))
+		return
+	}
+
+	resp := models.NewListResponse(deadLetters, func(deadLetter datastore.DeadLetter) models.DeadLetterResponse {
+		return models.DeadLetterResponse{DeadLetter: &deadLetter}
+	})
+	_ = render.Render(w, r, util.NewServerResponse("Dead letters fetched successfully",
+		pagedResponse{Content: resp, Pagination: &paginationData}, http.StatusOK))
+}
+
+// GetDeadLetter
+// @Summary Retrieve a dead letter
+// @Description This endpoint retrieves a dead letter along with its final delivery attempt
+// @Tags Dead Letters
+// @Accept  json
+// @Produce  json
+// @Param projectID path string true "Project ID"
+// @Param deadLetterID path string true "dead letter id"
+// @Success 200 {object} util.ServerResponse{data=models.DeadLetterResponse}
+// @Failure 400,401,404 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /v1/projects/{projectID}/dead-letters/{deadLetterID} [get]
+func (a *PublicHandler) GetDeadLetter(w http.ResponseWriter, r *http.Request) {
+	deadLetter, err := a.retrieveDeadLetter(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+		return
+	}
+
+	resp := &models.DeadLetterResponse{DeadLetter: deadLetter}
+	_ = render.Render(w, r, util.NewServerResponse("Dead letter fetched successfully",
+		resp, http.StatusOK))
+}
+
+// RedriveDeadLetter
+// @Summary Redrive a dead letter
+// @Description This endpoint re-enqueues a dead letter's event delivery with a fresh retry budget
+// @Tags Dead Letters
+// @Accept  json
+// @Produce  json
+// @Param projectID path string true "Project ID"
+// @Param deadLetterID path string true "dead letter id"
+// @Success 200 {object} util.ServerResponse{data=models.DeadLetterResponse}
+// @Failure 400,401,404 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /v1/projects/{projectID}/dead-letters/{deadLetterID}/redrive [put]
+func (a *PublicHandler) RedriveDeadLetter(w http.ResponseWriter, r *http.Request) {
+	deadLetter, err := a.retrieveDeadLetter(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+		return
+	}
+
+	project, err := a.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	rs := services.RedriveDeadLetterService{
+		DeadLetterRepo:    postgres.NewDeadLetterRepo(a.A.DB),
+		EventDeliveryRepo: postgres.NewEventDeliveryRepo(a.A.DB),
+		EndpointRepo:      postgres.NewEndpointRepo(a.A.DB),
+		Queue:             a.A.Queue,
+		DeadLetter:        deadLetter,
+		Project:           project,
+	}
+
+	err = rs.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	resp := &models.DeadLetterResponse{DeadLetter: deadLetter}
+	_ = render.Render(w, r, util.NewServerResponse("Dead letter redriven successfully", resp, http.StatusOK))
+}
+
+// BatchRedriveDeadLetters
+// @Summary Batch redrive dead letters
+// @Description This endpoint redrives every dead letter matching the endpoint, event type and time range filters
+// @Tags Dead Letters
+// @Accept  json
+// @Produce  json
+// @Param projectID path string true "Project ID"
+// @Param request query models.QueryBatchDeadLetter false "Query Params"
+// @Success 200 {object} util.ServerResponse{data=Stub}
+// @Failure 400,401,404 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /v1/projects/{projectID}/dead-letters/batchredrive [post]
+func (a *PublicHandler) BatchRedriveDeadLetters(w http.ResponseWriter, r *http.Request) {
+	var q *models.QueryBatchDeadLetter
+	data, err := q.Transform(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	project, err := a.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	br := services.BatchRedriveDeadLetterService{
+		DeadLetterRepo:    postgres.NewDeadLetterRepo(a.A.DB),
+		EventDeliveryRepo: postgres.NewEventDeliveryRepo(a.A.DB),
+		EndpointRepo:      postgres.NewEndpointRepo(a.A.DB),
+		Queue:             a.A.Queue,
+		Filter:            data.DeadLetterFilter,
+		Project:           project,
+	}
+
+	successes, failures, err := br.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse(fmt.Sprintf("%d successful, %d failed", successes, failures), nil, http.StatusOK))
+}
+
+// PurgeDeadLetter
+// @Summary Purge a dead letter
+// @Description This endpoint removes a dead letter without redriving it
+// @Tags Dead Letters
+// @Accept  json
+// @Produce  json
+// @Param projectID path string true "Project ID"
+// @Param deadLetterID path string true "dead letter id"
+// @Success 200 {object} util.ServerResponse{data=Stub}
+// @Failure 400,401,404 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /v1/projects/{projectID}/dead-letters/{deadLetterID} [delete]
+func (a *PublicHandler) PurgeDeadLetter(w http.ResponseWriter, r *http.Request) {
+	deadLetter, err := a.retrieveDeadLetter(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+		return
+	}
+
+	err = postgres.NewDeadLetterRepo(a.A.DB).DeleteDeadLetter(r.Context(), deadLetter.ProjectID, deadLetter.UID)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("failed to purge dead letter", http.StatusBadRequest))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Dead letter purged successfully", nil, http.StatusOK))
+}
+
+// PurgeDeadLetters
+// @Summary Batch purge dead letters
+// @Description This endpoint removes every dead letter matching the endpoint, event type and time range filters
+// @Tags Dead Letters
+// @Accept  json
+// @Produce  json
+// @Param projectID path string true "Project ID"
+// @Param request query models.QueryBatchDeadLetter false "Query Params"
+// @Success 200 {object} util.ServerResponse{data=Stub}
+// @Failure 400,401,404 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /v1/projects/{projectID}/dead-letters/purge [post]
+func (a *PublicHandler) PurgeDeadLetters(w http.ResponseWriter, r *http.Request) {
+	var q *models.QueryBatchDeadLetter
+	data, err := q.Transform(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	project, err := a.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	count, err := postgres.NewDeadLetterRepo(a.A.DB).DeleteDeadLetters(r.Context(), project.UID, data.DeadLetterFilter)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("failed to purge dead letters", http.StatusBadRequest))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse(fmt.Sprintf("%d dead letters purged", count), nil, http.StatusOK))
+}
+
+func (a *PublicHandler) retrieveDeadLetter(r *http.Request) (*datastore.DeadLetter, error) {
+	project, err := a.retrieveProject(r)
+	if err != nil {
+		return &datastore.DeadLetter{}, err
+	}
+
+	deadLetterID := chi.URLParam(r, "deadLetterID")
+	deadLetterRepo := postgres.NewDeadLetterRepo(a.A.DB)
+	return deadLetterRepo.FindDeadLetterByID(r.Context(), project.UID, deadLetterID)
+}
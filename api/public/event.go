@@ -12,6 +12,7 @@ import (
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/database/postgres"
 	"github.com/frain-dev/convoy/datastore"
+	m "github.com/frain-dev/convoy/internal/pkg/middleware"
 	"github.com/frain-dev/convoy/services"
 	"github.com/frain-dev/convoy/util"
 	"github.com/go-chi/chi/v5"
@@ -51,11 +52,12 @@ func (a *PublicHandler) CreateEndpointEvent(w http.ResponseWriter, r *http.Reque
 	}
 
 	ce := services.CreateEventService{
-		EndpointRepo: postgres.NewEndpointRepo(a.A.DB),
-		EventRepo:    postgres.NewEventRepo(a.A.DB),
-		Queue:        a.A.Queue,
-		NewMessage:   &newMessage,
-		Project:      project,
+		EndpointRepo:  postgres.NewEndpointRepo(a.A.DB),
+		EventRepo:     postgres.NewEventRepo(a.A.DB),
+		EventTypeRepo: postgres.NewEventTypeRepo(a.A.DB),
+		Queue:         a.A.Queue,
+		NewMessage:    &newMessage,
+		Project:       project,
 	}
 
 	event, err := ce.Run(r.Context())
@@ -72,6 +74,50 @@ func (a *PublicHandler) CreateEndpointEvent(w http.ResponseWriter, r *http.Reque
 	}
 }
 
+// CreateBulkEvents
+// @Summary Create events in bulk
+// @Description This endpoint creates up to 1000 events in one request, the body is either a JSON array of events or, with the application/x-ndjson content type, one event per line. Each event is validated on its own and the result of every event is returned
+// @Tags Events
+// @Accept  json
+// @Produce  json
+// @Param projectID path string true "Project ID"
+// @Param events body []models.CreateEvent true "Events"
+// @Success 201 {object} util.ServerResponse{data=models.BulkEventResponse}
+// @Failure 400,401,404 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /v1/projects/{projectID}/events/batch [post]
+func (a *PublicHandler) CreateBulkEvents(w http.ResponseWriter, r *http.Request) {
+	newMessages, err := models.ParseBulkEvents(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	project, err := a.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	bs := services.CreateBulkEventService{
+		EndpointRepo:  postgres.NewEndpointRepo(a.A.DB),
+		EventRepo:     postgres.NewEventRepo(a.A.DB),
+		EventTypeRepo: postgres.NewEventTypeRepo(a.A.DB),
+		Queue:         a.A.Queue,
+		NewMessages:   newMessages,
+		Project:       project,
+	}
+
+	resp, err := bs.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	msg := fmt.Sprintf("%d of %d events created successfully", resp.Created, len(newMessages))
+	_ = render.Render(w, r, util.NewServerResponse(msg, resp, http.StatusCreated))
+}
+
 // CreateEndpointFanoutEvent
 // @Summary Fan out an event
 // @Description This endpoint uses the owner_id to fan out an event to multiple endpoints.
@@ -107,6 +153,7 @@ func (a *PublicHandler) CreateEndpointFanoutEvent(w http.ResponseWriter, r *http
 	cf := services.CreateFanoutEventService{
 		EndpointRepo:   postgres.NewEndpointRepo(a.A.DB),
 		EventRepo:      postgres.NewEventRepo(a.A.DB),
+		EventTypeRepo:  postgres.NewEventTypeRepo(a.A.DB),
 		PortalLinkRepo: postgres.NewPortalLinkRepo(a.A.DB),
 		Queue:          a.A.Queue,
 		NewMessage:     &newMessage,
@@ -209,6 +256,74 @@ func (a *PublicHandler) ReplayEndpointEvent(w http.ResponseWriter, r *http.Reque
 	_ = render.Render(w, r, util.NewServerResponse("Endpoint event replayed successfully", resp, http.StatusOK))
 }
 
+// GetScheduledEventsPaged
+// @Summary List scheduled events
+// @Description This endpoint fetches the events whose deliveries are held until a later time, with pagination
+// @Tags Events
+// @Accept  json
+// @Produce  json
+// @Param projectID path string true "Project ID"
+// @Param request query datastore.Pageable false "Query Params"
+// @Success 200 {object} util.ServerResponse{data=pagedResponse{content=[]models.EventResponse}}
+// @Failure 400,401,404 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /v1/projects/{projectID}/events/scheduled [get]
+func (a *PublicHandler) GetScheduledEventsPaged(w http.ResponseWriter, r *http.Request) {
+	project, err := a.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	pageable := m.GetPageableFromContext(r.Context())
+	events, paginationData, err := postgres.NewEventRepo(a.A.DB).LoadScheduledEventsPaged(r.Context(), project.UID, pageable)
+	if err != nil {
+		log.FromContext(r.Context()).WithError(err).Error("failed to fetch scheduled events")
+		_ = render.Render(w, r, util.NewErrorResponse("an error occurred while fetching scheduled events", http.StatusBadRequest))
+		return
+	}
+
+	resp := models.NewListResponse(events, func(event datastore.Event) models.EventResponse {
+		return models.EventResponse{Event: &event}
+	})
+	_ = render.Render(w, r, util.NewServerResponse("Scheduled events fetched successfully",
+		pagedResponse{Content: resp, Pagination: &paginationData}, http.StatusOK))
+}
+
+// CancelScheduledEvent
+// @Summary Cancel a scheduled event
+// @Description This endpoint discards the deliveries of a scheduled event that haven't been sent yet
+// @Tags Events
+// @Accept  json
+// @Produce  json
+// @Param projectID path string true "Project ID"
+// @Param eventID path string true "event id"
+// @Success 200 {object} util.ServerResponse{data=models.EventResponse}
+// @Failure 400,401,404 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /v1/projects/{projectID}/events/{eventID}/cancel [put]
+func (a *PublicHandler) CancelScheduledEvent(w http.ResponseWriter, r *http.Request) {
+	event, err := a.retrieveEvent(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	cs := services.CancelScheduledEventService{
+		EventDeliveryRepo: postgres.NewEventDeliveryRepo(a.A.DB),
+		Event:             event,
+	}
+
+	err = cs.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	resp := &models.EventResponse{Event: event}
+	_ = render.Render(w, r, util.NewServerResponse("Scheduled event cancelled successfully", resp, http.StatusOK))
+}
+
 // BatchReplayEvents
 // @Summary Batch replay events
 // @Description This endpoint replays multiple events at once.
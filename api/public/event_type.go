@@ -0,0 +1,214 @@
+package public
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/database/postgres"
+	"github.com/frain-dev/convoy/datastore"
+	m "github.com/frain-dev/convoy/internal/pkg/middleware"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/services"
+	"github.com/frain-dev/convoy/util"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// CreateEventType
+// @Summary Create an event type
+// @Description This endpoint adds an event type to the project's catalog
+// @Tags Event Types
+// @Accept  json
+// @Produce  json
+// @Param projectID path string true "Project ID"
+// @Param eventType body models.CreateEventType true "Event Type Details"
+// @Success 201 {object} util.ServerResponse{data=models.EventTypeResponse}
+// @Failure 400,401,404 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /v1/projects/{projectID}/event-types [post]
+func (a *PublicHandler) CreateEventType(w http.ResponseWriter, r *http.Request) {
+	var newEventType models.CreateEventType
+	if err := util.ReadJSON(r, &newEventType); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	if err := newEventType.Validate(); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	project, err := a.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	ce := services.CreateEventTypeService{
+		EventTypeRepo: postgres.NewEventTypeRepo(a.A.DB),
+		NewEventType:  &newEventType,
+		Project:       project,
+	}
+
+	eventType, err := ce.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	resp := &models.EventTypeResponse{ProjectEventType: eventType}
+	_ = render.Render(w, r, util.NewServerResponse("Event type created successfully", resp, http.StatusCreated))
+}
+
+// GetEventTypes
+// @Summary List all event types
+// @Description This endpoint fetches the project's event type catalog, with pagination
+// @Tags Event Types
+// @Accept  json
+// @Produce  json
+// @Param projectID path string true "Project ID"
+// @Param request query datastore.Pageable false "Query Params"
+// @Success 200 {object} util.ServerResponse{data=pagedResponse{content=[]models.EventTypeResponse}}
+// @Failure 400,401,404 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /v1/projects/{projectID}/event-types [get]
+func (a *PublicHandler) GetEventTypes(w http.ResponseWriter, r *http.Request) {
+	project, err := a.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	pageable := m.GetPageableFromContext(r.Context())
+	eventTypes, paginationData, err := postgres.NewEventTypeRepo(a.A.DB).LoadEventTypesPaged(r.Context(), project.UID, pageable)
+	if err != nil {
+		log.FromContext(r.Context()).WithError(err).Error("an error occurred while fetching event types")
+		_ = render.Render(w, r, util.NewErrorResponse("an error occurred while fetching event types", http.StatusInternalServerError))
+		return
+	}
+
+	resp := models.NewListResponse(eventTypes, func(eventType datastore.ProjectEventType) models.EventTypeResponse {
+		return models.EventTypeResponse{ProjectEventType: &eventType}
+	})
+	_ = render.Render(w, r, util.NewServerResponse("Event types fetched successfully",
+		pagedResponse{Content: resp, Pagination: &paginationData}, http.StatusOK))
+}
+
+// GetEventType
+// @Summary Retrieve an event type
+// @Description This endpoint retrieves an event type from the project's catalog
+// @Tags Event Types
+// @Accept  json
+// @Produce  json
+// @Param projectID path string true "Project ID"
+// @Param eventTypeID path string true "event type id"
+// @Success 200 {object} util.ServerResponse{data=models.EventTypeResponse}
+// @Failure 400,401,404 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /v1/projects/{projectID}/event-types/{eventTypeID} [get]
+func (a *PublicHandler) GetEventType(w http.ResponseWriter, r *http.Request) {
+	eventType, err := a.retrieveEventType(r)
+	if err != nil {
+		a.renderEventTypeErr(w, r, err)
+		return
+	}
+
+	resp := &models.EventTypeResponse{ProjectEventType: eventType}
+	_ = render.Render(w, r, util.NewServerResponse("Event type fetched successfully", resp, http.StatusOK))
+}
+
+// UpdateEventType
+// @Summary Update an event type
+// @Description This endpoint updates an event type, its name can't be changed
+// @Tags Event Types
+// @Accept  json
+// @Produce  json
+// @Param projectID path string true "Project ID"
+// @Param eventTypeID path string true "event type id"
+// @Param eventType body models.UpdateEventType true "Event Type Details"
+// @Success 202 {object} util.ServerResponse{data=models.EventTypeResponse}
+// @Failure 400,401,404 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /v1/projects/{projectID}/event-types/{eventTypeID} [put]
+func (a *PublicHandler) UpdateEventType(w http.ResponseWriter, r *http.Request) {
+	var update models.UpdateEventType
+	if err := util.ReadJSON(r, &update); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	if err := update.Validate(); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	eventType, err := a.retrieveEventType(r)
+	if err != nil {
+		a.renderEventTypeErr(w, r, err)
+		return
+	}
+
+	us := services.UpdateEventTypeService{
+		EventTypeRepo: postgres.NewEventTypeRepo(a.A.DB),
+		Update:        &update,
+		EventType:     eventType,
+	}
+
+	eventType, err = us.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	resp := &models.EventTypeResponse{ProjectEventType: eventType}
+	_ = render.Render(w, r, util.NewServerResponse("Event type updated successfully", resp, http.StatusAccepted))
+}
+
+// DeleteEventType
+// @Summary Delete an event type
+// @Description This endpoint removes an event type from the project's catalog
+// @Tags Event Types
+// @Accept  json
+// @Produce  json
+// @Param projectID path string true "Project ID"
+// @Param eventTypeID path string true "event type id"
+// @Success 200 {object} util.ServerResponse{data=Stub}
+// @Failure 400,401,404 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /v1/projects/{projectID}/event-types/{eventTypeID} [delete]
+func (a *PublicHandler) DeleteEventType(w http.ResponseWriter, r *http.Request) {
+	eventType, err := a.retrieveEventType(r)
+	if err != nil {
+		a.renderEventTypeErr(w, r, err)
+		return
+	}
+
+	err = postgres.NewEventTypeRepo(a.A.DB).DeleteEventType(r.Context(), eventType.ProjectID, eventType.UID)
+	if err != nil {
+		log.FromContext(r.Context()).WithError(err).Error("failed to delete event type")
+		_ = render.Render(w, r, util.NewErrorResponse("failed to delete event type", http.StatusBadRequest))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Event type deleted successfully", nil, http.StatusOK))
+}
+
+func (a *PublicHandler) retrieveEventType(r *http.Request) (*datastore.ProjectEventType, error) {
+	project, err := a.retrieveProject(r)
+	if err != nil {
+		return &datastore.ProjectEventType{}, err
+	}
+
+	eventTypeID := chi.URLParam(r, "eventTypeID")
+	return postgres.NewEventTypeRepo(a.A.DB).FindEventTypeByID(r.Context(), project.UID, eventTypeID)
+}
+
+func (a *PublicHandler) renderEventTypeErr(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, datastore.ErrEventTypeNotFound) {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewErrorResponse("error retrieving event type", http.StatusBadRequest))
+}
@@ -64,13 +64,16 @@ func (a *PublicHandler) BuildRoutes() http.Handler {
 					// TODO(all): should the InstrumentPath change?
 					eventRouter.With(middleware.InstrumentPath("/events")).Post("/", a.CreateEndpointEvent)
 					eventRouter.Post("/fanout", a.CreateEndpointFanoutEvent)
+					eventRouter.Post("/batch", a.CreateBulkEvents)
 					eventRouter.Post("/dynamic", a.CreateDynamicEvent)
 					eventRouter.With(middleware.Pagination).Get("/", a.GetEventsPaged)
 					eventRouter.Post("/batchreplay", a.BatchReplayEvents)
+					eventRouter.With(middleware.Pagination).Get("/scheduled", a.GetScheduledEventsPaged)
 
 					eventRouter.Route("/{eventID}", func(eventSubRouter chi.Router) {
 						eventSubRouter.Get("/", a.GetEndpointEvent)
 						eventSubRouter.Put("/replay", a.ReplayEndpointEvent)
+						eventSubRouter.Put("/cancel", a.CancelScheduledEvent)
 					})
 				})
 
@@ -93,6 +96,7 @@ func (a *PublicHandler) BuildRoutes() http.Handler {
 				projectSubRouter.Route("/subscriptions", func(subscriptionRouter chi.Router) {
 					subscriptionRouter.Post("/", a.CreateSubscription)
 					subscriptionRouter.Post("/test_filter", a.TestSubscriptionFilter)
+					subscriptionRouter.Post("/test_function", a.TestSubscriptionFunction)
 					subscriptionRouter.With(middleware.Pagination).Get("/", a.GetSubscriptions)
 					subscriptionRouter.Delete("/{subscriptionID}", a.DeleteSubscription)
 					subscriptionRouter.Get("/{subscriptionID}", a.GetSubscription)
@@ -102,12 +106,25 @@ func (a *PublicHandler) BuildRoutes() http.Handler {
 
 				projectSubRouter.Route("/sources", func(sourceRouter chi.Router) {
 					sourceRouter.Post("/", a.CreateSource)
+					sourceRouter.Post("/test_mapping", a.TestSourceMapping)
 					sourceRouter.Get("/{sourceID}", a.GetSourceByID)
+					sourceRouter.With(middleware.Pagination).Get("/{sourceID}/dead-letters", a.GetSourceDeadLettersPaged)
 					sourceRouter.With(middleware.Pagination).Get("/", a.LoadSourcesPaged)
 					sourceRouter.Put("/{sourceID}", a.UpdateSource)
 					sourceRouter.Delete("/{sourceID}", a.DeleteSource)
 				})
 
+				projectSubRouter.Route("/event-types", func(eventTypeRouter chi.Router) {
+					eventTypeRouter.Post("/", a.CreateEventType)
+					eventTypeRouter.With(middleware.Pagination).Get("/", a.GetEventTypes)
+
+					eventTypeRouter.Route("/{eventTypeID}", func(eventTypeSubRouter chi.Router) {
+						eventTypeSubRouter.Get("/", a.GetEventType)
+						eventTypeSubRouter.Put("/", a.UpdateEventType)
+						eventTypeSubRouter.Delete("/", a.DeleteEventType)
+					})
+				})
+
 				projectSubRouter.Route("/portal-links", func(portalLinkRouter chi.Router) {
 					portalLinkRouter.Post("/", a.CreatePortalLink)
 					portalLinkRouter.Get("/{portalLinkID}", a.GetPortalLinkByID)
@@ -124,6 +141,23 @@ func (a *PublicHandler) BuildRoutes() http.Handler {
 						metaEventSubRouter.Put("/resend", a.ResendMetaEvent)
 					})
 				})
+
+				projectSubRouter.Route("/dead-letters", func(deadLetterRouter chi.Router) {
+					deadLetterRouter.With(middleware.Pagination).Get("/", a.GetDeadLettersPaged)
+					deadLetterRouter.Post("/batchredrive", a.BatchRedriveDeadLetters)
+					deadLetterRouter.Post("/purge", a.PurgeDeadLetters)
+
+					deadLetterRouter.Route("/{deadLetterID}", func(deadLetterSubRouter chi.Router) {
+						deadLetterSubRouter.Get("/", a.GetDeadLetter)
+						deadLetterSubRouter.Put("/redrive", a.RedriveDeadLetter)
+						deadLetterSubRouter.Delete("/", a.PurgeDeadLetter)
+					})
+				})
+
+				projectSubRouter.Route("/signing-keys", func(signingKeyRouter chi.Router) {
+					signingKeyRouter.Get("/", a.GetSigningKeys)
+					signingKeyRouter.Post("/rotate", a.RotateSigningKey)
+				})
 			})
 		})
 
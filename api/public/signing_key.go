@@ -0,0 +1,99 @@
+package public
+
+import (
+	"net/http"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/database/postgres"
+	"github.com/frain-dev/convoy/services"
+	"github.com/frain-dev/convoy/util"
+	"github.com/go-chi/render"
+)
+
+// GetSigningKeys
+// @Summary List all signing keys
+// @Description This endpoint fetches the project's unexpired asymmetric signing keys
+// @Tags Signing Keys
+// @Accept  json
+// @Produce  json
+// @Param projectID path string true "Project ID"
+// @Success 200 {object} util.ServerResponse{data=[]models.SigningKeyResponse}
+// @Failure 400,401,404 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /v1/projects/{projectID}/signing-keys [get]
+func (a *PublicHandler) GetSigningKeys(w http.ResponseWriter, r *http.Request) {
+	project, err := a.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	keys, err := postgres.NewSigningKeyRepo(a.A.DB).LoadActiveSigningKeys(r.Context(), project.UID)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("an error occurred while fetching signing keys", http.StatusInternalServerError))
+		return
+	}
+
+	resp := make([]models.SigningKeyResponse, 0, len(keys))
+	for i := range keys {
+		resp = append(resp, models.SigningKeyResponse{SigningKey: &keys[i]})
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Signing keys fetched successfully", resp, http.StatusOK))
+}
+
+// RotateSigningKey
+// @Summary Rotate the signing key
+// @Description This endpoint generates a new signing key, the current key stays in the project's JWKS until its expiration
+// @Tags Signing Keys
+// @Accept  json
+// @Produce  json
+// @Param projectID path string true "Project ID"
+// @Param signingKey body models.RotateSigningKey true "Signing Key Details"
+// @Success 201 {object} util.ServerResponse{data=models.SigningKeyResponse}
+// @Failure 400,401,404 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /v1/projects/{projectID}/signing-keys/rotate [post]
+func (a *PublicHandler) RotateSigningKey(w http.ResponseWriter, r *http.Request) {
+	var rs models.RotateSigningKey
+	err := util.ReadJSON(r, &rs)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	err = rs.Validate()
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	project, err := a.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	cfg, err := config.Get()
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusInternalServerError))
+		return
+	}
+
+	ks := services.RotateSigningKeyService{
+		SigningKeyRepo: postgres.NewSigningKeyRepo(a.A.DB),
+		EncryptionKey:  cfg.EncryptionKey,
+		R:              &rs,
+		Project:        project,
+	}
+
+	key, err := ks.Run(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	resp := &models.SigningKeyResponse{SigningKey: key}
+	_ = render.Render(w, r, util.NewServerResponse("Signing key rotated successfully", resp, http.StatusCreated))
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/database/postgres"
 	"github.com/frain-dev/convoy/datastore"
+	m "github.com/frain-dev/convoy/internal/pkg/middleware"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub"
 	"github.com/frain-dev/convoy/services"
 	"github.com/frain-dev/convoy/util"
 	"github.com/go-chi/chi/v5"
@@ -319,3 +321,97 @@ func fillSourceURL(s *datastore.Source, baseUrl string, customDomain string) {
 
 	s.URL = fmt.Sprintf("%s/ingest/%s", url, s.MaskID)
 }
+
+// TestSourceMapping
+// @Summary Test pub sub message mapping
+// @Description This endpoint applies a pub sub message mapping to a sample message and returns the event it would create
+// @Tags Sources
+// @Accept  json
+// @Produce  json
+// @Param projectID path string true "Project ID"
+// @Param mapping body models.TestPubSubMapping true "Mapping Details"
+// @Success 200 {object} util.ServerResponse{data=models.TestPubSubMappingResponse}
+// @Failure 400,401,404 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /v1/projects/{projectID}/sources/test_mapping [post]
+func (a *PublicHandler) TestSourceMapping(w http.ResponseWriter, r *http.Request) {
+	var test models.TestPubSubMapping
+	if err := util.ReadJSON(r, &test); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	if err := test.Validate(); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	mapping := test.Mapping.Transform()
+	if err := pubsub.ValidateMapping(mapping); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	msg, err := pubsub.ParseMessage(mapping, string(test.Payload), test.Attributes)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	resp := models.TestPubSubMappingResponse{
+		EventType:      msg.EventType,
+		EndpointID:     msg.EndpointID,
+		OwnerID:        msg.OwnerID,
+		IdempotencyKey: msg.IdempotencyKey,
+		Data:           msg.Data,
+		CustomHeaders:  msg.CustomHeaders,
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Mapping applied successfully", resp, http.StatusOK))
+}
+
+// GetSourceDeadLettersPaged
+// @Summary List a source's dead letters
+// @Description This endpoint fetches the pub sub messages a source moved to its dead letter table, with pagination
+// @Tags Sources
+// @Accept  json
+// @Produce  json
+// @Param projectID path string true "Project ID"
+// @Param sourceID path string true "source id"
+// @Param request query datastore.Pageable false "Query Params"
+// @Success 200 {object} util.ServerResponse{data=pagedResponse{content=[]models.SourceDeadLetterResponse}}
+// @Failure 400,401,404 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /v1/projects/{projectID}/sources/{sourceID}/dead-letters [get]
+func (a *PublicHandler) GetSourceDeadLettersPaged(w http.ResponseWriter, r *http.Request) {
+	project, err := a.retrieveProject(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	source, err := postgres.NewSourceRepo(a.A.DB).FindSourceByID(r.Context(), project.UID, chi.URLParam(r, "sourceID"))
+	if err != nil {
+		if err == datastore.ErrSourceNotFound {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusNotFound))
+			return
+		}
+
+		_ = render.Render(w, r, util.NewErrorResponse("error retrieving source", http.StatusBadRequest))
+		return
+	}
+
+	pageable := m.GetPageableFromContext(r.Context())
+	deadLetters, paginationData, err := postgres.NewSourceDeadLetterRepo(a.A.DB).LoadSourceDeadLettersPaged(r.Context(), project.UID, source.UID, pageable)
+	if err != nil {
+		log.FromContext(r.Context()).WithError(err).Error("an error occurred while fetching source dead letters")
+		_ = render.Render(w, r, util.NewErrorResponse("an error occurred while fetching source dead letters", http.StatusInternalServerError))
+		return
+	}
+
+	resp := models.NewListResponse(deadLetters, func(deadLetter datastore.SourceDeadLetter) models.SourceDeadLetterResponse {
+		return models.SourceDeadLetterResponse{SourceDeadLetter: &deadLetter}
+	})
+	_ = render.Render(w, r, util.NewServerResponse("Source dead letters fetched successfully",
+		pagedResponse{Content: resp, Pagination: &paginationData}, http.StatusOK))
+}
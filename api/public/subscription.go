@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/transform"
 
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/database/postgres"
@@ -281,6 +282,17 @@ func (a *PublicHandler) TestSubscriptionFilter(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	if test.Expression != "" {
+		isValid, err := test.TestExpression()
+		if err != nil {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+			return
+		}
+
+		_ = render.Render(w, r, util.NewServerResponse("Subscriptions filter validated successfully", isValid, http.StatusCreated))
+		return
+	}
+
 	subRepo := postgres.NewSubscriptionRepo(a.A.DB)
 	isBodyValid, err := subRepo.TestSubscriptionFilter(r.Context(), test.Request.Body, test.Schema.Body)
 	if err != nil {
@@ -300,3 +312,43 @@ func (a *PublicHandler) TestSubscriptionFilter(w http.ResponseWriter, r *http.Re
 
 	_ = render.Render(w, r, util.NewServerResponse("Subscriptions filter validated successfully", isValid, http.StatusCreated))
 }
+
+// TestSubscriptionFunction
+// @Summary Test subscription transform function
+// @Description This endpoint runs a transform function against a sample payload without sending it.
+// @Tags Subscriptions
+// @Accept json
+// @Produce json
+// @Param projectID path string true "Project ID"
+// @Param function body models.TestFunction true "Function Details"
+// @Success 200 {object} util.ServerResponse{data=models.TestFunctionResponse}
+// @Failure 400,401,404 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /v1/projects/{projectID}/subscriptions/test_function [post]
+func (a *PublicHandler) TestSubscriptionFunction(w http.ResponseWriter, r *http.Request) {
+	var test models.TestFunction
+	err := util.ReadJSON(r, &test)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	if err = test.Validate(); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	path := test.Path
+	if util.IsStringEmpty(path) {
+		path = "/"
+	}
+
+	res, err := transform.Transform(test.Function, &transform.Request{Body: test.Payload, Headers: test.Headers, Path: path})
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	resp := models.TestFunctionResponse{Payload: res.Body, Headers: res.Headers, Path: res.Path, Log: res.Logs}
+	_ = render.Render(w, r, util.NewServerResponse("Transform function run successfully", resp, http.StatusOK))
+}
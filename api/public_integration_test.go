@@ -22,10 +22,12 @@ import (
 	"github.com/frain-dev/convoy/database/postgres"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/pkg/metrics"
+	"github.com/frain-dev/convoy/pkg/httpheader"
 	"github.com/jaswdr/faker"
 	"github.com/oklog/ulid/v2"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"gopkg.in/guregu/null.v4"
 )
 
 type PublicEndpointIntegrationTestSuite struct {
@@ -763,6 +765,68 @@ func (s *PublicEventIntegrationTestSuite) Test_CreateEndpointEvent() {
 	require.Equal(s.T(), event.Endpoints[0], endpointID)
 }
 
+func (s *PublicEventIntegrationTestSuite) Test_CreateBulkEvents() {
+	endpointID := ulid.Make().String()
+	expectedStatusCode := http.StatusCreated
+
+	// Just Before.
+	_, _ = testdb.SeedEndpoint(s.ConvoyApp.A.DB, s.DefaultProject, endpointID, "", "", false, datastore.ActiveEndpointStatus)
+
+	bodyStr := `[
+		{"endpoint_id": "%s", "event_type":"*", "data":{"level":"test"}},
+		{"endpoint_id": "%s", "event_type":"*"},
+		{"endpoint_id": "%s", "event_type":"*", "data":{"level":"test"}}
+	]`
+	body := serialize(bodyStr, endpointID, endpointID, endpointID)
+
+	url := fmt.Sprintf("/api/v1/projects/%s/events/batch", s.DefaultProject.UID)
+	req := createRequest(http.MethodPost, url, s.APIKey, body)
+	w := httptest.NewRecorder()
+	// Act.
+	s.Router.ServeHTTP(w, req)
+
+	// Assert.
+	require.Equal(s.T(), expectedStatusCode, w.Code)
+
+	// Deep Assert.
+	var resp models.BulkEventResponse
+	parseResponse(s.T(), w.Result(), &resp)
+
+	require.Equal(s.T(), 2, resp.Created)
+	require.Equal(s.T(), 1, resp.Failed)
+	require.Equal(s.T(), "data:please provide your data", resp.Results[1].Error)
+
+	event, err := postgres.NewEventRepo(s.ConvoyApp.A.DB).FindEventByID(context.Background(), s.DefaultProject.UID, resp.Results[2].EventID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), endpointID, event.Endpoints[0])
+}
+
+func (s *PublicEventIntegrationTestSuite) Test_CreateBulkEvents_NDJSON() {
+	endpointID := ulid.Make().String()
+	expectedStatusCode := http.StatusCreated
+
+	// Just Before.
+	_, _ = testdb.SeedEndpoint(s.ConvoyApp.A.DB, s.DefaultProject, endpointID, "", "", false, datastore.ActiveEndpointStatus)
+
+	bodyStr := "{\"endpoint_id\": \"%s\", \"event_type\":\"*\", \"data\":{}}\n{\"endpoint_id\": \"%s\", \"event_type\":\"*\", \"data\":{}}\n"
+	body := serialize(bodyStr, endpointID, endpointID)
+
+	url := fmt.Sprintf("/api/v1/projects/%s/events/batch", s.DefaultProject.UID)
+	req := createRequest(http.MethodPost, url, s.APIKey, body)
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	w := httptest.NewRecorder()
+	// Act.
+	s.Router.ServeHTTP(w, req)
+
+	// Assert.
+	require.Equal(s.T(), expectedStatusCode, w.Code)
+
+	// Deep Assert.
+	var resp models.BulkEventResponse
+	parseResponse(s.T(), w.Result(), &resp)
+	require.Equal(s.T(), 2, resp.Created)
+}
+
 func (s *PublicEventIntegrationTestSuite) Test_CreateDynamicEvent() {
 	endpointID := ulid.Make().String()
 	expectedStatusCode := http.StatusCreated
@@ -1196,6 +1260,112 @@ func (s *PublicEventIntegrationTestSuite) Test_GetEventsPaged() {
 	}
 }
 
+func (s *PublicEventIntegrationTestSuite) seedScheduledEvent(endpoint *datastore.Endpoint, subscription *datastore.Subscription, deliverAt time.Time) *datastore.Event {
+	event := &datastore.Event{
+		UID:       ulid.Make().String(),
+		EventType: "*",
+		Data:      []byte(`{}`),
+		Endpoints: []string{endpoint.UID},
+		Headers:   httpheader.HTTPHeader{},
+		ProjectID: s.DefaultProject.UID,
+		DeliverAt: null.TimeFrom(deliverAt),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	require.NoError(s.T(), postgres.NewEventRepo(s.ConvoyApp.A.DB).CreateEvent(context.Background(), event))
+
+	eventDelivery := &datastore.EventDelivery{
+		UID:            ulid.Make().String(),
+		EventID:        event.UID,
+		EndpointID:     endpoint.UID,
+		SubscriptionID: subscription.UID,
+		ProjectID:      s.DefaultProject.UID,
+		Status:         datastore.ScheduledEventStatus,
+		Headers:        httpheader.HTTPHeader{},
+		Metadata:       &datastore.Metadata{NextSendTime: deliverAt},
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+	require.NoError(s.T(), postgres.NewEventDeliveryRepo(s.ConvoyApp.A.DB).CreateEventDelivery(context.Background(), eventDelivery))
+
+	return event
+}
+
+func (s *PublicEventIntegrationTestSuite) Test_GetScheduledEventsPaged() {
+	expectedStatusCode := http.StatusOK
+
+	// Just Before.
+	endpoint, err := testdb.SeedEndpoint(s.ConvoyApp.A.DB, s.DefaultProject, ulid.Make().String(), "", "", false, datastore.ActiveEndpointStatus)
+	require.NoError(s.T(), err)
+
+	subscription, err := testdb.SeedSubscription(s.ConvoyApp.A.DB, s.DefaultProject, ulid.Make().String(), datastore.OutgoingProject, &datastore.Source{}, endpoint, &datastore.RetryConfiguration{}, &datastore.AlertConfiguration{}, &datastore.FilterConfiguration{
+		EventTypes: []string{"*"},
+		Filter:     datastore.FilterSchema{Headers: datastore.M{}, Body: datastore.M{}},
+	})
+	require.NoError(s.T(), err)
+
+	scheduled := s.seedScheduledEvent(endpoint, subscription, time.Now().Add(time.Hour))
+	_ = s.seedScheduledEvent(endpoint, subscription, time.Now().Add(-time.Hour))
+
+	_, err = testdb.SeedEvent(s.ConvoyApp.A.DB, endpoint, s.DefaultProject.UID, ulid.Make().String(), "*", "", []byte(`{}`))
+	require.NoError(s.T(), err)
+
+	url := fmt.Sprintf("/api/v1/projects/%s/events/scheduled", s.DefaultProject.UID)
+	req := createRequest(http.MethodGet, url, s.APIKey, nil)
+	w := httptest.NewRecorder()
+
+	// Act.
+	s.Router.ServeHTTP(w, req)
+
+	// Assert.
+	require.Equal(s.T(), expectedStatusCode, w.Code)
+
+	// Deep Assert.
+	var respEvents []datastore.Event
+	resp := pagedResponse{Content: &respEvents}
+	parseResponse(s.T(), w.Result(), &resp)
+	require.Equal(s.T(), 1, len(respEvents))
+	require.Equal(s.T(), scheduled.UID, respEvents[0].UID)
+	require.True(s.T(), respEvents[0].DeliverAt.Valid)
+}
+
+func (s *PublicEventIntegrationTestSuite) Test_CancelScheduledEvent() {
+	expectedStatusCode := http.StatusOK
+
+	// Just Before.
+	endpoint, err := testdb.SeedEndpoint(s.ConvoyApp.A.DB, s.DefaultProject, ulid.Make().String(), "", "", false, datastore.ActiveEndpointStatus)
+	require.NoError(s.T(), err)
+
+	subscription, err := testdb.SeedSubscription(s.ConvoyApp.A.DB, s.DefaultProject, ulid.Make().String(), datastore.OutgoingProject, &datastore.Source{}, endpoint, &datastore.RetryConfiguration{}, &datastore.AlertConfiguration{}, &datastore.FilterConfiguration{
+		EventTypes: []string{"*"},
+		Filter:     datastore.FilterSchema{Headers: datastore.M{}, Body: datastore.M{}},
+	})
+	require.NoError(s.T(), err)
+
+	event := s.seedScheduledEvent(endpoint, subscription, time.Now().Add(time.Hour))
+
+	url := fmt.Sprintf("/api/v1/projects/%s/events/%s/cancel", s.DefaultProject.UID, event.UID)
+	req := createRequest(http.MethodPut, url, s.APIKey, nil)
+	w := httptest.NewRecorder()
+
+	// Act.
+	s.Router.ServeHTTP(w, req)
+
+	// Assert.
+	require.Equal(s.T(), expectedStatusCode, w.Code)
+
+	// Deep Assert.
+	deliveries, err := postgres.NewEventDeliveryRepo(s.ConvoyApp.A.DB).FindEventDeliveriesByEventID(context.Background(), s.DefaultProject.UID, event.UID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 1, len(deliveries))
+	require.Equal(s.T(), datastore.DiscardedEventStatus, deliveries[0].Status)
+
+	// a cancelled event can't be cancelled again
+	w = httptest.NewRecorder()
+	s.Router.ServeHTTP(w, createRequest(http.MethodPut, url, s.APIKey, nil))
+	require.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
 func (s *PublicEventIntegrationTestSuite) Test_GetEventDeliveriesPaged() {
 	eventDeliveryID := ulid.Make().String()
 	expectedStatusCode := http.StatusOK
@@ -1884,6 +2054,56 @@ func (s *PublicSourceIntegrationTestSuite) TearDownTest() {
 	metrics.Reset()
 }
 
+func (s *PublicSourceIntegrationTestSuite) Test_TestSourceMapping() {
+	// Arrange Request
+	url := fmt.Sprintf("/api/v1/projects/%s/sources/test_mapping", s.DefaultProject.UID)
+	bodyStr := `{
+		"mapping": {
+			"event_type": "body.type",
+			"owner_id": "attributes.tenant",
+			"idempotency_key": "body.id",
+			"data": "body.payload",
+			"headers": {"X-Region": "attributes.region"}
+		},
+		"payload": {"id": "evt_1", "type": "invoice.paid", "payload": {"amount": 2000}},
+		"attributes": {"tenant": "acme", "region": "eu"}
+	}`
+
+	body := serialize(bodyStr)
+	req := createRequest(http.MethodPost, url, s.APIKey, body)
+	w := httptest.NewRecorder()
+
+	// Act
+	s.Router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(s.T(), http.StatusOK, w.Code)
+
+	var resp models.TestPubSubMappingResponse
+	parseResponse(s.T(), w.Result(), &resp)
+	require.Equal(s.T(), "invoice.paid", resp.EventType)
+	require.Equal(s.T(), "acme", resp.OwnerID)
+	require.Equal(s.T(), "evt_1", resp.IdempotencyKey)
+	require.JSONEq(s.T(), `{"amount":2000}`, string(resp.Data))
+	require.Equal(s.T(), map[string]string{"X-Region": "eu"}, resp.CustomHeaders)
+}
+
+func (s *PublicSourceIntegrationTestSuite) Test_TestSourceMapping_InvalidPath() {
+	// Arrange Request
+	url := fmt.Sprintf("/api/v1/projects/%s/sources/test_mapping", s.DefaultProject.UID)
+	bodyStr := `{"mapping": {"event_type": "type"}, "payload": {"type": "invoice.paid"}}`
+
+	body := serialize(bodyStr)
+	req := createRequest(http.MethodPost, url, s.APIKey, body)
+	w := httptest.NewRecorder()
+
+	// Act
+	s.Router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
 func (s *PublicSourceIntegrationTestSuite) Test_GetSourceByID_SourceNotFound() {
 	sourceID := "123"
 
@@ -2602,6 +2822,50 @@ func (s *PublicSubscriptionIntegrationTestSuite) Test_DeleteSubscription() {
 	require.ErrorIs(s.T(), err, datastore.ErrSubscriptionNotFound)
 }
 
+func (s *PublicSubscriptionIntegrationTestSuite) Test_TestSubscriptionFunction() {
+	// Arrange Request
+	url := fmt.Sprintf("/api/v1/projects/%s/subscriptions/test_function", s.DefaultProject.UID)
+	bodyStr := `{
+		"payload": {"event": "invoice.paid", "amount": 2000},
+		"headers": {"X-Source": ["billing"]},
+		"path": "/hooks",
+		"function": "function transform(req) { console.log(req.body.event); req.body = { amount: req.body.amount / 100 }; req.path = '/v2' + req.path; return req; }"
+	}`
+
+	body := serialize(bodyStr)
+	req := createRequest(http.MethodPost, url, s.APIKey, body)
+	w := httptest.NewRecorder()
+
+	// Act
+	s.Router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(s.T(), http.StatusOK, w.Code)
+
+	var resp models.TestFunctionResponse
+	parseResponse(s.T(), w.Result(), &resp)
+	require.JSONEq(s.T(), `{"amount":20}`, string(resp.Payload))
+	require.Equal(s.T(), []string{"billing"}, resp.Headers["X-Source"])
+	require.Equal(s.T(), "/v2/hooks", resp.Path)
+	require.Equal(s.T(), []string{"invoice.paid"}, resp.Log)
+}
+
+func (s *PublicSubscriptionIntegrationTestSuite) Test_TestSubscriptionFunction_InvalidFunction() {
+	// Arrange Request
+	url := fmt.Sprintf("/api/v1/projects/%s/subscriptions/test_function", s.DefaultProject.UID)
+	bodyStr := `{"payload": {"amount": 2000}, "function": "function handle(req) { return req; }"}`
+
+	body := serialize(bodyStr)
+	req := createRequest(http.MethodPost, url, s.APIKey, body)
+	w := httptest.NewRecorder()
+
+	// Act
+	s.Router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
 func (s *PublicSubscriptionIntegrationTestSuite) Test_UpdateSubscription() {
 	subscriptionId := ulid.Make().String()
 
@@ -2774,3 +3038,224 @@ func (s *PublicMetaEventIntegrationTestSuite) Test_GetMetaEvent_Valid_MetaEvent(
 func TestPublicMetaEventIntegrationTestSuite(t *testing.T) {
 	suite.Run(t, new(PublicMetaEventIntegrationTestSuite))
 }
+
+type PublicEventTypeIntegrationTestSuite struct {
+	suite.Suite
+	DB             database.Database
+	Router         http.Handler
+	ConvoyApp      *ApplicationHandler
+	DefaultOrg     *datastore.Organisation
+	DefaultProject *datastore.Project
+	APIKey         string
+}
+
+func (s *PublicEventTypeIntegrationTestSuite) SetupSuite() {
+	s.DB = getDB()
+	s.ConvoyApp = buildServer()
+	s.Router = s.ConvoyApp.BuildRoutes()
+}
+
+func (s *PublicEventTypeIntegrationTestSuite) SetupTest() {
+	testdb.PurgeDB(s.T(), s.DB)
+
+	user, err := testdb.SeedDefaultUser(s.ConvoyApp.A.DB)
+	require.NoError(s.T(), err)
+
+	org, err := testdb.SeedDefaultOrganisation(s.ConvoyApp.A.DB, user)
+	require.NoError(s.T(), err)
+	s.DefaultOrg = org
+
+	// Setup Default Project.
+	s.DefaultProject, err = testdb.SeedDefaultProject(s.ConvoyApp.A.DB, s.DefaultOrg.UID)
+	require.NoError(s.T(), err)
+
+	// Seed Auth
+	role := auth.Role{
+		Type:    auth.RoleAdmin,
+		Project: s.DefaultProject.UID,
+	}
+
+	_, s.APIKey, _ = testdb.SeedAPIKey(s.ConvoyApp.A.DB, role, "", "test", "", "")
+
+	// Setup Config.
+	err = config.LoadConfig("./testdata/Auth_Config/full-convoy.json")
+	require.NoError(s.T(), err)
+
+	apiRepo := postgres.NewAPIKeyRepo(s.ConvoyApp.A.DB)
+	userRepo := postgres.NewUserRepo(s.ConvoyApp.A.DB)
+	initRealmChain(s.T(), apiRepo, userRepo, s.ConvoyApp.A.Cache)
+}
+
+func (s *PublicEventTypeIntegrationTestSuite) TearDownTest() {
+	testdb.PurgeDB(s.T(), s.DB)
+	metrics.Reset()
+}
+
+func (s *PublicEventTypeIntegrationTestSuite) seedEventType(name string, schema string) *datastore.ProjectEventType {
+	eventType := &datastore.ProjectEventType{
+		UID:        ulid.Make().String(),
+		ProjectID:  s.DefaultProject.UID,
+		Name:       name,
+		JSONSchema: []byte(schema),
+	}
+
+	require.NoError(s.T(), postgres.NewEventTypeRepo(s.ConvoyApp.A.DB).CreateEventType(context.Background(), eventType))
+	return eventType
+}
+
+func (s *PublicEventTypeIntegrationTestSuite) Test_CreateEventType() {
+	// Arrange Request
+	url := fmt.Sprintf("/api/v1/projects/%s/event-types", s.DefaultProject.UID)
+	bodyStr := `{
+		"name": "user.created",
+		"description": "a user signed up",
+		"version": "v1",
+		"json_schema": {"type": "object", "required": ["id"]}
+	}`
+
+	body := serialize(bodyStr)
+	req := createRequest(http.MethodPost, url, s.APIKey, body)
+	w := httptest.NewRecorder()
+
+	// Act
+	s.Router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(s.T(), http.StatusCreated, w.Code)
+
+	var eventType datastore.ProjectEventType
+	parseResponse(s.T(), w.Result(), &eventType)
+
+	dbEventType, err := postgres.NewEventTypeRepo(s.ConvoyApp.A.DB).FindEventTypeByID(context.Background(), s.DefaultProject.UID, eventType.UID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "user.created", dbEventType.Name)
+	require.Equal(s.T(), "v1", dbEventType.Version)
+	require.JSONEq(s.T(), `{"type": "object", "required": ["id"]}`, string(dbEventType.JSONSchema))
+}
+
+func (s *PublicEventTypeIntegrationTestSuite) Test_CreateEventType_InvalidSchema() {
+	// Arrange Request
+	url := fmt.Sprintf("/api/v1/projects/%s/event-types", s.DefaultProject.UID)
+	bodyStr := `{"name": "user.created", "json_schema": {"type": "objec"}}`
+
+	body := serialize(bodyStr)
+	req := createRequest(http.MethodPost, url, s.APIKey, body)
+	w := httptest.NewRecorder()
+
+	// Act
+	s.Router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *PublicEventTypeIntegrationTestSuite) Test_CreateEventType_DuplicateName() {
+	s.seedEventType("user.created", `{}`)
+
+	// Arrange Request
+	url := fmt.Sprintf("/api/v1/projects/%s/event-types", s.DefaultProject.UID)
+	body := serialize(`{"name": "user.created"}`)
+	req := createRequest(http.MethodPost, url, s.APIKey, body)
+	w := httptest.NewRecorder()
+
+	// Act
+	s.Router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(s.T(), http.StatusBadRequest, w.Code)
+}
+
+func (s *PublicEventTypeIntegrationTestSuite) Test_GetEventTypes() {
+	s.seedEventType("user.created", `{}`)
+	s.seedEventType("user.deleted", `{}`)
+
+	// Arrange Request
+	url := fmt.Sprintf("/api/v1/projects/%s/event-types", s.DefaultProject.UID)
+	req := createRequest(http.MethodGet, url, s.APIKey, nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	s.Router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(s.T(), http.StatusOK, w.Code)
+
+	var eventTypes []datastore.ProjectEventType
+	resp := pagedResponse{Content: &eventTypes}
+	parseResponse(s.T(), w.Result(), &resp)
+	require.Equal(s.T(), 2, len(eventTypes))
+}
+
+func (s *PublicEventTypeIntegrationTestSuite) Test_UpdateEventType() {
+	eventType := s.seedEventType("user.created", `{"type": "object"}`)
+
+	// Arrange Request
+	url := fmt.Sprintf("/api/v1/projects/%s/event-types/%s", s.DefaultProject.UID, eventType.UID)
+	body := serialize(`{"version": "v2", "deprecated": true}`)
+	req := createRequest(http.MethodPut, url, s.APIKey, body)
+	w := httptest.NewRecorder()
+
+	// Act
+	s.Router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(s.T(), http.StatusAccepted, w.Code)
+
+	dbEventType, err := postgres.NewEventTypeRepo(s.ConvoyApp.A.DB).FindEventTypeByID(context.Background(), s.DefaultProject.UID, eventType.UID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "v2", dbEventType.Version)
+	require.True(s.T(), dbEventType.Deprecated)
+	require.JSONEq(s.T(), `{"type": "object"}`, string(dbEventType.JSONSchema))
+}
+
+func (s *PublicEventTypeIntegrationTestSuite) Test_DeleteEventType() {
+	eventType := s.seedEventType("user.created", `{}`)
+
+	// Arrange Request
+	url := fmt.Sprintf("/api/v1/projects/%s/event-types/%s", s.DefaultProject.UID, eventType.UID)
+	req := createRequest(http.MethodDelete, url, s.APIKey, nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	s.Router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(s.T(), http.StatusOK, w.Code)
+
+	_, err := postgres.NewEventTypeRepo(s.ConvoyApp.A.DB).FindEventTypeByID(context.Background(), s.DefaultProject.UID, eventType.UID)
+	require.ErrorIs(s.T(), err, datastore.ErrEventTypeNotFound)
+}
+
+func (s *PublicEventTypeIntegrationTestSuite) Test_CreateEndpointEvent_SchemaValidation() {
+	endpointID := ulid.Make().String()
+	_, _ = testdb.SeedEndpoint(s.ConvoyApp.A.DB, s.DefaultProject, endpointID, "", "", false, datastore.ActiveEndpointStatus)
+	s.seedEventType("user.created", `{"type": "object", "properties": {"id": {"type": "string"}}, "required": ["id"]}`)
+
+	s.DefaultProject.Config.IsSchemaValidationEnabled = true
+	require.NoError(s.T(), postgres.NewProjectRepo(s.ConvoyApp.A.DB).UpdateProject(context.Background(), s.DefaultProject))
+
+	url := fmt.Sprintf("/api/v1/projects/%s/events", s.DefaultProject.UID)
+
+	// Act
+	body := serialize(`{"endpoint_id": "%s", "event_type": "user.created", "data": {"id": 1}}`, endpointID)
+	req := createRequest(http.MethodPost, url, s.APIKey, body)
+	w := httptest.NewRecorder()
+	s.Router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(s.T(), http.StatusBadRequest, w.Code)
+	require.Contains(s.T(), w.Body.String(), "/id: expected string, but got number")
+
+	// Act
+	body = serialize(`{"endpoint_id": "%s", "event_type": "user.created", "data": {"id": "usr_1"}}`, endpointID)
+	req = createRequest(http.MethodPost, url, s.APIKey, body)
+	w = httptest.NewRecorder()
+	s.Router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(s.T(), http.StatusCreated, w.Code)
+}
+
+func TestPublicEventTypeIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(PublicEventTypeIntegrationTestSuite))
+}
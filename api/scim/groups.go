@@ -0,0 +1,97 @@
+package scim
+
+import (
+	"net/http"
+
+	"github.com/frain-dev/convoy/pkg/scim"
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *SCIMHandler) GetGroups(w http.ResponseWriter, r *http.Request) {
+	filter, startIndex, count, err := parseListQuery(r)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	groups, err := h.service().ListGroups(r.Context(), getOrganisation(r), filter)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	resources := make([]interface{}, len(groups))
+	for i := range groups {
+		resources[i] = groups[i]
+	}
+
+	respond(w, http.StatusOK, scim.NewListResponse(resources, startIndex, count))
+}
+
+func (h *SCIMHandler) GetGroup(w http.ResponseWriter, r *http.Request) {
+	group, err := h.service().GetGroup(r.Context(), getOrganisation(r), chi.URLParam(r, "groupID"))
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	respond(w, http.StatusOK, group)
+}
+
+func (h *SCIMHandler) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	var newGroup scim.Group
+	if err := readBody(r, &newGroup); err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	group, err := h.service().CreateGroup(r.Context(), getOrganisation(r), &newGroup)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	respond(w, http.StatusCreated, group)
+}
+
+func (h *SCIMHandler) ReplaceGroup(w http.ResponseWriter, r *http.Request) {
+	var update scim.Group
+	if err := readBody(r, &update); err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	group, err := h.service().ReplaceGroup(r.Context(), getOrganisation(r), chi.URLParam(r, "groupID"), &update)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	respond(w, http.StatusOK, group)
+}
+
+func (h *SCIMHandler) PatchGroup(w http.ResponseWriter, r *http.Request) {
+	var req scim.PatchRequest
+	if err := readBody(r, &req); err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	group, err := h.service().PatchGroup(r.Context(), getOrganisation(r), chi.URLParam(r, "groupID"), &req)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	respond(w, http.StatusOK, group)
+}
+
+func (h *SCIMHandler) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	err := h.service().DeleteGroup(r.Context(), getOrganisation(r), chi.URLParam(r, "groupID"))
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,165 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/frain-dev/convoy/api/types"
+	"github.com/frain-dev/convoy/database/postgres"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/scim"
+	"github.com/frain-dev/convoy/services"
+	"github.com/go-chi/chi/v5"
+)
+
+const orgCtx types.ContextKey = "scimOrganisation"
+
+// SCIMHandler serves the SCIM 2.0 API identity providers use to provision
+// an organisation's users and groups, requests are authenticated with the
+// organisation's SCIM token.
+type SCIMHandler struct {
+	A *types.APIOptions
+}
+
+func NewSCIMHandler(a *types.APIOptions) *SCIMHandler {
+	return &SCIMHandler{A: a}
+}
+
+func (h *SCIMHandler) BuildRoutes() http.Handler {
+	router := chi.NewRouter()
+
+	router.Use(h.requireToken)
+
+	router.Get("/ServiceProviderConfig", h.GetServiceProviderConfig)
+	router.Get("/ResourceTypes", h.GetResourceTypes)
+
+	router.Route("/Users", func(userRouter chi.Router) {
+		userRouter.Get("/", h.GetUsers)
+		userRouter.Post("/", h.CreateUser)
+		userRouter.Get("/{userID}", h.GetUser)
+		userRouter.Put("/{userID}", h.ReplaceUser)
+		userRouter.Patch("/{userID}", h.PatchUser)
+		userRouter.Delete("/{userID}", h.DeleteUser)
+	})
+
+	router.Route("/Groups", func(groupRouter chi.Router) {
+		groupRouter.Get("/", h.GetGroups)
+		groupRouter.Post("/", h.CreateGroup)
+		groupRouter.Get("/{groupID}", h.GetGroup)
+		groupRouter.Put("/{groupID}", h.ReplaceGroup)
+		groupRouter.Patch("/{groupID}", h.PatchGroup)
+		groupRouter.Delete("/{groupID}", h.DeleteGroup)
+	})
+
+	return router
+}
+
+func (h *SCIMHandler) service() *services.SCIMService {
+	return services.NewSCIMService(
+		postgres.NewSCIMRepo(h.A.DB),
+		postgres.NewOrgRepo(h.A.DB),
+		postgres.NewOrgMemberRepo(h.A.DB),
+		postgres.NewUserRepo(h.A.DB),
+		postgres.NewProjectRepo(h.A.DB),
+	)
+}
+
+func (h *SCIMHandler) requireToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authInfo := strings.Split(r.Header.Get("Authorization"), " ")
+		if len(authInfo) != 2 || !strings.EqualFold(authInfo[0], "Bearer") {
+			respondError(w, r, scim.NewError(http.StatusUnauthorized, "", "invalid header structure"))
+			return
+		}
+
+		org, err := h.service().Authenticate(r.Context(), authInfo[1])
+		if err != nil {
+			respondError(w, r, scim.NewError(http.StatusUnauthorized, "", err.Error()))
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), orgCtx, org)))
+	})
+}
+
+func getOrganisation(r *http.Request) *datastore.Organisation {
+	return r.Context().Value(orgCtx).(*datastore.Organisation)
+}
+
+func (h *SCIMHandler) GetServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	respond(w, http.StatusOK, scim.NewServiceProviderConfig())
+}
+
+func (h *SCIMHandler) GetResourceTypes(w http.ResponseWriter, r *http.Request) {
+	resourceTypes := scim.ResourceTypes()
+
+	resources := make([]interface{}, len(resourceTypes))
+	for i := range resourceTypes {
+		resources[i] = resourceTypes[i]
+	}
+
+	respond(w, http.StatusOK, scim.NewListResponse(resources, 1, len(resources)))
+}
+
+// parseListQuery reads the filter, startIndex and count query parameters
+// of a list request.
+func parseListQuery(r *http.Request) (filter scim.Filter, startIndex int, count int, err error) {
+	q := r.URL.Query()
+
+	if f := q.Get("filter"); f != "" {
+		filter, err = scim.ParseFilter(f)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+	}
+
+	startIndex, count = 1, scim.MaxResults
+	if s := q.Get("startIndex"); s != "" {
+		startIndex, err = strconv.Atoi(s)
+		if err != nil {
+			return nil, 0, 0, scim.BadRequest(scim.ErrInvalidValue, "startIndex must be an integer")
+		}
+	}
+
+	if c := q.Get("count"); c != "" {
+		count, err = strconv.Atoi(c)
+		if err != nil {
+			return nil, 0, 0, scim.BadRequest(scim.ErrInvalidValue, "count must be an integer")
+		}
+	}
+
+	return filter, startIndex, count, nil
+}
+
+func readBody(r *http.Request, dst interface{}) error {
+	err := json.NewDecoder(r.Body).Decode(dst)
+	if err != nil {
+		return scim.BadRequest(scim.ErrInvalidSyntax, "request body is not valid JSON: %v", err)
+	}
+
+	return nil
+}
+
+func respond(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", scim.ContentType)
+	w.WriteHeader(status)
+
+	if v != nil {
+		_ = json.NewEncoder(w).Encode(v)
+	}
+}
+
+func respondError(w http.ResponseWriter, r *http.Request, err error) {
+	var scimErr *scim.Error
+	if !errors.As(err, &scimErr) {
+		log.FromContext(r.Context()).WithError(err).Error("scim request failed")
+		scimErr = scim.NewError(http.StatusInternalServerError, "", "internal server error")
+	}
+
+	respond(w, scimErr.StatusCode(), scimErr)
+}
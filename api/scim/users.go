@@ -0,0 +1,97 @@
+package scim
+
+import (
+	"net/http"
+
+	"github.com/frain-dev/convoy/pkg/scim"
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *SCIMHandler) GetUsers(w http.ResponseWriter, r *http.Request) {
+	filter, startIndex, count, err := parseListQuery(r)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	users, err := h.service().ListUsers(r.Context(), getOrganisation(r), filter)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	resources := make([]interface{}, len(users))
+	for i := range users {
+		resources[i] = users[i]
+	}
+
+	respond(w, http.StatusOK, scim.NewListResponse(resources, startIndex, count))
+}
+
+func (h *SCIMHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	user, err := h.service().GetUser(r.Context(), getOrganisation(r), chi.URLParam(r, "userID"))
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	respond(w, http.StatusOK, user)
+}
+
+func (h *SCIMHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	var newUser scim.User
+	if err := readBody(r, &newUser); err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	user, err := h.service().CreateUser(r.Context(), getOrganisation(r), &newUser)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	respond(w, http.StatusCreated, user)
+}
+
+func (h *SCIMHandler) ReplaceUser(w http.ResponseWriter, r *http.Request) {
+	var update scim.User
+	if err := readBody(r, &update); err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	user, err := h.service().ReplaceUser(r.Context(), getOrganisation(r), chi.URLParam(r, "userID"), &update)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	respond(w, http.StatusOK, user)
+}
+
+func (h *SCIMHandler) PatchUser(w http.ResponseWriter, r *http.Request) {
+	var req scim.PatchRequest
+	if err := readBody(r, &req); err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	user, err := h.service().PatchUser(r.Context(), getOrganisation(r), chi.URLParam(r, "userID"), &req)
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	respond(w, http.StatusOK, user)
+}
+
+func (h *SCIMHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	err := h.service().DeleteUser(r.Context(), getOrganisation(r), chi.URLParam(r, "userID"))
+	if err != nil {
+		respondError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,184 @@
+package circuitbreaker
+
+import (
+	"context"
+	"time"
+
+	"github.com/frain-dev/convoy/config"
+)
+
+type State string
+
+const (
+	StateClosed   State = "closed"
+	StateOpen     State = "open"
+	StateHalfOpen State = "half-open"
+)
+
+type CircuitBreaker interface {
+	// Allow reports whether a request for key may be sent. An open breaker
+	// whose error timeout has elapsed moves to half-open and lets exactly one
+	// probe through.
+	Allow(ctx context.Context, key string) (*Result, error)
+
+	// Record reports the outcome of a request that was allowed through.
+	Record(ctx context.Context, key string, success bool) (*Result, error)
+}
+
+// Result is the breaker's state after a call to Allow or Record.
+// PreviousState differs from State when the call caused a transition.
+type Result struct {
+	Allowed       bool
+	State         State
+	PreviousState State
+	FailureRate   float64
+
+	// RetryAfter is how long a short-circuited request should
+	// wait before it is attempted again.
+	RetryAfter time.Duration
+}
+
+func (r *Result) HasTransitioned() bool {
+	return r.State != r.PreviousState
+}
+
+type Config struct {
+	// FailureThreshold is the failure rate, in percent, at or above
+	// which the breaker trips.
+	FailureThreshold float64
+
+	// MinimumRequestCount is the number of requests that must be seen in an
+	// observation window before the failure rate is considered.
+	MinimumRequestCount uint64
+
+	ObservationWindow time.Duration
+
+	// ErrorTimeout is how long the breaker stays open before a probe is sent.
+	ErrorTimeout time.Duration
+}
+
+func NewConfig(c config.CircuitBreakerConfiguration) Config {
+	return Config{
+		FailureThreshold:    float64(c.FailureThreshold),
+		MinimumRequestCount: c.MinimumRequestCount,
+		ObservationWindow:   time.Duration(c.ObservationWindow) * time.Second,
+		ErrorTimeout:        time.Duration(c.ErrorTimeout) * time.Second,
+	}
+}
+
+func NewCircuitBreaker(redisCfg config.RedisConfiguration, c config.CircuitBreakerConfiguration) (CircuitBreaker, error) {
+	if !c.Enabled {
+		return NewNoopCircuitBreaker(), nil
+	}
+
+	return NewRedisCircuitBreaker(redisCfg.BuildDsn(), NewConfig(c))
+}
+
+// Breaker holds the persisted state of a single circuit breaker.
+type Breaker struct {
+	State       State     `json:"state"`
+	Requests    uint64    `json:"requests"`
+	Failures    uint64    `json:"failures"`
+	WindowStart time.Time `json:"window_start"`
+	OpenedAt    time.Time `json:"opened_at"`
+	ProbeSentAt time.Time `json:"probe_sent_at"`
+}
+
+func NewBreaker(now time.Time) *Breaker {
+	return &Breaker{State: StateClosed, WindowStart: now}
+}
+
+func (b *Breaker) FailureRate() float64 {
+	if b.Requests == 0 {
+		return 0
+	}
+
+	return float64(b.Failures) / float64(b.Requests) * 100
+}
+
+func (b *Breaker) Allow(c Config, now time.Time) *Result {
+	res := &Result{PreviousState: b.State}
+
+	switch b.State {
+	case StateOpen:
+		if now.Sub(b.OpenedAt) >= c.ErrorTimeout {
+			b.State = StateHalfOpen
+			b.ProbeSentAt = now
+			res.Allowed = true
+		} else {
+			res.RetryAfter = b.OpenedAt.Add(c.ErrorTimeout).Sub(now)
+		}
+	case StateHalfOpen:
+		// only one probe may be in flight, a probe that never reported
+		// back is considered lost after another error timeout.
+		if b.ProbeSentAt.IsZero() || now.Sub(b.ProbeSentAt) >= c.ErrorTimeout {
+			b.ProbeSentAt = now
+			res.Allowed = true
+		} else {
+			res.RetryAfter = b.ProbeSentAt.Add(c.ErrorTimeout).Sub(now)
+		}
+	default:
+		b.State = StateClosed
+		if now.Sub(b.WindowStart) >= c.ObservationWindow {
+			b.reset(now)
+		}
+		res.Allowed = true
+	}
+
+	res.State = b.State
+	res.FailureRate = b.FailureRate()
+	return res
+}
+
+func (b *Breaker) Record(c Config, success bool, now time.Time) *Result {
+	res := &Result{PreviousState: b.State, Allowed: true}
+
+	switch b.State {
+	case StateHalfOpen:
+		if success {
+			b.State = StateClosed
+			b.reset(now)
+		} else {
+			b.trip(now)
+		}
+	case StateOpen:
+		// a request that was let through before the breaker
+		// tripped has finished, there is nothing to update.
+	default:
+		if now.Sub(b.WindowStart) >= c.ObservationWindow {
+			b.reset(now)
+		}
+
+		b.Requests++
+		if !success {
+			b.Failures++
+		}
+
+		if b.Requests >= c.MinimumRequestCount && b.FailureRate() >= c.FailureThreshold {
+			b.trip(now)
+		}
+	}
+
+	res.State = b.State
+	res.FailureRate = b.FailureRate()
+	if b.State == StateOpen {
+		res.Allowed = false
+		res.RetryAfter = c.ErrorTimeout
+	}
+
+	return res
+}
+
+func (b *Breaker) trip(now time.Time) {
+	b.State = StateOpen
+	b.OpenedAt = now
+	b.ProbeSentAt = time.Time{}
+}
+
+func (b *Breaker) reset(now time.Time) {
+	b.Requests = 0
+	b.Failures = 0
+	b.WindowStart = now
+	b.OpenedAt = time.Time{}
+	b.ProbeSentAt = time.Time{}
+}
@@ -0,0 +1,118 @@
+package circuitbreaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = Config{
+	FailureThreshold:    50,
+	MinimumRequestCount: 4,
+	ObservationWindow:   time.Minute,
+	ErrorTimeout:        30 * time.Second,
+}
+
+func TestBreaker_TripsAtFailureThreshold(t *testing.T) {
+	now := time.Now()
+	b := NewBreaker(now)
+
+	outcomes := []bool{true, false, true}
+	for _, success := range outcomes {
+		require.True(t, b.Allow(testConfig, now).Allowed)
+		res := b.Record(testConfig, success, now)
+		require.Equal(t, StateClosed, res.State)
+	}
+
+	// the fourth request reaches the minimum request count at a 50% failure rate
+	res := b.Record(testConfig, false, now)
+	require.Equal(t, StateOpen, res.State)
+	require.Equal(t, StateClosed, res.PreviousState)
+	require.True(t, res.HasTransitioned())
+	require.Equal(t, float64(50), res.FailureRate)
+
+	res = b.Allow(testConfig, now.Add(10*time.Second))
+	require.False(t, res.Allowed)
+	require.Equal(t, 20*time.Second, res.RetryAfter)
+}
+
+func TestBreaker_DoesNotTripBelowMinimumRequests(t *testing.T) {
+	now := time.Now()
+	b := NewBreaker(now)
+
+	for i := 0; i < 3; i++ {
+		res := b.Record(testConfig, false, now)
+		require.Equal(t, StateClosed, res.State)
+	}
+}
+
+func TestBreaker_ResetsAfterObservationWindow(t *testing.T) {
+	now := time.Now()
+	b := NewBreaker(now)
+
+	for i := 0; i < 3; i++ {
+		b.Record(testConfig, false, now)
+	}
+
+	later := now.Add(testConfig.ObservationWindow)
+	res := b.Allow(testConfig, later)
+	require.True(t, res.Allowed)
+	require.Equal(t, uint64(0), b.Requests)
+
+	res = b.Record(testConfig, false, later)
+	require.Equal(t, StateClosed, res.State)
+}
+
+func TestBreaker_HalfOpen(t *testing.T) {
+	tests := []struct {
+		name          string
+		probeSuccess  bool
+		expectedState State
+	}{
+		{
+			name:          "successful probe closes the breaker",
+			probeSuccess:  true,
+			expectedState: StateClosed,
+		},
+		{
+			name:          "failed probe re-opens the breaker",
+			probeSuccess:  false,
+			expectedState: StateOpen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			b := NewBreaker(now)
+			b.trip(now)
+
+			later := now.Add(testConfig.ErrorTimeout)
+			res := b.Allow(testConfig, later)
+			require.True(t, res.Allowed)
+			require.Equal(t, StateHalfOpen, res.State)
+			require.True(t, res.HasTransitioned())
+
+			// only a single probe is let through
+			res = b.Allow(testConfig, later.Add(time.Second))
+			require.False(t, res.Allowed)
+			require.False(t, res.HasTransitioned())
+
+			res = b.Record(testConfig, tt.probeSuccess, later.Add(time.Second))
+			require.Equal(t, tt.expectedState, res.State)
+			require.Equal(t, StateHalfOpen, res.PreviousState)
+		})
+	}
+}
+
+func TestBreaker_LostProbeIsReplaced(t *testing.T) {
+	now := time.Now()
+	b := NewBreaker(now)
+	b.trip(now)
+
+	probeAt := now.Add(testConfig.ErrorTimeout)
+	require.True(t, b.Allow(testConfig, probeAt).Allowed)
+	require.False(t, b.Allow(testConfig, probeAt.Add(time.Second)).Allowed)
+	require.True(t, b.Allow(testConfig, probeAt.Add(testConfig.ErrorTimeout)).Allowed)
+}
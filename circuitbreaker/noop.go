@@ -0,0 +1,17 @@
+package circuitbreaker
+
+import "context"
+
+type NoopCircuitBreaker struct{}
+
+func NewNoopCircuitBreaker() *NoopCircuitBreaker {
+	return &NoopCircuitBreaker{}
+}
+
+func (n *NoopCircuitBreaker) Allow(ctx context.Context, key string) (*Result, error) {
+	return &Result{Allowed: true, State: StateClosed, PreviousState: StateClosed}, nil
+}
+
+func (n *NoopCircuitBreaker) Record(ctx context.Context, key string, success bool) (*Result, error) {
+	return &Result{Allowed: true, State: StateClosed, PreviousState: StateClosed}, nil
+}
@@ -0,0 +1,122 @@
+package circuitbreaker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frain-dev/convoy/internal/pkg/rdb"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	keyPrefix = "convoy:circuit_breaker"
+
+	// maxTxRetries is the number of times a state update is retried
+	// when another worker modifies the same breaker concurrently.
+	maxTxRetries = 10
+)
+
+var ErrTooManyConflicts = errors.New("circuit breaker: too many concurrent updates")
+
+// RedisCircuitBreaker stores breaker state in redis so that
+// every worker shares the same view of an endpoint.
+type RedisCircuitBreaker struct {
+	client *redis.Client
+	config Config
+}
+
+func NewRedisCircuitBreaker(dsn string, c Config) (*RedisCircuitBreaker, error) {
+	client, err := rdb.NewClient(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RedisCircuitBreaker{client: client.Client(), config: c}, nil
+}
+
+func (r *RedisCircuitBreaker) Allow(ctx context.Context, key string) (*Result, error) {
+	return r.update(ctx, key, func(b *Breaker, now time.Time) *Result {
+		return b.Allow(r.config, now)
+	})
+}
+
+func (r *RedisCircuitBreaker) Record(ctx context.Context, key string, success bool) (*Result, error) {
+	return r.update(ctx, key, func(b *Breaker, now time.Time) *Result {
+		return b.Record(r.config, success, now)
+	})
+}
+
+func (r *RedisCircuitBreaker) update(ctx context.Context, key string, fn func(*Breaker, time.Time) *Result) (*Result, error) {
+	k := fmt.Sprintf("%s:%s", keyPrefix, key)
+
+	var res *Result
+	txf := func(tx *redis.Tx) error {
+		now := time.Now()
+
+		b, err := r.load(ctx, tx, k, now)
+		if err != nil {
+			return err
+		}
+
+		res = fn(b, now)
+
+		data, err := json.Marshal(b)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, k, data, r.ttl())
+			return nil
+		})
+		return err
+	}
+
+	for i := 0; i < maxTxRetries; i++ {
+		err := r.client.Watch(ctx, txf, k)
+		if err == nil {
+			return res, nil
+		}
+
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+
+		return nil, err
+	}
+
+	return nil, ErrTooManyConflicts
+}
+
+func (r *RedisCircuitBreaker) load(ctx context.Context, tx *redis.Tx, key string, now time.Time) (*Breaker, error) {
+	data, err := tx.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return NewBreaker(now), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var b Breaker
+	err = json.Unmarshal(data, &b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+// ttl keeps idle breakers from piling up in redis; a breaker that has not been
+// touched for a few windows carries no information worth keeping.
+func (r *RedisCircuitBreaker) ttl() time.Duration {
+	d := r.config.ObservationWindow
+	if r.config.ErrorTimeout > d {
+		d = r.config.ErrorTimeout
+	}
+
+	return 3 * d
+}
@@ -0,0 +1,84 @@
+//go:build integration
+// +build integration
+
+package circuitbreaker
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func getDSN() string {
+	port, _ := strconv.Atoi(os.Getenv("TEST_REDIS_PORT"))
+	c := config.RedisConfiguration{
+		Scheme: "redis",
+		Host:   os.Getenv("TEST_REDIS_HOST"),
+		Port:   port,
+	}
+	return c.BuildDsn()
+}
+
+func flushRedis(dsn string) error {
+	opts, err := redis.ParseURL(dsn)
+	if err != nil {
+		return err
+	}
+
+	client := redis.NewClient(opts)
+
+	_, err = client.FlushAll(context.Background()).Result()
+
+	return err
+}
+
+func Test_RedisCircuitBreaker(t *testing.T) {
+	dsn := getDSN()
+	require.NoError(t, flushRedis(dsn))
+
+	cb, err := NewRedisCircuitBreaker(dsn, Config{
+		FailureThreshold:    50,
+		MinimumRequestCount: 2,
+		ObservationWindow:   time.Minute,
+		ErrorTimeout:        time.Second,
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		res, err := cb.Allow(ctx, "endpoint-1")
+		require.NoError(t, err)
+		require.True(t, res.Allowed)
+
+		_, err = cb.Record(ctx, "endpoint-1", false)
+		require.NoError(t, err)
+	}
+
+	res, err := cb.Allow(ctx, "endpoint-1")
+	require.NoError(t, err)
+	require.False(t, res.Allowed)
+	require.Equal(t, StateOpen, res.State)
+
+	// other endpoints are unaffected
+	res, err = cb.Allow(ctx, "endpoint-2")
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+
+	time.Sleep(time.Second)
+
+	res, err = cb.Allow(ctx, "endpoint-1")
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+	require.Equal(t, StateHalfOpen, res.State)
+
+	res, err = cb.Record(ctx, "endpoint-1", true)
+	require.NoError(t, err)
+	require.Equal(t, StateClosed, res.State)
+}
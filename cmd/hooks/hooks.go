@@ -20,6 +20,7 @@ import (
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/pkg/apm"
 	"github.com/frain-dev/convoy/internal/pkg/cli"
+	"github.com/frain-dev/convoy/internal/pkg/payload"
 	"github.com/frain-dev/convoy/internal/pkg/rdb"
 	"github.com/frain-dev/convoy/pkg/log"
 	redisQueue "github.com/frain-dev/convoy/queue/redis"
@@ -116,17 +117,42 @@ func PreRun(app *cli.App, db *postgres.Postgres) func(cmd *cobra.Command, args [
 
 		*db = *postgresDB
 
+		hooks := dbhook.Init()
+
 		projectRepo := postgres.NewProjectRepo(postgresDB)
 		metaEventRepo := postgres.NewMetaEventRepo(postgresDB)
 		endpointListener := listener.NewEndpointListener(q, projectRepo, metaEventRepo)
 		eventDeliveryListener := listener.NewEventDeliveryListener(q, projectRepo, metaEventRepo)
-
-		hooks := dbhook.Init()
-		hooks.RegisterHook(datastore.EndpointCreated, endpointListener.AfterCreate)
-		hooks.RegisterHook(datastore.EndpointUpdated, endpointListener.AfterUpdate)
-		hooks.RegisterHook(datastore.EndpointDeleted, endpointListener.AfterDelete)
+		cacheListener := listener.NewCacheListener(ca)
+
+		hooks.RegisterBackgroundHook(datastore.EndpointCreated, endpointListener.AfterCreate)
+		hooks.RegisterBackgroundHook(datastore.EndpointUpdated, endpointListener.AfterUpdate)
+		hooks.RegisterBackgroundHook(datastore.EndpointDeleted, endpointListener.AfterDelete)
+		hooks.RegisterHook(datastore.EndpointCircuitBreakerOpened, endpointListener.AfterCircuitBreakerOpened)
+		hooks.RegisterHook(datastore.EndpointCircuitBreakerHalfOpen, endpointListener.AfterCircuitBreakerHalfOpen)
+		hooks.RegisterHook(datastore.EndpointCircuitBreakerClosed, endpointListener.AfterCircuitBreakerClosed)
 		hooks.RegisterHook(datastore.EventDeliveryUpdated, eventDeliveryListener.AfterUpdate)
 
+		// project, endpoint and subscription writes fire their hooks before
+		// returning, so cached entries are gone by the time a write is
+		// acknowledged, while meta events are still sent in the background
+		hooks.RegisterHook(datastore.ProjectUpdated, cacheListener.AfterProjectChange)
+		hooks.RegisterHook(datastore.ProjectDeleted, cacheListener.AfterProjectChange)
+		hooks.RegisterHook(datastore.EndpointUpdated, cacheListener.AfterEndpointChange)
+		hooks.RegisterHook(datastore.EndpointDeleted, cacheListener.AfterEndpointChange)
+		hooks.RegisterHook(datastore.EndpointCircuitBreakerOpened, cacheListener.AfterCircuitBreakerChange)
+		hooks.RegisterHook(datastore.EndpointCircuitBreakerHalfOpen, cacheListener.AfterCircuitBreakerChange)
+		hooks.RegisterHook(datastore.EndpointCircuitBreakerClosed, cacheListener.AfterCircuitBreakerChange)
+		hooks.RegisterHook(datastore.SubscriptionCreated, cacheListener.AfterSubscriptionChange)
+		hooks.RegisterHook(datastore.SubscriptionUpdated, cacheListener.AfterSubscriptionChange)
+		hooks.RegisterHook(datastore.SubscriptionDeleted, cacheListener.AfterSubscriptionChange)
+
+		payloadStore, err := payload.New(cfg)
+		if err != nil {
+			return err
+		}
+		payload.Init(payloadStore)
+
 		if ok := shouldCheckMigration(cmd); ok {
 			err = checkPendingMigrations(db)
 			if err != nil {
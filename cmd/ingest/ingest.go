@@ -6,11 +6,13 @@ import (
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/database/postgres"
 	"github.com/frain-dev/convoy/internal/pkg/cli"
+	"github.com/frain-dev/convoy/internal/pkg/metrics"
 	"github.com/frain-dev/convoy/internal/pkg/pubsub"
 	"github.com/frain-dev/convoy/internal/pkg/server"
 	"github.com/frain-dev/convoy/pkg/log"
 	"github.com/frain-dev/convoy/util"
 	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 )
 
@@ -45,6 +47,8 @@ func AddIngestCommand(a *cli.App) *cobra.Command {
 			sourceRepo := postgres.NewSourceRepo(a.DB)
 			projectRepo := postgres.NewProjectRepo(a.DB)
 			endpointRepo := postgres.NewEndpointRepo(a.DB)
+			eventRepo := postgres.NewEventRepo(a.DB)
+			sourceDeadLetterRepo := postgres.NewSourceDeadLetterRepo(a.DB)
 
 			lo := a.Logger.(*log.Logger)
 			lo.SetPrefix("ingester")
@@ -57,13 +61,17 @@ func AddIngestCommand(a *cli.App) *cobra.Command {
 			lo.SetLevel(lvl)
 
 			sourcePool := pubsub.NewSourcePool(lo)
-			sourceLoader := pubsub.NewSourceLoader(endpointRepo, sourceRepo, projectRepo, a.Queue, sourcePool, lo)
+			sourceLoader := pubsub.NewSourceLoader(endpointRepo, eventRepo, sourceDeadLetterRepo, sourceRepo, projectRepo, a.Queue, sourcePool, lo)
 
 			stop := make(chan struct{})
 			go sourceLoader.Run(context.Background(), interval, stop)
 
 			srv := server.NewServer(cfg.Server.HTTP.Port, func() { stop <- struct{}{} })
-			srv.SetHandler(chi.NewMux())
+			metrics.RegisterPubSubMetrics()
+
+			router := chi.NewRouter()
+			router.Handle("/metrics", promhttp.HandlerFor(metrics.Reg(), promhttp.HandlerOpts{}))
+			srv.SetHandler(router)
 
 			srv.Listen()
 
@@ -9,12 +9,15 @@ import (
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/analytics"
+	"github.com/frain-dev/convoy/circuitbreaker"
 	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/database/cached"
 	"github.com/frain-dev/convoy/database/postgres"
 	"github.com/frain-dev/convoy/internal/pkg/cli"
 	"github.com/frain-dev/convoy/internal/pkg/metrics"
 	"github.com/frain-dev/convoy/internal/pkg/searcher"
 	"github.com/frain-dev/convoy/internal/pkg/smtp"
+	"github.com/frain-dev/convoy/limiter"
 	"github.com/frain-dev/convoy/pkg/log"
 	"github.com/frain-dev/convoy/util"
 	"github.com/frain-dev/convoy/worker"
@@ -81,6 +84,8 @@ func AddWorkerCommand(a *cli.App) *cobra.Command {
 			subRepo := postgres.NewSubscriptionRepo(a.DB)
 			deviceRepo := postgres.NewDeviceRepo(a.DB)
 			configRepo := postgres.NewConfigRepo(a.DB)
+			deadLetterRepo := postgres.NewDeadLetterRepo(a.DB)
+			signingKeyRepo := postgres.NewSigningKeyRepo(a.DB)
 			searchBackend, err := searcher.NewSearchClient(cfg)
 			if err != nil {
 				a.Logger.Debug("Failed to initialise search backend")
@@ -91,21 +96,57 @@ func AddWorkerCommand(a *cli.App) *cobra.Command {
 				return err
 			}
 
+			breaker, err := circuitbreaker.NewCircuitBreaker(cfg.Redis, cfg.CircuitBreaker)
+			if err != nil {
+				a.Logger.WithError(err).Error("Failed to initialise circuit breaker")
+				return err
+			}
+
+			rateLimiter, err := limiter.NewLimiter(cfg.Redis)
+			if err != nil {
+				a.Logger.WithError(err).Error("Failed to initialise rate limiter")
+				return err
+			}
+
 			consumer.RegisterHandlers(convoy.EventProcessor, task.ProcessEventDelivery(
 				endpointRepo,
 				eventDeliveryRepo,
 				projectRepo,
 				subRepo,
+				deadLetterRepo,
+				signingKeyRepo,
+				a.Cache,
+				breaker,
+				rateLimiter,
 				a.Queue))
 
-			consumer.RegisterHandlers(convoy.CreateEventProcessor, task.ProcessEventCreation(
+			consumer.RegisterHandlers(convoy.FlushEventBatchProcessor, task.FlushEventBatch(
+				eventDeliveryRepo,
+				subRepo,
+				a.Queue))
+
+			consumer.RegisterHandlers(convoy.BatchEventProcessor, task.ProcessEventBatch(
 				endpointRepo,
-				eventRepo,
-				projectRepo,
 				eventDeliveryRepo,
+				projectRepo,
+				deadLetterRepo,
+				subRepo,
+				signingKeyRepo,
 				a.Cache,
+				breaker,
+				rateLimiter,
+				a.Queue))
+
+			// event creation reads the same projects, endpoints and
+			// subscriptions for every event, so it reads them through the
+			// cache, which the API invalidates when they change
+			consumer.RegisterHandlers(convoy.CreateEventProcessor, task.ProcessEventCreation(
+				cached.NewEndpointRepo(endpointRepo, a.Cache, cached.DefaultTTL),
+				eventRepo,
+				cached.NewProjectRepo(projectRepo, a.Cache, cached.DefaultTTL),
+				eventDeliveryRepo,
 				a.Queue,
-				subRepo,
+				cached.NewSubscriptionRepo(subRepo, a.Cache, cached.DefaultTTL),
 				deviceRepo))
 
 			consumer.RegisterHandlers(convoy.CreateDynamicEventProcessor, task.ProcessDynamicEventCreation(
@@ -4,12 +4,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"reflect"
 	"strings"
 	"sync/atomic"
 
 	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/pkg/compress"
 	"github.com/frain-dev/convoy/pkg/log"
 	"github.com/kelseyhightower/envconfig"
 )
@@ -62,6 +64,13 @@ var DefaultConfiguration = Configuration{
 			Path: convoy.DefaultOnPremDir,
 		},
 	},
+	CircuitBreaker: CircuitBreakerConfiguration{
+		Enabled:             false,
+		FailureThreshold:    50,
+		MinimumRequestCount: 10,
+		ObservationWindow:   60,
+		ErrorTimeout:        30,
+	},
 	Auth: AuthConfiguration{
 		IsSignupEnabled: true,
 		Native: NativeRealmOptions{
@@ -250,6 +259,47 @@ type OnPremStorage struct {
 	Path string `json:"path" envconfig:"CONVOY_STORAGE_PREM_PATH"`
 }
 
+type CircuitBreakerConfiguration struct {
+	Enabled bool `json:"enabled" envconfig:"CONVOY_CIRCUIT_BREAKER_ENABLED"`
+
+	// FailureThreshold is the failure rate in percent that trips an endpoint's breaker
+	FailureThreshold uint64 `json:"failure_threshold" envconfig:"CONVOY_CIRCUIT_BREAKER_FAILURE_THRESHOLD"`
+
+	// MinimumRequestCount is the number of deliveries required in a window before it can trip
+	MinimumRequestCount uint64 `json:"minimum_request_count" envconfig:"CONVOY_CIRCUIT_BREAKER_MINIMUM_REQUEST_COUNT"`
+
+	// ObservationWindow is the length in seconds of the window failures are counted in
+	ObservationWindow uint64 `json:"observation_window" envconfig:"CONVOY_CIRCUIT_BREAKER_OBSERVATION_WINDOW"`
+
+	// ErrorTimeout is the time in seconds a tripped breaker stays open before a probe is sent
+	ErrorTimeout uint64 `json:"error_timeout" envconfig:"CONVOY_CIRCUIT_BREAKER_ERROR_TIMEOUT"`
+}
+
+// EgressConfiguration restricts the hosts deliveries can be sent to.
+// Loopback, private and link-local addresses are blocked by default.
+type EgressConfiguration struct {
+	AllowPrivateNetworks bool `json:"allow_private_networks" envconfig:"CONVOY_EGRESS_ALLOW_PRIVATE_NETWORKS"`
+
+	// AllowList holds CIDRs, IPs or hostnames exempted from the private network block
+	AllowList []string `json:"allow_list" envconfig:"CONVOY_EGRESS_ALLOW_LIST"`
+
+	// DenyList holds CIDRs, IPs or hostnames deliveries are never sent to
+	DenyList []string `json:"deny_list" envconfig:"CONVOY_EGRESS_DENY_LIST"`
+}
+
+// PayloadStorageConfiguration sets how event payloads are stored. Both
+// thresholds are in bytes, payloads at or below them are kept as they are.
+type PayloadStorageConfiguration struct {
+	// Compression is the encoding stored payloads are compressed with,
+	// gzip or zstd, payloads aren't compressed when it's empty
+	Compression          string `json:"compression" envconfig:"CONVOY_PAYLOAD_COMPRESSION"`
+	CompressionThreshold int    `json:"compression_threshold" envconfig:"CONVOY_PAYLOAD_COMPRESSION_THRESHOLD"`
+
+	// OffloadThreshold moves larger payloads out of postgres and into the
+	// storage policy's object store, 0 keeps every payload in postgres
+	OffloadThreshold int `json:"offload_threshold" envconfig:"CONVOY_PAYLOAD_OFFLOAD_THRESHOLD"`
+}
+
 const (
 	envPrefix      string = "convoy"
 	OSSEnvironment string = "oss"
@@ -280,22 +330,29 @@ func (s SignatureHeaderProvider) String() string {
 }
 
 type Configuration struct {
-	Auth               AuthConfiguration          `json:"auth,omitempty"`
-	Database           DatabaseConfiguration      `json:"database"`
-	Redis              RedisConfiguration         `json:"redis"`
-	Prometheus         PrometheusConfiguration    `json:"prometheus"`
-	Server             ServerConfiguration        `json:"server"`
-	MaxResponseSize    uint64                     `json:"max_response_size" envconfig:"CONVOY_MAX_RESPONSE_SIZE"`
-	SMTP               SMTPConfiguration          `json:"smtp"`
-	Environment        string                     `json:"env" envconfig:"CONVOY_ENV"`
-	Logger             LoggerConfiguration        `json:"logger"`
-	Tracer             TracerConfiguration        `json:"tracer"`
-	Host               string                     `json:"host" envconfig:"CONVOY_HOST"`
-	CustomDomainSuffix string                     `json:"custom_domain_suffix" envconfig:"CONVOY_CUSTOM_DOMAIN_SUFFIX"`
-	Search             SearchConfiguration        `json:"search"`
-	FeatureFlag        FeatureFlagConfiguration   `json:"feature_flag"`
-	Analytics          AnalyticsConfiguration     `json:"analytics"`
-	StoragePolicy      StoragePolicyConfiguration `json:"storage_policy"`
+	Auth               AuthConfiguration           `json:"auth,omitempty"`
+	Database           DatabaseConfiguration       `json:"database"`
+	Redis              RedisConfiguration          `json:"redis"`
+	Prometheus         PrometheusConfiguration     `json:"prometheus"`
+	Server             ServerConfiguration         `json:"server"`
+	MaxResponseSize    uint64                      `json:"max_response_size" envconfig:"CONVOY_MAX_RESPONSE_SIZE"`
+	SMTP               SMTPConfiguration           `json:"smtp"`
+	Environment        string                      `json:"env" envconfig:"CONVOY_ENV"`
+	Logger             LoggerConfiguration         `json:"logger"`
+	Tracer             TracerConfiguration         `json:"tracer"`
+	Host               string                      `json:"host" envconfig:"CONVOY_HOST"`
+	CustomDomainSuffix string                      `json:"custom_domain_suffix" envconfig:"CONVOY_CUSTOM_DOMAIN_SUFFIX"`
+	Search             SearchConfiguration         `json:"search"`
+	FeatureFlag        FeatureFlagConfiguration    `json:"feature_flag"`
+	Analytics          AnalyticsConfiguration      `json:"analytics"`
+	StoragePolicy      StoragePolicyConfiguration  `json:"storage_policy"`
+	CircuitBreaker     CircuitBreakerConfiguration `json:"circuit_breaker"`
+	Egress             EgressConfiguration         `json:"egress"`
+	PayloadStorage     PayloadStorageConfiguration `json:"payload_storage"`
+
+	// EncryptionKey encrypts secrets stored at rest, e.g. the private
+	// halves of project signing keys
+	EncryptionKey string `json:"encryption_key" envconfig:"CONVOY_ENCRYPTION_KEY"`
 }
 
 // Get fetches the application configuration. LoadConfig must have been called
@@ -387,6 +444,54 @@ func LoadConfig(p string) error {
 	return nil
 }
 
+func ensureCircuitBreaker(c CircuitBreakerConfiguration) error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.FailureThreshold == 0 || c.FailureThreshold > 100 {
+		return errors.New("circuit breaker failure_threshold must be between 1 and 100")
+	}
+
+	if c.ObservationWindow == 0 {
+		return errors.New("circuit breaker observation_window cannot be zero")
+	}
+
+	if c.ErrorTimeout == 0 {
+		return errors.New("circuit breaker error_timeout cannot be zero")
+	}
+
+	return nil
+}
+
+func ensureEgress(c EgressConfiguration) error {
+	for _, list := range [][]string{c.AllowList, c.DenyList} {
+		for _, entry := range list {
+			if !strings.Contains(entry, "/") {
+				continue
+			}
+
+			if _, _, err := net.ParseCIDR(strings.TrimSpace(entry)); err != nil {
+				return fmt.Errorf("invalid egress cidr %s: %v", entry, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func ensurePayloadStorage(c PayloadStorageConfiguration) error {
+	if !compress.Encoding(c.Compression).IsValid() {
+		return fmt.Errorf("unsupported payload compression %s", c.Compression)
+	}
+
+	if c.CompressionThreshold < 0 || c.OffloadThreshold < 0 {
+		return errors.New("payload storage thresholds cannot be negative")
+	}
+
+	return nil
+}
+
 func ensureSSL(s ServerConfiguration) error {
 	if s.HTTP.SSL {
 		if s.HTTP.SSLCertFile == "" || s.HTTP.SSLKeyFile == "" {
@@ -425,5 +530,17 @@ func validate(c *Configuration) error {
 		return err
 	}
 
+	if err := ensureCircuitBreaker(c.CircuitBreaker); err != nil {
+		return err
+	}
+
+	if err := ensureEgress(c.Egress); err != nil {
+		return err
+	}
+
+	if err := ensurePayloadStorage(c.PayloadStorage); err != nil {
+		return err
+	}
+
 	return nil
 }
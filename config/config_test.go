@@ -117,7 +117,8 @@ func TestLoadConfig(t *testing.T) {
 					Host:   "localhost",
 					Port:   8379,
 				},
-				Search: DefaultConfiguration.Search,
+				Search:         DefaultConfiguration.Search,
+				CircuitBreaker: DefaultConfiguration.CircuitBreaker,
 				Server: ServerConfiguration{
 					HTTP: HTTPServerConfiguration{
 						Port:       80,
@@ -174,7 +175,8 @@ func TestLoadConfig(t *testing.T) {
 					Host:   "localhost",
 					Port:   8379,
 				},
-				Search: DefaultConfiguration.Search,
+				Search:         DefaultConfiguration.Search,
+				CircuitBreaker: DefaultConfiguration.CircuitBreaker,
 				Server: ServerConfiguration{
 					HTTP: HTTPServerConfiguration{
 						Port:       80,
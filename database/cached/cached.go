@@ -0,0 +1,85 @@
+// Package cached provides read-through caches over the repositories the
+// event creation path reads for every event. Entries are removed by the
+// cache listener in database/listener, which the repositories run before
+// a write returns, and expire after their TTL in case a change never
+// fires a hook.
+package cached
+
+import (
+	"context"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/datastore"
+)
+
+const (
+	// DefaultTTL bounds how long a change that didn't fire a hook, e.g. a
+	// hook that failed to reach the cache, can go unseen.
+	DefaultTTL = time.Minute
+
+	// versionTTL only has to outlive the entries cached under a version.
+	versionTTL = 24 * time.Hour
+)
+
+func projectKey(id string) string {
+	return convoy.ProjectsCacheKey.Get(id).String()
+}
+
+func endpointKey(id string) string {
+	return convoy.EndpointsCacheKey.Get(id).String()
+}
+
+// subscriptionsVersion is cached as a struct since the cache stores plain
+// strings without encoding them.
+type subscriptionsVersion struct {
+	Version string
+}
+
+func subscriptionsVersionKey(projectID string) string {
+	return convoy.SubscriptionsCacheKey.Get(projectID).Get("version").String()
+}
+
+// subscriptionsKey is where a list of a project's subscriptions is cached.
+// The key includes the project's subscriptions version, so changing the
+// version drops every list at once, including lists a changed subscription
+// was moved out of.
+func subscriptionsKey(ctx context.Context, c cache.Cache, projectID, kind, id string) (string, error) {
+	var v *subscriptionsVersion
+	err := c.Get(ctx, subscriptionsVersionKey(projectID), &v)
+	if err != nil {
+		return "", err
+	}
+
+	var version string
+	if v != nil {
+		version = v.Version
+	}
+
+	return convoy.SubscriptionsCacheKey.Get(projectID).Get(version).Get(kind).Get(id).String(), nil
+}
+
+// InvalidateProject removes the cached project.
+func InvalidateProject(ctx context.Context, c cache.Cache, projectID string) error {
+	return c.Delete(ctx, projectKey(projectID))
+}
+
+// InvalidateEndpoint removes the cached endpoint along with the cached
+// subscriptions of its project, which are deleted with it.
+func InvalidateEndpoint(ctx context.Context, c cache.Cache, endpoint *datastore.Endpoint) error {
+	err := c.Delete(ctx, endpointKey(endpoint.UID))
+	if err != nil {
+		return err
+	}
+
+	return InvalidateSubscriptions(ctx, c, endpoint.ProjectID)
+}
+
+// InvalidateSubscriptions removes every cached list of the project's
+// subscriptions.
+func InvalidateSubscriptions(ctx context.Context, c cache.Cache, projectID string) error {
+	return c.Set(ctx, subscriptionsVersionKey(projectID), &subscriptionsVersion{Version: ulid.Make().String()}, versionTTL)
+}
@@ -0,0 +1,124 @@
+package cached
+
+import (
+	"context"
+	"testing"
+
+	mcache "github.com/frain-dev/convoy/cache/memory"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectRepo_FetchProjectByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	c := mcache.NewMemoryCache()
+
+	repo := mocks.NewMockProjectRepository(ctrl)
+	repo.EXPECT().FetchProjectByID(gomock.Any(), "project-1").Times(2).Return(&datastore.Project{UID: "project-1", Name: "test"}, nil)
+
+	projectRepo := NewProjectRepo(repo, c, DefaultTTL)
+
+	for i := 0; i < 2; i++ {
+		project, err := projectRepo.FetchProjectByID(ctx, "project-1")
+		require.NoError(t, err)
+		require.Equal(t, "test", project.Name)
+	}
+
+	require.NoError(t, InvalidateProject(ctx, c, "project-1"))
+
+	_, err := projectRepo.FetchProjectByID(ctx, "project-1")
+	require.NoError(t, err)
+}
+
+func TestEndpointRepo_FindEndpointByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	c := mcache.NewMemoryCache()
+
+	endpoint := &datastore.Endpoint{UID: "endpoint-1", ProjectID: "project-1", Title: "test"}
+
+	repo := mocks.NewMockEndpointRepository(ctrl)
+	repo.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-1", "project-1").Times(2).Return(endpoint, nil)
+	repo.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-2", "project-1").Times(1).Return(nil, datastore.ErrEndpointNotFound)
+
+	endpointRepo := NewEndpointRepo(repo, c, DefaultTTL)
+
+	for i := 0; i < 2; i++ {
+		e, err := endpointRepo.FindEndpointByID(ctx, "endpoint-1", "project-1")
+		require.NoError(t, err)
+		require.Equal(t, "test", e.Title)
+	}
+
+	_, err := endpointRepo.FindEndpointByID(ctx, "endpoint-1", "project-2")
+	require.ErrorIs(t, err, datastore.ErrEndpointNotFound)
+
+	// errors aren't cached
+	_, err = endpointRepo.FindEndpointByID(ctx, "endpoint-2", "project-1")
+	require.ErrorIs(t, err, datastore.ErrEndpointNotFound)
+
+	require.NoError(t, InvalidateEndpoint(ctx, c, endpoint))
+
+	_, err = endpointRepo.FindEndpointByID(ctx, "endpoint-1", "project-1")
+	require.NoError(t, err)
+}
+
+func TestSubscriptionRepo_FindSubscriptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	c := mcache.NewMemoryCache()
+
+	subscription := datastore.Subscription{
+		UID:        "sub-1",
+		ProjectID:  "project-1",
+		EndpointID: "endpoint-1",
+		SourceID:   "source-1",
+		FilterConfig: &datastore.FilterConfiguration{
+			EventTypes: []string{"invoice.*"},
+			Filter: datastore.FilterSchema{
+				Headers: datastore.M{},
+				Body:    datastore.M{"amount": map[string]interface{}{"$gte": float64(10)}},
+			},
+		},
+	}
+
+	repo := mocks.NewMockSubscriptionRepository(ctrl)
+	repo.EXPECT().FindSubscriptionsByEndpointID(gomock.Any(), "project-1", "endpoint-1").Times(2).Return([]datastore.Subscription{subscription}, nil)
+	repo.EXPECT().FindSubscriptionsBySourceID(gomock.Any(), "project-1", "source-1").Times(2).Return([]datastore.Subscription{subscription}, nil)
+	repo.EXPECT().FindSubscriptionsByEndpointID(gomock.Any(), "project-1", "endpoint-2").Times(1).Return([]datastore.Subscription{}, nil)
+
+	subRepo := NewSubscriptionRepo(repo, c, DefaultTTL)
+
+	for i := 0; i < 2; i++ {
+		subs, err := subRepo.FindSubscriptionsByEndpointID(ctx, "project-1", "endpoint-1")
+		require.NoError(t, err)
+		require.Len(t, subs, 1)
+		require.Equal(t, subscription.FilterConfig.Filter.Body, subs[0].FilterConfig.Filter.Body)
+		require.Equal(t, subscription.FilterConfig.EventTypes, subs[0].FilterConfig.EventTypes)
+
+		subs, err = subRepo.FindSubscriptionsBySourceID(ctx, "project-1", "source-1")
+		require.NoError(t, err)
+		require.Len(t, subs, 1)
+
+		// an endpoint without subscriptions is cached too
+		subs, err = subRepo.FindSubscriptionsByEndpointID(ctx, "project-1", "endpoint-2")
+		require.NoError(t, err)
+		require.Empty(t, subs)
+	}
+
+	require.NoError(t, InvalidateSubscriptions(ctx, c, "project-1"))
+
+	_, err := subRepo.FindSubscriptionsByEndpointID(ctx, "project-1", "endpoint-1")
+	require.NoError(t, err)
+
+	_, err = subRepo.FindSubscriptionsBySourceID(ctx, "project-1", "source-1")
+	require.NoError(t, err)
+}
@@ -0,0 +1,53 @@
+package cached
+
+import (
+	"context"
+	"time"
+
+	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/datastore"
+)
+
+type endpointRepo struct {
+	datastore.EndpointRepository
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewEndpointRepo caches the endpoints repo finds by id, every other
+// method goes straight to repo.
+func NewEndpointRepo(repo datastore.EndpointRepository, c cache.Cache, ttl time.Duration) datastore.EndpointRepository {
+	return &endpointRepo{EndpointRepository: repo, cache: c, ttl: ttl}
+}
+
+func (e *endpointRepo) FindEndpointByID(ctx context.Context, id string, projectID string) (*datastore.Endpoint, error) {
+	key := endpointKey(id)
+
+	var endpoint *datastore.Endpoint
+	err := e.cache.Get(ctx, key, &endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint != nil {
+		// endpoints are cached by id alone, so one from another project
+		// is treated as missing just like the query would
+		if endpoint.ProjectID != projectID {
+			return nil, datastore.ErrEndpointNotFound
+		}
+
+		return endpoint, nil
+	}
+
+	endpoint, err = e.EndpointRepository.FindEndpointByID(ctx, id, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = e.cache.Set(ctx, key, endpoint, e.ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint, nil
+}
@@ -0,0 +1,47 @@
+package cached
+
+import (
+	"context"
+	"time"
+
+	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/datastore"
+)
+
+type projectRepo struct {
+	datastore.ProjectRepository
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewProjectRepo caches the projects repo fetches by id, every other
+// method goes straight to repo.
+func NewProjectRepo(repo datastore.ProjectRepository, c cache.Cache, ttl time.Duration) datastore.ProjectRepository {
+	return &projectRepo{ProjectRepository: repo, cache: c, ttl: ttl}
+}
+
+func (p *projectRepo) FetchProjectByID(ctx context.Context, id string) (*datastore.Project, error) {
+	key := projectKey(id)
+
+	var project *datastore.Project
+	err := p.cache.Get(ctx, key, &project)
+	if err != nil {
+		return nil, err
+	}
+
+	if project != nil {
+		return project, nil
+	}
+
+	project, err = p.ProjectRepository.FetchProjectByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.cache.Set(ctx, key, project, p.ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
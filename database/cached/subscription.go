@@ -0,0 +1,68 @@
+package cached
+
+import (
+	"context"
+	"time"
+
+	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/datastore"
+)
+
+type subscriptionRepo struct {
+	datastore.SubscriptionRepository
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// subscriptions wraps a list of subscriptions so an endpoint or source
+// without any is still a cache hit.
+type subscriptions struct {
+	Subscriptions []datastore.Subscription
+}
+
+// NewSubscriptionRepo caches the subscriptions repo finds by endpoint and
+// by source, every other method goes straight to repo.
+func NewSubscriptionRepo(repo datastore.SubscriptionRepository, c cache.Cache, ttl time.Duration) datastore.SubscriptionRepository {
+	return &subscriptionRepo{SubscriptionRepository: repo, cache: c, ttl: ttl}
+}
+
+func (s *subscriptionRepo) FindSubscriptionsByEndpointID(ctx context.Context, projectID string, endpointID string) ([]datastore.Subscription, error) {
+	return s.find(ctx, projectID, "endpoints", endpointID, func() ([]datastore.Subscription, error) {
+		return s.SubscriptionRepository.FindSubscriptionsByEndpointID(ctx, projectID, endpointID)
+	})
+}
+
+func (s *subscriptionRepo) FindSubscriptionsBySourceID(ctx context.Context, projectID string, sourceID string) ([]datastore.Subscription, error) {
+	return s.find(ctx, projectID, "sources", sourceID, func() ([]datastore.Subscription, error) {
+		return s.SubscriptionRepository.FindSubscriptionsBySourceID(ctx, projectID, sourceID)
+	})
+}
+
+func (s *subscriptionRepo) find(ctx context.Context, projectID, kind, id string, load func() ([]datastore.Subscription, error)) ([]datastore.Subscription, error) {
+	key, err := subscriptionsKey(ctx, s.cache, projectID, kind, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var cached *subscriptions
+	err = s.cache.Get(ctx, key, &cached)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached != nil {
+		return cached.Subscriptions, nil
+	}
+
+	subs, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.cache.Set(ctx, key, &subscriptions{Subscriptions: subs}, s.ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return subs, nil
+}
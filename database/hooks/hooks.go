@@ -7,7 +7,7 @@ import (
 	"github.com/frain-dev/convoy/datastore"
 )
 
-type hookMap map[datastore.HookEventType]func(data interface{})
+type hookMap map[datastore.HookEventType][]func(data interface{})
 
 type Hook struct {
 	fns hookMap
@@ -26,17 +26,31 @@ func Get() (*Hook, error) {
 	return ho, nil
 }
 
+// Init creates the hook every repository fires its events on, it's set
+// up first so repositories created before their listeners use it too.
 func Init() *Hook {
-	return &Hook{fns: hookMap{}}
+	h := &Hook{fns: hookMap{}}
+	hookSingleton.Store(h)
+	return h
 }
 
 func (h *Hook) Fire(eventType datastore.HookEventType, data interface{}) {
-	if fn, ok := h.fns[eventType]; ok {
+	for _, fn := range h.fns[eventType] {
 		fn(data)
 	}
 }
 
+// RegisterHook adds fn to the functions called when eventType is fired,
+// they're called in the order they were registered.
 func (h *Hook) RegisterHook(eventType datastore.HookEventType, fn func(data interface{})) {
-	h.fns[eventType] = fn
+	h.fns[eventType] = append(h.fns[eventType], fn)
 	hookSingleton.Store(h)
 }
+
+// RegisterBackgroundHook is like RegisterHook, but fn runs in its own
+// goroutine so the write that fired eventType doesn't wait for it.
+func (h *Hook) RegisterBackgroundHook(eventType datastore.HookEventType, fn func(data interface{})) {
+	h.RegisterHook(eventType, func(data interface{}) {
+		go fn(data)
+	})
+}
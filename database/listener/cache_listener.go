@@ -0,0 +1,72 @@
+package listener
+
+import (
+	"context"
+
+	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/database/cached"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/log"
+)
+
+// CacheListener removes cached projects, endpoints and subscriptions when
+// they change, so workers reading through the cached repositories see the
+// change on their next read.
+type CacheListener struct {
+	cache cache.Cache
+}
+
+func NewCacheListener(c cache.Cache) *CacheListener {
+	return &CacheListener{cache: c}
+}
+
+func (c *CacheListener) AfterProjectChange(data interface{}) {
+	project, ok := data.(*datastore.Project)
+	if !ok {
+		log.Errorf("invalid type for project cache invalidation")
+		return
+	}
+
+	ctx := context.Background()
+	if err := cached.InvalidateProject(ctx, c.cache, project.UID); err != nil {
+		log.WithError(err).Error("failed to invalidate cached project")
+	}
+
+	if err := cached.InvalidateSubscriptions(ctx, c.cache, project.UID); err != nil {
+		log.WithError(err).Error("failed to invalidate cached subscriptions")
+	}
+}
+
+func (c *CacheListener) AfterEndpointChange(data interface{}) {
+	endpoint, ok := data.(*datastore.Endpoint)
+	if !ok {
+		log.Errorf("invalid type for endpoint cache invalidation")
+		return
+	}
+
+	if err := cached.InvalidateEndpoint(context.Background(), c.cache, endpoint); err != nil {
+		log.WithError(err).Error("failed to invalidate cached endpoint")
+	}
+}
+
+func (c *CacheListener) AfterCircuitBreakerChange(data interface{}) {
+	change, ok := data.(*datastore.CircuitBreakerStateChange)
+	if !ok || change.Endpoint == nil {
+		log.Errorf("invalid type for endpoint cache invalidation")
+		return
+	}
+
+	c.AfterEndpointChange(&datastore.Endpoint{UID: change.Endpoint.UID, ProjectID: change.Endpoint.ProjectID})
+}
+
+func (c *CacheListener) AfterSubscriptionChange(data interface{}) {
+	subscription, ok := data.(*datastore.Subscription)
+	if !ok {
+		log.Errorf("invalid type for subscription cache invalidation")
+		return
+	}
+
+	if err := cached.InvalidateSubscriptions(context.Background(), c.cache, subscription.ProjectID); err != nil {
+		log.WithError(err).Error("failed to invalidate cached subscriptions")
+	}
+}
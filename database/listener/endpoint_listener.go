@@ -1,6 +1,7 @@
 package listener
 
 import (
+	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/pkg/log"
 	"github.com/frain-dev/convoy/queue"
@@ -35,7 +36,33 @@ func (e *EndpointListener) metaEvent(eventType string, data interface{}) {
 		return
 	}
 
-	if err := e.mEvent.Run(eventType, endpoint.ProjectID, endpoint); err != nil {
+	// the endpoint is sent like it is returned by the api, without its
+	// mtls client key and oauth2 client secret
+	if err := e.mEvent.Run(eventType, endpoint.ProjectID, models.EndpointResponse{Endpoint: endpoint}); err != nil {
 		log.WithError(err).Error("endpoint meta event failed")
 	}
 }
+
+func (e *EndpointListener) AfterCircuitBreakerOpened(data interface{}) {
+	e.circuitBreakerMetaEvent(string(datastore.EndpointCircuitBreakerOpened), data)
+}
+
+func (e *EndpointListener) AfterCircuitBreakerHalfOpen(data interface{}) {
+	e.circuitBreakerMetaEvent(string(datastore.EndpointCircuitBreakerHalfOpen), data)
+}
+
+func (e *EndpointListener) AfterCircuitBreakerClosed(data interface{}) {
+	e.circuitBreakerMetaEvent(string(datastore.EndpointCircuitBreakerClosed), data)
+}
+
+func (e *EndpointListener) circuitBreakerMetaEvent(eventType string, data interface{}) {
+	change, ok := data.(*datastore.CircuitBreakerStateChange)
+	if !ok || change.Endpoint == nil {
+		log.Errorf("invalid type for event - %s", eventType)
+		return
+	}
+
+	if err := e.mEvent.Run(eventType, change.Endpoint.ProjectID, change); err != nil {
+		log.WithError(err).Error("endpoint circuit breaker meta event failed")
+	}
+}
@@ -0,0 +1,281 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrDeadLetterNotCreated = errors.New("dead letter could not be created")
+	ErrDeadLetterNotDeleted = errors.New("dead letter could not be deleted")
+)
+
+const (
+	// a delivery can only have one live dead letter, so moving it into the
+	// queue a second time refreshes the existing entry with the newest attempt.
+	createDeadLetter = `
+	INSERT INTO convoy.dead_letters (id, project_id, event_delivery_id, event_id, endpoint_id, event_type, reason, attempt)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT (event_delivery_id) WHERE deleted_at IS NULL
+	DO UPDATE SET reason = EXCLUDED.reason, attempt = EXCLUDED.attempt, updated_at = now()
+	`
+
+	fetchDeadLetterById = `
+	SELECT id, project_id, event_delivery_id, event_id,
+	COALESCE(endpoint_id, '') AS endpoint_id, event_type,
+	COALESCE(reason, '') AS reason, attempt, created_at, updated_at
+	FROM convoy.dead_letters WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL;
+	`
+
+	baseDeadLettersPaged = `
+	SELECT dl.id, dl.project_id, dl.event_delivery_id, dl.event_id,
+	COALESCE(dl.endpoint_id, '') AS endpoint_id, dl.event_type,
+	COALESCE(dl.reason, '') AS reason, dl.attempt,
+	dl.created_at, dl.updated_at FROM convoy.dead_letters dl
+	WHERE dl.deleted_at IS NULL
+	`
+	baseDeadLettersPagedForward = `%s %s AND dl.id <= :cursor
+	GROUP BY dl.id
+	ORDER BY dl.id DESC
+	LIMIT :limit
+	`
+	baseDeadLettersPagedBackward = `
+	WITH dead_letters AS (
+		%s %s AND dl.id >= :cursor
+		GROUP BY dl.id
+		ORDER BY dl.id ASC
+		LIMIT :limit
+	)
+
+	SELECT * from dead_letters ORDER BY id DESC
+	`
+	baseDeadLetterFilter = ` AND dl.project_id = :project_id
+	AND dl.created_at >= :start_date
+	AND dl.created_at <= :end_date`
+
+	baseCountPrevDeadLetters = `
+	SELECT count(distinct(dl.id)) as count
+	FROM convoy.dead_letters dl WHERE dl.deleted_at IS NULL
+	`
+	countPrevDeadLetters = ` AND dl.id > :cursor GROUP BY dl.id ORDER BY dl.id DESC LIMIT 1`
+
+	softDeleteDeadLetter = `
+	UPDATE convoy.dead_letters SET deleted_at = now()
+	WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL;
+	`
+
+	baseSoftDeleteDeadLetters = `
+	UPDATE convoy.dead_letters dl SET deleted_at = now()
+	WHERE dl.deleted_at IS NULL
+	`
+)
+
+type deadLetterRepo struct {
+	db *sqlx.DB
+}
+
+func NewDeadLetterRepo(db database.Database) datastore.DeadLetterRepository {
+	return &deadLetterRepo{db: db.GetDB()}
+}
+
+func (d *deadLetterRepo) CreateDeadLetter(ctx context.Context, deadLetter *datastore.DeadLetter) error {
+	var endpointID *string
+	if len(deadLetter.EndpointID) > 0 {
+		endpointID = &deadLetter.EndpointID
+	}
+
+	r, err := d.db.ExecContext(ctx, createDeadLetter, deadLetter.UID, deadLetter.ProjectID, deadLetter.EventDeliveryID,
+		deadLetter.EventID, endpointID, deadLetter.EventType, deadLetter.Reason, deadLetter.Attempt,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrDeadLetterNotCreated
+	}
+
+	return nil
+}
+
+func (d *deadLetterRepo) FindDeadLetterByID(ctx context.Context, projectID string, id string) (*datastore.DeadLetter, error) {
+	deadLetter := &datastore.DeadLetter{}
+	err := d.db.QueryRowxContext(ctx, fetchDeadLetterById, id, projectID).StructScan(deadLetter)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, datastore.ErrDeadLetterNotFound
+		}
+
+		return nil, err
+	}
+
+	return deadLetter, nil
+}
+
+func (d *deadLetterRepo) LoadDeadLettersPaged(ctx context.Context, projectID string, filter *datastore.DeadLetterFilter) ([]datastore.DeadLetter, datastore.PaginationData, error) {
+	var query, countQuery string
+	var err error
+	var args, qargs []interface{}
+
+	arg := deadLetterFilterArgs(projectID, filter)
+	arg["limit"] = filter.Pageable.Limit()
+	arg["cursor"] = filter.Pageable.Cursor()
+
+	var baseQueryPagination string
+	if filter.Pageable.Direction == datastore.Next {
+		baseQueryPagination = baseDeadLettersPagedForward
+	} else {
+		baseQueryPagination = baseDeadLettersPagedBackward
+	}
+
+	filterQuery := deadLetterFilterQuery(filter)
+	query = fmt.Sprintf(baseQueryPagination, baseDeadLettersPaged, filterQuery)
+
+	query, args, err = sqlx.Named(query, arg)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	query = d.db.Rebind(query)
+	rows, err := d.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	deadLetters := make([]datastore.DeadLetter, 0)
+	for rows.Next() {
+		var data datastore.DeadLetter
+
+		err = rows.StructScan(&data)
+		if err != nil {
+			return nil, datastore.PaginationData{}, err
+		}
+
+		deadLetters = append(deadLetters, data)
+	}
+
+	var count datastore.PrevRowCount
+	if len(deadLetters) > 0 {
+		first := deadLetters[0]
+		qarg := arg
+		qarg["cursor"] = first.UID
+
+		cq := baseCountPrevDeadLetters + filterQuery + countPrevDeadLetters
+		countQuery, qargs, err = sqlx.Named(cq, qarg)
+		if err != nil {
+			return nil, datastore.PaginationData{}, err
+		}
+
+		countQuery, qargs, err = sqlx.In(countQuery, qargs...)
+		if err != nil {
+			return nil, datastore.PaginationData{}, err
+		}
+
+		countQuery = d.db.Rebind(countQuery)
+		rows, err := d.db.QueryxContext(ctx, countQuery, qargs...)
+		if err != nil {
+			return nil, datastore.PaginationData{}, err
+		}
+
+		if rows.Next() {
+			err = rows.StructScan(&count)
+			if err != nil {
+				return nil, datastore.PaginationData{}, err
+			}
+		}
+
+		rows.Close()
+	}
+
+	ids := make([]string, len(deadLetters))
+	for i := range deadLetters {
+		ids[i] = deadLetters[i].UID
+	}
+
+	if len(deadLetters) > filter.Pageable.PerPage {
+		deadLetters = deadLetters[:len(deadLetters)-1]
+	}
+
+	pagination := &datastore.PaginationData{PrevRowCount: count}
+	pagination = pagination.Build(filter.Pageable, ids)
+
+	return deadLetters, *pagination, rows.Close()
+}
+
+func (d *deadLetterRepo) DeleteDeadLetter(ctx context.Context, projectID string, id string) error {
+	result, err := d.db.ExecContext(ctx, softDeleteDeadLetter, id, projectID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrDeadLetterNotDeleted
+	}
+
+	return nil
+}
+
+func (d *deadLetterRepo) DeleteDeadLetters(ctx context.Context, projectID string, filter *datastore.DeadLetterFilter) (int64, error) {
+	query, args, err := sqlx.Named(baseSoftDeleteDeadLetters+deadLetterFilterQuery(filter), deadLetterFilterArgs(projectID, filter))
+	if err != nil {
+		return 0, err
+	}
+
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := d.db.ExecContext(ctx, d.db.Rebind(query), args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func deadLetterFilterArgs(projectID string, filter *datastore.DeadLetterFilter) map[string]interface{} {
+	startDate, endDate := getCreatedDateFilter(filter.SearchParams.CreatedAtStart, filter.SearchParams.CreatedAtEnd)
+
+	return map[string]interface{}{
+		"project_id":   projectID,
+		"endpoint_ids": filter.EndpointIDs,
+		"event_type":   filter.EventType,
+		"start_date":   startDate,
+		"end_date":     endDate,
+	}
+}
+
+func deadLetterFilterQuery(filter *datastore.DeadLetterFilter) string {
+	filterQuery := baseDeadLetterFilter
+	if len(filter.EndpointIDs) > 0 {
+		filterQuery += ` AND dl.endpoint_id IN (:endpoint_ids)`
+	}
+
+	if len(filter.EventType) > 0 {
+		filterQuery += ` AND dl.event_type = :event_type`
+	}
+
+	return filterQuery
+}
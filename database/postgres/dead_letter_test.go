@@ -0,0 +1,181 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateDeadLetter(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	deadLetterRepo := NewDeadLetterRepo(db)
+	deadLetter := generateDeadLetter(t, db)
+	ctx := context.Background()
+
+	require.NoError(t, deadLetterRepo.CreateDeadLetter(ctx, deadLetter))
+
+	newDeadLetter, err := deadLetterRepo.FindDeadLetterByID(ctx, deadLetter.ProjectID, deadLetter.UID)
+	require.NoError(t, err)
+
+	newDeadLetter.CreatedAt, newDeadLetter.UpdatedAt = time.Time{}, time.Time{}
+	deadLetter.CreatedAt, deadLetter.UpdatedAt = time.Time{}, time.Time{}
+	newDeadLetter.Attempt.CreatedAt, newDeadLetter.Attempt.UpdatedAt = time.Time{}, time.Time{}
+	deadLetter.Attempt.CreatedAt, deadLetter.Attempt.UpdatedAt = time.Time{}, time.Time{}
+
+	require.Equal(t, deadLetter, newDeadLetter)
+
+	// moving the same delivery again refreshes the existing entry
+	deadLetter.Reason = "Retry limit exceeded again"
+	require.NoError(t, deadLetterRepo.CreateDeadLetter(ctx, &datastore.DeadLetter{
+		UID:             ulid.Make().String(),
+		ProjectID:       deadLetter.ProjectID,
+		EventDeliveryID: deadLetter.EventDeliveryID,
+		EventID:         deadLetter.EventID,
+		EndpointID:      deadLetter.EndpointID,
+		EventType:       deadLetter.EventType,
+		Reason:          deadLetter.Reason,
+		Attempt:         deadLetter.Attempt,
+	}))
+
+	newDeadLetter, err = deadLetterRepo.FindDeadLetterByID(ctx, deadLetter.ProjectID, deadLetter.UID)
+	require.NoError(t, err)
+	require.Equal(t, "Retry limit exceeded again", newDeadLetter.Reason)
+}
+
+func Test_FindDeadLetterByID(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	deadLetterRepo := NewDeadLetterRepo(db)
+	deadLetter := generateDeadLetter(t, db)
+	ctx := context.Background()
+
+	_, err := deadLetterRepo.FindDeadLetterByID(ctx, deadLetter.ProjectID, deadLetter.UID)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, datastore.ErrDeadLetterNotFound))
+
+	require.NoError(t, deadLetterRepo.CreateDeadLetter(ctx, deadLetter))
+
+	_, err = deadLetterRepo.FindDeadLetterByID(ctx, deadLetter.ProjectID, deadLetter.UID)
+	require.NoError(t, err)
+}
+
+func Test_DeleteDeadLetter(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	deadLetterRepo := NewDeadLetterRepo(db)
+	deadLetter := generateDeadLetter(t, db)
+	ctx := context.Background()
+
+	require.NoError(t, deadLetterRepo.CreateDeadLetter(ctx, deadLetter))
+	require.NoError(t, deadLetterRepo.DeleteDeadLetter(ctx, deadLetter.ProjectID, deadLetter.UID))
+
+	_, err := deadLetterRepo.FindDeadLetterByID(ctx, deadLetter.ProjectID, deadLetter.UID)
+	require.True(t, errors.Is(err, datastore.ErrDeadLetterNotFound))
+}
+
+func Test_LoadDeadLettersPaged(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	deadLetterRepo := NewDeadLetterRepo(db)
+	ctx := context.Background()
+
+	deadLetter := generateDeadLetter(t, db)
+	require.NoError(t, deadLetterRepo.CreateDeadLetter(ctx, deadLetter))
+
+	tests := []struct {
+		name     string
+		filter   *datastore.DeadLetterFilter
+		expected int
+	}{
+		{
+			name:     "no filters",
+			filter:   &datastore.DeadLetterFilter{},
+			expected: 1,
+		},
+		{
+			name:     "matching endpoint",
+			filter:   &datastore.DeadLetterFilter{EndpointIDs: []string{deadLetter.EndpointID}},
+			expected: 1,
+		},
+		{
+			name:     "other endpoint",
+			filter:   &datastore.DeadLetterFilter{EndpointIDs: []string{ulid.Make().String()}},
+			expected: 0,
+		},
+		{
+			name:     "matching event type",
+			filter:   &datastore.DeadLetterFilter{EventType: string(deadLetter.EventType)},
+			expected: 1,
+		},
+		{
+			name:     "other event type",
+			filter:   &datastore.DeadLetterFilter{EventType: "invoice.paid"},
+			expected: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.filter.Pageable = datastore.Pageable{
+				PerPage:    10,
+				Direction:  datastore.Next,
+				NextCursor: datastore.DefaultCursor,
+			}
+			tc.filter.SearchParams = datastore.SearchParams{
+				CreatedAtStart: time.Now().Add(-time.Hour).Unix(),
+				CreatedAtEnd:   time.Now().Add(time.Hour).Unix(),
+			}
+
+			deadLetters, _, err := deadLetterRepo.LoadDeadLettersPaged(ctx, deadLetter.ProjectID, tc.filter)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, len(deadLetters))
+
+			count, err := deadLetterRepo.DeleteDeadLetters(ctx, ulid.Make().String(), tc.filter)
+			require.NoError(t, err)
+			require.Equal(t, int64(0), count)
+		})
+	}
+}
+
+func generateDeadLetter(t *testing.T, db database.Database) *datastore.DeadLetter {
+	source := seedSource(t, db)
+	project := seedProject(t, db)
+	device := seedDevice(t, db)
+	endpoint := seedEndpoint(t, db)
+	event := seedEvent(t, db, project)
+	sub := seedSubscription(t, db, project, source, endpoint, device)
+
+	ed := generateEventDelivery(project, endpoint, event, device, sub)
+	require.NoError(t, NewEventDeliveryRepo(db).CreateEventDelivery(context.Background(), ed))
+
+	return &datastore.DeadLetter{
+		UID:             ulid.Make().String(),
+		ProjectID:       project.UID,
+		EventDeliveryID: ed.UID,
+		EventID:         event.UID,
+		EndpointID:      endpoint.UID,
+		EventType:       event.EventType,
+		Reason:          "Retry limit exceeded",
+		Attempt: &datastore.DeliveryAttempt{
+			UID:              ulid.Make().String(),
+			MsgID:            ed.UID,
+			EndpointID:       endpoint.UID,
+			HttpResponseCode: "500 Internal Server Error",
+			Error:            "failed",
+		},
+	}
+}
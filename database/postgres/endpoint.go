@@ -27,12 +27,17 @@ const (
 		id, title, status, secrets, owner_id, target_url, description, http_timeout,
 		rate_limit, rate_limit_duration, advanced_signatures, slack_webhook_url,
 		support_email, app_id, project_id, authentication_type, authentication_type_api_key_header_name,
-		authentication_type_api_key_header_value
+		authentication_type_api_key_header_value, ordered_delivery, partition_key_path,
+		authentication_type_mtls_client_cert, authentication_type_mtls_client_key,
+		authentication_type_mtls_ca_cert, authentication_type_oauth2_token_url,
+		authentication_type_oauth2_client_id, authentication_type_oauth2_client_secret,
+		authentication_type_oauth2_scope, kind, destination, content_encoding
 	)
 	VALUES
 	  (
 		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13,
-		$14, $15, $16, $17, $18
+		$14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24,
+		$25, $26, $27, $28, $29, $30
 	  );
 	`
 
@@ -45,7 +50,16 @@ const (
 	e.project_id, e.secrets, e.created_at, e.updated_at,
 	e.authentication_type AS "authentication.type",
 	e.authentication_type_api_key_header_name AS "authentication.api_key.header_name",
-	e.authentication_type_api_key_header_value AS "authentication.api_key.header_value"
+	e.authentication_type_api_key_header_value AS "authentication.api_key.header_value",
+	e.authentication_type_mtls_client_cert AS "authentication.mtls.client_cert",
+	e.authentication_type_mtls_client_key AS "authentication.mtls.client_key",
+	e.authentication_type_mtls_ca_cert AS "authentication.mtls.ca_cert",
+	e.authentication_type_oauth2_token_url AS "authentication.oauth2.token_url",
+	e.authentication_type_oauth2_client_id AS "authentication.oauth2.client_id",
+	e.authentication_type_oauth2_client_secret AS "authentication.oauth2.client_secret",
+	e.authentication_type_oauth2_scope AS "authentication.oauth2.scope",
+	e.ordered_delivery, COALESCE(e.partition_key_path, '') AS partition_key_path,
+	e.kind, e.destination, e.content_encoding
 	FROM convoy.endpoints AS e
 	LEFT JOIN convoy.events_endpoints AS ee ON e.id = ee.endpoint_id
 	WHERE e.deleted_at IS NULL
@@ -66,7 +80,16 @@ const (
     e.app_id, e.project_id, e.secrets, e.created_at, e.updated_at,
     e.authentication_type AS "authentication.type",
     e.authentication_type_api_key_header_name AS "authentication.api_key.header_name",
-    e.authentication_type_api_key_header_value AS "authentication.api_key.header_value"
+    e.authentication_type_api_key_header_value AS "authentication.api_key.header_value",
+    e.authentication_type_mtls_client_cert AS "authentication.mtls.client_cert",
+    e.authentication_type_mtls_client_key AS "authentication.mtls.client_key",
+    e.authentication_type_mtls_ca_cert AS "authentication.mtls.ca_cert",
+    e.authentication_type_oauth2_token_url AS "authentication.oauth2.token_url",
+    e.authentication_type_oauth2_client_id AS "authentication.oauth2.client_id",
+    e.authentication_type_oauth2_client_secret AS "authentication.oauth2.client_secret",
+    e.authentication_type_oauth2_scope AS "authentication.oauth2.scope",
+    e.ordered_delivery, COALESCE(e.partition_key_path, '') AS partition_key_path,
+    e.kind, e.destination, e.content_encoding
     FROM convoy.endpoints AS e WHERE e.deleted_at IS NULL AND e.target_url = $1 AND e.project_id = $2;
     `
 
@@ -78,6 +101,12 @@ const (
 	slack_webhook_url = $12, support_email = $13,
 	authentication_type = $14, authentication_type_api_key_header_name = $15,
 	authentication_type_api_key_header_value = $16, secrets = $17,
+	ordered_delivery = $18, partition_key_path = $19,
+	authentication_type_mtls_client_cert = $20, authentication_type_mtls_client_key = $21,
+	authentication_type_mtls_ca_cert = $22, authentication_type_oauth2_token_url = $23,
+	authentication_type_oauth2_client_id = $24, authentication_type_oauth2_client_secret = $25,
+	authentication_type_oauth2_scope = $26, kind = $27, destination = $28,
+	content_encoding = $29,
 	updated_at = now()
 	WHERE id = $1 AND project_id = $2 AND deleted_at is NULL;
 	`
@@ -117,7 +146,16 @@ const (
 	e.project_id, e.secrets, e.created_at, e.updated_at,
 	e.authentication_type AS "authentication.type",
 	e.authentication_type_api_key_header_name AS "authentication.api_key.header_name",
-	e.authentication_type_api_key_header_value AS "authentication.api_key.header_value"
+	e.authentication_type_api_key_header_value AS "authentication.api_key.header_value",
+	e.authentication_type_mtls_client_cert AS "authentication.mtls.client_cert",
+	e.authentication_type_mtls_client_key AS "authentication.mtls.client_key",
+	e.authentication_type_mtls_ca_cert AS "authentication.mtls.ca_cert",
+	e.authentication_type_oauth2_token_url AS "authentication.oauth2.token_url",
+	e.authentication_type_oauth2_client_id AS "authentication.oauth2.client_id",
+	e.authentication_type_oauth2_client_secret AS "authentication.oauth2.client_secret",
+	e.authentication_type_oauth2_scope AS "authentication.oauth2.scope",
+	e.ordered_delivery, COALESCE(e.partition_key_path, '') AS partition_key_path,
+	e.kind, e.destination, e.content_encoding
 	FROM convoy.endpoints AS e
 	WHERE e.deleted_at IS NULL
 	AND e.project_id = :project_id
@@ -174,6 +212,10 @@ func (e *endpointRepo) CreateEndpoint(ctx context.Context, endpoint *datastore.E
 		endpoint.Description, endpoint.HttpTimeout, endpoint.RateLimit, endpoint.RateLimitDuration,
 		endpoint.AdvancedSignatures, endpoint.SlackWebhookURL, endpoint.SupportEmail, endpoint.AppID,
 		projectID, ac.Type, ac.ApiKey.HeaderName, ac.ApiKey.HeaderValue,
+		endpoint.OrderedDelivery, endpoint.PartitionKeyPath,
+		ac.MTLS.ClientCert, ac.MTLS.ClientKey, ac.MTLS.CACert,
+		ac.OAuth2.TokenURL, ac.OAuth2.ClientID, ac.OAuth2.ClientSecret, ac.OAuth2.Scope,
+		endpointKind(endpoint), endpoint.Destination, endpoint.ContentEncoding,
 	}
 
 	result, err := e.db.ExecContext(ctx, createEndpoint, args...)
@@ -190,7 +232,7 @@ func (e *endpointRepo) CreateEndpoint(ctx context.Context, endpoint *datastore.E
 		return ErrEndpointNotCreated
 	}
 
-	go e.hook.Fire(datastore.EndpointCreated, endpoint)
+	e.hook.Fire(datastore.EndpointCreated, endpoint)
 	return nil
 }
 
@@ -204,6 +246,7 @@ func (e *endpointRepo) FindEndpointByID(ctx context.Context, id, projectID strin
 		return nil, err
 	}
 
+	nullifyEmptyAuthConfig(endpoint)
 	return endpoint, nil
 }
 
@@ -247,6 +290,10 @@ func (e *endpointRepo) UpdateEndpoint(ctx context.Context, endpoint *datastore.E
 		endpoint.Description, endpoint.HttpTimeout, endpoint.RateLimit, endpoint.RateLimitDuration,
 		endpoint.AdvancedSignatures, endpoint.SlackWebhookURL, endpoint.SupportEmail,
 		ac.Type, ac.ApiKey.HeaderName, ac.ApiKey.HeaderValue, endpoint.Secrets,
+		endpoint.OrderedDelivery, endpoint.PartitionKeyPath,
+		ac.MTLS.ClientCert, ac.MTLS.ClientKey, ac.MTLS.CACert,
+		ac.OAuth2.TokenURL, ac.OAuth2.ClientID, ac.OAuth2.ClientSecret, ac.OAuth2.Scope,
+		endpointKind(endpoint), endpoint.Destination, endpoint.ContentEncoding,
 	)
 	if err != nil {
 		return err
@@ -261,7 +308,7 @@ func (e *endpointRepo) UpdateEndpoint(ctx context.Context, endpoint *datastore.E
 		return ErrEndpointNotUpdated
 	}
 
-	go e.hook.Fire(datastore.EndpointUpdated, endpoint)
+	e.hook.Fire(datastore.EndpointUpdated, endpoint)
 	return nil
 }
 
@@ -280,6 +327,12 @@ func (e *endpointRepo) UpdateEndpointStatus(ctx context.Context, projectID strin
 		return ErrEndpointNotUpdated
 	}
 
+	endpoint, err := e.FindEndpointByID(ctx, endpointID, projectID)
+	if err != nil {
+		endpoint = &datastore.Endpoint{UID: endpointID, ProjectID: projectID, Status: status}
+	}
+
+	e.hook.Fire(datastore.EndpointUpdated, endpoint)
 	return nil
 }
 
@@ -310,7 +363,7 @@ func (e *endpointRepo) DeleteEndpoint(ctx context.Context, endpoint *datastore.E
 		return err
 	}
 
-	go e.hook.Fire(datastore.EndpointDeleted, endpoint)
+	e.hook.Fire(datastore.EndpointDeleted, endpoint)
 	return nil
 }
 
@@ -335,6 +388,7 @@ func (e *endpointRepo) FindEndpointByTargetURL(ctx context.Context, projectID st
 		return nil, err
 	}
 
+	nullifyEmptyAuthConfig(endpoint)
 	return endpoint, nil
 }
 
@@ -481,12 +535,38 @@ func (e *endpointRepo) scanEndpoints(rows *sqlx.Rows) ([]datastore.Endpoint, err
 			return nil, err
 		}
 
+		nullifyEmptyAuthConfig(&endpoint)
 		endpoints = append(endpoints, endpoint)
 	}
 
 	return endpoints, nil
 }
 
+// nullifyEmptyAuthConfig drops the authentication configs an endpoint
+// doesn't use, their columns are always scanned.
+func nullifyEmptyAuthConfig(endpoint *datastore.Endpoint) {
+	if endpoint.Authentication == nil {
+		return
+	}
+
+	if endpoint.Authentication.MTLS != nil && *endpoint.Authentication.MTLS == (datastore.MTLSClientCert{}) {
+		endpoint.Authentication.MTLS = nil
+	}
+
+	if endpoint.Authentication.OAuth2 != nil && *endpoint.Authentication.OAuth2 == (datastore.OAuth2{}) {
+		endpoint.Authentication.OAuth2 = nil
+	}
+}
+
+// endpointKind defaults endpoints without a kind to http
+func endpointKind(endpoint *datastore.Endpoint) datastore.EndpointKind {
+	if util.IsStringEmpty(string(endpoint.Kind)) {
+		return datastore.HTTPEndpointKind
+	}
+
+	return endpoint.Kind
+}
+
 type EndpointPaginated struct {
 	EndpointSecret
 }
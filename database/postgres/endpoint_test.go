@@ -47,6 +47,7 @@ func Test_UpdateEndpoint(t *testing.T) {
 		RateLimit:          8898,
 		Status:             datastore.ActiveEndpointStatus,
 		RateLimitDuration:  "48s",
+		Kind:               datastore.HTTPEndpointKind,
 		Authentication: &datastore.EndpointAuthentication{
 			Type: datastore.APIKeyAuthentication,
 			ApiKey: &datastore.ApiKey{
@@ -98,12 +99,22 @@ func Test_UpdateEndpointStatus(t *testing.T) {
 
 	endpoint.Status = status
 
+	var updated *datastore.Endpoint
+	db.GetHook().RegisterHook(datastore.EndpointUpdated, func(data interface{}) {
+		updated = data.(*datastore.Endpoint)
+	})
+
 	require.NoError(t, endpointRepo.UpdateEndpointStatus(context.Background(), project.UID, endpoint.UID, status))
 
 	dbEndpoint, err := endpointRepo.FindEndpointByID(context.Background(), endpoint.UID, project.UID)
 	require.NoError(t, err)
 
 	require.Equal(t, status, dbEndpoint.Status)
+
+	// the hook has run by the time the update returns
+	require.NotNil(t, updated)
+	require.Equal(t, endpoint.UID, updated.UID)
+	require.Equal(t, status, updated.Status)
 }
 
 func Test_DeleteEndpoint(t *testing.T) {
@@ -196,6 +207,39 @@ func Test_CreateEndpoint(t *testing.T) {
 	require.Equal(t, endpoint, dbEndpoint)
 }
 
+func Test_CreateEndpoint_Destination(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	endpointRepo := NewEndpointRepo(db)
+
+	project := seedProject(t, db)
+	endpoint := generateEndpoint(project)
+	endpoint.Authentication = nil
+	endpoint.TargetURL = "kafka://localhost:9092/events"
+	endpoint.Kind = datastore.KafkaEndpointKind
+	endpoint.Destination = &datastore.EndpointDestination{
+		Kafka: &datastore.KafkaTopicConfig{Brokers: []string{"localhost:9092"}, Topic: "events"},
+	}
+
+	require.NoError(t, endpointRepo.CreateEndpoint(context.Background(), endpoint, project.UID))
+
+	dbEndpoint, err := endpointRepo.FindEndpointByID(context.Background(), endpoint.UID, project.UID)
+	require.NoError(t, err)
+	require.Equal(t, datastore.KafkaEndpointKind, dbEndpoint.Kind)
+	require.Equal(t, endpoint.Destination, dbEndpoint.Destination)
+
+	dbEndpoint.Kind = datastore.HTTPEndpointKind
+	dbEndpoint.Destination = nil
+	dbEndpoint.TargetURL = "https://example.com"
+	require.NoError(t, endpointRepo.UpdateEndpoint(context.Background(), dbEndpoint, project.UID))
+
+	dbEndpoint, err = endpointRepo.FindEndpointByID(context.Background(), endpoint.UID, project.UID)
+	require.NoError(t, err)
+	require.Equal(t, datastore.HTTPEndpointKind, dbEndpoint.Kind)
+	require.Nil(t, dbEndpoint.Destination)
+}
+
 func Test_LoadEndpointsPaged(t *testing.T) {
 	db, closeFn := getDB(t)
 	defer closeFn()
@@ -576,6 +620,7 @@ func generateEndpoint(project *datastore.Project) *datastore.Endpoint {
 		RateLimit:          300,
 		Status:             datastore.ActiveEndpointStatus,
 		RateLimitDuration:  "4s",
+		Kind:               datastore.HTTPEndpointKind,
 		Secrets: []datastore.Secret{
 			{
 				UID:       ulid.Make().String(),
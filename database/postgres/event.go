@@ -9,6 +9,7 @@ import (
 
 	"github.com/frain-dev/convoy/database"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/payload"
 	"github.com/frain-dev/convoy/util"
 	"github.com/jmoiron/sqlx"
 )
@@ -17,8 +18,16 @@ const (
 	createEvent = `
 	INSERT INTO convoy.events (id,event_type,endpoints,project_id,
 	                           source_id,headers,raw,data,url_query_params,
-	                           idempotency_key,is_duplicate_event,created_at,updated_at)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	                           idempotency_key,is_duplicate_event,deliver_at,expires_at,payload,created_at,updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+	`
+
+	createEvents = `
+	INSERT INTO convoy.events (id,event_type,endpoints,project_id,
+	                           source_id,headers,raw,data,url_query_params,
+	                           idempotency_key,is_duplicate_event,deliver_at,expires_at,payload,created_at,updated_at)
+	VALUES (:id, :event_type, :endpoints, :project_id, :source_id, :headers, :raw, :data, :url_query_params,
+	        :idempotency_key, :is_duplicate_event, :deliver_at, :expires_at, :payload, :created_at, :updated_at)
 	`
 
 	createEventEndpoints = `
@@ -27,7 +36,7 @@ const (
 
 	fetchEventById = `
 	SELECT id, event_type, endpoints, project_id,
-    raw, data, headers, is_duplicate_event,
+    raw, data, payload, headers, is_duplicate_event, deliver_at, expires_at,
 	COALESCE(source_id, '') AS source_id,
 	COALESCE(idempotency_key, '') AS idempotency_key,
 	COALESCE(url_query_params, '') AS url_query_params
@@ -54,7 +63,7 @@ const (
 	COALESCE(ev.source_id, '') AS source_id,
 	COALESCE(ev.idempotency_key, '') AS idempotency_key,
 	COALESCE(ev.url_query_params, '') AS url_query_params,
-	ev.headers, ev.raw, ev.data, ev.created_at,
+	ev.headers, ev.raw, ev.data, ev.payload, ev.deliver_at, ev.expires_at, ev.created_at,
 	ev.updated_at, ev.deleted_at,
 	COALESCE(s.id, '') AS "source_metadata.id",
 	COALESCE(s.name, '') AS "source_metadata.name"
@@ -82,7 +91,7 @@ const (
 	SELECT ev.id, ev.project_id,
 	ev.id as event_type, ev.is_duplicate_event,
 	COALESCE(ev.source_id, '') AS source_id,
-	ev.headers, ev.raw, ev.data, ev.created_at,
+	ev.headers, ev.raw, ev.data, ev.payload, ev.deliver_at, ev.expires_at, ev.created_at,
 	COALESCE(idempotency_key, '') AS idempotency_key,
 	COALESCE(url_query_params, '') AS url_query_params,
 	ev.updated_at, ev.deleted_at,
@@ -127,6 +136,53 @@ const (
 	`
 	countPrevEvents = ` AND ev.id > :cursor GROUP BY ev.id ORDER BY ev.id DESC LIMIT 1`
 
+	// an event stays scheduled while any of its deliveries is still held,
+	// events whose deliveries were all cancelled drop out of the list.
+	baseScheduledEventsPaged = `
+	SELECT ev.id, ev.project_id, ev.event_type, ev.endpoints,
+	ev.is_duplicate_event, ev.headers, ev.raw, ev.data, ev.payload, ev.deliver_at, ev.expires_at,
+	COALESCE(ev.source_id, '') AS source_id,
+	COALESCE(ev.idempotency_key, '') AS idempotency_key,
+	COALESCE(ev.url_query_params, '') AS url_query_params,
+	ev.created_at, ev.updated_at
+	FROM convoy.events ev
+	WHERE ev.deleted_at IS NULL
+	AND ev.project_id = :project_id
+	AND ev.deliver_at > now()
+	AND EXISTS (
+		SELECT 1 FROM convoy.event_deliveries ed
+		WHERE ed.event_id = ev.id AND ed.status = :status
+		AND ed.deleted_at IS NULL
+	)`
+
+	scheduledEventsPagedForward = `%s AND ev.id <= :cursor
+	ORDER BY ev.id DESC
+	LIMIT :limit
+	`
+
+	scheduledEventsPagedBackward = `
+	WITH events AS (
+		%s AND ev.id >= :cursor
+		ORDER BY ev.id ASC
+		LIMIT :limit
+	)
+
+	SELECT * FROM events ORDER BY id DESC
+	`
+
+	countPrevScheduledEvents = `
+	SELECT count(distinct(ev.id)) AS count
+	FROM convoy.events ev
+	WHERE ev.deleted_at IS NULL
+	AND ev.project_id = :project_id
+	AND ev.deliver_at > now()
+	AND EXISTS (
+		SELECT 1 FROM convoy.event_deliveries ed
+		WHERE ed.event_id = ev.id AND ed.status = :status
+		AND ed.deleted_at IS NULL
+	)
+	AND ev.id > :cursor GROUP BY ev.id ORDER BY ev.id DESC LIMIT 1`
+
 	softDeleteProjectEvents = `
 	UPDATE convoy.events SET deleted_at = now()
 	WHERE project_id = $1 AND created_at >= $2 AND created_at <= $3
@@ -153,6 +209,11 @@ func (e *eventRepo) CreateEvent(ctx context.Context, event *datastore.Event) err
 		sourceID = &event.SourceID
 	}
 
+	raw, data, err := encodeEventPayload(ctx, event)
+	if err != nil {
+		return err
+	}
+
 	tx, err := e.db.BeginTxx(ctx, &sql.TxOptions{})
 	if err != nil {
 		return err
@@ -165,11 +226,14 @@ func (e *eventRepo) CreateEvent(ctx context.Context, event *datastore.Event) err
 		event.ProjectID,
 		sourceID,
 		event.Headers,
-		event.Raw,
-		event.Data,
+		raw,
+		data,
 		event.URLQueryParams,
 		event.IdempotencyKey,
 		event.IsDuplicateEvent,
+		event.DeliverAt,
+		event.ExpiresAt,
+		event.Payload,
 		event.CreatedAt,
 		event.UpdatedAt,
 	)
@@ -192,6 +256,123 @@ func (e *eventRepo) CreateEvent(ctx context.Context, event *datastore.Event) err
 	return tx.Commit()
 }
 
+// eventsInsertBatchSize keeps a batched insert well under postgres'
+// limit of 65535 bind parameters.
+const eventsInsertBatchSize = 1000
+
+// CreateEvents writes events in a single transaction, either every event
+// is created or none is.
+func (e *eventRepo) CreateEvents(ctx context.Context, events []*datastore.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	tx, err := e.db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer rollbackTx(tx)
+
+	rows := make([]interface{}, 0, len(events))
+	var endpoints []interface{}
+	for _, event := range events {
+		var sourceID *string
+		if !util.IsStringEmpty(event.SourceID) {
+			sourceID = &event.SourceID
+		}
+
+		raw, data, err := encodeEventPayload(ctx, event)
+		if err != nil {
+			return err
+		}
+
+		rows = append(rows, map[string]interface{}{
+			"id":                 event.UID,
+			"event_type":         event.EventType,
+			"endpoints":          event.Endpoints,
+			"project_id":         event.ProjectID,
+			"source_id":          sourceID,
+			"headers":            event.Headers,
+			"raw":                raw,
+			"data":               data,
+			"url_query_params":   event.URLQueryParams,
+			"idempotency_key":    event.IdempotencyKey,
+			"is_duplicate_event": event.IsDuplicateEvent,
+			"deliver_at":         event.DeliverAt,
+			"expires_at":         event.ExpiresAt,
+			"payload":            event.Payload,
+			"created_at":         event.CreatedAt,
+			"updated_at":         event.UpdatedAt,
+		})
+
+		for _, endpointID := range event.Endpoints {
+			endpoints = append(endpoints, &EventEndpoint{EventID: event.UID, EndpointID: endpointID})
+		}
+	}
+
+	for _, batch := range chunkRows(rows, eventsInsertBatchSize) {
+		_, err = tx.NamedExecContext(ctx, createEvents, batch)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, batch := range chunkRows(endpoints, eventsInsertBatchSize) {
+		_, err = tx.NamedExecContext(ctx, createEventEndpoints, batch)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// encodeEventPayload returns the raw and data values to store for the
+// event, event.Payload is set when its data was compressed or offloaded.
+func encodeEventPayload(ctx context.Context, event *datastore.Event) (string, []byte, error) {
+	data, sp, err := payload.Get().Encode(ctx, payload.ObjectKey(event.ProjectID, event.UID), event.Data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	event.Payload = sp
+	if sp == nil {
+		return event.Raw, event.Data, nil
+	}
+
+	return "", data, nil
+}
+
+// decodeEventPayload restores the data of an event that was stored
+// compressed or offloaded.
+func decodeEventPayload(ctx context.Context, event *datastore.Event) error {
+	if event.Payload == nil {
+		return nil
+	}
+
+	data, err := payload.Get().Decode(ctx, event.Data, event.Payload)
+	if err != nil {
+		return err
+	}
+
+	event.Data = data
+	event.Raw = string(data)
+	return nil
+}
+
+func chunkRows(rows []interface{}, size int) [][]interface{} {
+	var chunks [][]interface{}
+	for size < len(rows) {
+		rows, chunks = rows[size:], append(chunks, rows[:size])
+	}
+
+	if len(rows) > 0 {
+		chunks = append(chunks, rows)
+	}
+
+	return chunks
+}
+
 func (e *eventRepo) FindEventByID(ctx context.Context, projectID string, id string) (*datastore.Event, error) {
 	event := &datastore.Event{}
 	err := e.db.QueryRowxContext(ctx, fetchEventById, id, projectID).StructScan(event)
@@ -202,6 +383,12 @@ func (e *eventRepo) FindEventByID(ctx context.Context, projectID string, id stri
 
 		return nil, err
 	}
+
+	err = decodeEventPayload(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
 	return event, nil
 }
 
@@ -226,6 +413,11 @@ func (e *eventRepo) FindEventsByIDs(ctx context.Context, projectID string, ids [
 			return nil, err
 		}
 
+		err = decodeEventPayload(ctx, &event)
+		if err != nil {
+			return nil, err
+		}
+
 		events = append(events, event)
 	}
 
@@ -354,6 +546,11 @@ func (e *eventRepo) LoadEventsPaged(ctx context.Context, projectID string, filte
 			return nil, datastore.PaginationData{}, err
 		}
 
+		err = decodeEventPayload(ctx, &data)
+		if err != nil {
+			return nil, datastore.PaginationData{}, err
+		}
+
 		events = append(events, data)
 	}
 
@@ -407,6 +604,80 @@ func (e *eventRepo) LoadEventsPaged(ctx context.Context, projectID string, filte
 	return events, *pagination, rows.Close()
 }
 
+func (e *eventRepo) LoadScheduledEventsPaged(ctx context.Context, projectID string, pageable datastore.Pageable) ([]datastore.Event, datastore.PaginationData, error) {
+	arg := map[string]interface{}{
+		"project_id": projectID,
+		"status":     datastore.ScheduledEventStatus,
+		"limit":      pageable.Limit(),
+		"cursor":     pageable.Cursor(),
+	}
+
+	var query string
+	if pageable.Direction == datastore.Next {
+		query = scheduledEventsPagedForward
+	} else {
+		query = scheduledEventsPagedBackward
+	}
+
+	query, args, err := sqlx.Named(fmt.Sprintf(query, baseScheduledEventsPaged), arg)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	query = e.db.Rebind(query)
+	rows, err := e.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	events := make([]datastore.Event, 0)
+	for rows.Next() {
+		var data datastore.Event
+
+		err = rows.StructScan(&data)
+		if err != nil {
+			return nil, datastore.PaginationData{}, err
+		}
+
+		err = decodeEventPayload(ctx, &data)
+		if err != nil {
+			return nil, datastore.PaginationData{}, err
+		}
+
+		events = append(events, data)
+	}
+
+	var count datastore.PrevRowCount
+	if len(events) > 0 {
+		qarg := arg
+		qarg["cursor"] = events[0].UID
+
+		countQuery, qargs, err := sqlx.Named(countPrevScheduledEvents, qarg)
+		if err != nil {
+			return nil, datastore.PaginationData{}, err
+		}
+
+		err = e.db.GetContext(ctx, &count, e.db.Rebind(countQuery), qargs...)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, datastore.PaginationData{}, err
+		}
+	}
+
+	ids := make([]string, len(events))
+	for i := range events {
+		ids[i] = events[i].UID
+	}
+
+	if len(events) > pageable.PerPage {
+		events = events[:len(events)-1]
+	}
+
+	pagination := &datastore.PaginationData{PrevRowCount: count}
+	pagination = pagination.Build(pageable, ids)
+
+	return events, *pagination, rows.Close()
+}
+
 func (e *eventRepo) DeleteProjectEvents(ctx context.Context, projectID string, filter *datastore.EventFilter, hardDelete bool) error {
 	query := softDeleteProjectEvents
 	startDate, endDate := getCreatedDateFilter(filter.CreatedAtStart, filter.CreatedAtEnd)
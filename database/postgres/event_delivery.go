@@ -7,11 +7,13 @@ import (
 	"errors"
 	"fmt"
 	"github.com/lib/pq"
+	"sort"
 	"time"
 
 	"github.com/frain-dev/convoy/database"
 	"github.com/frain-dev/convoy/database/hooks"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/payload"
 	"github.com/frain-dev/convoy/pkg/httpheader"
 	"github.com/frain-dev/convoy/util"
 	"github.com/jmoiron/sqlx"
@@ -27,13 +29,14 @@ var (
 	ErrEventDeliveryNotCreated         = errors.New("event delivery could not be created")
 	ErrEventDeliveryStatusNotUpdated   = errors.New("event delivery status could not be updated")
 	ErrEventDeliveryAttemptsNotUpdated = errors.New("event delivery attempts could not be updated")
+	ErrEventDeliveryMetadataNotUpdated = errors.New("event delivery metadata could not be updated")
 	ErrEventDeliveriesNotDeleted       = errors.New("event deliveries could not be deleted")
 )
 
 const (
 	createEventDelivery = `
-    INSERT INTO convoy.event_deliveries (id,project_id,event_id,endpoint_id,device_id,subscription_id,headers,attempts,status,metadata,cli_metadata,description,url_query_params,idempotency_key,created_at,updated_at)
-    VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16);
+    INSERT INTO convoy.event_deliveries (id,project_id,event_id,endpoint_id,device_id,subscription_id,headers,attempts,status,metadata,cli_metadata,description,url_query_params,idempotency_key,created_at,updated_at,partition_key)
+    VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17);
     `
 
 	baseFetchEventDelivery = `
@@ -42,6 +45,8 @@ const (
         ed.headers,ed.attempts,ed.status,ed.metadata,ed.cli_metadata,
         COALESCE(ed.url_query_params, '') AS url_query_params,
         COALESCE(ed.idempotency_key, '') AS idempotency_key,
+        COALESCE(ed.partition_key, '') AS partition_key,
+        COALESCE(ed.batch_id, '') AS batch_id,
         ed.description,ed.created_at,ed.updated_at,
         COALESCE(ed.device_id,'') as "device_id",
         COALESCE(ed.endpoint_id,'') as "endpoint_id",
@@ -92,6 +97,63 @@ const (
 
 	fetchEventDeliveryByID = baseFetchEventDelivery + ` AND ed.id = $1 AND ed.project_id = $2`
 
+	// deliveryNotDue matches deliveries of scheduled events that are held
+	// until their event's delivery time, they don't block the deliveries
+	// after them in their partition and can't be batched until then.
+	deliveryNotDue = `COALESCE((metadata->>'next_send_time')::TIMESTAMPTZ > now(), FALSE)`
+
+	// only deliveries that are still held can be discarded, batched
+	// deliveries have already been claimed to be sent.
+	discardScheduledEventDeliveries = `
+    UPDATE convoy.event_deliveries SET status = $3, description = $4, updated_at = now()
+    WHERE project_id = $1 AND event_id = $2 AND status = $5
+    AND batch_id IS NULL AND deleted_at IS NULL AND ` + deliveryNotDue + `;
+    `
+
+	// deliveries are ordered by their event rather than their own id, since
+	// deliveries are created asynchronously and may not follow event order.
+	fetchPendingEventDeliveryBefore = `
+    SELECT id, project_id, event_id, status, metadata, COALESCE(partition_key, '') AS partition_key
+    FROM convoy.event_deliveries
+    WHERE project_id = $1 AND endpoint_id = $2 AND partition_key = $3
+    AND (event_id, id) < ($4, $5)
+    AND status IN ($6, $7, $8)
+    AND NOT (status = $6 AND ` + deliveryNotDue + `)
+    AND deleted_at IS NULL
+    ORDER BY event_id, id
+    LIMIT 1;
+    `
+
+	countPendingBatchEventDeliveries = `
+    SELECT COUNT(id) FROM convoy.event_deliveries
+    WHERE project_id = $1 AND subscription_id = $2 AND status = $3
+    AND batch_id IS NULL AND deleted_at IS NULL AND NOT ` + deliveryNotDue + `;
+    `
+
+	// the oldest pending deliveries are claimed until either the count or the
+	// byte limit is reached. The first delivery is always claimed so a single
+	// payload larger than the byte limit can't stall the subscription.
+	createEventDeliveryBatch = `
+    UPDATE convoy.event_deliveries SET batch_id = $3, updated_at = now()
+    WHERE id IN (
+        SELECT id FROM (
+            SELECT id,
+            row_number() OVER (ORDER BY id) AS position,
+            sum(COALESCE((metadata->'payload'->>'size')::BIGINT, octet_length(metadata->>'raw'))) OVER (ORDER BY id) AS total_bytes
+            FROM convoy.event_deliveries
+            WHERE project_id = $1 AND subscription_id = $2 AND status = $4
+            AND batch_id IS NULL AND deleted_at IS NULL AND NOT ` + deliveryNotDue + `
+        ) pending
+        WHERE position <= $5 AND (position = 1 OR $6 = 0 OR total_bytes <= $6)
+    ) AND batch_id IS NULL;
+    `
+
+	// deliveries of compressed or offloaded events don't keep a copy of
+	// the payload, it is read from the event instead.
+	fetchEventPayloads = `
+    SELECT id, data, payload FROM convoy.events WHERE id IN (?);
+    `
+
 	baseEventDeliveryFilter = ` AND (ed.project_id = :project_id OR :project_id = '')
 	AND (ed.event_id = :event_id OR :event_id = '')
 	AND ed.created_at >= :start_date
@@ -130,6 +192,8 @@ const (
         headers,attempts,status,metadata,cli_metadata,
         COALESCE(ed.idempotency_key, '') AS idempotency_key,
         COALESCE(url_query_params, '') AS url_query_params,
+        COALESCE(partition_key, '') AS partition_key,
+        COALESCE(batch_id, '') AS batch_id,
         description,created_at,updated_at,
         COALESCE(device_id,'') as "device_id",
         COALESCE(endpoint_id,'') as "endpoint_id"
@@ -163,7 +227,16 @@ const (
     `
 
 	updateEventDeliveryAttempts = `
-    UPDATE convoy.event_deliveries SET attempts = $1, status = $2, metadata = $3,  updated_at = now() WHERE id = $4 AND project_id = $5 AND deleted_at IS NULL;
+    UPDATE convoy.event_deliveries SET attempts = $1, status = $2, metadata = $3, description = $6, updated_at = now() WHERE id = $4 AND project_id = $5 AND deleted_at IS NULL;
+    `
+
+	discardEventDeliveries = `
+    UPDATE convoy.event_deliveries SET status = ?, description = ?, updated_at = now()
+    WHERE project_id = ? AND id IN (?) AND deleted_at IS NULL;
+    `
+
+	updateEventDeliveryMetadata = `
+    UPDATE convoy.event_deliveries SET status = $1, metadata = $2, updated_at = now() WHERE id = $3 AND project_id = $4 AND deleted_at IS NULL;
     `
 
 	softDeleteProjectEventDeliveries = `
@@ -182,6 +255,7 @@ func NewEventDeliveryRepo(db database.Database) datastore.EventDeliveryRepositor
 func (e *eventDeliveryRepo) CreateEventDelivery(ctx context.Context, delivery *datastore.EventDelivery) error {
 	var endpointID *string
 	var deviceID *string
+	var partitionKey *string
 
 	if !util.IsStringEmpty(delivery.EndpointID) {
 		endpointID = &delivery.EndpointID
@@ -191,12 +265,16 @@ func (e *eventDeliveryRepo) CreateEventDelivery(ctx context.Context, delivery *d
 		deviceID = &delivery.DeviceID
 	}
 
+	if !util.IsStringEmpty(delivery.PartitionKey) {
+		partitionKey = &delivery.PartitionKey
+	}
+
 	result, err := e.db.ExecContext(
 		ctx, createEventDelivery, delivery.UID, delivery.ProjectID,
 		delivery.EventID, endpointID, deviceID,
 		delivery.SubscriptionID, delivery.Headers, delivery.DeliveryAttempts, delivery.Status,
 		delivery.Metadata, delivery.CLIMetadata, delivery.Description, delivery.URLQueryParams, delivery.IdempotencyKey,
-		delivery.CreatedAt, delivery.UpdatedAt,
+		delivery.CreatedAt, delivery.UpdatedAt, partitionKey,
 	)
 	if err != nil {
 		return err
@@ -224,9 +302,94 @@ func (e *eventDeliveryRepo) FindEventDeliveryByID(ctx context.Context, projectID
 		return nil, err
 	}
 
+	err = e.restorePayloads(ctx, []datastore.EventDelivery{*eventDelivery})
+	if err != nil {
+		return nil, err
+	}
+
+	return eventDelivery, nil
+}
+
+func (e *eventDeliveryRepo) FindPendingEventDeliveryBefore(ctx context.Context, projectID string, delivery *datastore.EventDelivery) (*datastore.EventDelivery, error) {
+	eventDelivery := &datastore.EventDelivery{}
+	err := e.db.QueryRowxContext(ctx, fetchPendingEventDeliveryBefore, projectID, delivery.EndpointID, delivery.PartitionKey,
+		delivery.EventID, delivery.UID, datastore.ScheduledEventStatus, datastore.ProcessingEventStatus, datastore.RetryEventStatus,
+	).StructScan(eventDelivery)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, datastore.ErrEventDeliveryNotFound
+		}
+
+		return nil, err
+	}
+
+	err = e.restorePayloads(ctx, []datastore.EventDelivery{*eventDelivery})
+	if err != nil {
+		return nil, err
+	}
+
 	return eventDelivery, nil
 }
 
+func (e *eventDeliveryRepo) CountPendingBatchEventDeliveries(ctx context.Context, projectID string, subscriptionID string) (int64, error) {
+	var count int64
+	err := e.db.QueryRowxContext(ctx, countPendingBatchEventDeliveries, projectID, subscriptionID, datastore.ScheduledEventStatus).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (e *eventDeliveryRepo) CreateEventDeliveryBatch(ctx context.Context, projectID string, subscriptionID string, batchID string, config datastore.BatchConfiguration) (int64, error) {
+	result, err := e.db.ExecContext(ctx, createEventDeliveryBatch, projectID, subscriptionID, batchID,
+		datastore.ScheduledEventStatus, config.MaxCount, config.MaxBytes,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (e *eventDeliveryRepo) FindEventDeliveriesByBatchID(ctx context.Context, projectID string, batchID string) ([]datastore.EventDelivery, error) {
+	eventDeliveries := make([]datastore.EventDelivery, 0)
+
+	q := fmt.Sprintf(fetchEventDeliveries, "batch_id = $1 AND project_id = $2")
+	rows, err := e.db.QueryxContext(ctx, q, batchID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ed datastore.EventDelivery
+		err = rows.StructScan(&ed)
+		if err != nil {
+			return nil, err
+		}
+
+		eventDeliveries = append(eventDeliveries, ed)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	err = e.restorePayloads(ctx, eventDeliveries)
+	if err != nil {
+		return nil, err
+	}
+
+	// ids are ulids, so this keeps the batch in creation order
+	sort.Slice(eventDeliveries, func(i, j int) bool {
+		return eventDeliveries[i].UID < eventDeliveries[j].UID
+	})
+
+	return eventDeliveries, nil
+}
+
 func (e *eventDeliveryRepo) FindEventDeliveriesByIDs(ctx context.Context, projectID string, ids []string) ([]datastore.EventDelivery, error) {
 	eventDeliveries := make([]datastore.EventDelivery, 0)
 
@@ -252,6 +415,11 @@ func (e *eventDeliveryRepo) FindEventDeliveriesByIDs(ctx context.Context, projec
 		eventDeliveries = append(eventDeliveries, ed)
 	}
 
+	err = e.restorePayloads(ctx, eventDeliveries)
+	if err != nil {
+		return nil, err
+	}
+
 	return eventDeliveries, rows.Close()
 }
 
@@ -274,6 +442,11 @@ func (e *eventDeliveryRepo) FindEventDeliveriesByEventID(ctx context.Context, pr
 		eventDeliveries = append(eventDeliveries, ed)
 	}
 
+	err = e.restorePayloads(ctx, eventDeliveries)
+	if err != nil {
+		return nil, err
+	}
+
 	return eventDeliveries, rows.Close()
 }
 
@@ -342,6 +515,42 @@ func (e *eventDeliveryRepo) UpdateStatusOfEventDeliveries(ctx context.Context, p
 	return nil
 }
 
+func (e *eventDeliveryRepo) DiscardScheduledEventDeliveries(ctx context.Context, projectID string, eventID string, reason string) (int64, error) {
+	result, err := e.db.ExecContext(ctx, discardScheduledEventDeliveries, projectID, eventID,
+		datastore.DiscardedEventStatus, reason, datastore.ScheduledEventStatus,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (e *eventDeliveryRepo) DiscardEventDeliveries(ctx context.Context, projectID string, ids []string, reason string) error {
+	query, args, err := sqlx.In(discardEventDeliveries, datastore.DiscardedEventStatus, reason, projectID, ids)
+	if err != nil {
+		return err
+	}
+
+	query = e.db.Rebind(query)
+
+	result, err := e.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrEventDeliveryStatusNotUpdated
+	}
+
+	return nil
+}
+
 func (e *eventDeliveryRepo) FindDiscardedEventDeliveries(ctx context.Context, projectID, deviceId string, searchParams datastore.SearchParams) ([]datastore.EventDelivery, error) {
 	eventDeliveries := make([]datastore.EventDelivery, 0)
 
@@ -363,13 +572,18 @@ func (e *eventDeliveryRepo) FindDiscardedEventDeliveries(ctx context.Context, pr
 		eventDeliveries = append(eventDeliveries, ed)
 	}
 
+	err = e.restorePayloads(ctx, eventDeliveries)
+	if err != nil {
+		return nil, err
+	}
+
 	return eventDeliveries, rows.Close()
 }
 
 func (e *eventDeliveryRepo) UpdateEventDeliveryWithAttempt(ctx context.Context, projectID string, delivery datastore.EventDelivery, attempt datastore.DeliveryAttempt) error {
 	delivery.DeliveryAttempts = append(delivery.DeliveryAttempts, attempt)
 
-	result, err := e.db.ExecContext(ctx, updateEventDeliveryAttempts, delivery.DeliveryAttempts, delivery.Status, delivery.Metadata, delivery.UID, projectID)
+	result, err := e.db.ExecContext(ctx, updateEventDeliveryAttempts, delivery.DeliveryAttempts, delivery.Status, delivery.Metadata, delivery.UID, projectID, delivery.Description)
 	if err != nil {
 		return err
 	}
@@ -387,6 +601,25 @@ func (e *eventDeliveryRepo) UpdateEventDeliveryWithAttempt(ctx context.Context,
 	return nil
 }
 
+func (e *eventDeliveryRepo) UpdateEventDeliveryMetadata(ctx context.Context, projectID string, delivery *datastore.EventDelivery) error {
+	result, err := e.db.ExecContext(ctx, updateEventDeliveryMetadata, delivery.Status, delivery.Metadata, delivery.UID, projectID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrEventDeliveryMetadataNotUpdated
+	}
+
+	go e.hook.Fire(datastore.EventDeliveryUpdated, delivery)
+	return nil
+}
+
 func (e *eventDeliveryRepo) CountEventDeliveries(ctx context.Context, projectID string, endpointIDs []string, eventID string, status []datastore.EventDeliveryStatus, params datastore.SearchParams) (int64, error) {
 	count := struct {
 		Count int64
@@ -540,6 +773,8 @@ func (e *eventDeliveryRepo) LoadEventDeliveriesPaged(ctx context.Context, projec
 			DeviceID:       ev.DeviceID,
 			SubscriptionID: ev.SubscriptionID,
 			IdempotencyKey: ev.IdempotencyKey,
+			PartitionKey:   ev.PartitionKey,
+			BatchID:        ev.BatchID,
 			Headers:        ev.Headers,
 			URLQueryParams: ev.URLQueryParams,
 			Endpoint: &datastore.Endpoint{
@@ -618,12 +853,71 @@ func (e *eventDeliveryRepo) LoadEventDeliveriesPaged(ctx context.Context, projec
 		eventDeliveries = eventDeliveries[:len(eventDeliveries)-1]
 	}
 
+	err = e.restorePayloads(ctx, eventDeliveries)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
 	pagination := &datastore.PaginationData{PrevRowCount: count}
 	pagination = pagination.Build(pageable, ids)
 
 	return eventDeliveries, *pagination, nil
 }
 
+// restorePayloads sets the data of deliveries whose event payload was
+// compressed or offloaded, they share the payload stored with the event.
+func (e *eventDeliveryRepo) restorePayloads(ctx context.Context, deliveries []datastore.EventDelivery) error {
+	var eventIDs []string
+	for i := range deliveries {
+		if deliveries[i].Metadata != nil && deliveries[i].Metadata.Payload != nil {
+			eventIDs = append(eventIDs, deliveries[i].EventID)
+		}
+	}
+
+	if len(eventIDs) == 0 {
+		return nil
+	}
+
+	query, args, err := sqlx.In(fetchEventPayloads, eventIDs)
+	if err != nil {
+		return err
+	}
+
+	rows, err := e.db.QueryxContext(ctx, e.db.Rebind(query), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	payloads := make(map[string][]byte, len(eventIDs))
+	for rows.Next() {
+		var event datastore.Event
+		err = rows.StructScan(&event)
+		if err != nil {
+			return err
+		}
+
+		payloads[event.UID], err = payload.Get().Decode(ctx, event.Data, event.Payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range deliveries {
+		md := deliveries[i].Metadata
+		if md == nil || md.Payload == nil {
+			continue
+		}
+
+		if data, ok := payloads[deliveries[i].EventID]; ok {
+			md.Data = data
+			md.Raw = string(data)
+		}
+	}
+
+	return nil
+}
+
 const (
 	dailyIntervalFormat   = "yyyy-mm-dd"        // 1 day
 	weeklyIntervalFormat  = dailyIntervalFormat // 1 week
@@ -785,6 +1079,8 @@ type EventDeliveryPaginated struct {
 	Headers        httpheader.HTTPHeader `json:"headers" db:"headers"`
 	URLQueryParams string                `json:"url_query_params" db:"url_query_params"`
 	IdempotencyKey string                `json:"idempotency_key" db:"idempotency_key"`
+	PartitionKey   string                `json:"partition_key,omitempty" db:"partition_key"`
+	BatchID        string                `json:"batch_id,omitempty" db:"batch_id"`
 
 	Endpoint *EndpointMetadata `json:"endpoint_metadata,omitempty" db:"endpoint_metadata"`
 	Event    *EventMetadata    `json:"event_metadata,omitempty" db:"event_metadata"`
@@ -347,6 +347,200 @@ func Test_eventDeliveryRepo_UpdateEventDeliveryWithAttempt(t *testing.T) {
 	require.Equal(t, newAttempt, dbEventDelivery.DeliveryAttempts[1])
 }
 
+func Test_eventDeliveryRepo_UpdateEventDeliveryMetadata(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	source := seedSource(t, db)
+	project := seedProject(t, db)
+	device := seedDevice(t, db)
+	endpoint := seedEndpoint(t, db)
+	event := seedEvent(t, db, project)
+	sub := seedSubscription(t, db, project, source, endpoint, device)
+
+	ed := generateEventDelivery(project, endpoint, event, device, sub)
+
+	edRepo := NewEventDeliveryRepo(db)
+	err := edRepo.CreateEventDelivery(context.Background(), ed)
+	require.NoError(t, err)
+
+	ed.Status = datastore.FailureEventStatus
+	ed.Metadata.NumTrials = 0
+
+	err = edRepo.UpdateEventDeliveryMetadata(context.Background(), project.UID, ed)
+	require.NoError(t, err)
+
+	dbEventDelivery, err := edRepo.FindEventDeliveryByID(context.Background(), project.UID, ed.UID)
+	require.NoError(t, err)
+
+	require.Equal(t, datastore.FailureEventStatus, dbEventDelivery.Status)
+	require.Equal(t, uint64(0), dbEventDelivery.Metadata.NumTrials)
+}
+
+func Test_eventDeliveryRepo_FindPendingEventDeliveryBefore(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	source := seedSource(t, db)
+	project := seedProject(t, db)
+	device := seedDevice(t, db)
+	endpoint := seedEndpoint(t, db)
+	event := seedEvent(t, db, project)
+	sub := seedSubscription(t, db, project, source, endpoint, device)
+
+	edRepo := NewEventDeliveryRepo(db)
+
+	first := generateEventDelivery(project, endpoint, event, device, sub)
+	first.Status = datastore.RetryEventStatus
+	first.PartitionKey = endpoint.UID
+	require.NoError(t, edRepo.CreateEventDelivery(context.Background(), first))
+
+	second := generateEventDelivery(project, endpoint, event, device, sub)
+	second.Status = datastore.ScheduledEventStatus
+	second.PartitionKey = endpoint.UID
+	require.NoError(t, edRepo.CreateEventDelivery(context.Background(), second))
+
+	head, err := edRepo.FindPendingEventDeliveryBefore(context.Background(), project.UID, second)
+	require.NoError(t, err)
+	require.Equal(t, first.UID, head.UID)
+
+	_, err = edRepo.FindPendingEventDeliveryBefore(context.Background(), project.UID, first)
+	require.ErrorIs(t, err, datastore.ErrEventDeliveryNotFound)
+
+	// a delivery that has succeeded no longer holds back the ones after it
+	require.NoError(t, edRepo.UpdateStatusOfEventDelivery(context.Background(), project.UID, *first, datastore.SuccessEventStatus))
+
+	_, err = edRepo.FindPendingEventDeliveryBefore(context.Background(), project.UID, second)
+	require.ErrorIs(t, err, datastore.ErrEventDeliveryNotFound)
+}
+
+func Test_eventDeliveryRepo_DiscardScheduledEventDeliveries(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	source := seedSource(t, db)
+	project := seedProject(t, db)
+	device := seedDevice(t, db)
+	endpoint := seedEndpoint(t, db)
+	event := seedEvent(t, db, project)
+	sub := seedSubscription(t, db, project, source, endpoint, device)
+
+	edRepo := NewEventDeliveryRepo(db)
+
+	held := generateEventDelivery(project, endpoint, event, device, sub)
+	held.Status = datastore.ScheduledEventStatus
+	held.PartitionKey = endpoint.UID
+	require.NoError(t, edRepo.CreateEventDelivery(context.Background(), held))
+
+	due := generateEventDelivery(project, endpoint, event, device, sub)
+	due.Status = datastore.ScheduledEventStatus
+	due.PartitionKey = endpoint.UID
+	due.Metadata.NextSendTime = time.Now().Add(-time.Minute)
+	require.NoError(t, edRepo.CreateEventDelivery(context.Background(), due))
+
+	// a delivery held until later doesn't block the partition
+	_, err := edRepo.FindPendingEventDeliveryBefore(context.Background(), project.UID, due)
+	require.ErrorIs(t, err, datastore.ErrEventDeliveryNotFound)
+
+	n, err := edRepo.DiscardScheduledEventDeliveries(context.Background(), project.UID, event.UID, "cancelled")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	dbHeld, err := edRepo.FindEventDeliveryByID(context.Background(), project.UID, held.UID)
+	require.NoError(t, err)
+	require.Equal(t, datastore.DiscardedEventStatus, dbHeld.Status)
+
+	dbDue, err := edRepo.FindEventDeliveryByID(context.Background(), project.UID, due.UID)
+	require.NoError(t, err)
+	require.Equal(t, datastore.ScheduledEventStatus, dbDue.Status)
+
+	n, err = edRepo.DiscardScheduledEventDeliveries(context.Background(), project.UID, event.UID, "cancelled")
+	require.NoError(t, err)
+	require.Equal(t, int64(0), n)
+}
+
+func Test_eventDeliveryRepo_DiscardEventDeliveries(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	source := seedSource(t, db)
+	project := seedProject(t, db)
+	device := seedDevice(t, db)
+	endpoint := seedEndpoint(t, db)
+	event := seedEvent(t, db, project)
+	sub := seedSubscription(t, db, project, source, endpoint, device)
+
+	edRepo := NewEventDeliveryRepo(db)
+
+	expired := generateEventDelivery(project, endpoint, event, device, sub)
+	require.NoError(t, edRepo.CreateEventDelivery(context.Background(), expired))
+
+	live := generateEventDelivery(project, endpoint, event, device, sub)
+	require.NoError(t, edRepo.CreateEventDelivery(context.Background(), live))
+
+	err := edRepo.DiscardEventDeliveries(context.Background(), project.UID, []string{expired.UID}, "expired")
+	require.NoError(t, err)
+
+	dbExpired, err := edRepo.FindEventDeliveryByID(context.Background(), project.UID, expired.UID)
+	require.NoError(t, err)
+	require.Equal(t, datastore.DiscardedEventStatus, dbExpired.Status)
+	require.Equal(t, "expired", dbExpired.Description)
+
+	dbLive, err := edRepo.FindEventDeliveryByID(context.Background(), project.UID, live.UID)
+	require.NoError(t, err)
+	require.Equal(t, live.Status, dbLive.Status)
+}
+
+func Test_eventDeliveryRepo_CreateEventDeliveryBatch(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	source := seedSource(t, db)
+	project := seedProject(t, db)
+	device := seedDevice(t, db)
+	endpoint := seedEndpoint(t, db)
+	event := seedEvent(t, db, project)
+	sub := seedSubscription(t, db, project, source, endpoint, device)
+
+	edRepo := NewEventDeliveryRepo(db)
+
+	for i := 0; i < 5; i++ {
+		ed := generateEventDelivery(project, endpoint, event, device, sub)
+		ed.Status = datastore.ScheduledEventStatus
+		ed.Metadata.NextSendTime = time.Now()
+		require.NoError(t, edRepo.CreateEventDelivery(context.Background(), ed))
+	}
+
+	// a scheduled delivery isn't batched before it's due
+	notDue := generateEventDelivery(project, endpoint, event, device, sub)
+	notDue.Status = datastore.ScheduledEventStatus
+	require.NoError(t, edRepo.CreateEventDelivery(context.Background(), notDue))
+
+	count, err := edRepo.CountPendingBatchEventDeliveries(context.Background(), project.UID, sub.UID)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), count)
+
+	// each payload is 15 bytes, so only two fit under the byte limit
+	batchID := ulid.Make().String()
+	n, err := edRepo.CreateEventDeliveryBatch(context.Background(), project.UID, sub.UID, batchID, datastore.BatchConfiguration{MaxCount: 3, MaxBytes: 40})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+
+	n, err = edRepo.CreateEventDeliveryBatch(context.Background(), project.UID, sub.UID, ulid.Make().String(), datastore.BatchConfiguration{MaxCount: 10})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), n)
+
+	count, err = edRepo.CountPendingBatchEventDeliveries(context.Background(), project.UID, sub.UID)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), count)
+
+	deliveries, err := edRepo.FindEventDeliveriesByBatchID(context.Background(), project.UID, batchID)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(deliveries))
+	require.Equal(t, batchID, deliveries[0].BatchID)
+	require.True(t, deliveries[0].UID < deliveries[1].UID)
+}
+
 func Test_eventDeliveryRepo_CountEventDeliveries(t *testing.T) {
 	db, closeFn := getDB(t)
 	defer closeFn()
@@ -7,11 +7,15 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/database"
 	"github.com/frain-dev/convoy/datastore"
+	objectstore "github.com/frain-dev/convoy/datastore/object-store"
+	"github.com/frain-dev/convoy/internal/pkg/payload"
 	"github.com/frain-dev/convoy/pkg/httpheader"
 	"github.com/frain-dev/convoy/util"
 	"github.com/oklog/ulid/v2"
@@ -38,6 +42,71 @@ func Test_CreateEvent(t *testing.T) {
 	require.Equal(t, event, newEvent)
 }
 
+func Test_CreateEvents(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	eventRepo := NewEventRepo(db)
+	ctx := context.Background()
+
+	first := generateEvent(t, db)
+	events := []*datastore.Event{first}
+	for i := 0; i < 2; i++ {
+		event := *first
+		event.UID = ulid.Make().String()
+		events = append(events, &event)
+	}
+
+	require.NoError(t, eventRepo.CreateEvents(ctx, events))
+
+	for _, event := range events {
+		newEvent, err := eventRepo.FindEventByID(ctx, event.ProjectID, event.UID)
+		require.NoError(t, err)
+		require.Equal(t, event.Endpoints, newEvent.Endpoints)
+	}
+
+	// a failing event rolls back the rest of the batch
+	fresh := *first
+	fresh.UID = ulid.Make().String()
+	require.Error(t, eventRepo.CreateEvents(ctx, []*datastore.Event{&fresh, first}))
+
+	_, err := eventRepo.FindEventByID(ctx, fresh.ProjectID, fresh.UID)
+	require.ErrorIs(t, err, datastore.ErrEventNotFound)
+}
+
+func Test_CreateEvent_StoredPayload(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	objects, err := objectstore.NewOnPremClient(objectstore.ObjectStoreOptions{OnPremStorageDir: t.TempDir()})
+	require.NoError(t, err)
+
+	payload.Init(payload.NewStore(config.PayloadStorageConfiguration{
+		Compression:          "gzip",
+		CompressionThreshold: 16,
+		OffloadThreshold:     64,
+	}, objects))
+	defer payload.Init(&payload.Store{})
+
+	eventRepo := NewEventRepo(db)
+	ctx := context.Background()
+
+	for _, data := range []string{`{"name":"convoy"}`, `{"items":"` + strings.Repeat("convoy", 20) + `"}`} {
+		event := generateEvent(t, db)
+		event.Data = []byte(data)
+		event.Raw = data
+
+		require.NoError(t, eventRepo.CreateEvent(ctx, event))
+		require.NotNil(t, event.Payload)
+		require.Equal(t, len(data) > 64, event.Payload.IsOffloaded())
+
+		newEvent, err := eventRepo.FindEventByID(ctx, event.ProjectID, event.UID)
+		require.NoError(t, err)
+		require.Equal(t, data, string(newEvent.Data))
+		require.Equal(t, data, newEvent.Raw)
+	}
+}
+
 func Test_FindEventByID(t *testing.T) {
 	db, closeFn := getDB(t)
 	defer closeFn()
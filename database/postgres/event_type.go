@@ -0,0 +1,261 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrEventTypeNotCreated = errors.New("event type could not be created")
+	ErrEventTypeNotUpdated = errors.New("event type could not be updated")
+	ErrEventTypeNotDeleted = errors.New("event type could not be deleted")
+)
+
+const (
+	createEventType = `
+	INSERT INTO convoy.event_types (id, project_id, name, description, version, json_schema, deprecated)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	updateEventType = `
+	UPDATE convoy.event_types SET
+	description = $3,
+	version = $4,
+	json_schema = $5,
+	deprecated = $6,
+	updated_at = now()
+	WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL;
+	`
+
+	baseFetchEventType = `
+	SELECT id, project_id, name,
+	COALESCE(description, '') AS description,
+	COALESCE(version, '') AS version,
+	COALESCE(json_schema, 'null') AS json_schema,
+	deprecated, created_at, updated_at
+	FROM convoy.event_types
+	`
+
+	fetchEventTypeById = baseFetchEventType + `WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL;`
+
+	fetchEventTypeByName = baseFetchEventType + `WHERE name = $1 AND project_id = $2 AND deleted_at IS NULL;`
+
+	baseEventTypesPaged = `
+	SELECT et.id, et.project_id, et.name,
+	COALESCE(et.description, '') AS description,
+	COALESCE(et.version, '') AS version,
+	COALESCE(et.json_schema, 'null') AS json_schema,
+	et.deprecated, et.created_at, et.updated_at
+	FROM convoy.event_types et
+	WHERE et.deleted_at IS NULL
+	AND et.project_id = :project_id
+	`
+
+	eventTypesPagedForward = `%s AND et.id <= :cursor
+	ORDER BY et.id DESC
+	LIMIT :limit
+	`
+
+	eventTypesPagedBackward = `
+	WITH event_types AS (
+		%s AND et.id >= :cursor
+		ORDER BY et.id ASC
+		LIMIT :limit
+	)
+
+	SELECT * FROM event_types ORDER BY id DESC
+	`
+
+	countPrevEventTypes = `
+	SELECT count(distinct(et.id)) AS count
+	FROM convoy.event_types et
+	WHERE et.deleted_at IS NULL
+	AND et.project_id = :project_id
+	AND et.id > :cursor GROUP BY et.id ORDER BY et.id DESC LIMIT 1`
+
+	softDeleteEventType = `
+	UPDATE convoy.event_types SET deleted_at = now()
+	WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL;
+	`
+)
+
+type eventTypeRepo struct {
+	db *sqlx.DB
+}
+
+func NewEventTypeRepo(db database.Database) datastore.EventTypeRepository {
+	return &eventTypeRepo{db: db.GetDB()}
+}
+
+func (e *eventTypeRepo) CreateEventType(ctx context.Context, eventType *datastore.ProjectEventType) error {
+	r, err := e.db.ExecContext(ctx, createEventType, eventType.UID, eventType.ProjectID, eventType.Name,
+		eventType.Description, eventType.Version, jsonSchema(eventType), eventType.Deprecated,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate") {
+			return datastore.ErrDuplicateEventType
+		}
+		return err
+	}
+
+	rowsAffected, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrEventTypeNotCreated
+	}
+
+	return nil
+}
+
+func (e *eventTypeRepo) UpdateEventType(ctx context.Context, eventType *datastore.ProjectEventType) error {
+	r, err := e.db.ExecContext(ctx, updateEventType, eventType.UID, eventType.ProjectID,
+		eventType.Description, eventType.Version, jsonSchema(eventType), eventType.Deprecated,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrEventTypeNotUpdated
+	}
+
+	return nil
+}
+
+func (e *eventTypeRepo) FindEventTypeByID(ctx context.Context, projectID string, id string) (*datastore.ProjectEventType, error) {
+	eventType := &datastore.ProjectEventType{}
+	err := e.db.QueryRowxContext(ctx, fetchEventTypeById, id, projectID).StructScan(eventType)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, datastore.ErrEventTypeNotFound
+		}
+
+		return nil, err
+	}
+
+	return eventType, nil
+}
+
+func (e *eventTypeRepo) FindEventTypeByName(ctx context.Context, projectID string, name string) (*datastore.ProjectEventType, error) {
+	eventType := &datastore.ProjectEventType{}
+	err := e.db.QueryRowxContext(ctx, fetchEventTypeByName, name, projectID).StructScan(eventType)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, datastore.ErrEventTypeNotFound
+		}
+
+		return nil, err
+	}
+
+	return eventType, nil
+}
+
+func (e *eventTypeRepo) LoadEventTypesPaged(ctx context.Context, projectID string, pageable datastore.Pageable) ([]datastore.ProjectEventType, datastore.PaginationData, error) {
+	arg := map[string]interface{}{
+		"project_id": projectID,
+		"limit":      pageable.Limit(),
+		"cursor":     pageable.Cursor(),
+	}
+
+	var query string
+	if pageable.Direction == datastore.Next {
+		query = eventTypesPagedForward
+	} else {
+		query = eventTypesPagedBackward
+	}
+
+	query, args, err := sqlx.Named(fmt.Sprintf(query, baseEventTypesPaged), arg)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	query = e.db.Rebind(query)
+	rows, err := e.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	eventTypes := make([]datastore.ProjectEventType, 0)
+	for rows.Next() {
+		var data datastore.ProjectEventType
+
+		err = rows.StructScan(&data)
+		if err != nil {
+			return nil, datastore.PaginationData{}, err
+		}
+
+		eventTypes = append(eventTypes, data)
+	}
+
+	var count datastore.PrevRowCount
+	if len(eventTypes) > 0 {
+		qarg := arg
+		qarg["cursor"] = eventTypes[0].UID
+
+		countQuery, qargs, err := sqlx.Named(countPrevEventTypes, qarg)
+		if err != nil {
+			return nil, datastore.PaginationData{}, err
+		}
+
+		err = e.db.GetContext(ctx, &count, e.db.Rebind(countQuery), qargs...)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, datastore.PaginationData{}, err
+		}
+	}
+
+	ids := make([]string, len(eventTypes))
+	for i := range eventTypes {
+		ids[i] = eventTypes[i].UID
+	}
+
+	if len(eventTypes) > pageable.PerPage {
+		eventTypes = eventTypes[:len(eventTypes)-1]
+	}
+
+	pagination := &datastore.PaginationData{PrevRowCount: count}
+	pagination = pagination.Build(pageable, ids)
+
+	return eventTypes, *pagination, rows.Close()
+}
+
+func (e *eventTypeRepo) DeleteEventType(ctx context.Context, projectID string, id string) error {
+	result, err := e.db.ExecContext(ctx, softDeleteEventType, id, projectID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrEventTypeNotDeleted
+	}
+
+	return nil
+}
+
+// jsonSchema stores a missing schema as NULL rather than an empty document.
+func jsonSchema(eventType *datastore.ProjectEventType) interface{} {
+	if len(eventType.JSONSchema) == 0 || string(eventType.JSONSchema) == "null" {
+		return nil
+	}
+
+	return []byte(eventType.JSONSchema)
+}
@@ -0,0 +1,110 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateEventType(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	repo := NewEventTypeRepo(db)
+	eventType := generateEventType(t, db)
+
+	require.NoError(t, repo.CreateEventType(context.Background(), eventType))
+
+	duplicate := generateEventType(t, db)
+	duplicate.ProjectID = eventType.ProjectID
+	require.ErrorIs(t, repo.CreateEventType(context.Background(), duplicate), datastore.ErrDuplicateEventType)
+
+	newEventType, err := repo.FindEventTypeByName(context.Background(), eventType.ProjectID, eventType.Name)
+	require.NoError(t, err)
+	require.Equal(t, eventType.UID, newEventType.UID)
+	require.JSONEq(t, string(eventType.JSONSchema), string(newEventType.JSONSchema))
+}
+
+func Test_UpdateEventType(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	repo := NewEventTypeRepo(db)
+	eventType := generateEventType(t, db)
+	require.NoError(t, repo.CreateEventType(context.Background(), eventType))
+
+	eventType.Version = "v2"
+	eventType.Deprecated = true
+	eventType.JSONSchema = nil
+	require.NoError(t, repo.UpdateEventType(context.Background(), eventType))
+
+	newEventType, err := repo.FindEventTypeByID(context.Background(), eventType.ProjectID, eventType.UID)
+	require.NoError(t, err)
+	require.Equal(t, "v2", newEventType.Version)
+	require.True(t, newEventType.Deprecated)
+	require.Equal(t, "null", string(newEventType.JSONSchema))
+}
+
+func Test_DeleteEventType(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	repo := NewEventTypeRepo(db)
+	eventType := generateEventType(t, db)
+	require.NoError(t, repo.CreateEventType(context.Background(), eventType))
+
+	require.NoError(t, repo.DeleteEventType(context.Background(), eventType.ProjectID, eventType.UID))
+
+	_, err := repo.FindEventTypeByID(context.Background(), eventType.ProjectID, eventType.UID)
+	require.ErrorIs(t, err, datastore.ErrEventTypeNotFound)
+
+	// the name is free again once the type is deleted
+	eventType.UID = ulid.Make().String()
+	require.NoError(t, repo.CreateEventType(context.Background(), eventType))
+}
+
+func Test_LoadEventTypesPaged(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	repo := NewEventTypeRepo(db)
+	project := seedProject(t, db)
+
+	for _, name := range []string{"user.created", "user.updated", "user.deleted"} {
+		require.NoError(t, repo.CreateEventType(context.Background(), &datastore.ProjectEventType{
+			UID:       ulid.Make().String(),
+			ProjectID: project.UID,
+			Name:      name,
+		}))
+	}
+
+	eventTypes, pagination, err := repo.LoadEventTypesPaged(context.Background(), project.UID, datastore.Pageable{
+		PerPage:    2,
+		Direction:  datastore.Next,
+		NextCursor: datastore.DefaultCursor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(eventTypes))
+	require.True(t, pagination.HasNextPage)
+	require.Equal(t, "user.deleted", eventTypes[0].Name)
+}
+
+func generateEventType(t *testing.T, db database.Database) *datastore.ProjectEventType {
+	project := seedProject(t, db)
+
+	return &datastore.ProjectEventType{
+		UID:         ulid.Make().String(),
+		ProjectID:   project.UID,
+		Name:        "user.created",
+		Description: "a user signed up",
+		Version:     "v1",
+		JSONSchema:  []byte(`{"type": "object", "required": ["id"]}`),
+	}
+}
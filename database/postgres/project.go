@@ -10,6 +10,7 @@ import (
 	"github.com/oklog/ulid/v2"
 
 	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/database/hooks"
 	"github.com/frain-dev/convoy/datastore"
 )
 
@@ -35,12 +36,16 @@ const (
 		strategy_duration, strategy_retry_count,
 		signature_header, signature_versions, disable_endpoint,
 		meta_events_enabled, meta_events_type, meta_events_event_type,
-		meta_events_url, meta_events_secret, meta_events_pub_sub
+		meta_events_url, meta_events_secret, meta_events_pub_sub,
+		strategy_max_duration, strategy_jitter, strategy_intervals,
+		strategy_honor_retry_after, signature_scheme,
+		schema_validation_enabled
 	  )
 	  VALUES
 		(
 		  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13,
-		  $14, $15, $16, $17, $18, $19
+		  $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24,
+		  $25
 		);
 	`
 
@@ -64,6 +69,12 @@ const (
 		meta_events_url = $17,
 		meta_events_secret = $18,
 		meta_events_pub_sub = $19,
+		strategy_max_duration = $20,
+		strategy_jitter = $21,
+		strategy_intervals = $22,
+		strategy_honor_retry_after = $23,
+		signature_scheme = $24,
+		schema_validation_enabled = $25,
 		updated_at = now()
 	WHERE id = $1 AND deleted_at IS NULL;
 	`
@@ -85,7 +96,12 @@ const (
 		c.strategy_type as "config.strategy.type",
 		c.strategy_duration as "config.strategy.duration",
 		c.strategy_retry_count as "config.strategy.retry_count",
+		c.strategy_max_duration as "config.strategy.max_duration",
+		c.strategy_jitter as "config.strategy.jitter",
+		c.strategy_intervals as "config.strategy.intervals",
+		c.strategy_honor_retry_after as "config.strategy.honor_retry_after",
 		c.signature_header as "config.signature.header",
+		c.signature_scheme as "config.signature.scheme",
 		c.signature_versions as "config.signature.versions",
 		c.disable_endpoint as "config.disable_endpoint",
 		c.meta_events_enabled as "config.meta_event.is_enabled",
@@ -94,6 +110,7 @@ const (
 		COALESCE(c.meta_events_url, '') as "config.meta_event.url",
 		COALESCE(c.meta_events_secret, '') as "config.meta_event.secret",
 		c.meta_events_pub_sub as "config.meta_event.pub_sub",
+		c.schema_validation_enabled as "config.schema_validation_enabled",
 		p.created_at,
 		p.updated_at,
 		p.deleted_at
@@ -121,7 +138,12 @@ const (
 	c.strategy_type as "config.strategy.type",
 	c.strategy_duration as "config.strategy.duration",
 	c.strategy_retry_count as "config.strategy.retry_count",
+	c.strategy_max_duration as "config.strategy.max_duration",
+	c.strategy_jitter as "config.strategy.jitter",
+	c.strategy_intervals as "config.strategy.intervals",
+	c.strategy_honor_retry_after as "config.strategy.honor_retry_after",
 	c.signature_header as "config.signature.header",
+	c.signature_scheme as "config.signature.scheme",
 	c.signature_versions as "config.signature.versions",
 	c.meta_events_enabled as "config.meta_event.is_enabled",
 	COALESCE(c.meta_events_type, '') as "config.meta_event.type",
@@ -129,6 +151,7 @@ const (
 	COALESCE(c.meta_events_url, '') as "config.meta_event.url",
 	COALESCE(c.meta_events_secret, '') as "config.meta_event.secret",
 	c.meta_events_pub_sub as "config.meta_event.pub_sub",
+	c.schema_validation_enabled as "config.schema_validation_enabled",
 	p.created_at,
 	p.updated_at,
 	p.deleted_at
@@ -185,11 +208,12 @@ const (
 )
 
 type projectRepo struct {
-	db *sqlx.DB
+	db   *sqlx.DB
+	hook *hooks.Hook
 }
 
 func NewProjectRepo(db database.Database) datastore.ProjectRepository {
-	return &projectRepo{db: db.GetDB()}
+	return &projectRepo{db: db.GetDB(), hook: db.GetHook()}
 }
 
 func (p *projectRepo) CreateProject(ctx context.Context, project *datastore.Project) error {
@@ -226,6 +250,12 @@ func (p *projectRepo) CreateProject(ctx context.Context, project *datastore.Proj
 		me.URL,
 		me.Secret,
 		me.PubSub,
+		sc.MaxDuration,
+		sc.Jitter,
+		sc.Intervals,
+		sc.HonorRetryAfter,
+		sgc.Scheme,
+		project.Config.IsSchemaValidationEnabled,
 	)
 	if err != nil {
 		return err
@@ -324,6 +354,12 @@ func (p *projectRepo) UpdateProject(ctx context.Context, project *datastore.Proj
 		me.URL,
 		me.Secret,
 		me.PubSub,
+		project.Config.Strategy.MaxDuration,
+		project.Config.Strategy.Jitter,
+		project.Config.Strategy.Intervals,
+		project.Config.Strategy.HonorRetryAfter,
+		project.Config.GetSignatureConfig().Scheme,
+		project.Config.IsSchemaValidationEnabled,
 	)
 	if err != nil {
 		return err
@@ -352,7 +388,13 @@ func (p *projectRepo) UpdateProject(ctx context.Context, project *datastore.Proj
 		}
 	}
 
-	return tx.Commit()
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	p.hook.Fire(datastore.ProjectUpdated, project)
+	return nil
 }
 
 func (p *projectRepo) FetchProjectByID(ctx context.Context, id string) (*datastore.Project, error) {
@@ -406,5 +448,11 @@ func (p *projectRepo) DeleteProject(ctx context.Context, id string) error {
 		return err
 	}
 
-	return tx.Commit()
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	p.hook.Fire(datastore.ProjectDeleted, &datastore.Project{UID: id})
+	return nil
 }
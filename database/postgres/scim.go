@@ -0,0 +1,491 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrSCIMTokenNotCreated = errors.New("scim token could not be created")
+	ErrSCIMUserNotCreated  = errors.New("scim user could not be created")
+	ErrSCIMUserNotUpdated  = errors.New("scim user could not be updated")
+	ErrSCIMGroupNotCreated = errors.New("scim group could not be created")
+	ErrSCIMGroupNotUpdated = errors.New("scim group could not be updated")
+	ErrSCIMGroupNotDeleted = errors.New("scim group could not be deleted")
+)
+
+const (
+	revokeSCIMTokens = `
+	UPDATE convoy.scim_tokens SET deleted_at = now()
+	WHERE organisation_id = $1 AND deleted_at IS NULL;
+	`
+
+	createSCIMToken = `
+	INSERT INTO convoy.scim_tokens (id, organisation_id, mask_id, hash, salt)
+	VALUES ($1, $2, $3, $4, $5);
+	`
+
+	fetchSCIMTokenByMaskID = `
+	SELECT id, organisation_id, mask_id, hash, salt, created_at, updated_at
+	FROM convoy.scim_tokens
+	WHERE mask_id = $1 AND deleted_at IS NULL;
+	`
+
+	createSCIMUser = `
+	INSERT INTO convoy.scim_users (organisation_id, user_id, external_id, provisioned)
+	VALUES ($1, $2, $3, $4);
+	`
+
+	updateSCIMUser = `
+	UPDATE convoy.scim_users SET
+	external_id = $3,
+	updated_at = now()
+	WHERE organisation_id = $1 AND user_id = $2;
+	`
+
+	baseFetchSCIMUsers = `
+	SELECT
+		s.organisation_id, s.user_id, s.external_id, s.provisioned,
+		u.first_name, u.last_name, u.email,
+		COALESCE(o.id, '') AS member_id,
+		s.created_at, s.updated_at
+	FROM convoy.scim_users s
+	JOIN convoy.users u ON u.id = s.user_id
+	LEFT JOIN convoy.organisation_members o
+		ON o.user_id = s.user_id
+		AND o.organisation_id = s.organisation_id
+		AND o.deleted_at IS NULL
+	`
+
+	fetchSCIMUser = baseFetchSCIMUsers + `WHERE s.organisation_id = $1 AND s.user_id = $2;`
+
+	fetchSCIMUsers = baseFetchSCIMUsers + `WHERE s.organisation_id = $1 ORDER BY s.created_at ASC, s.user_id ASC;`
+
+	deleteSCIMUser = `
+	DELETE FROM convoy.scim_users
+	WHERE organisation_id = $1 AND user_id = $2;
+	`
+
+	deleteSCIMUserGroupMemberships = `
+	DELETE FROM convoy.scim_group_members
+	WHERE user_id = $2 AND group_id IN (
+		SELECT id FROM convoy.scim_groups WHERE organisation_id = $1
+	);
+	`
+
+	createSCIMGroup = `
+	INSERT INTO convoy.scim_groups (id, organisation_id, display_name, external_id, role_type, role_project)
+	VALUES ($1, $2, $3, $4, $5, $6);
+	`
+
+	updateSCIMGroup = `
+	UPDATE convoy.scim_groups SET
+	display_name = $3,
+	external_id = $4,
+	role_type = $5,
+	role_project = $6,
+	updated_at = now()
+	WHERE id = $1 AND organisation_id = $2 AND deleted_at IS NULL;
+	`
+
+	baseFetchSCIMGroups = `
+	SELECT
+		g.id, g.organisation_id, g.display_name, g.external_id,
+		g.role_type AS "role.type",
+		g.role_project AS "role.project",
+		g.created_at, g.updated_at
+	FROM convoy.scim_groups g
+	`
+
+	fetchSCIMGroupByID = baseFetchSCIMGroups + `WHERE g.id = $1 AND g.organisation_id = $2 AND g.deleted_at IS NULL;`
+
+	fetchSCIMGroups = baseFetchSCIMGroups + `WHERE g.organisation_id = $1 AND g.deleted_at IS NULL ORDER BY g.id ASC;`
+
+	fetchSCIMGroupsByUserID = baseFetchSCIMGroups + `
+	JOIN convoy.scim_group_members m ON m.group_id = g.id
+	WHERE g.organisation_id = $1 AND m.user_id = $2 AND g.deleted_at IS NULL
+	ORDER BY g.id ASC;
+	`
+
+	fetchSCIMGroupMembers = `
+	SELECT group_id, user_id FROM convoy.scim_group_members
+	WHERE group_id IN (?) ORDER BY user_id ASC;
+	`
+
+	createSCIMGroupMember = `
+	INSERT INTO convoy.scim_group_members (group_id, user_id)
+	VALUES ($1, $2) ON CONFLICT DO NOTHING;
+	`
+
+	deleteSCIMGroupMembers = `DELETE FROM convoy.scim_group_members WHERE group_id = $1;`
+
+	softDeleteSCIMGroup = `
+	UPDATE convoy.scim_groups SET deleted_at = now()
+	WHERE id = $1 AND organisation_id = $2 AND deleted_at IS NULL;
+	`
+)
+
+type scimRepo struct {
+	db *sqlx.DB
+}
+
+func NewSCIMRepo(db database.Database) datastore.SCIMRepository {
+	return &scimRepo{db: db.GetDB()}
+}
+
+func (s *scimRepo) CreateSCIMToken(ctx context.Context, token *datastore.SCIMToken) error {
+	tx, err := s.db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer rollbackTx(tx)
+
+	_, err = tx.ExecContext(ctx, revokeSCIMTokens, token.OrganisationID)
+	if err != nil {
+		return err
+	}
+
+	r, err := tx.ExecContext(ctx, createSCIMToken, token.UID, token.OrganisationID, token.MaskID, token.Hash, token.Salt)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrSCIMTokenNotCreated
+	}
+
+	return tx.Commit()
+}
+
+func (s *scimRepo) FindSCIMTokenByMaskID(ctx context.Context, maskID string) (*datastore.SCIMToken, error) {
+	token := &datastore.SCIMToken{}
+	err := s.db.QueryRowxContext(ctx, fetchSCIMTokenByMaskID, maskID).StructScan(token)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, datastore.ErrSCIMTokenNotFound
+		}
+
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (s *scimRepo) CreateSCIMUser(ctx context.Context, user *datastore.SCIMUser) error {
+	r, err := s.db.ExecContext(ctx, createSCIMUser, user.OrganisationID, user.UserID, user.ExternalID, user.Provisioned)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrSCIMUserNotCreated
+	}
+
+	return nil
+}
+
+func (s *scimRepo) UpdateSCIMUser(ctx context.Context, user *datastore.SCIMUser) error {
+	r, err := s.db.ExecContext(ctx, updateSCIMUser, user.OrganisationID, user.UserID, user.ExternalID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrSCIMUserNotUpdated
+	}
+
+	return nil
+}
+
+func (s *scimRepo) FindSCIMUser(ctx context.Context, organisationID string, userID string) (*datastore.SCIMUser, error) {
+	user := &datastore.SCIMUser{}
+	err := s.db.QueryRowxContext(ctx, fetchSCIMUser, organisationID, userID).StructScan(user)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, datastore.ErrSCIMUserNotFound
+		}
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *scimRepo) LoadSCIMUsers(ctx context.Context, organisationID string) ([]datastore.SCIMUser, error) {
+	rows, err := s.db.QueryxContext(ctx, fetchSCIMUsers, organisationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make([]datastore.SCIMUser, 0)
+	for rows.Next() {
+		var user datastore.SCIMUser
+
+		err = rows.StructScan(&user)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+func (s *scimRepo) DeleteSCIMUser(ctx context.Context, organisationID string, userID string) error {
+	tx, err := s.db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer rollbackTx(tx)
+
+	_, err = tx.ExecContext(ctx, deleteSCIMUserGroupMemberships, organisationID, userID)
+	if err != nil {
+		return err
+	}
+
+	r, err := tx.ExecContext(ctx, deleteSCIMUser, organisationID, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return datastore.ErrSCIMUserNotFound
+	}
+
+	return tx.Commit()
+}
+
+func (s *scimRepo) CreateSCIMGroup(ctx context.Context, group *datastore.SCIMGroup) error {
+	tx, err := s.db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer rollbackTx(tx)
+
+	r, err := tx.ExecContext(ctx, createSCIMGroup, group.UID, group.OrganisationID, group.DisplayName,
+		group.ExternalID, group.Role.Type, group.Role.Project,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate") {
+			return datastore.ErrDuplicateSCIMGroup
+		}
+		return err
+	}
+
+	rowsAffected, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrSCIMGroupNotCreated
+	}
+
+	err = setSCIMGroupMembers(ctx, tx, group)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *scimRepo) UpdateSCIMGroup(ctx context.Context, group *datastore.SCIMGroup) error {
+	tx, err := s.db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer rollbackTx(tx)
+
+	r, err := tx.ExecContext(ctx, updateSCIMGroup, group.UID, group.OrganisationID, group.DisplayName,
+		group.ExternalID, group.Role.Type, group.Role.Project,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate") {
+			return datastore.ErrDuplicateSCIMGroup
+		}
+		return err
+	}
+
+	rowsAffected, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrSCIMGroupNotUpdated
+	}
+
+	err = setSCIMGroupMembers(ctx, tx, group)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func setSCIMGroupMembers(ctx context.Context, tx *sqlx.Tx, group *datastore.SCIMGroup) error {
+	_, err := tx.ExecContext(ctx, deleteSCIMGroupMembers, group.UID)
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range group.Members {
+		_, err = tx.ExecContext(ctx, createSCIMGroupMember, group.UID, userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *scimRepo) FindSCIMGroupByID(ctx context.Context, organisationID string, id string) (*datastore.SCIMGroup, error) {
+	group := datastore.SCIMGroup{}
+	err := s.db.QueryRowxContext(ctx, fetchSCIMGroupByID, id, organisationID).StructScan(&group)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, datastore.ErrSCIMGroupNotFound
+		}
+
+		return nil, err
+	}
+
+	groups := []datastore.SCIMGroup{group}
+	err = s.loadSCIMGroupMembers(ctx, groups)
+	if err != nil {
+		return nil, err
+	}
+
+	return &groups[0], nil
+}
+
+func (s *scimRepo) LoadSCIMGroups(ctx context.Context, organisationID string) ([]datastore.SCIMGroup, error) {
+	return s.fetchSCIMGroups(ctx, fetchSCIMGroups, organisationID)
+}
+
+func (s *scimRepo) FindSCIMGroupsByUserID(ctx context.Context, organisationID string, userID string) ([]datastore.SCIMGroup, error) {
+	return s.fetchSCIMGroups(ctx, fetchSCIMGroupsByUserID, organisationID, userID)
+}
+
+func (s *scimRepo) fetchSCIMGroups(ctx context.Context, query string, args ...interface{}) ([]datastore.SCIMGroup, error) {
+	rows, err := s.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := make([]datastore.SCIMGroup, 0)
+	for rows.Next() {
+		var group datastore.SCIMGroup
+
+		err = rows.StructScan(&group)
+		if err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, group)
+	}
+
+	err = s.loadSCIMGroupMembers(ctx, groups)
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (s *scimRepo) loadSCIMGroupMembers(ctx context.Context, groups []datastore.SCIMGroup) error {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(groups))
+	index := make(map[string]int, len(groups))
+	for i := range groups {
+		ids[i] = groups[i].UID
+		index[groups[i].UID] = i
+		groups[i].Members = make([]string, 0)
+	}
+
+	query, args, err := sqlx.In(fetchSCIMGroupMembers, ids)
+	if err != nil {
+		return err
+	}
+
+	rows, err := s.db.QueryxContext(ctx, s.db.Rebind(query), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var groupID, userID string
+
+		err = rows.Scan(&groupID, &userID)
+		if err != nil {
+			return err
+		}
+
+		i := index[groupID]
+		groups[i].Members = append(groups[i].Members, userID)
+	}
+
+	return nil
+}
+
+func (s *scimRepo) DeleteSCIMGroup(ctx context.Context, organisationID string, id string) error {
+	tx, err := s.db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer rollbackTx(tx)
+
+	_, err = tx.ExecContext(ctx, deleteSCIMGroupMembers, id)
+	if err != nil {
+		return err
+	}
+
+	r, err := tx.ExecContext(ctx, softDeleteSCIMGroup, id, organisationID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrSCIMGroupNotDeleted
+	}
+
+	return tx.Commit()
+}
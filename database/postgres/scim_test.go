@@ -0,0 +1,125 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frain-dev/convoy/auth"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateSCIMToken(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	repo := NewSCIMRepo(db)
+	org := seedOrg(t, db)
+
+	first := &datastore.SCIMToken{UID: ulid.Make().String(), OrganisationID: org.UID, MaskID: ulid.Make().String(), Hash: "hash", Salt: "salt"}
+	require.NoError(t, repo.CreateSCIMToken(context.Background(), first))
+
+	second := &datastore.SCIMToken{UID: ulid.Make().String(), OrganisationID: org.UID, MaskID: ulid.Make().String(), Hash: "hash", Salt: "salt"}
+	require.NoError(t, repo.CreateSCIMToken(context.Background(), second))
+
+	_, err := repo.FindSCIMTokenByMaskID(context.Background(), first.MaskID)
+	require.ErrorIs(t, err, datastore.ErrSCIMTokenNotFound)
+
+	token, err := repo.FindSCIMTokenByMaskID(context.Background(), second.MaskID)
+	require.NoError(t, err)
+	require.Equal(t, org.UID, token.OrganisationID)
+}
+
+func Test_SCIMUsers(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	repo := NewSCIMRepo(db)
+	org := seedOrg(t, db)
+	user := seedUser(t, db)
+
+	require.NoError(t, repo.CreateSCIMUser(context.Background(), &datastore.SCIMUser{
+		OrganisationID: org.UID,
+		UserID:         user.UID,
+		ExternalID:     "ext-1",
+		Provisioned:    true,
+	}))
+
+	scimUser, err := repo.FindSCIMUser(context.Background(), org.UID, user.UID)
+	require.NoError(t, err)
+	require.Equal(t, user.Email, scimUser.Email)
+	require.Empty(t, scimUser.MemberID)
+
+	member := &datastore.OrganisationMember{
+		UID:            ulid.Make().String(),
+		OrganisationID: org.UID,
+		UserID:         user.UID,
+		Role:           auth.Role{Type: auth.RoleMember},
+	}
+	require.NoError(t, NewOrgMemberRepo(db).CreateOrganisationMember(context.Background(), member))
+
+	scimUser.ExternalID = "ext-2"
+	require.NoError(t, repo.UpdateSCIMUser(context.Background(), scimUser))
+
+	users, err := repo.LoadSCIMUsers(context.Background(), org.UID)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	require.Equal(t, "ext-2", users[0].ExternalID)
+	require.Equal(t, member.UID, users[0].MemberID)
+
+	require.NoError(t, repo.DeleteSCIMUser(context.Background(), org.UID, user.UID))
+
+	_, err = repo.FindSCIMUser(context.Background(), org.UID, user.UID)
+	require.ErrorIs(t, err, datastore.ErrSCIMUserNotFound)
+}
+
+func Test_SCIMGroups(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	repo := NewSCIMRepo(db)
+	org := seedOrg(t, db)
+	user := seedUser(t, db)
+
+	group := &datastore.SCIMGroup{
+		UID:            ulid.Make().String(),
+		OrganisationID: org.UID,
+		DisplayName:    "Engineering",
+		Role:           auth.Role{Type: auth.RoleAdmin},
+		Members:        []string{user.UID},
+	}
+	require.NoError(t, repo.CreateSCIMGroup(context.Background(), group))
+
+	duplicate := &datastore.SCIMGroup{
+		UID:            ulid.Make().String(),
+		OrganisationID: org.UID,
+		DisplayName:    "Engineering",
+		Role:           auth.Role{Type: auth.RoleMember},
+	}
+	require.ErrorIs(t, repo.CreateSCIMGroup(context.Background(), duplicate), datastore.ErrDuplicateSCIMGroup)
+
+	groups, err := repo.FindSCIMGroupsByUserID(context.Background(), org.UID, user.UID)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	require.Equal(t, auth.RoleAdmin, groups[0].Role.Type)
+	require.Equal(t, []string{user.UID}, groups[0].Members)
+
+	group.DisplayName = "Platform"
+	group.Members = []string{}
+	require.NoError(t, repo.UpdateSCIMGroup(context.Background(), group))
+
+	newGroup, err := repo.FindSCIMGroupByID(context.Background(), org.UID, group.UID)
+	require.NoError(t, err)
+	require.Equal(t, "Platform", newGroup.DisplayName)
+	require.Empty(t, newGroup.Members)
+
+	require.NoError(t, repo.DeleteSCIMGroup(context.Background(), org.UID, group.UID))
+
+	groups, err = repo.LoadSCIMGroups(context.Background(), org.UID)
+	require.NoError(t, err)
+	require.Empty(t, groups)
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrSigningKeyNotCreated = errors.New("signing key could not be created")
+	ErrSigningKeyNotUpdated = errors.New("signing key could not be updated")
+)
+
+const (
+	createSigningKey = `
+	INSERT INTO convoy.signing_keys (id, project_id, algorithm, public_key, private_key, expires_at)
+	VALUES ($1, $2, $3, $4, $5, $6);
+	`
+
+	updateSigningKey = `
+	UPDATE convoy.signing_keys SET expires_at = $3, updated_at = now()
+	WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL;
+	`
+
+	fetchActiveSigningKeys = `
+	SELECT id, project_id, algorithm, public_key, private_key,
+	expires_at, created_at, updated_at
+	FROM convoy.signing_keys
+	WHERE project_id = $1 AND deleted_at IS NULL
+	AND (expires_at IS NULL OR expires_at > now())
+	ORDER BY id DESC;
+	`
+)
+
+type signingKeyRepo struct {
+	db *sqlx.DB
+}
+
+func NewSigningKeyRepo(db database.Database) datastore.SigningKeyRepository {
+	return &signingKeyRepo{db: db.GetDB()}
+}
+
+func (s *signingKeyRepo) CreateSigningKey(ctx context.Context, key *datastore.SigningKey) error {
+	r, err := s.db.ExecContext(ctx, createSigningKey, key.UID, key.ProjectID, key.Algorithm,
+		key.PublicKey, key.PrivateKey, key.ExpiresAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrSigningKeyNotCreated
+	}
+
+	return nil
+}
+
+func (s *signingKeyRepo) UpdateSigningKey(ctx context.Context, key *datastore.SigningKey) error {
+	r, err := s.db.ExecContext(ctx, updateSigningKey, key.UID, key.ProjectID, key.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrSigningKeyNotUpdated
+	}
+
+	return nil
+}
+
+func (s *signingKeyRepo) LoadActiveSigningKeys(ctx context.Context, projectID string) ([]datastore.SigningKey, error) {
+	rows, err := s.db.QueryxContext(ctx, fetchActiveSigningKeys, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make([]datastore.SigningKey, 0)
+	for rows.Next() {
+		var key datastore.SigningKey
+
+		err = rows.StructScan(&key)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,64 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+)
+
+func Test_SigningKeys(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	signingKeyRepo := NewSigningKeyRepo(db)
+	project := seedProject(t, db)
+	ctx := context.Background()
+
+	oldKey := &datastore.SigningKey{
+		UID:        ulid.Make().String(),
+		ProjectID:  project.UID,
+		Algorithm:  datastore.Ed25519SigningKeyAlgorithm,
+		PublicKey:  "old-public-key",
+		PrivateKey: "old-private-key",
+	}
+	require.NoError(t, signingKeyRepo.CreateSigningKey(ctx, oldKey))
+
+	newKey := &datastore.SigningKey{
+		UID:        ulid.Make().String(),
+		ProjectID:  project.UID,
+		Algorithm:  datastore.RSAPSSSigningKeyAlgorithm,
+		PublicKey:  "new-public-key",
+		PrivateKey: "new-private-key",
+	}
+	require.NoError(t, signingKeyRepo.CreateSigningKey(ctx, newKey))
+
+	keys, err := signingKeyRepo.LoadActiveSigningKeys(ctx, project.UID)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	require.Equal(t, newKey.UID, keys[0].UID)
+	require.Equal(t, "new-private-key", keys[0].PrivateKey)
+
+	// a key is listed until it expires
+	oldKey.ExpiresAt = null.TimeFrom(time.Now().Add(time.Hour))
+	require.NoError(t, signingKeyRepo.UpdateSigningKey(ctx, oldKey))
+
+	keys, err = signingKeyRepo.LoadActiveSigningKeys(ctx, project.UID)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+
+	oldKey.ExpiresAt = null.TimeFrom(time.Now().Add(-time.Minute))
+	require.NoError(t, signingKeyRepo.UpdateSigningKey(ctx, oldKey))
+
+	keys, err = signingKeyRepo.LoadActiveSigningKeys(ctx, project.UID)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.Equal(t, newKey.UID, keys[0].UID)
+}
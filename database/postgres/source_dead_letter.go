@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/jmoiron/sqlx"
+)
+
+var ErrSourceDeadLetterNotCreated = errors.New("source dead letter could not be created")
+
+const (
+	createSourceDeadLetter = `
+	INSERT INTO convoy.source_dead_letters (id, project_id, source_id, data, attributes, reason, deliveries)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	baseSourceDeadLettersPaged = `
+	SELECT sdl.id, sdl.project_id, sdl.source_id, sdl.data,
+	COALESCE(sdl.attributes, '{}') AS attributes,
+	COALESCE(sdl.reason, '') AS reason, sdl.deliveries,
+	sdl.created_at, sdl.updated_at FROM convoy.source_dead_letters sdl
+	WHERE sdl.deleted_at IS NULL
+	AND sdl.project_id = :project_id
+	AND sdl.source_id = :source_id
+	`
+
+	sourceDeadLettersPagedForward = `%s AND sdl.id <= :cursor
+	ORDER BY sdl.id DESC
+	LIMIT :limit
+	`
+
+	sourceDeadLettersPagedBackward = `
+	WITH source_dead_letters AS (
+		%s AND sdl.id >= :cursor
+		ORDER BY sdl.id ASC
+		LIMIT :limit
+	)
+
+	SELECT * FROM source_dead_letters ORDER BY id DESC
+	`
+
+	countPrevSourceDeadLetters = `
+	SELECT count(distinct(sdl.id)) AS count
+	FROM convoy.source_dead_letters sdl
+	WHERE sdl.deleted_at IS NULL
+	AND sdl.project_id = :project_id
+	AND sdl.source_id = :source_id
+	AND sdl.id > :cursor GROUP BY sdl.id ORDER BY sdl.id DESC LIMIT 1`
+)
+
+type sourceDeadLetterRepo struct {
+	db *sqlx.DB
+}
+
+func NewSourceDeadLetterRepo(db database.Database) datastore.SourceDeadLetterRepository {
+	return &sourceDeadLetterRepo{db: db.GetDB()}
+}
+
+func (s *sourceDeadLetterRepo) CreateSourceDeadLetter(ctx context.Context, deadLetter *datastore.SourceDeadLetter) error {
+	r, err := s.db.ExecContext(ctx, createSourceDeadLetter, deadLetter.UID, deadLetter.ProjectID, deadLetter.SourceID,
+		deadLetter.Data, deadLetter.Attributes, deadLetter.Reason, deadLetter.Deliveries,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := r.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected < 1 {
+		return ErrSourceDeadLetterNotCreated
+	}
+
+	return nil
+}
+
+func (s *sourceDeadLetterRepo) LoadSourceDeadLettersPaged(ctx context.Context, projectID string, sourceID string, pageable datastore.Pageable) ([]datastore.SourceDeadLetter, datastore.PaginationData, error) {
+	arg := map[string]interface{}{
+		"project_id": projectID,
+		"source_id":  sourceID,
+		"limit":      pageable.Limit(),
+		"cursor":     pageable.Cursor(),
+	}
+
+	var query string
+	if pageable.Direction == datastore.Next {
+		query = sourceDeadLettersPagedForward
+	} else {
+		query = sourceDeadLettersPagedBackward
+	}
+
+	query, args, err := sqlx.Named(fmt.Sprintf(query, baseSourceDeadLettersPaged), arg)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	query = s.db.Rebind(query)
+	rows, err := s.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	deadLetters := make([]datastore.SourceDeadLetter, 0)
+	for rows.Next() {
+		var data datastore.SourceDeadLetter
+
+		err = rows.StructScan(&data)
+		if err != nil {
+			return nil, datastore.PaginationData{}, err
+		}
+
+		deadLetters = append(deadLetters, data)
+	}
+
+	var count datastore.PrevRowCount
+	if len(deadLetters) > 0 {
+		qarg := arg
+		qarg["cursor"] = deadLetters[0].UID
+
+		countQuery, qargs, err := sqlx.Named(countPrevSourceDeadLetters, qarg)
+		if err != nil {
+			return nil, datastore.PaginationData{}, err
+		}
+
+		err = s.db.GetContext(ctx, &count, s.db.Rebind(countQuery), qargs...)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, datastore.PaginationData{}, err
+		}
+	}
+
+	ids := make([]string, len(deadLetters))
+	for i := range deadLetters {
+		ids[i] = deadLetters[i].UID
+	}
+
+	if len(deadLetters) > pageable.PerPage {
+		deadLetters = deadLetters[:len(deadLetters)-1]
+	}
+
+	pagination := &datastore.PaginationData{PrevRowCount: count}
+	pagination = pagination.Build(pageable, ids)
+
+	return deadLetters, *pagination, rows.Close()
+}
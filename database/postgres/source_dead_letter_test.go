@@ -0,0 +1,56 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LoadSourceDeadLettersPaged(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	repo := NewSourceDeadLetterRepo(db)
+	ctx := context.Background()
+
+	source := seedSource(t, db)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, repo.CreateSourceDeadLetter(ctx, &datastore.SourceDeadLetter{
+			UID:        ulid.Make().String(),
+			ProjectID:  source.ProjectID,
+			SourceID:   source.UID,
+			Data:       `{"amount":2000}`,
+			Attributes: datastore.M{"region": "eu"},
+			Reason:     "pub sub message is malformed",
+			Deliveries: i + 1,
+		}))
+	}
+
+	deadLetters, pagination, err := repo.LoadSourceDeadLettersPaged(ctx, source.ProjectID, source.UID, datastore.Pageable{
+		PerPage:    2,
+		Direction:  datastore.Next,
+		NextCursor: datastore.DefaultCursor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(deadLetters))
+	require.True(t, pagination.HasNextPage)
+
+	require.Equal(t, 3, deadLetters[0].Deliveries)
+	require.Equal(t, datastore.M{"region": "eu"}, deadLetters[0].Attributes)
+	require.Equal(t, "pub sub message is malformed", deadLetters[0].Reason)
+
+	deadLetters, _, err = repo.LoadSourceDeadLettersPaged(ctx, source.ProjectID, ulid.Make().String(), datastore.Pageable{
+		PerPage:    2,
+		Direction:  datastore.Next,
+		NextCursor: datastore.DefaultCursor,
+	})
+	require.NoError(t, err)
+	require.Empty(t, deadLetters)
+}
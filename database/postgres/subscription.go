@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"reflect"
 
 	"github.com/frain-dev/convoy/database"
+	"github.com/frain-dev/convoy/database/hooks"
 	"github.com/frain-dev/convoy/pkg/compare"
 	"github.com/frain-dev/convoy/pkg/flatten"
 	"github.com/frain-dev/convoy/util"
@@ -24,9 +26,14 @@ const (
 	retry_config_type,retry_config_duration,
 	retry_config_retry_count,filter_config_event_types,
 	filter_config_filter_headers,filter_config_filter_body,
-	rate_limit_config_count,rate_limit_config_duration
+	rate_limit_config_count,rate_limit_config_duration,
+	retry_config_max_duration,retry_config_jitter,
+	retry_config_intervals,retry_config_honor_retry_after,
+	batch_config_max_count,batch_config_max_bytes,
+	batch_config_max_wait,function,ttl,
+	filter_config_expression
 	)
-    VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17);
+    VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23,$24,$25,$26,$27);
     `
 
 	updateSubscription = `
@@ -43,7 +50,17 @@ const (
 	filter_config_filter_headers=$12,
 	filter_config_filter_body=$13,
 	rate_limit_config_count=$14,
-	rate_limit_config_duration=$15
+	rate_limit_config_duration=$15,
+	retry_config_max_duration=$16,
+	retry_config_jitter=$17,
+	retry_config_intervals=$18,
+	retry_config_honor_retry_after=$19,
+	batch_config_max_count=$20,
+	batch_config_max_bytes=$21,
+	batch_config_max_wait=$22,
+	function=$23,
+	ttl=$24,
+	filter_config_expression=$25
     WHERE id = $1 AND project_id = $2
 	AND deleted_at IS NULL;
     `
@@ -63,11 +80,21 @@ const (
 	s.retry_config_type as "retry_config.type",
 	s.retry_config_duration as "retry_config.duration",
 	s.retry_config_retry_count as "retry_config.retry_count",
+	s.retry_config_max_duration as "retry_config.max_duration",
+	s.retry_config_jitter as "retry_config.jitter",
+	s.retry_config_intervals as "retry_config.intervals",
+	s.retry_config_honor_retry_after as "retry_config.honor_retry_after",
 	s.filter_config_event_types as "filter_config.event_types",
 	s.filter_config_filter_headers as "filter_config.filter.headers",
 	s.filter_config_filter_body as "filter_config.filter.body",
+	s.filter_config_expression as "filter_config.expression",
 	s.rate_limit_config_count as "rate_limit_config.count",
 	s.rate_limit_config_duration as "rate_limit_config.duration",
+	s.batch_config_max_count as "batch_config.max_count",
+	s.batch_config_max_bytes as "batch_config.max_bytes",
+	s.batch_config_max_wait as "batch_config.max_wait",
+	s.function,
+	s.ttl,
 
 	COALESCE(em.secrets,'[]') as "endpoint_metadata.secrets",
 	COALESCE(em.id,'') as "endpoint_metadata.id",
@@ -158,11 +185,21 @@ const (
 	s.retry_config_type as "retry_config.type",
 	s.retry_config_duration as "retry_config.duration",
 	s.retry_config_retry_count as "retry_config.retry_count",
+	s.retry_config_max_duration as "retry_config.max_duration",
+	s.retry_config_jitter as "retry_config.jitter",
+	s.retry_config_intervals as "retry_config.intervals",
+	s.retry_config_honor_retry_after as "retry_config.honor_retry_after",
 	s.filter_config_event_types as "filter_config.event_types",
 	s.filter_config_filter_headers as "filter_config.filter.headers",
 	s.filter_config_filter_body as "filter_config.filter.body",
+	s.filter_config_expression as "filter_config.expression",
 	s.rate_limit_config_count as "rate_limit_config.count",
 	s.rate_limit_config_duration as "rate_limit_config.duration",
+	s.batch_config_max_count as "batch_config.max_count",
+	s.batch_config_max_bytes as "batch_config.max_bytes",
+	s.batch_config_max_wait as "batch_config.max_wait",
+	s.function,
+	s.ttl,
 
 	COALESCE(d.id,'') as "device_metadata.id",
 	COALESCE(d.status,'') as "device_metadata.status",
@@ -188,11 +225,12 @@ var (
 )
 
 type subscriptionRepo struct {
-	db *sqlx.DB
+	db   *sqlx.DB
+	hook *hooks.Hook
 }
 
 func NewSubscriptionRepo(db database.Database) datastore.SubscriptionRepository {
-	return &subscriptionRepo{db: db.GetDB()}
+	return &subscriptionRepo{db: db.GetDB(), hook: db.GetHook()}
 }
 
 func (s *subscriptionRepo) CreateSubscription(ctx context.Context, projectID string, subscription *datastore.Subscription) error {
@@ -204,6 +242,7 @@ func (s *subscriptionRepo) CreateSubscription(ctx context.Context, projectID str
 	rc := subscription.GetRetryConfig()
 	fc := subscription.GetFilterConfig()
 	rlc := subscription.GetRateLimitConfig()
+	bc := subscription.GetBatchConfig()
 
 	var endpointID, sourceID, deviceID *string
 	if !util.IsStringEmpty(subscription.EndpointID) {
@@ -224,6 +263,9 @@ func (s *subscriptionRepo) CreateSubscription(ctx context.Context, projectID str
 		endpointID, deviceID, sourceID,
 		ac.Count, ac.Threshold, rc.Type, rc.Duration, rc.RetryCount,
 		fc.EventTypes, fc.Filter.Headers, fc.Filter.Body, rlc.Count, rlc.Duration,
+		rc.MaxDuration, rc.Jitter, rc.Intervals, rc.HonorRetryAfter,
+		bc.MaxCount, bc.MaxBytes, bc.MaxWait, subscription.Function, subscription.TTL,
+		fc.Expression,
 	)
 	if err != nil {
 		return err
@@ -238,6 +280,7 @@ func (s *subscriptionRepo) CreateSubscription(ctx context.Context, projectID str
 		return ErrSubscriptionNotCreated
 	}
 
+	s.hook.Fire(datastore.SubscriptionCreated, subscription)
 	return nil
 }
 
@@ -246,6 +289,7 @@ func (s *subscriptionRepo) UpdateSubscription(ctx context.Context, projectID str
 	rc := subscription.GetRetryConfig()
 	fc := subscription.GetFilterConfig()
 	rlc := subscription.GetRateLimitConfig()
+	bc := subscription.GetBatchConfig()
 
 	var sourceID *string
 	if !util.IsStringEmpty(subscription.SourceID) {
@@ -257,6 +301,9 @@ func (s *subscriptionRepo) UpdateSubscription(ctx context.Context, projectID str
 		subscription.Name, subscription.EndpointID, sourceID,
 		ac.Count, ac.Threshold, rc.Type, rc.Duration, rc.RetryCount,
 		fc.EventTypes, fc.Filter.Headers, fc.Filter.Body, rlc.Count, rlc.Duration,
+		rc.MaxDuration, rc.Jitter, rc.Intervals, rc.HonorRetryAfter,
+		bc.MaxCount, bc.MaxBytes, bc.MaxWait, subscription.Function, subscription.TTL,
+		fc.Expression,
 	)
 	if err != nil {
 		return err
@@ -271,6 +318,7 @@ func (s *subscriptionRepo) UpdateSubscription(ctx context.Context, projectID str
 		return ErrSubscriptionNotUpdated
 	}
 
+	s.hook.Fire(datastore.SubscriptionUpdated, subscription)
 	return nil
 }
 
@@ -391,6 +439,7 @@ func (s *subscriptionRepo) DeleteSubscription(ctx context.Context, projectID str
 		return ErrSubscriptionNotDeleted
 	}
 
+	s.hook.Fire(datastore.SubscriptionDeleted, subscription)
 	return nil
 }
 
@@ -470,12 +519,18 @@ func (s *subscriptionRepo) CountEndpointSubscriptions(ctx context.Context, proje
 	return count, nil
 }
 
+// TestSubscriptionFilter matches payload against filter, filter is either
+// a filter as it is stored or one compiled with compare.Compile.
 func (s *subscriptionRepo) TestSubscriptionFilter(ctx context.Context, payload, filter interface{}) (bool, error) {
 	p, err := flatten.Flatten(payload)
 	if err != nil {
 		return false, err
 	}
 
+	if compiled, ok := filter.(*compare.Filter); ok {
+		return compiled.Match(p)
+	}
+
 	f, err := flatten.Flatten(filter)
 	if err != nil {
 		return false, err
@@ -488,6 +543,7 @@ var (
 	emptyAlertConfig     = datastore.AlertConfiguration{}
 	emptyRetryConfig     = datastore.RetryConfiguration{}
 	emptyRateLimitConfig = datastore.RateLimitConfiguration{}
+	emptyBatchConfig     = datastore.BatchConfiguration{}
 )
 
 func nullifyEmptyConfig(sub *datastore.Subscription) {
@@ -495,13 +551,22 @@ func nullifyEmptyConfig(sub *datastore.Subscription) {
 		sub.AlertConfig = nil
 	}
 
-	if *sub.RetryConfig == emptyRetryConfig {
+	rc := *sub.RetryConfig
+	if len(rc.Intervals) == 0 {
+		rc.Intervals = nil
+	}
+
+	if reflect.DeepEqual(rc, emptyRetryConfig) {
 		sub.RetryConfig = nil
 	}
 
 	if *sub.RateLimitConfig == emptyRateLimitConfig {
 		sub.RateLimitConfig = nil
 	}
+
+	if *sub.BatchConfig == emptyBatchConfig {
+		sub.BatchConfig = nil
+	}
 }
 
 func scanSubscriptions(rows *sqlx.Rows) ([]datastore.Subscription, error) {
@@ -14,6 +14,7 @@ import (
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/oklog/ulid/v2"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
 )
 
 func generateSubscription(project *datastore.Project, source *datastore.Source, endpoint *datastore.Endpoint, device *datastore.Device) *datastore.Subscription {
@@ -41,6 +42,7 @@ func generateSubscription(project *datastore.Project, source *datastore.Source,
 				Body:    datastore.M{},
 			},
 		},
+		Function: null.StringFrom("function transform(req) { return req; }"),
 	}
 }
 
@@ -19,6 +19,13 @@ type Filter struct {
 	SearchParams   SearchParams
 }
 
+type DeadLetterFilter struct {
+	EndpointIDs  []string
+	EventType    string
+	Pageable     Pageable
+	SearchParams SearchParams
+}
+
 type SourceFilter struct {
 	Type     string
 	Provider string
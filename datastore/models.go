@@ -3,6 +3,7 @@ package datastore
 import (
 	"context"
 	"database/sql/driver"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,7 +16,9 @@ import (
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/auth"
 	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/pkg/compress"
 	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/frain-dev/convoy/pkg/signature"
 	"github.com/lib/pq"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -125,13 +128,34 @@ type (
 	StorageType      string
 	KeyType          string
 	PubSubType       string
-	PubSubHandler    func(context.Context, *Source, string) error
+	PubSubHandler    func(context.Context, *Source, *PubSubMessage) error
 	MetaEventType    string
 	HookEventType    string
 )
 
 type EndpointAuthenticationType string
 
+// EndpointKind is how an endpoint receives its events, over http or
+// published to a queue.
+type EndpointKind string
+
+const (
+	HTTPEndpointKind   EndpointKind = "http"
+	SqsEndpointKind    EndpointKind = "sqs"
+	GoogleEndpointKind EndpointKind = "google"
+	KafkaEndpointKind  EndpointKind = "kafka"
+	AmqpEndpointKind   EndpointKind = "amqp"
+)
+
+func (k EndpointKind) IsValid() bool {
+	switch k {
+	case HTTPEndpointKind, SqsEndpointKind, GoogleEndpointKind, KafkaEndpointKind, AmqpEndpointKind:
+		return true
+	}
+
+	return false
+}
+
 const (
 	HTTPSource     SourceType = "http"
 	RestApiSource  SourceType = "rest_api"
@@ -151,26 +175,59 @@ const (
 	EventDeliveryUpdated HookEventType = "eventdelivery.updated"
 	EventDeliverySuccess HookEventType = "eventdelivery.success"
 	EventDeliveryFailed  HookEventType = "eventdelivery.failed"
+
+	EndpointCircuitBreakerOpened   HookEventType = "endpoint.circuit_breaker.opened"
+	EndpointCircuitBreakerHalfOpen HookEventType = "endpoint.circuit_breaker.half_open"
+	EndpointCircuitBreakerClosed   HookEventType = "endpoint.circuit_breaker.closed"
+
+	ProjectUpdated      HookEventType = "project.updated"
+	ProjectDeleted      HookEventType = "project.deleted"
+	SubscriptionCreated HookEventType = "subscription.created"
+	SubscriptionUpdated HookEventType = "subscription.updated"
+	SubscriptionDeleted HookEventType = "subscription.deleted"
 )
 
 const (
 	GithubSourceProvider  SourceProvider = "github"
 	TwitterSourceProvider SourceProvider = "twitter"
 	ShopifySourceProvider SourceProvider = "shopify"
+
+	StandardWebhooksSourceProvider SourceProvider = "standard_webhooks"
 )
 
 const (
 	APIKeyAuthentication EndpointAuthenticationType = "api_key"
+	MTLSAuthentication   EndpointAuthenticationType = "mtls"
+	OAuth2Authentication EndpointAuthenticationType = "oauth2"
 )
 
 const (
 	SqsPubSub    PubSubType = "sqs"
 	GooglePubSub PubSubType = "google"
+	KafkaPubSub  PubSubType = "kafka"
+	AmqpPubSub   PubSubType = "amqp"
+	NatsPubSub   PubSubType = "nats"
+)
+
+type PubSubDeadLetterType string
+
+const (
+	SqsDeadLetter    PubSubDeadLetterType = "sqs"
+	GoogleDeadLetter PubSubDeadLetterType = "google"
+	TableDeadLetter  PubSubDeadLetterType = "table"
+)
+
+type KafkaSASLMechanism string
+
+const (
+	KafkaSASLPlain       KafkaSASLMechanism = "plain"
+	KafkaSASLScramSHA256 KafkaSASLMechanism = "scram-sha-256"
+	KafkaSASLScramSHA512 KafkaSASLMechanism = "scram-sha-512"
 )
 
 func (s SourceProvider) IsValid() bool {
 	switch s {
-	case GithubSourceProvider, TwitterSourceProvider, ShopifySourceProvider:
+	case GithubSourceProvider, TwitterSourceProvider, ShopifySourceProvider, StandardWebhooksSourceProvider:
 		return true
 	}
 	return false
@@ -238,6 +295,15 @@ const (
 	DefaultStrategyProvider                      = LinearStrategyProvider
 	LinearStrategyProvider      StrategyProvider = "linear"
 	ExponentialStrategyProvider StrategyProvider = "exponential"
+	CustomStrategyProvider      StrategyProvider = "custom"
+)
+
+type JitterType string
+
+const (
+	NoJitter           JitterType = "none"
+	FullJitter         JitterType = "full"
+	DecorrelatedJitter JitterType = "decorrelated"
 )
 
 var (
@@ -376,11 +442,90 @@ type Endpoint struct {
 	RateLimitDuration string                  `json:"rate_limit_duration" db:"rate_limit_duration"`
 	Authentication    *EndpointAuthentication `json:"authentication" db:"authentication"`
 
+	// OrderedDelivery makes the endpoint receive its events one at a time in
+	// the order they were created, a failing delivery holds back every later
+	// one until it succeeds or is dead-lettered. PartitionKeyPath optionally
+	// narrows the ordering to events sharing the value at that JSON path.
+	OrderedDelivery  bool   `json:"ordered_delivery" db:"ordered_delivery"`
+	PartitionKeyPath string `json:"partition_key_path" db:"partition_key_path"`
+
+	// Kind defaults to http, other kinds publish their events to the
+	// queue in Destination, TargetURL then identifies that queue.
+	Kind        EndpointKind         `json:"kind" db:"kind"`
+	Destination *EndpointDestination `json:"destination,omitempty" db:"destination"`
+
+	// ContentEncoding compresses the requests sent to the endpoint with
+	// gzip or zstd, they're sent uncompressed when it's empty.
+	ContentEncoding compress.Encoding `json:"content_encoding" db:"content_encoding"`
+
 	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at,omitempty" swaggertype:"string"`
 	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at,omitempty" swaggertype:"string"`
 	DeletedAt null.Time `json:"deleted_at,omitempty" db:"deleted_at" swaggertype:"string"`
 }
 
+// IsHTTP reports whether the endpoint receives its events over http,
+// endpoints created before kinds existed have none.
+func (e *Endpoint) IsHTTP() bool {
+	return e.Kind == "" || e.Kind == HTTPEndpointKind
+}
+
+// EndpointDestination is the queue a non http endpoint publishes its
+// events to, only the config matching the endpoint's kind is set.
+type EndpointDestination struct {
+	Sqs    *SQSPubSubConfig         `json:"sqs,omitempty" db:"sqs"`
+	Google *GooglePubSubTopicConfig `json:"google,omitempty" db:"google"`
+	Kafka  *KafkaTopicConfig        `json:"kafka,omitempty" db:"kafka"`
+	Amqp   *AmqpExchangeConfig      `json:"amqp,omitempty" db:"amqp"`
+}
+
+func (d *EndpointDestination) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("unsupported value type %T", value)
+	}
+
+	var dest EndpointDestination
+	err := json.Unmarshal(b, &dest)
+	if err != nil {
+		return err
+	}
+
+	*d = dest
+	return nil
+}
+
+func (d *EndpointDestination) Value() (driver.Value, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+type KafkaTopicConfig struct {
+	Brokers []string `json:"brokers" db:"brokers"`
+	Topic   string   `json:"topic" db:"topic"`
+
+	TLS    bool   `json:"tls" db:"tls"`
+	CACert string `json:"ca_cert,omitempty" db:"ca_cert"`
+
+	SASL *KafkaSASLConfig `json:"sasl,omitempty" db:"sasl"`
+}
+
+// AmqpExchangeConfig publishes to Exchange with RoutingKey, an empty
+// exchange is the default exchange which routes to the queue named by
+// the routing key.
+type AmqpExchangeConfig struct {
+	URL        string `json:"url" db:"url"`
+	Exchange   string `json:"exchange" db:"exchange"`
+	RoutingKey string `json:"routing_key" db:"routing_key"`
+}
+
 func (e *Endpoint) FindSecret(secretID string) *Secret {
 	for i := range e.Secrets {
 		secret := &e.Secrets[i]
@@ -399,13 +544,24 @@ type EndpointConfig struct {
 }
 
 func (e *Endpoint) GetAuthConfig() EndpointAuthentication {
+	ac := EndpointAuthentication{}
 	if e.Authentication != nil {
-		if e.Authentication.ApiKey != nil {
-			return *e.Authentication
-		}
+		ac = *e.Authentication
+	}
+
+	if ac.ApiKey == nil {
+		ac.ApiKey = &ApiKey{}
+	}
+
+	if ac.MTLS == nil {
+		ac.MTLS = &MTLSClientCert{}
 	}
 
-	return EndpointAuthentication{ApiKey: &ApiKey{}}
+	if ac.OAuth2 == nil {
+		ac.OAuth2 = &OAuth2{}
+	}
+
+	return ac
 }
 
 func (e *Endpoint) GetActiveSecretIndex() (int, error) {
@@ -428,8 +584,30 @@ type Secret struct {
 }
 
 type EndpointAuthentication struct {
-	Type   EndpointAuthenticationType `json:"type,omitempty" db:"type" valid:"optional,in(api_key)~unsupported authentication type"`
+	Type   EndpointAuthenticationType `json:"type,omitempty" db:"type" valid:"optional,in(api_key|mtls|oauth2)~unsupported authentication type"`
 	ApiKey *ApiKey                    `json:"api_key" db:"api_key"`
+	MTLS   *MTLSClientCert            `json:"mtls,omitempty" db:"mtls"`
+	OAuth2 *OAuth2                    `json:"oauth2,omitempty" db:"oauth2"`
+}
+
+// MTLSClientCert is the PEM encoded client certificate presented to an
+// endpoint, CACert optionally replaces the system roots used to verify it.
+// ClientKey is stored encrypted, see util.Encrypt.
+type MTLSClientCert struct {
+	ClientCert string `json:"client_cert" db:"client_cert" valid:"required~please provide a client certificate"`
+	ClientKey  string `json:"client_key,omitempty" db:"client_key" valid:"required~please provide a client key"`
+	CACert     string `json:"ca_cert,omitempty" db:"ca_cert"`
+}
+
+// OAuth2 holds the client credentials used to fetch the bearer token sent
+// to an endpoint. ClientSecret is stored encrypted, see util.Encrypt.
+type OAuth2 struct {
+	TokenURL     string `json:"token_url" db:"token_url" valid:"required~please provide a token url,url~please provide a valid token url"`
+	ClientID     string `json:"client_id" db:"client_id" valid:"required~please provide a client id"`
+	ClientSecret string `json:"client_secret,omitempty" db:"client_secret" valid:"required~please provide a client secret"`
+
+	// Scope is a space separated list of scopes to request
+	Scope string `json:"scope,omitempty" db:"scope"`
 }
 
 var (
@@ -489,6 +667,10 @@ type ProjectConfig struct {
 	Strategy                 *StrategyConfiguration        `json:"strategy" db:"strategy"`
 	Signature                *SignatureConfiguration       `json:"signature" db:"signature"`
 	MetaEvent                *MetaEventConfiguration       `json:"meta_event" db:"meta_event"`
+
+	// IsSchemaValidationEnabled rejects events whose data doesn't match
+	// the json schema of their type in the project's event type catalog.
+	IsSchemaValidationEnabled bool `json:"schema_validation_enabled" db:"schema_validation_enabled"`
 }
 
 func (p *ProjectConfig) GetRateLimitConfig() RateLimitConfiguration {
@@ -533,17 +715,41 @@ type RateLimitConfiguration struct {
 }
 
 type StrategyConfiguration struct {
-	Type       StrategyProvider `json:"type" db:"type" valid:"optional~please provide a valid strategy type, in(linear|exponential)~unsupported strategy type"`
+	Type       StrategyProvider `json:"type" db:"type" valid:"optional~please provide a valid strategy type, in(linear|exponential|custom)~unsupported strategy type"`
 	Duration   uint64           `json:"duration" db:"duration" valid:"optional~please provide a valid duration in seconds,int"`
 	RetryCount uint64           `json:"retry_count" db:"retry_count" valid:"optional~please provide a valid retry count,int"`
+
+	// MaxDuration caps the exponential backoff interval, in seconds
+	MaxDuration     uint64        `json:"max_duration" db:"max_duration"`
+	Jitter          JitterType    `json:"jitter" db:"jitter" valid:"optional,in(none|full|decorrelated)~unsupported jitter type"`
+	Intervals       pq.Int64Array `json:"intervals" db:"intervals"`
+	HonorRetryAfter bool          `json:"honor_retry_after" db:"honor_retry_after"`
 }
 
+type SignatureScheme string
+
+const (
+	// ConvoySignatureScheme is the default and is used when no scheme is
+	// set, it signs requests with a single header holding either a bare
+	// hmac or the advanced t=...,v1=... format.
+	ConvoySignatureScheme SignatureScheme = "convoy"
+
+	// StandardWebhooksSignatureScheme signs requests following the
+	// Standard Webhooks spec (https://www.standardwebhooks.com).
+	StandardWebhooksSignatureScheme SignatureScheme = "standard_webhooks"
+)
+
 type SignatureConfiguration struct {
 	Hash     string                         `json:"-" db:"hash"` // Deprecated
 	Header   config.SignatureHeaderProvider `json:"header,omitempty" valid:"required~please provide a valid signature header"`
+	Scheme   SignatureScheme                `json:"scheme,omitempty" db:"scheme"`
 	Versions SignatureVersions              `json:"versions" db:"versions"`
 }
 
+func (s SignatureConfiguration) IsStandardWebhooks() bool {
+	return s.Scheme == StandardWebhooksSignatureScheme
+}
+
 type SignatureVersion struct {
 	UID       string       `json:"uid" db:"id"`
 	Hash      string       `json:"hash,omitempty" db:"hash" valid:"required~please provide a valid hash,supported_hash~unsupported hash type"`
@@ -610,6 +816,11 @@ var (
 	ErrNoActiveSecret                = errors.New("no active secret found")
 	ErrSecretNotFound                = errors.New("secret not found")
 	ErrMetaEventNotFound             = errors.New("meta event not found")
+	ErrDeadLetterNotFound            = errors.New("dead letter not found")
+	ErrSigningKeyNotFound            = errors.New("signing key not found")
+	ErrInvalidPubSubMessage          = errors.New("pub sub message is malformed")
+	ErrEventTypeNotFound             = errors.New("event type not found")
+	ErrDuplicateEventType            = errors.New("an event type with this name already exists")
 )
 
 type AppMetadata struct {
@@ -656,16 +867,34 @@ type Event struct {
 	IdempotencyKey   string                `json:"idempotency_key" db:"idempotency_key"`
 	IsDuplicateEvent bool                  `json:"is_duplicate_event" db:"is_duplicate_event"`
 
+	// DeliverAt is when the event's deliveries are sent, it is null for
+	// events that are delivered as soon as they're created
+	DeliverAt null.Time `json:"deliver_at,omitempty" db:"deliver_at" swaggertype:"string"`
+
+	// ExpiresAt is when the event's deliveries are discarded instead of
+	// being sent or retried, it is null for events that never expire
+	ExpiresAt null.Time `json:"expires_at,omitempty" db:"expires_at" swaggertype:"string"`
+
 	// Data is an arbitrary JSON value that gets sent as the body of the
 	// webhook to the endpoints
 	Data json.RawMessage `json:"data,omitempty" db:"data"`
 	Raw  string          `json:"raw,omitempty" db:"raw"`
 
+	// Payload describes how Data is stored when it was compressed or
+	// offloaded to the object store, it is nil for inline payloads
+	Payload *StoredPayload `json:"-" db:"payload"`
+
 	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at,omitempty" swaggertype:"string"`
 	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at,omitempty" swaggertype:"string"`
 	DeletedAt null.Time `json:"deleted_at,omitempty" db:"deleted_at" swaggertype:"string"`
 }
 
+// IsScheduled reports whether the event's deliveries are still being held
+// until its delivery time.
+func (e *Event) IsScheduled() bool {
+	return e.DeliverAt.Valid && e.DeliverAt.Time.After(time.Now())
+}
+
 func (e *Event) GetRawHeaders() interface{} {
 	h := map[string]interface{}{}
 	for k, v := range e.Headers {
@@ -739,6 +968,81 @@ type Metadata struct {
 	IntervalSeconds uint64 `json:"interval_seconds" bson:"interval_seconds"`
 
 	RetryLimit uint64 `json:"retry_limit" bson:"retry_limit"`
+
+	// MaxIntervalSeconds caps the interval of the exponential strategy.
+	MaxIntervalSeconds uint64     `json:"max_interval_seconds,omitempty" bson:"max_interval_seconds"`
+	Jitter             JitterType `json:"jitter,omitempty" bson:"jitter"`
+
+	// Intervals is the schedule, in seconds, used by the custom strategy.
+	Intervals []uint64 `json:"intervals,omitempty" bson:"intervals"`
+
+	// HonorRetryAfter makes a Retry-After header sent with a 429 or 503
+	// response take precedence over the strategy's interval.
+	HonorRetryAfter bool `json:"honor_retry_after,omitempty" bson:"honor_retry_after"`
+
+	// LastIntervalMillis is the previous retry interval, it is
+	// needed to compute decorrelated jitter.
+	LastIntervalMillis uint64 `json:"last_interval_millis,omitempty" bson:"last_interval_millis"`
+
+	// ExpiresAt is when the delivery stops being sent, it is the
+	// earlier of the event's expiry and the subscription's ttl.
+	ExpiresAt null.Time `json:"expires_at,omitempty" bson:"expires_at"`
+
+	// Payload is set when the event's payload is stored compressed or
+	// offloaded, Data and Raw are then read from the event.
+	Payload *StoredPayload `json:"payload,omitempty" bson:"payload"`
+}
+
+// StoredPayload describes an event payload that isn't stored as it is.
+type StoredPayload struct {
+	// Encoding is the compression the payload is stored with
+	Encoding string `json:"encoding,omitempty"`
+
+	// ObjectKey is set when the payload was offloaded to the object store
+	ObjectKey string `json:"object_key,omitempty"`
+
+	// Size is the length of the uncompressed payload
+	Size int `json:"size"`
+}
+
+// IsOffloaded reports whether the payload is kept in the object store.
+func (s *StoredPayload) IsOffloaded() bool {
+	return s.ObjectKey != ""
+}
+
+func (s *StoredPayload) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("unsupported value type %T", value)
+	}
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	return json.Unmarshal(b, s)
+}
+
+func (s *StoredPayload) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// IsExpired reports whether the delivery has expired by t.
+func (m *Metadata) IsExpired(t time.Time) bool {
+	return m.ExpiresAt.Valid && !t.Before(m.ExpiresAt.Time)
 }
 
 func (m *Metadata) Scan(value interface{}) error {
@@ -763,6 +1067,14 @@ func (m *Metadata) Value() (driver.Value, error) {
 		return nil, nil
 	}
 
+	if m.Payload != nil {
+		// the payload is restored from the event, so it isn't duplicated
+		// in every delivery
+		md := *m
+		md.Data, md.Raw = nil, ""
+		m = &md
+	}
+
 	b, err := json.Marshal(m)
 	if err != nil {
 		return nil, err
@@ -789,6 +1101,7 @@ type DeliveryAttempt struct {
 	Method     string `json:"method" db:"method"`
 	EndpointID string `json:"endpoint_id" db:"endpoint_id"`
 	APIVersion string `json:"api_version" db:"api_version"`
+	BatchID    string `json:"batch_id,omitempty" db:"batch_id"`
 
 	IPAddress        string     `json:"ip_address,omitempty" db:"ip_address"`
 	RequestHeader    HttpHeader `json:"request_http_header,omitempty" db:"request_http_header"`
@@ -803,6 +1116,40 @@ type DeliveryAttempt struct {
 	DeletedAt null.Time `json:"deleted_at,omitempty" db:"deleted_at" swaggertype:"string"`
 }
 
+func (d *DeliveryAttempt) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("unsupported value type %T", value)
+	}
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	if err := json.Unmarshal(b, &d); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (d *DeliveryAttempt) Value() (driver.Value, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
 type DeliveryAttempts []DeliveryAttempt
 
 func (h *DeliveryAttempts) Scan(value interface{}) error {
@@ -850,6 +1197,8 @@ type EventDelivery struct {
 	Headers        httpheader.HTTPHeader `json:"headers" db:"headers"`
 	URLQueryParams string                `json:"url_query_params" db:"url_query_params"`
 	IdempotencyKey string                `json:"idempotency_key" db:"idempotency_key"`
+	PartitionKey   string                `json:"partition_key,omitempty" db:"partition_key"`
+	BatchID        string                `json:"batch_id,omitempty" db:"batch_id"`
 
 	Endpoint *Endpoint `json:"endpoint_metadata,omitempty" db:"endpoint_metadata"`
 	Event    *Event    `json:"event_metadata,omitempty" db:"event_metadata"`
@@ -938,6 +1287,15 @@ type Subscription struct {
 	RetryConfig     *RetryConfiguration     `json:"retry_config,omitempty" db:"retry_config"`
 	FilterConfig    *FilterConfiguration    `json:"filter_config,omitempty" db:"filter_config"`
 	RateLimitConfig *RateLimitConfiguration `json:"rate_limit_config,omitempty" db:"rate_limit_config"`
+	BatchConfig     *BatchConfiguration     `json:"batch_config,omitempty" db:"batch_config"`
+
+	// Function is a javascript transform(request) that can rewrite a
+	// delivery's body, headers and path before it is signed and sent
+	Function null.String `json:"function" db:"function"`
+
+	// TTL is how long, in seconds, the subscription's deliveries stay
+	// worth sending after their event's delivery time, 0 means forever
+	TTL uint64 `json:"ttl,omitempty" db:"ttl"`
 
 	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at" swaggertype:"string"`
 	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at" swaggertype:"string"`
@@ -980,6 +1338,13 @@ func (s *Subscription) GetRateLimitConfig() RateLimitConfiguration {
 	return RateLimitConfiguration{}
 }
 
+func (s *Subscription) GetBatchConfig() BatchConfiguration {
+	if s.BatchConfig != nil {
+		return *s.BatchConfig
+	}
+	return BatchConfiguration{}
+}
+
 type CustomResponse struct {
 	Body        string `json:"body" db:"body"`
 	ContentType string `json:"content_type" db:"content_type"`
@@ -1012,6 +1377,25 @@ type PubSubConfig struct {
 	Workers int                 `json:"workers" db:"workers"`
 	Sqs     *SQSPubSubConfig    `json:"sqs" db:"sqs"`
 	Google  *GooglePubSubConfig `json:"google" db:"google"`
+	Kafka   *KafkaPubSubConfig  `json:"kafka" db:"kafka"`
+	Amqp    *AmqpPubSubConfig   `json:"amqp" db:"amqp"`
+	Nats    *NatsPubSubConfig   `json:"nats" db:"nats"`
+
+	// Mapping extracts events from messages that aren't in the
+	// default format
+	Mapping *PubSubMessageMapping `json:"mapping,omitempty" db:"mapping"`
+
+	// DeadLetter is where messages that can't be turned into events
+	// are moved to, without it they are retried or dropped
+	DeadLetter *PubSubDeadLetterConfig `json:"dead_letter,omitempty" db:"dead_letter"`
+}
+
+// PubSubMessage is a message read from a pub sub source. Deliveries is
+// how many times it has been delivered, including this delivery.
+type PubSubMessage struct {
+	Data       string
+	Attributes map[string]string
+	Deliveries int
 }
 
 func (p *PubSubConfig) Scan(value interface{}) error {
@@ -1039,6 +1423,35 @@ func (p PubSubConfig) Value() (driver.Value, error) {
 	return b, nil
 }
 
+// PubSubMessageMapping holds the paths an event's fields are read from.
+// Paths are gjson paths into {"body": <message>, "attributes": {...}},
+// where attributes are the message's attributes or headers. Without a
+// data path the whole body is the event's data.
+type PubSubMessageMapping struct {
+	EventType      string            `json:"event_type,omitempty" db:"event_type"`
+	EndpointID     string            `json:"endpoint_id,omitempty" db:"endpoint_id"`
+	OwnerID        string            `json:"owner_id,omitempty" db:"owner_id"`
+	IdempotencyKey string            `json:"idempotency_key,omitempty" db:"idempotency_key"`
+	Data           string            `json:"data,omitempty" db:"data"`
+	Headers        map[string]string `json:"headers,omitempty" db:"headers"`
+}
+
+// PubSubDeadLetterConfig moves malformed messages, and messages that
+// still fail after MaxRedeliveries redeliveries, to another sqs queue,
+// google pub/sub topic or the source_dead_letters table.
+type PubSubDeadLetterConfig struct {
+	Type            PubSubDeadLetterType     `json:"type" db:"type"`
+	MaxRedeliveries int                      `json:"max_redeliveries" db:"max_redeliveries"`
+	Sqs             *SQSPubSubConfig         `json:"sqs,omitempty" db:"sqs"`
+	Google          *GooglePubSubTopicConfig `json:"google,omitempty" db:"google"`
+}
+
+type GooglePubSubTopicConfig struct {
+	TopicID        string `json:"topic_id" db:"topic_id"`
+	ServiceAccount []byte `json:"service_account" db:"service_account"`
+	ProjectID      string `json:"project_id" db:"project_id"`
+}
+
 type SQSPubSubConfig struct {
 	AccessKeyID   string `json:"access_key_id" db:"access_key_id"`
 	SecretKey     string `json:"secret_key" db:"secret_key"`
@@ -1052,6 +1465,50 @@ type GooglePubSubConfig struct {
 	ProjectID      string `json:"project_id" db:"project_id"`
 }
 
+type KafkaPubSubConfig struct {
+	Brokers         []string `json:"brokers" db:"brokers"`
+	Topic           string   `json:"topic" db:"topic"`
+	ConsumerGroupID string   `json:"consumer_group_id" db:"consumer_group_id"`
+
+	// TLS turns on tls for broker connections, CACert optionally
+	// replaces the system roots used to verify the brokers
+	TLS    bool   `json:"tls" db:"tls"`
+	CACert string `json:"ca_cert,omitempty" db:"ca_cert"`
+
+	SASL *KafkaSASLConfig `json:"sasl,omitempty" db:"sasl"`
+}
+
+type KafkaSASLConfig struct {
+	Mechanism KafkaSASLMechanism `json:"mechanism" db:"mechanism"`
+	Username  string             `json:"username" db:"username"`
+	Password  string             `json:"password" db:"password"`
+}
+
+type AmqpPubSubConfig struct {
+	// URL is the broker's amqp:// or amqps:// url, credentials and the
+	// vhost are part of it
+	URL   string `json:"url" db:"url"`
+	Queue string `json:"queue" db:"queue"`
+
+	// Prefetch is the number of unacknowledged messages each worker
+	// may hold at a time
+	Prefetch int `json:"prefetch" db:"prefetch"`
+}
+
+type NatsPubSubConfig struct {
+	URL      string `json:"url" db:"url"`
+	Stream   string `json:"stream" db:"stream"`
+	Consumer string `json:"consumer" db:"consumer"`
+	Subject  string `json:"subject,omitempty" db:"subject"`
+
+	// Prefetch is the number of messages each worker fetches per batch
+	Prefetch int `json:"prefetch" db:"prefetch"`
+
+	Username string `json:"username,omitempty" db:"username"`
+	Password string `json:"password,omitempty" db:"password"`
+	Token    string `json:"token,omitempty" db:"token"`
+}
+
 type User struct {
 	UID                        string    `json:"uid" db:"id"`
 	FirstName                  string    `json:"first_name" db:"first_name"`
@@ -1072,6 +1529,12 @@ type RetryConfiguration struct {
 	Type       StrategyProvider `json:"type,omitempty" db:"type" valid:"supported_retry_strategy~please provide a valid retry strategy type"`
 	Duration   uint64           `json:"duration,omitempty" db:"duration" valid:"duration~please provide a valid time duration"`
 	RetryCount uint64           `json:"retry_count" db:"retry_count" valid:"int~please provide a valid retry count"`
+
+	// MaxDuration caps the exponential backoff interval, in seconds
+	MaxDuration     uint64        `json:"max_duration,omitempty" db:"max_duration"`
+	Jitter          JitterType    `json:"jitter,omitempty" db:"jitter"`
+	Intervals       pq.Int64Array `json:"intervals,omitempty" db:"intervals"`
+	HonorRetryAfter bool          `json:"honor_retry_after" db:"honor_retry_after"`
 }
 
 type AlertConfiguration struct {
@@ -1079,9 +1542,26 @@ type AlertConfiguration struct {
 	Threshold string `json:"threshold" db:"threshold" valid:"duration~please provide a valid time duration"`
 }
 
+// BatchConfiguration turns on batched delivery for a subscription. Event
+// deliveries are accumulated and sent as a single JSON array once MaxCount
+// deliveries are pending, MaxBytes of payload has been collected or the
+// oldest delivery has waited for MaxWait seconds, whichever comes first.
+type BatchConfiguration struct {
+	MaxCount uint64 `json:"max_count" db:"max_count"`
+	MaxBytes uint64 `json:"max_bytes" db:"max_bytes"`
+	MaxWait  uint64 `json:"max_wait" db:"max_wait"`
+}
+
 type FilterConfiguration struct {
+	// EventTypes are patterns like invoice.*, *.deleted, order.** or
+	// !order.test, see package eventtype for how they're matched
 	EventTypes pq.StringArray `json:"event_types" db:"event_types"`
 	Filter     FilterSchema   `json:"filter" db:"filter"`
+
+	// Expression is a CEL expression that is used instead of Filter when
+	// it is set, it is evaluated over the event's body, headers, event
+	// type and source.
+	Expression string `json:"expression,omitempty" db:"expression"`
 }
 
 type M map[string]interface{}
@@ -1333,6 +1813,76 @@ type MetaEventPayload struct {
 	Data      json.RawMessage `json:"data"`
 }
 
+// CircuitBreakerStateChange is the payload of the endpoint circuit breaker
+// hooks, it is fired whenever an endpoint's breaker changes state.
+type CircuitBreakerStateChange struct {
+	Endpoint      *CircuitBreakerEndpoint `json:"endpoint"`
+	PreviousState string                  `json:"previous_state"`
+	State         string                  `json:"state"`
+	FailureRate   float64                 `json:"failure_rate"`
+	ChangedAt     time.Time               `json:"changed_at"`
+}
+
+// CircuitBreakerEndpoint identifies the endpoint of a breaker state change,
+// it's sent to the meta event url so it leaves out the endpoint's secrets
+// and credentials.
+type CircuitBreakerEndpoint struct {
+	UID       string `json:"uid"`
+	ProjectID string `json:"project_id"`
+	Name      string `json:"name"`
+	URL       string `json:"url"`
+}
+
+// SourceDeadLetter is a pub sub message that couldn't be turned into an
+// event, kept as it was received.
+type SourceDeadLetter struct {
+	UID        string `json:"uid" db:"id"`
+	ProjectID  string `json:"project_id" db:"project_id"`
+	SourceID   string `json:"source_id" db:"source_id"`
+	Data       string `json:"data" db:"data"`
+	Attributes M      `json:"attributes" db:"attributes"`
+	Reason     string `json:"reason" db:"reason"`
+	Deliveries int    `json:"deliveries" db:"deliveries"`
+
+	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at,omitempty" swaggertype:"string"`
+	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at,omitempty" swaggertype:"string"`
+	DeletedAt null.Time `json:"deleted_at,omitempty" db:"deleted_at" swaggertype:"string"`
+}
+
+// ProjectEventType is an entry in a project's event type catalog, events
+// of the type are validated against its json schema when the project has
+// schema validation enabled.
+type ProjectEventType struct {
+	UID         string          `json:"uid" db:"id"`
+	ProjectID   string          `json:"project_id" db:"project_id"`
+	Name        string          `json:"name" db:"name"`
+	Description string          `json:"description" db:"description"`
+	Version     string          `json:"version" db:"version"`
+	JSONSchema  json.RawMessage `json:"json_schema" db:"json_schema" swaggertype:"object"`
+	Deprecated  bool            `json:"deprecated" db:"deprecated"`
+
+	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at,omitempty" swaggertype:"string"`
+	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at,omitempty" swaggertype:"string"`
+	DeletedAt null.Time `json:"deleted_at,omitempty" db:"deleted_at" swaggertype:"string"`
+}
+
+// DeadLetter is an event delivery that exhausted its retry budget, it
+// holds the final delivery attempt so it can be inspected and redriven.
+type DeadLetter struct {
+	UID             string           `json:"uid" db:"id"`
+	ProjectID       string           `json:"project_id" db:"project_id"`
+	EventDeliveryID string           `json:"event_delivery_id" db:"event_delivery_id"`
+	EventID         string           `json:"event_id" db:"event_id"`
+	EndpointID      string           `json:"endpoint_id" db:"endpoint_id"`
+	EventType       EventType        `json:"event_type" db:"event_type"`
+	Reason          string           `json:"reason" db:"reason"`
+	Attempt         *DeliveryAttempt `json:"attempt" db:"attempt"`
+
+	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at,omitempty" swaggertype:"string"`
+	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at,omitempty" swaggertype:"string"`
+	DeletedAt null.Time `json:"deleted_at,omitempty" db:"deleted_at" swaggertype:"string"`
+}
+
 type MetaEventAttempt struct {
 	RequestHeader  HttpHeader `json:"request_http_header" db:"request_http_header"`
 	ResponseHeader HttpHeader `json:"response_http_header" db:"response_http_header"`
@@ -1401,3 +1951,104 @@ func (p *Password) Matches() (bool, error) {
 
 	return true, err
 }
+
+type SigningKeyAlgorithm string
+
+const (
+	Ed25519SigningKeyAlgorithm SigningKeyAlgorithm = "EdDSA"
+	RSAPSSSigningKeyAlgorithm  SigningKeyAlgorithm = "PS256"
+)
+
+func (s SigningKeyAlgorithm) IsValid() bool {
+	switch s {
+	case Ed25519SigningKeyAlgorithm, RSAPSSSigningKeyAlgorithm:
+		return true
+	}
+	return false
+}
+
+// SigningKey is a project's key pair for asymmetric payload signing. Like
+// endpoint secrets, a key is rotated by setting ExpiresAt, it stays in the
+// project's JWKS until then so receivers can keep verifying with it.
+type SigningKey struct {
+	UID       string              `json:"uid" db:"id"`
+	ProjectID string              `json:"project_id" db:"project_id"`
+	Algorithm SigningKeyAlgorithm `json:"algorithm" db:"algorithm"`
+
+	// PublicKey is the base64 encoded PKIX public key
+	PublicKey string `json:"public_key" db:"public_key"`
+
+	// PrivateKey is the encrypted PKCS #8 private key, see util.Encrypt
+	PrivateKey string `json:"-" db:"private_key"`
+
+	ExpiresAt null.Time `json:"expires_at,omitempty" db:"expires_at" swaggertype:"string"`
+	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at,omitempty" swaggertype:"string"`
+	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at,omitempty" swaggertype:"string"`
+	DeletedAt null.Time `json:"deleted_at,omitempty" db:"deleted_at" swaggertype:"string"`
+}
+
+// JWK returns the public half of the key as published in the project's JWKS.
+func (s *SigningKey) JWK() (*signature.JWK, error) {
+	pub, err := base64.StdEncoding.DecodeString(s.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return signature.NewJWK(string(s.Algorithm), s.UID, pub)
+}
+
+var (
+	ErrSCIMTokenNotFound  = errors.New("scim token not found")
+	ErrSCIMUserNotFound   = errors.New("scim user not found")
+	ErrSCIMGroupNotFound  = errors.New("scim group not found")
+	ErrDuplicateSCIMGroup = errors.New("a scim group with this display name already exists")
+)
+
+// SCIMToken authenticates an organisation's identity provider against the
+// SCIM server, only its hash is stored, the same way API keys are.
+type SCIMToken struct {
+	UID            string `json:"uid" db:"id"`
+	OrganisationID string `json:"organisation_id" db:"organisation_id"`
+	MaskID         string `json:"mask_id" db:"mask_id"`
+	Hash           string `json:"-" db:"hash"`
+	Salt           string `json:"-" db:"salt"`
+
+	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at,omitempty" swaggertype:"string"`
+	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at,omitempty" swaggertype:"string"`
+	DeletedAt null.Time `json:"deleted_at,omitempty" db:"deleted_at" swaggertype:"string"`
+}
+
+// SCIMUser links a user to the organisation whose identity provider
+// provisioned or adopted them. Provisioned is only set for users the
+// organisation created, those are the only users it may rename.
+type SCIMUser struct {
+	OrganisationID string `json:"organisation_id" db:"organisation_id"`
+	UserID         string `json:"user_id" db:"user_id"`
+	ExternalID     string `json:"external_id" db:"external_id"`
+	Provisioned    bool   `json:"provisioned" db:"provisioned"`
+
+	// FirstName, LastName, Email and MemberID are read from the user and
+	// their organisation membership, MemberID is empty when the user is
+	// deactivated.
+	FirstName string `json:"first_name" db:"first_name"`
+	LastName  string `json:"last_name" db:"last_name"`
+	Email     string `json:"email" db:"email"`
+	MemberID  string `json:"member_id" db:"member_id"`
+
+	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at,omitempty" swaggertype:"string"`
+	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at,omitempty" swaggertype:"string"`
+}
+
+// SCIMGroup grants its members Role in the organisation.
+type SCIMGroup struct {
+	UID            string    `json:"uid" db:"id"`
+	OrganisationID string    `json:"organisation_id" db:"organisation_id"`
+	DisplayName    string    `json:"display_name" db:"display_name"`
+	ExternalID     string    `json:"external_id" db:"external_id"`
+	Role           auth.Role `json:"role" db:"role"`
+	Members        []string  `json:"members" db:"-"`
+
+	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at,omitempty" swaggertype:"string"`
+	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at,omitempty" swaggertype:"string"`
+	DeletedAt null.Time `json:"deleted_at,omitempty" db:"deleted_at" swaggertype:"string"`
+}
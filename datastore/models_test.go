@@ -71,3 +71,21 @@ func TestProject_IsOwner(t *testing.T) {
 		})
 	}
 }
+
+func TestMetadata_Value(t *testing.T) {
+	m := &Metadata{Data: []byte(`{"name":"convoy"}`), Raw: `{"name":"convoy"}`, NumTrials: 1}
+
+	v, err := m.Value()
+	require.NoError(t, err)
+	require.Contains(t, string(v.([]byte)), `"raw":"{\"name\":\"convoy\"}"`)
+
+	m.Payload = &StoredPayload{Encoding: "gzip", Size: 17}
+
+	v, err = m.Value()
+	require.NoError(t, err)
+	require.Contains(t, string(v.([]byte)), `"raw":""`)
+	require.Contains(t, string(v.([]byte)), `"payload":{"encoding":"gzip","size":17}`)
+
+	// the payload is only left out of what's stored
+	require.Equal(t, `{"name":"convoy"}`, m.Raw)
+}
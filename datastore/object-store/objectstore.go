@@ -1,7 +1,16 @@
 package objectstore
 
+import "context"
+
 type ObjectStore interface {
+	// Save uploads an exported file
 	Save(string) error
+
+	// Put stores data under key, replacing what was there
+	Put(ctx context.Context, key string, data []byte) error
+
+	// Get returns the data stored under key
+	Get(ctx context.Context, key string) ([]byte, error)
 }
 
 type ObjectStoreOptions struct {
@@ -1,7 +1,9 @@
 package objectstore
 
 import (
+	"context"
 	"os"
+	"path/filepath"
 
 	"github.com/frain-dev/convoy/pkg/log"
 )
@@ -25,3 +27,16 @@ func (o *OnPremClient) Save(filename string) error {
 	log.Printf("Successfully saved %q \n", filename)
 	return nil
 }
+
+func (o *OnPremClient) Put(_ context.Context, key string, data []byte) error {
+	path := filepath.Join(o.opts.OnPremStorageDir, filepath.Clean("/"+key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+func (o *OnPremClient) Get(_ context.Context, key string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(o.opts.OnPremStorageDir, filepath.Clean("/"+key)))
+}
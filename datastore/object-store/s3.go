@@ -1,12 +1,16 @@
 package objectstore
 
 import (
+	"bytes"
+	"context"
+	"io"
 	"os"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/frain-dev/convoy/pkg/log"
 )
@@ -60,3 +64,26 @@ func (s3 *S3Client) Save(filename string) error {
 	log.Printf("Successfully saved %q to %q\n", filename, s3.opts.Bucket)
 	return nil
 }
+
+func (s3c *S3Client) Put(ctx context.Context, key string, data []byte) error {
+	_, err := s3.New(s3c.session).PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s3c.opts.Bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(data),
+	})
+
+	return err
+}
+
+func (s3c *S3Client) Get(ctx context.Context, key string) ([]byte, error) {
+	out, err := s3.New(s3c.session).GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s3c.opts.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer out.Body.Close()
+
+	return io.ReadAll(out.Body)
+}
@@ -22,12 +22,19 @@ type EventDeliveryRepository interface {
 	FindEventDeliveryByID(ctx context.Context, projectID string, id string) (*EventDelivery, error)
 	FindEventDeliveriesByIDs(ctx context.Context, projectID string, ids []string) ([]EventDelivery, error)
 	FindEventDeliveriesByEventID(ctx context.Context, projectID string, id string) ([]EventDelivery, error)
+	FindPendingEventDeliveryBefore(ctx context.Context, projectID string, eventDelivery *EventDelivery) (*EventDelivery, error)
+	FindEventDeliveriesByBatchID(ctx context.Context, projectID string, batchID string) ([]EventDelivery, error)
+	CountPendingBatchEventDeliveries(ctx context.Context, projectID string, subscriptionID string) (int64, error)
+	CreateEventDeliveryBatch(ctx context.Context, projectID string, subscriptionID string, batchID string, config BatchConfiguration) (int64, error)
 	CountDeliveriesByStatus(ctx context.Context, projectID string, status EventDeliveryStatus, params SearchParams) (int64, error)
 	UpdateStatusOfEventDelivery(ctx context.Context, projectID string, eventDelivery EventDelivery, status EventDeliveryStatus) error
 	UpdateStatusOfEventDeliveries(ctx context.Context, projectID string, ids []string, status EventDeliveryStatus) error
 	FindDiscardedEventDeliveries(ctx context.Context, projectID, deviceId string, params SearchParams) ([]EventDelivery, error)
+	DiscardScheduledEventDeliveries(ctx context.Context, projectID string, eventID string, reason string) (int64, error)
+	DiscardEventDeliveries(ctx context.Context, projectID string, ids []string, reason string) error
 
 	UpdateEventDeliveryWithAttempt(ctx context.Context, projectID string, eventDelivery EventDelivery, attempt DeliveryAttempt) error
+	UpdateEventDeliveryMetadata(ctx context.Context, projectID string, eventDelivery *EventDelivery) error
 	CountEventDeliveries(ctx context.Context, projectID string, endpointIDs []string, eventID string, status []EventDeliveryStatus, params SearchParams) (int64, error)
 	DeleteProjectEventDeliveries(ctx context.Context, projectID string, filter *EventDeliveryFilter, hardDelete bool) error
 	LoadEventDeliveriesPaged(ctx context.Context, projectID string, endpointIDs []string, eventID string, status []EventDeliveryStatus, params SearchParams, pageable Pageable, idempotencyKey string) ([]EventDelivery, PaginationData, error)
@@ -36,11 +43,13 @@ type EventDeliveryRepository interface {
 
 type EventRepository interface {
 	CreateEvent(context.Context, *Event) error
+	CreateEvents(context.Context, []*Event) error
 	FindEventByID(ctx context.Context, projectID string, id string) (*Event, error)
 	FindEventsByIDs(ctx context.Context, projectID string, ids []string) ([]Event, error)
 	CountProjectMessages(ctx context.Context, projectID string) (int64, error)
 	CountEvents(ctx context.Context, projectID string, f *Filter) (int64, error)
 	LoadEventsPaged(ctx context.Context, projectID string, f *Filter) ([]Event, PaginationData, error)
+	LoadScheduledEventsPaged(ctx context.Context, projectID string, pageable Pageable) ([]Event, PaginationData, error)
 	DeleteProjectEvents(ctx context.Context, projectID string, f *EventFilter, hardDelete bool) error
 	FindEventsByIdempotencyKey(ctx context.Context, projectID string, idempotencyKey string) ([]Event, error)
 	FindFirstEventWithIdempotencyKey(ctx context.Context, projectID string, idempotencyKey string) (*Event, error)
@@ -170,3 +179,54 @@ type MetaEventRepository interface {
 	LoadMetaEventsPaged(ctx context.Context, projectID string, f *Filter) ([]MetaEvent, PaginationData, error)
 	UpdateMetaEvent(ctx context.Context, projectID string, metaEvent *MetaEvent) error
 }
+
+type DeadLetterRepository interface {
+	CreateDeadLetter(context.Context, *DeadLetter) error
+	FindDeadLetterByID(ctx context.Context, projectID string, id string) (*DeadLetter, error)
+	LoadDeadLettersPaged(ctx context.Context, projectID string, f *DeadLetterFilter) ([]DeadLetter, PaginationData, error)
+	DeleteDeadLetter(ctx context.Context, projectID string, id string) error
+	DeleteDeadLetters(ctx context.Context, projectID string, f *DeadLetterFilter) (int64, error)
+}
+
+type SourceDeadLetterRepository interface {
+	CreateSourceDeadLetter(context.Context, *SourceDeadLetter) error
+	LoadSourceDeadLettersPaged(ctx context.Context, projectID string, sourceID string, pageable Pageable) ([]SourceDeadLetter, PaginationData, error)
+}
+
+type EventTypeRepository interface {
+	CreateEventType(context.Context, *ProjectEventType) error
+	UpdateEventType(context.Context, *ProjectEventType) error
+	FindEventTypeByID(ctx context.Context, projectID string, id string) (*ProjectEventType, error)
+	FindEventTypeByName(ctx context.Context, projectID string, name string) (*ProjectEventType, error)
+	LoadEventTypesPaged(ctx context.Context, projectID string, pageable Pageable) ([]ProjectEventType, PaginationData, error)
+	DeleteEventType(ctx context.Context, projectID string, id string) error
+}
+
+type SigningKeyRepository interface {
+	CreateSigningKey(context.Context, *SigningKey) error
+	UpdateSigningKey(context.Context, *SigningKey) error
+	// LoadActiveSigningKeys returns the project's unexpired keys, newest first.
+	LoadActiveSigningKeys(ctx context.Context, projectID string) ([]SigningKey, error)
+}
+
+type SCIMRepository interface {
+	// CreateSCIMToken revokes the organisation's current token, if any, and
+	// stores the new one.
+	CreateSCIMToken(context.Context, *SCIMToken) error
+	FindSCIMTokenByMaskID(ctx context.Context, maskID string) (*SCIMToken, error)
+
+	CreateSCIMUser(context.Context, *SCIMUser) error
+	UpdateSCIMUser(context.Context, *SCIMUser) error
+	FindSCIMUser(ctx context.Context, organisationID string, userID string) (*SCIMUser, error)
+	LoadSCIMUsers(ctx context.Context, organisationID string) ([]SCIMUser, error)
+	// DeleteSCIMUser unlinks the user and removes them from every group.
+	DeleteSCIMUser(ctx context.Context, organisationID string, userID string) error
+
+	// CreateSCIMGroup and UpdateSCIMGroup also store the group's members.
+	CreateSCIMGroup(context.Context, *SCIMGroup) error
+	UpdateSCIMGroup(context.Context, *SCIMGroup) error
+	FindSCIMGroupByID(ctx context.Context, organisationID string, id string) (*SCIMGroup, error)
+	LoadSCIMGroups(ctx context.Context, organisationID string) ([]SCIMGroup, error)
+	FindSCIMGroupsByUserID(ctx context.Context, organisationID string, userID string) ([]SCIMGroup, error)
+	DeleteSCIMGroup(ctx context.Context, organisationID string, id string) error
+}
@@ -11,8 +11,9 @@ import (
 
 var reg *prometheus.Registry
 var requestDuration *prometheus.HistogramVec
+var pubSub *PubSubMetrics
 
-var re, rd sync.Once
+var re, rd, ps sync.Once
 
 func Reg() *prometheus.Registry {
 	re.Do(func() {
@@ -24,8 +25,8 @@ func Reg() *prometheus.Registry {
 
 // Reset is only intended for use in tests
 func Reset() {
-	requestDuration, reg = nil, nil
-	re, rd = sync.Once{}, sync.Once{}
+	requestDuration, reg, pubSub = nil, nil, nil
+	re, rd, ps = sync.Once{}, sync.Once{}, sync.Once{}
 	prometheus.DefaultRegisterer = prometheus.NewRegistry()
 }
 
@@ -46,3 +47,38 @@ func RegisterQueueMetrics(q queue.Queuer) {
 		metrics.NewQueueMetricsCollector(q.(*redisqueue.RedisQueue).Inspector()),
 	)
 }
+
+// PubSubMetrics count the messages read from pub sub sources, labelled
+// by project and source.
+type PubSubMetrics struct {
+	Consumed     *prometheus.CounterVec
+	Failed       *prometheus.CounterVec
+	DeadLettered *prometheus.CounterVec
+}
+
+func PubSub() *PubSubMetrics {
+	ps.Do(func() {
+		labels := []string{"project_id", "source_id"}
+		pubSub = &PubSubMetrics{
+			Consumed: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "pubsub_messages_consumed_total",
+				Help: "Number of messages read from pub sub sources, redeliveries included.",
+			}, labels),
+			Failed: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "pubsub_messages_failed_total",
+				Help: "Number of pub sub messages that couldn't be turned into events.",
+			}, labels),
+			DeadLettered: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "pubsub_messages_dead_lettered_total",
+				Help: "Number of pub sub messages moved to a dead letter destination.",
+			}, labels),
+		}
+	})
+
+	return pubSub
+}
+
+func RegisterPubSubMetrics() {
+	p := PubSub()
+	Reg().MustRegister(p.Consumed, p.Failed, p.DeadLettered)
+}
@@ -0,0 +1,151 @@
+package payload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	objectstore "github.com/frain-dev/convoy/datastore/object-store"
+	"github.com/frain-dev/convoy/pkg/compress"
+)
+
+var (
+	storeSingleton atomic.Value
+
+	ErrObjectStoreNotConfigured = errors.New("payload is offloaded but no object store is configured")
+)
+
+// Store decides how event payloads are kept, small payloads are stored
+// as they are, larger ones are compressed and the largest are offloaded
+// to the object store.
+type Store struct {
+	compression   compress.Encoding
+	compressAbove int
+	offloadAbove  int
+	objects       objectstore.ObjectStore
+}
+
+func NewStore(cfg config.PayloadStorageConfiguration, objects objectstore.ObjectStore) *Store {
+	s := &Store{
+		compression:   compress.Encoding(cfg.Compression),
+		compressAbove: cfg.CompressionThreshold,
+		objects:       objects,
+	}
+
+	if objects != nil {
+		s.offloadAbove = cfg.OffloadThreshold
+	}
+
+	return s
+}
+
+// New builds a Store from cfg, the storage policy's object store is only
+// created when offloading is enabled.
+func New(cfg config.Configuration) (*Store, error) {
+	if cfg.PayloadStorage.OffloadThreshold <= 0 {
+		return NewStore(cfg.PayloadStorage, nil), nil
+	}
+
+	var objects objectstore.ObjectStore
+	var err error
+
+	switch datastore.StorageType(cfg.StoragePolicy.Type) {
+	case datastore.S3:
+		objects, err = objectstore.NewS3Client(objectstore.ObjectStoreOptions{
+			Bucket:       cfg.StoragePolicy.S3.Bucket,
+			Endpoint:     cfg.StoragePolicy.S3.Endpoint,
+			AccessKey:    cfg.StoragePolicy.S3.AccessKey,
+			SecretKey:    cfg.StoragePolicy.S3.SecretKey,
+			SessionToken: cfg.StoragePolicy.S3.SessionToken,
+			Region:       cfg.StoragePolicy.S3.Region,
+		})
+	default:
+		objects, err = objectstore.NewOnPremClient(objectstore.ObjectStoreOptions{
+			OnPremStorageDir: cfg.StoragePolicy.OnPrem.Path,
+		})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewStore(cfg.PayloadStorage, objects), nil
+}
+
+func Init(s *Store) {
+	storeSingleton.Store(s)
+}
+
+// Get returns the store set with Init, payloads are kept as they are
+// when Init was never called.
+func Get() *Store {
+	s, ok := storeSingleton.Load().(*Store)
+	if !ok {
+		return &Store{}
+	}
+
+	return s
+}
+
+// ObjectKey is the object store key an event's payload is offloaded to.
+func ObjectKey(projectID, eventID string) string {
+	return fmt.Sprintf("payloads/%s/%s", projectID, eventID)
+}
+
+// Encode prepares data to be stored, the returned StoredPayload is nil
+// when data is stored as it is. Offloaded payloads are written to the
+// object store under key and an empty value is returned to be stored.
+func (s *Store) Encode(ctx context.Context, key string, data []byte) ([]byte, *datastore.StoredPayload, error) {
+	compressed := s.compression != compress.None && s.compressAbove > 0 && len(data) > s.compressAbove
+	offloaded := s.offloadAbove > 0 && len(data) > s.offloadAbove
+	if !compressed && !offloaded {
+		return data, nil, nil
+	}
+
+	sp := &datastore.StoredPayload{Size: len(data)}
+
+	stored := data
+	if compressed {
+		var err error
+		stored, err = compress.Encode(s.compression, data)
+		if err != nil {
+			return nil, nil, err
+		}
+		sp.Encoding = s.compression.String()
+	}
+
+	if offloaded {
+		if err := s.objects.Put(ctx, key, stored); err != nil {
+			return nil, nil, err
+		}
+
+		sp.ObjectKey = key
+		stored = []byte{}
+	}
+
+	return stored, sp, nil
+}
+
+// Decode returns the original payload from what was stored for it.
+func (s *Store) Decode(ctx context.Context, data []byte, sp *datastore.StoredPayload) ([]byte, error) {
+	if sp == nil {
+		return data, nil
+	}
+
+	if sp.IsOffloaded() {
+		if s.objects == nil {
+			return nil, ErrObjectStoreNotConfigured
+		}
+
+		var err error
+		data, err = s.objects.Get(ctx, sp.ObjectKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return compress.Decode(compress.Encoding(sp.Encoding), data)
+}
@@ -0,0 +1,82 @@
+package payload
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	objectstore "github.com/frain-dev/convoy/datastore/object-store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_EncodeDecode(t *testing.T) {
+	small := []byte(`{"name": "convoy"}`)
+	large := []byte(`{"items": [` + strings.Repeat(`{"name": "convoy"},`, 200) + `{}]}`)
+
+	tests := []struct {
+		name        string
+		cfg         config.PayloadStorageConfiguration
+		data        []byte
+		wantPayload *datastore.StoredPayload
+		wantEmpty   bool
+	}{
+		{
+			name: "should_store_as_is_without_config",
+			data: large,
+		},
+		{
+			name: "should_store_as_is_below_thresholds",
+			cfg:  config.PayloadStorageConfiguration{Compression: "gzip", CompressionThreshold: 1024, OffloadThreshold: 2048},
+			data: small,
+		},
+		{
+			name:        "should_compress",
+			cfg:         config.PayloadStorageConfiguration{Compression: "zstd", CompressionThreshold: 1024},
+			data:        large,
+			wantPayload: &datastore.StoredPayload{Encoding: "zstd", Size: len(large)},
+		},
+		{
+			name:        "should_offload",
+			cfg:         config.PayloadStorageConfiguration{OffloadThreshold: 1024},
+			data:        large,
+			wantPayload: &datastore.StoredPayload{ObjectKey: "payloads/p/e", Size: len(large)},
+			wantEmpty:   true,
+		},
+		{
+			name:        "should_compress_and_offload",
+			cfg:         config.PayloadStorageConfiguration{Compression: "gzip", CompressionThreshold: 1024, OffloadThreshold: 1024},
+			data:        large,
+			wantPayload: &datastore.StoredPayload{Encoding: "gzip", ObjectKey: "payloads/p/e", Size: len(large)},
+			wantEmpty:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects, err := objectstore.NewOnPremClient(objectstore.ObjectStoreOptions{OnPremStorageDir: t.TempDir()})
+			require.NoError(t, err)
+
+			s := NewStore(tt.cfg, objects)
+			ctx := context.Background()
+
+			stored, sp, err := s.Encode(ctx, ObjectKey("p", "e"), tt.data)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantPayload, sp)
+
+			if tt.wantEmpty {
+				require.Empty(t, stored)
+			}
+
+			data, err := s.Decode(ctx, stored, sp)
+			require.NoError(t, err)
+			require.Equal(t, tt.data, data)
+		})
+	}
+}
+
+func TestStore_DecodeWithoutObjectStore(t *testing.T) {
+	_, err := Get().Decode(context.Background(), []byte{}, &datastore.StoredPayload{ObjectKey: "payloads/p/e"})
+	require.ErrorIs(t, err, ErrObjectStoreNotConfigured)
+}
@@ -0,0 +1,367 @@
+package amqp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/log"
+	amqp091 "github.com/rabbitmq/amqp091-go"
+)
+
+var ErrInvalidCredentials = errors.New("your amqp credentials are invalid. please verify you're providing the correct credentials")
+
+const (
+	dialTimeout     = 10 * time.Second
+	defaultPrefetch = 10
+
+	// retryDelay is how long to wait before retrying a message whose
+	// job couldn't be enqueued, and before reconnecting to the broker
+	retryDelay = time.Second
+)
+
+type Amqp struct {
+	Cfg     *datastore.AmqpPubSubConfig
+	source  *datastore.Source
+	workers int
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	handler datastore.PubSubHandler
+	log     log.StdLogger
+}
+
+func New(source *datastore.Source, handler datastore.PubSubHandler, log log.StdLogger) *Amqp {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Amqp{
+		Cfg:     source.PubSub.Amqp,
+		source:  source,
+		workers: source.PubSub.Workers,
+		ctx:     ctx,
+		cancel:  cancel,
+		handler: handler,
+		log:     log,
+	}
+}
+
+// Start runs a consumer with its own connection per worker, the broker
+// spreads the queue's messages between them.
+func (a *Amqp) Start() {
+	for i := 1; i <= a.workers; i++ {
+		a.wg.Add(1)
+		go func() {
+			defer a.wg.Done()
+			a.Consume()
+		}()
+	}
+}
+
+func (a *Amqp) Stop() {
+	a.cancel()
+	a.wg.Wait()
+}
+
+// Verify ensures the amqp credentials are correct and the queue exists
+func (a *Amqp) Verify() error {
+	conn, err := a.dial()
+	if err != nil {
+		log.WithError(err).Error("failed to connect to broker - amqp")
+		return ErrInvalidCredentials
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		log.WithError(err).Error("failed to open channel - amqp")
+		return ErrInvalidCredentials
+	}
+	defer ch.Close()
+
+	_, err = ch.QueueDeclarePassive(a.Cfg.Queue, true, false, false, false, nil)
+	if err != nil {
+		log.WithError(err).Error("failed to inspect queue - amqp")
+		return fmt.Errorf("queue with name %s does not exist", a.Cfg.Queue)
+	}
+
+	return nil
+}
+
+// Consume reads messages from the queue until the consumer is stopped,
+// reconnecting whenever the connection to the broker is lost.
+func (a *Amqp) Consume() {
+	defer a.handleError()
+
+	for {
+		err := a.consume()
+		if a.ctx.Err() != nil {
+			return
+		}
+
+		a.log.WithError(err).Error("lost connection to broker, reconnecting - amqp")
+
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+func (a *Amqp) consume() error {
+	conn, err := a.dial()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+
+	err = ch.Qos(a.prefetch(), 0, false)
+	if err != nil {
+		return err
+	}
+
+	deliveries, err := ch.Consume(a.Cfg.Queue, "", false, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	return a.process(deliveries)
+}
+
+// process handles deliveries until the channel is closed or the consumer
+// is stopped. Messages still unacknowledged when the connection closes
+// are requeued by the broker.
+func (a *Amqp) process(deliveries <-chan amqp091.Delivery) error {
+	for {
+		select {
+		case <-a.ctx.Done():
+			return nil
+		case d, ok := <-deliveries:
+			if !ok {
+				return errors.New("delivery channel closed")
+			}
+
+			a.handle(d)
+		}
+	}
+}
+
+// handle acks a message once its job is enqueued. Malformed messages are
+// rejected without being requeued, so they are dropped or dead-lettered
+// by the queue. Other failures are retried while the message is held, so
+// its deliveries can be counted, the broker requeues it if the consumer
+// is stopped first.
+func (a *Amqp) handle(d amqp091.Delivery) {
+	attrs := headers(d.Headers)
+
+	for attempt := 1; ; attempt++ {
+		err := a.handler(a.ctx, a.source, &datastore.PubSubMessage{Data: string(d.Body), Attributes: attrs, Deliveries: attempt})
+		if err == nil {
+			if err := d.Ack(false); err != nil {
+				a.log.WithError(err).Error("failed to ack message - amqp")
+			}
+			return
+		}
+
+		if errors.Is(err, datastore.ErrInvalidPubSubMessage) {
+			a.log.WithError(err).Error("rejecting malformed message - amqp")
+			if err := d.Nack(false, false); err != nil {
+				a.log.WithError(err).Error("failed to nack message - amqp")
+			}
+			return
+		}
+
+		a.log.WithError(err).Error("failed to write message to create event queue - amqp")
+
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+// headers returns a message's headers as attributes for the mapping.
+func headers(table amqp091.Table) map[string]string {
+	attributes := make(map[string]string, len(table))
+	for k, v := range table {
+		switch val := v.(type) {
+		case []byte:
+			attributes[k] = string(val)
+		default:
+			attributes[k] = fmt.Sprint(val)
+		}
+	}
+
+	return attributes
+}
+
+func (a *Amqp) dial() (*amqp091.Connection, error) {
+	return dial(a.Cfg.URL, nil)
+}
+
+// dial connects to the broker at url with dialContext, the default dialer
+// is used when it is nil.
+func dial(url string, dialContext func(ctx context.Context, network, address string) (net.Conn, error)) (*amqp091.Connection, error) {
+	d := amqp091.DefaultDial(dialTimeout)
+	if dialContext != nil {
+		d = func(network, addr string) (net.Conn, error) {
+			ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+			defer cancel()
+
+			conn, err := dialContext(ctx, network, addr)
+			if err != nil {
+				return nil, err
+			}
+
+			// like the default dialer, the deadline covers the handshakes
+			// and is cleared once the connection is open
+			err = conn.SetDeadline(time.Now().Add(dialTimeout))
+			if err != nil {
+				conn.Close()
+				return nil, err
+			}
+
+			return conn, nil
+		}
+	}
+
+	return amqp091.DialConfig(url, amqp091.Config{
+		Heartbeat: 10 * time.Second,
+		Dial:      d,
+	})
+}
+
+func (a *Amqp) prefetch() int {
+	if a.Cfg.Prefetch > 0 {
+		return a.Cfg.Prefetch
+	}
+
+	return defaultPrefetch
+}
+
+func (a *Amqp) handleError() {
+	if err := recover(); err != nil {
+		a.log.WithError(fmt.Errorf("sourceID: %s, Errror: %s", a.source.UID, err)).Error("amqp pubsub source crashed")
+	}
+}
+
+// Publisher sends messages to an exchange, its connection to the broker
+// is kept open between messages and reopened if the broker closes it.
+type Publisher struct {
+	cfg         *datastore.AmqpExchangeConfig
+	dialContext func(ctx context.Context, network, address string) (net.Conn, error)
+
+	mu     sync.Mutex
+	conn   *amqp091.Connection
+	closed bool
+}
+
+// NewPublisher returns a publisher for the exchange in cfg. The broker is
+// connected to with dialContext, the default dialer is used when it is nil.
+func NewPublisher(cfg *datastore.AmqpExchangeConfig, dialContext func(ctx context.Context, network, address string) (net.Conn, error)) *Publisher {
+	return &Publisher{cfg: cfg, dialContext: dialContext}
+}
+
+func (p *Publisher) connection() (*amqp091.Connection, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, amqp091.ErrClosed
+	}
+
+	if p.conn == nil || p.conn.IsClosed() {
+		conn, err := dial(p.cfg.URL, p.dialContext)
+		if err != nil {
+			return nil, err
+		}
+
+		p.conn = conn
+	}
+
+	return p.conn, nil
+}
+
+// Close closes the publisher's connection to the broker.
+func (p *Publisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	if p.conn == nil || p.conn.IsClosed() {
+		return nil
+	}
+
+	return p.conn.Close()
+}
+
+// Publish sends msg to the exchange and waits for the broker to confirm
+// it, msg's attributes and attrs are sent as message headers. Messages
+// are persistent and mandatory so unroutable messages fail instead of
+// being dropped. Each message is sent on its own channel, so a failed
+// message doesn't close the channel of another.
+func (p *Publisher) Publish(ctx context.Context, msg *datastore.PubSubMessage, attrs map[string]string) error {
+	cfg := p.cfg
+
+	conn, err := p.connection()
+	if err != nil {
+		return err
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	err = ch.Confirm(false)
+	if err != nil {
+		return err
+	}
+
+	returns := ch.NotifyReturn(make(chan amqp091.Return, 1))
+
+	table := amqp091.Table{}
+	for _, a := range []map[string]string{msg.Attributes, attrs} {
+		for k, v := range a {
+			table[k] = v
+		}
+	}
+
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(ctx, cfg.Exchange, cfg.RoutingKey, true, false, amqp091.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp091.Persistent,
+		Headers:      table,
+		Body:         []byte(msg.Data),
+	})
+	if err != nil {
+		return err
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !acked {
+		return errors.New("message was rejected by the broker")
+	}
+
+	// the broker sends a return before the ack of an unroutable message
+	select {
+	case r := <-returns:
+		return fmt.Errorf("message could not be routed: %s", r.ReplyText)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,304 @@
+package destination
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	stdnet "net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub/amqp"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub/google"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub/kafka"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub/sqs"
+	"github.com/frain-dev/convoy/net"
+	"github.com/frain-dev/convoy/util"
+)
+
+// sqsRegion matches aws region names, the region is part of the queue's
+// host so it can't be used to point the client at another host.
+var sqsRegion = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// Destination is the queue a non http endpoint's events are published
+// to, deliveries to it are retried, rate limited and recorded like
+// http deliveries.
+type Destination interface {
+	// URL identifies the queue without its credentials, it is used
+	// as the endpoint's target url.
+	URL() string
+
+	// Publish sends data to the queue with attrs as its attributes
+	// or headers. The queue's client is created on the first publish
+	// and reused until the destination is closed.
+	Publish(ctx context.Context, data []byte, attrs map[string]string) error
+
+	// Close closes the queue's client and its connections.
+	Close() error
+}
+
+// New returns the destination for kind, connections to its brokers are
+// checked against policy when they are dialled. A nil policy allows
+// every broker, it is used when only the destination's url is needed.
+func New(kind datastore.EndpointKind, dest *datastore.EndpointDestination, policy *net.EgressPolicy) (Destination, error) {
+	if dest == nil {
+		return nil, fmt.Errorf("%s destination config is required", kind)
+	}
+
+	switch kind {
+	case datastore.SqsEndpointKind:
+		if dest.Sqs == nil {
+			return nil, errors.New("sqs destination config is required")
+		}
+
+		cfg := dest.Sqs
+		return &sqsDestination{cfg: cfg, client: &client{new: func() (publisher, error) {
+			return sqs.NewPublisher(cfg, httpClient(policy))
+		}}}, nil
+
+	case datastore.GoogleEndpointKind:
+		if dest.Google == nil {
+			return nil, errors.New("google pub sub destination config is required")
+		}
+
+		cfg := dest.Google
+		return &googleDestination{cfg: cfg, client: &client{new: func() (publisher, error) {
+			return google.NewPublisher(context.Background(), cfg)
+		}}}, nil
+
+	case datastore.KafkaEndpointKind:
+		if dest.Kafka == nil {
+			return nil, errors.New("kafka destination config is required")
+		}
+
+		cfg := dest.Kafka
+		return &kafkaDestination{cfg: cfg, client: &client{new: func() (publisher, error) {
+			return kafka.NewPublisher(cfg, dialer(policy))
+		}}}, nil
+
+	case datastore.AmqpEndpointKind:
+		if dest.Amqp == nil {
+			return nil, errors.New("amqp destination config is required")
+		}
+
+		cfg := dest.Amqp
+		return &amqpDestination{cfg: cfg, client: &client{new: func() (publisher, error) {
+			return amqp.NewPublisher(cfg, dialer(policy)), nil
+		}}}, nil
+	}
+
+	return nil, fmt.Errorf("endpoint kind %s has no destination", kind)
+}
+
+type SqsQueue struct {
+	AccessKeyID   string `json:"access_key_id" valid:"required~access key id is required"`
+	SecretKey     string `json:"secret_key" valid:"required~secret key is required"`
+	DefaultRegion string `json:"default_region" valid:"required~default region is required"`
+	QueueName     string `json:"queue_name" valid:"required~queue name is required"`
+}
+
+type GoogleTopic struct {
+	ServiceAccount []byte `json:"service_account" valid:"required~service account is required"`
+	TopicID        string `json:"topic_id" valid:"required~topic id is required"`
+	ProjectID      string `json:"project_id" valid:"required~project id is required"`
+}
+
+type KafkaTopic struct {
+	Topic string `json:"topic" valid:"required~topic is required"`
+}
+
+type AmqpExchange struct {
+	URL string `json:"url" valid:"required~url is required"`
+}
+
+// Validate checks the destination config for kind is complete and its
+// brokers are allowed by policy. Brokers are only checked by address, they
+// aren't connected to, so hostnames are checked again when dialled.
+func Validate(kind datastore.EndpointKind, dest *datastore.EndpointDestination, policy *net.EgressPolicy) error {
+	d, err := New(kind, dest, policy)
+	if err != nil {
+		return err
+	}
+
+	switch d := d.(type) {
+	case *sqsDestination:
+		err = util.Validate(&SqsQueue{
+			AccessKeyID:   d.cfg.AccessKeyID,
+			SecretKey:     d.cfg.SecretKey,
+			DefaultRegion: d.cfg.DefaultRegion,
+			QueueName:     d.cfg.QueueName,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !sqsRegion.MatchString(d.cfg.DefaultRegion) {
+			return fmt.Errorf("invalid sqs region %s", d.cfg.DefaultRegion)
+		}
+
+	case *googleDestination:
+		return util.Validate(&GoogleTopic{
+			ServiceAccount: d.cfg.ServiceAccount,
+			TopicID:        d.cfg.TopicID,
+			ProjectID:      d.cfg.ProjectID,
+		})
+
+	case *kafkaDestination:
+		if len(d.cfg.Brokers) == 0 {
+			return errors.New("at least one broker is required")
+		}
+
+		err = util.Validate(&KafkaTopic{Topic: d.cfg.Topic})
+		if err != nil {
+			return err
+		}
+
+		for _, broker := range d.cfg.Brokers {
+			_, _, err = stdnet.SplitHostPort(broker)
+			if err != nil {
+				return fmt.Errorf("invalid kafka broker %s", broker)
+			}
+
+			err = checkEgress(policy, &url.URL{Host: broker})
+			if err != nil {
+				return err
+			}
+		}
+
+	case *amqpDestination:
+		err = util.Validate(&AmqpExchange{URL: d.cfg.URL})
+		if err != nil {
+			return err
+		}
+
+		u, err := url.Parse(d.cfg.URL)
+		if err != nil || (u.Scheme != "amqp" && u.Scheme != "amqps") {
+			return errors.New("invalid amqp url")
+		}
+
+		return checkEgress(policy, u)
+	}
+
+	return nil
+}
+
+func checkEgress(policy *net.EgressPolicy, u *url.URL) error {
+	if policy == nil {
+		return nil
+	}
+
+	return policy.CheckURL(u)
+}
+
+// dialer returns the dialer broker connections are made with, it is nil
+// when there is no policy so the client's default dialer is used.
+func dialer(policy *net.EgressPolicy) func(ctx context.Context, network, address string) (stdnet.Conn, error) {
+	if policy == nil {
+		return nil
+	}
+
+	return policy.DialContext
+}
+
+// httpClient returns the client requests to the queue's api are sent with,
+// it is nil when there is no policy so the client's default is used.
+func httpClient(policy *net.EgressPolicy) *http.Client {
+	if policy == nil || policy.Transport() == nil {
+		return nil
+	}
+
+	return &http.Client{Transport: policy.Transport()}
+}
+
+// publisher is the client of a queue.
+type publisher interface {
+	Publish(ctx context.Context, msg *datastore.PubSubMessage, attrs map[string]string) error
+	Close() error
+}
+
+// client creates its publisher the first time a message is published, so
+// destinations only used for their url don't create one.
+type client struct {
+	new func() (publisher, error)
+
+	mu sync.Mutex
+	p  publisher
+}
+
+func (c *client) Publish(ctx context.Context, data []byte, attrs map[string]string) error {
+	c.mu.Lock()
+	if c.p == nil {
+		p, err := c.new()
+		if err != nil {
+			c.mu.Unlock()
+			return err
+		}
+
+		c.p = p
+	}
+	p := c.p
+	c.mu.Unlock()
+
+	return p.Publish(ctx, &datastore.PubSubMessage{Data: string(data)}, attrs)
+}
+
+func (c *client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.p == nil {
+		return nil
+	}
+
+	return c.p.Close()
+}
+
+type sqsDestination struct {
+	*client
+	cfg *datastore.SQSPubSubConfig
+}
+
+func (s *sqsDestination) URL() string {
+	return fmt.Sprintf("sqs://%s/%s", s.cfg.DefaultRegion, s.cfg.QueueName)
+}
+
+type googleDestination struct {
+	*client
+	cfg *datastore.GooglePubSubTopicConfig
+}
+
+func (g *googleDestination) URL() string {
+	return fmt.Sprintf("google://%s/%s", g.cfg.ProjectID, g.cfg.TopicID)
+}
+
+type kafkaDestination struct {
+	*client
+	cfg *datastore.KafkaTopicConfig
+}
+
+func (k *kafkaDestination) URL() string {
+	return fmt.Sprintf("kafka://%s/%s", strings.Join(k.cfg.Brokers, ","), k.cfg.Topic)
+}
+
+type amqpDestination struct {
+	*client
+	cfg *datastore.AmqpExchangeConfig
+}
+
+// URL drops the credentials in the broker url and adds the exchange
+// and routing key as query params.
+func (a *amqpDestination) URL() string {
+	u, err := url.Parse(a.cfg.URL)
+	if err != nil {
+		return "amqp://"
+	}
+
+	u.User = nil
+	u.RawQuery = url.Values{"exchange": {a.cfg.Exchange}, "routing_key": {a.cfg.RoutingKey}}.Encode()
+
+	return u.String()
+}
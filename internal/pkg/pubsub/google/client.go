@@ -5,6 +5,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/pkg/log"
 	"google.golang.org/api/option"
@@ -85,12 +87,27 @@ func (g *Google) Consume() {
 	// NumGoroutines determines the number of goroutines sub.Receive will spawn to pull messages
 	sub.ReceiveSettings.NumGoroutines = g.workers
 
+	deliveries := newDeliveryCounter()
+
 	err = sub.Receive(g.ctx, func(ctx context.Context, m *pubsub.Message) {
-		if err := g.handler(ctx, g.source, string(m.Data)); err != nil {
-			g.log.WithError(err).Error("failed to write message to create event queue - google pub sub")
-		} else {
+		msg := &datastore.PubSubMessage{Data: string(m.Data), Attributes: m.Attributes, Deliveries: deliveries.count(m)}
+
+		err := g.handler(ctx, g.source, msg)
+		if err == nil {
+			deliveries.done(m)
+			m.Ack()
+			return
+		}
+
+		if errors.Is(err, datastore.ErrInvalidPubSubMessage) {
+			g.log.WithError(err).Error("dropping malformed message - google pub sub")
+			deliveries.done(m)
 			m.Ack()
+			return
 		}
+
+		g.log.WithError(err).Error("failed to write message to create event queue - google pub sub")
+		m.Nack()
 	})
 
 	if err != nil {
@@ -99,6 +116,36 @@ func (g *Google) Consume() {
 	}
 }
 
+// deliveryCounter counts deliveries of messages from subscriptions without
+// a dead letter policy, pub/sub only reports the attempt for those with one.
+type deliveryCounter struct {
+	mu       sync.Mutex
+	attempts map[string]int
+}
+
+func newDeliveryCounter() *deliveryCounter {
+	return &deliveryCounter{attempts: map[string]int{}}
+}
+
+func (d *deliveryCounter) count(m *pubsub.Message) int {
+	if m.DeliveryAttempt != nil {
+		return *m.DeliveryAttempt
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.attempts[m.ID]++
+	return d.attempts[m.ID]
+}
+
+func (d *deliveryCounter) done(m *pubsub.Message) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.attempts, m.ID)
+}
+
 func (g *Google) handleError(client *pubsub.Client) {
 	if err := client.Close(); err != nil {
 		g.log.WithError(err).Error("an error occurred while closing the client")
@@ -108,3 +155,55 @@ func (g *Google) handleError(client *pubsub.Client) {
 		g.log.WithError(fmt.Errorf("sourceID: %s, Errror: %s", g.source.UID, err)).Error("google pubsub source crashed")
 	}
 }
+
+// Publish sends msg to the topic in cfg, it is used to dead letter
+// messages.
+func Publish(ctx context.Context, cfg *datastore.GooglePubSubTopicConfig, msg *datastore.PubSubMessage, attrs map[string]string) error {
+	p, err := NewPublisher(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+
+	return p.Publish(ctx, msg, attrs)
+}
+
+// Publisher sends messages to a topic, its client's connections are kept
+// open between messages until it is closed.
+type Publisher struct {
+	client *pubsub.Client
+	topic  *pubsub.Topic
+}
+
+// NewPublisher returns a publisher for the topic in cfg, ctx is only used
+// to create the client.
+func NewPublisher(ctx context.Context, cfg *datastore.GooglePubSubTopicConfig) (*Publisher, error) {
+	client, err := pubsub.NewClient(ctx, cfg.ProjectID, option.WithCredentialsJSON(cfg.ServiceAccount))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Publisher{client: client, topic: client.Topic(cfg.TopicID)}, nil
+}
+
+// Publish sends msg to the topic, msg's attributes and attrs are sent as
+// message attributes.
+func (p *Publisher) Publish(ctx context.Context, msg *datastore.PubSubMessage, attrs map[string]string) error {
+	attributes := make(map[string]string, len(msg.Attributes)+len(attrs))
+	for k, v := range msg.Attributes {
+		attributes[k] = v
+	}
+
+	for k, v := range attrs {
+		attributes[k] = v
+	}
+
+	_, err := p.topic.Publish(ctx, &pubsub.Message{Data: []byte(msg.Data), Attributes: attributes}).Get(ctx)
+	return err
+}
+
+// Close sends the topic's pending messages and closes the client.
+func (p *Publisher) Close() error {
+	p.topic.Stop()
+	return p.client.Close()
+}
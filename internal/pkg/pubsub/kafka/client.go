@@ -0,0 +1,308 @@
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+var ErrInvalidCredentials = errors.New("your kafka credentials are invalid. please verify you're providing the correct credentials")
+
+const (
+	dialTimeout = 10 * time.Second
+
+	// a message is retried until its job is enqueued, the
+	// delay between attempts doubles up to maxRetryDelay
+	minRetryDelay = time.Second
+	maxRetryDelay = time.Minute
+)
+
+type Kafka struct {
+	Cfg     *datastore.KafkaPubSubConfig
+	source  *datastore.Source
+	workers int
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	handler datastore.PubSubHandler
+	log     log.StdLogger
+}
+
+func New(source *datastore.Source, handler datastore.PubSubHandler, log log.StdLogger) *Kafka {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Kafka{
+		Cfg:     source.PubSub.Kafka,
+		source:  source,
+		workers: source.PubSub.Workers,
+		ctx:     ctx,
+		cancel:  cancel,
+		handler: handler,
+		log:     log,
+	}
+}
+
+// Start runs a consumer per worker, they share the topic's partitions
+// through the consumer group.
+func (k *Kafka) Start() {
+	for i := 1; i <= k.workers; i++ {
+		k.wg.Add(1)
+		go func() {
+			defer k.wg.Done()
+			k.Consume()
+		}()
+	}
+}
+
+func (k *Kafka) Stop() {
+	k.cancel()
+	k.wg.Wait()
+}
+
+// Verify ensures the kafka credentials are correct and the topic exists
+func (k *Kafka) Verify() error {
+	dialer, err := k.dialer()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+
+	var conn *kafka.Conn
+	for _, broker := range k.Cfg.Brokers {
+		conn, err = dialer.DialContext(ctx, "tcp", broker)
+		if err == nil {
+			break
+		}
+	}
+
+	if err != nil {
+		log.WithError(err).Error("failed to connect to broker - kafka")
+		return ErrInvalidCredentials
+	}
+	defer conn.Close()
+
+	partitions, err := conn.ReadPartitions(k.Cfg.Topic)
+	if err != nil || len(partitions) == 0 {
+		log.WithError(err).Error("failed to read topic partitions - kafka")
+		return fmt.Errorf("topic with name %s does not exist", k.Cfg.Topic)
+	}
+
+	return nil
+}
+
+// Consume reads messages from the topic. A message's offset is only
+// committed once its job has been enqueued, so messages that fail are
+// retried instead of skipped.
+func (k *Kafka) Consume() {
+	defer k.handleError()
+
+	dialer, err := k.dialer()
+	if err != nil {
+		k.log.WithError(err).Error("failed to create dialer - kafka")
+		return
+	}
+
+	r := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: k.Cfg.Brokers,
+		GroupID: k.Cfg.ConsumerGroupID,
+		Topic:   k.Cfg.Topic,
+		Dialer:  dialer,
+	})
+
+	defer func() {
+		if err := r.Close(); err != nil {
+			k.log.WithError(err).Error("an error occurred while closing the reader - kafka")
+		}
+	}()
+
+	for {
+		m, err := r.FetchMessage(k.ctx)
+		if err != nil {
+			if k.ctx.Err() != nil {
+				return
+			}
+
+			k.log.WithError(err).Error("failed to fetch message - kafka")
+
+			select {
+			case <-k.ctx.Done():
+				return
+			case <-time.After(minRetryDelay):
+			}
+			continue
+		}
+
+		if !k.handle(m) {
+			return
+		}
+
+		if err := r.CommitMessages(k.ctx, m); err != nil && k.ctx.Err() == nil {
+			k.log.WithError(err).Error("failed to commit message - kafka")
+		}
+	}
+}
+
+// handle retries a message until it is handled, it returns false if the
+// consumer was stopped before then. Malformed messages are skipped.
+func (k *Kafka) handle(m kafka.Message) bool {
+	delay := minRetryDelay
+	for attempt := 1; ; attempt++ {
+		msg := &datastore.PubSubMessage{Data: string(m.Value), Attributes: headers(m.Headers), Deliveries: attempt}
+		err := k.handler(k.ctx, k.source, msg)
+		if err == nil {
+			return true
+		}
+
+		if errors.Is(err, datastore.ErrInvalidPubSubMessage) {
+			k.log.WithError(err).Errorf("skipping malformed message - kafka, partition: %d, offset: %d", m.Partition, m.Offset)
+			return true
+		}
+
+		k.log.WithError(err).Errorf("failed to write message to create event queue - kafka, partition: %d, offset: %d", m.Partition, m.Offset)
+
+		select {
+		case <-k.ctx.Done():
+			return false
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+	}
+}
+
+// headers returns a message's headers as attributes for the mapping.
+func headers(h []kafka.Header) map[string]string {
+	attributes := make(map[string]string, len(h))
+	for _, header := range h {
+		attributes[header.Key] = string(header.Value)
+	}
+
+	return attributes
+}
+
+func (k *Kafka) dialer() (*kafka.Dialer, error) {
+	return newDialer(k.Cfg.TLS, k.Cfg.CACert, k.Cfg.SASL)
+}
+
+func newDialer(useTLS bool, caCert string, saslCfg *datastore.KafkaSASLConfig) (*kafka.Dialer, error) {
+	dialer := &kafka.Dialer{Timeout: dialTimeout, DualStack: true}
+
+	if useTLS {
+		cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+		if len(caCert) > 0 {
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM([]byte(caCert)) {
+				return nil, errors.New("invalid kafka ca certificate")
+			}
+			cfg.RootCAs = pool
+		}
+
+		dialer.TLS = cfg
+	}
+
+	if saslCfg != nil {
+		mechanism, err := saslMechanism(saslCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		dialer.SASLMechanism = mechanism
+	}
+
+	return dialer, nil
+}
+
+func saslMechanism(cfg *datastore.KafkaSASLConfig) (sasl.Mechanism, error) {
+	switch cfg.Mechanism {
+	case datastore.KafkaSASLPlain:
+		return plain.Mechanism{Username: cfg.Username, Password: cfg.Password}, nil
+	case datastore.KafkaSASLScramSHA256:
+		return scram.Mechanism(scram.SHA256, cfg.Username, cfg.Password)
+	case datastore.KafkaSASLScramSHA512:
+		return scram.Mechanism(scram.SHA512, cfg.Username, cfg.Password)
+	}
+
+	return nil, fmt.Errorf("sasl mechanism %s is not supported", cfg.Mechanism)
+}
+
+func (k *Kafka) handleError() {
+	if err := recover(); err != nil {
+		k.log.WithError(fmt.Errorf("sourceID: %s, Errror: %s", k.source.UID, err)).Error("kafka pubsub source crashed")
+	}
+}
+
+// Publisher writes messages to a topic, its connections to the brokers
+// are kept open between messages until it is closed.
+type Publisher struct {
+	w *kafka.Writer
+}
+
+// NewPublisher returns a publisher for the topic in cfg. Brokers are
+// connected to with dial, the default dialer is used when it is nil.
+func NewPublisher(cfg *datastore.KafkaTopicConfig, dial func(ctx context.Context, network, address string) (net.Conn, error)) (*Publisher, error) {
+	dialer, err := newDialer(cfg.TLS, cfg.CACert, cfg.SASL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Publisher{w: &kafka.Writer{
+		Addr:         kafka.TCP(cfg.Brokers...),
+		Topic:        cfg.Topic,
+		RequiredAcks: kafka.RequireAll,
+		Transport: &kafka.Transport{
+			TLS:         dialer.TLS,
+			SASL:        dialer.SASLMechanism,
+			Dial:        dial,
+			DialTimeout: dialTimeout,
+		},
+	}}, nil
+}
+
+// Publish writes msg to the topic, msg's attributes and attrs are sent
+// as message headers.
+func (p *Publisher) Publish(ctx context.Context, msg *datastore.PubSubMessage, attrs map[string]string) error {
+	return p.w.WriteMessages(ctx, kafka.Message{
+		Value:   []byte(msg.Data),
+		Headers: messageHeaders(msg.Attributes, attrs),
+	})
+}
+
+// Close waits for pending messages to be written and closes the
+// publisher's connections.
+func (p *Publisher) Close() error {
+	return p.w.Close()
+}
+
+// messageHeaders merges attribute sets into headers, later sets win.
+func messageHeaders(attributes ...map[string]string) []kafka.Header {
+	merged := map[string]string{}
+	for _, a := range attributes {
+		for k, v := range a {
+			merged[k] = v
+		}
+	}
+
+	h := make([]kafka.Header, 0, len(merged))
+	for k, v := range merged {
+		h = append(h, kafka.Header{Key: k, Value: []byte(v)})
+	}
+
+	return h
+}
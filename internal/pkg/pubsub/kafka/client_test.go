@@ -0,0 +1,50 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKafka_Dialer(t *testing.T) {
+	k := &Kafka{Cfg: &datastore.KafkaPubSubConfig{
+		Brokers: []string{"localhost:9092"},
+		TLS:     true,
+		SASL: &datastore.KafkaSASLConfig{
+			Mechanism: datastore.KafkaSASLPlain,
+			Username:  "convoy",
+			Password:  "secret",
+		},
+	}}
+
+	dialer, err := k.dialer()
+	require.NoError(t, err)
+	require.NotNil(t, dialer.TLS)
+	require.Equal(t, plain.Mechanism{Username: "convoy", Password: "secret"}, dialer.SASLMechanism)
+
+	k.Cfg.SASL.Mechanism = datastore.KafkaSASLScramSHA512
+	dialer, err = k.dialer()
+	require.NoError(t, err)
+	require.Equal(t, "SCRAM-SHA-512", dialer.SASLMechanism.Name())
+
+	k.Cfg.SASL.Mechanism = "gssapi"
+	_, err = k.dialer()
+	require.EqualError(t, err, "sasl mechanism gssapi is not supported")
+
+	k.Cfg.SASL = nil
+	k.Cfg.CACert = "not a certificate"
+	_, err = k.dialer()
+	require.EqualError(t, err, "invalid kafka ca certificate")
+}
+
+func TestKafka_Verify_UnreachableBroker(t *testing.T) {
+	k := &Kafka{Cfg: &datastore.KafkaPubSubConfig{
+		Brokers:         []string{"127.0.0.1:1"},
+		Topic:           "events",
+		ConsumerGroupID: "convoy",
+	}}
+
+	require.ErrorIs(t, k.Verify(), ErrInvalidCredentials)
+}
@@ -0,0 +1,135 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/tidwall/gjson"
+)
+
+// Message is the event read from a pub sub message.
+type Message struct {
+	EndpointID     string            `json:"endpoint_id"`
+	OwnerID        string            `json:"owner_id"`
+	EventType      string            `json:"event_type"`
+	IdempotencyKey string            `json:"idempotency_key"`
+	Data           json.RawMessage   `json:"data"`
+	CustomHeaders  map[string]string `json:"custom_headers"`
+}
+
+// ParseMessage reads an event from msg. Without a mapping msg has to be
+// in the default format, a Message encoded as json.
+func ParseMessage(mapping *datastore.PubSubMessageMapping, msg string, attributes map[string]string) (*Message, error) {
+	if mapping == nil {
+		var m Message
+		if err := json.Unmarshal([]byte(msg), &m); err != nil {
+			return nil, fmt.Errorf("%w: %v", datastore.ErrInvalidPubSubMessage, err)
+		}
+
+		return &m, nil
+	}
+
+	if !gjson.Valid(msg) {
+		return nil, fmt.Errorf("%w: message body is not valid json", datastore.ErrInvalidPubSubMessage)
+	}
+
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
+
+	attrs, err := json.Marshal(attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope := `{"body":` + msg + `,"attributes":` + string(attrs) + `}`
+
+	get := func(path string) string {
+		if len(path) == 0 {
+			return ""
+		}
+
+		return gjson.Get(envelope, path).String()
+	}
+
+	m := &Message{
+		EndpointID:     get(mapping.EndpointID),
+		OwnerID:        get(mapping.OwnerID),
+		EventType:      get(mapping.EventType),
+		IdempotencyKey: get(mapping.IdempotencyKey),
+	}
+
+	dataPath := mapping.Data
+	if len(dataPath) == 0 {
+		dataPath = "body"
+	}
+
+	data := gjson.Get(envelope, dataPath)
+	if !data.Exists() {
+		return nil, fmt.Errorf("%w: no data found at %s", datastore.ErrInvalidPubSubMessage, dataPath)
+	}
+	m.Data = json.RawMessage(data.Raw)
+
+	for header, path := range mapping.Headers {
+		if v := get(path); len(v) > 0 {
+			if m.CustomHeaders == nil {
+				m.CustomHeaders = map[string]string{}
+			}
+			m.CustomHeaders[header] = v
+		}
+	}
+
+	return m, nil
+}
+
+// ValidateMapping ensures every path in mapping reads from the message's
+// body or attributes.
+func ValidateMapping(mapping *datastore.PubSubMessageMapping) error {
+	if mapping == nil {
+		return nil
+	}
+
+	paths := map[string]string{
+		"event_type":      mapping.EventType,
+		"endpoint_id":     mapping.EndpointID,
+		"owner_id":        mapping.OwnerID,
+		"idempotency_key": mapping.IdempotencyKey,
+		"data":            mapping.Data,
+	}
+
+	for header, path := range mapping.Headers {
+		if len(strings.TrimSpace(header)) == 0 {
+			return errors.New("mapping header names cannot be empty")
+		}
+
+		if len(path) == 0 {
+			return fmt.Errorf("mapping path for header %s is required", header)
+		}
+
+		paths["header "+header] = path
+	}
+
+	for field, path := range paths {
+		if len(path) == 0 {
+			continue
+		}
+
+		if !isMappingPath(path, "body") && !isMappingPath(path, "attributes") {
+			return fmt.Errorf("mapping path for %s must start with body or attributes", field)
+		}
+	}
+
+	return nil
+}
+
+func isMappingPath(path, root string) bool {
+	if !strings.HasPrefix(path, root) {
+		return false
+	}
+
+	rest := path[len(root):]
+	return len(rest) == 0 || rest[0] == '.' || rest[0] == '|'
+}
@@ -0,0 +1,114 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMessage(t *testing.T) {
+	tests := []struct {
+		name       string
+		mapping    *datastore.PubSubMessageMapping
+		msg        string
+		attributes map[string]string
+		want       *Message
+		wantErr    error
+	}{
+		{
+			name: "should_parse_default_format",
+			msg:  `{"endpoint_id":"ep-1","event_type":"invoice.paid","data":{"amount":2000},"custom_headers":{"X-Source":"billing"}}`,
+			want: &Message{
+				EndpointID:    "ep-1",
+				EventType:     "invoice.paid",
+				Data:          []byte(`{"amount":2000}`),
+				CustomHeaders: map[string]string{"X-Source": "billing"},
+			},
+		},
+		{
+			name:    "should_reject_malformed_default_format",
+			msg:     `not json`,
+			wantErr: datastore.ErrInvalidPubSubMessage,
+		},
+		{
+			name: "should_map_body_and_attributes",
+			mapping: &datastore.PubSubMessageMapping{
+				EventType:      "attributes.type",
+				OwnerID:        "body.customer.id",
+				IdempotencyKey: "body.id",
+				Data:           "body.data",
+				Headers:        map[string]string{"X-Region": "attributes.region", "X-Missing": "body.missing"},
+			},
+			msg:        `{"id":"evt_1","customer":{"id":"cus_1"},"data":{"amount":2000}}`,
+			attributes: map[string]string{"type": "invoice.paid", "region": "eu"},
+			want: &Message{
+				OwnerID:        "cus_1",
+				EventType:      "invoice.paid",
+				IdempotencyKey: "evt_1",
+				Data:           []byte(`{"amount":2000}`),
+				CustomHeaders:  map[string]string{"X-Region": "eu"},
+			},
+		},
+		{
+			name:    "should_use_whole_body_as_data",
+			mapping: &datastore.PubSubMessageMapping{EndpointID: "attributes.endpoint"},
+			msg:     `{"amount":2000}`,
+			attributes: map[string]string{
+				"endpoint": "ep-1",
+			},
+			want: &Message{
+				EndpointID: "ep-1",
+				Data:       []byte(`{"amount":2000}`),
+			},
+		},
+		{
+			name:    "should_reject_non_json_body",
+			mapping: &datastore.PubSubMessageMapping{},
+			msg:     `amount=2000`,
+			wantErr: datastore.ErrInvalidPubSubMessage,
+		},
+		{
+			name:    "should_reject_missing_data",
+			mapping: &datastore.PubSubMessageMapping{Data: "body.data"},
+			msg:     `{"amount":2000}`,
+			wantErr: datastore.ErrInvalidPubSubMessage,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := ParseMessage(tc.mapping, tc.msg, tc.attributes)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want.EndpointID, m.EndpointID)
+			require.Equal(t, tc.want.OwnerID, m.OwnerID)
+			require.Equal(t, tc.want.EventType, m.EventType)
+			require.Equal(t, tc.want.IdempotencyKey, m.IdempotencyKey)
+			require.JSONEq(t, string(tc.want.Data), string(m.Data))
+			require.Equal(t, tc.want.CustomHeaders, m.CustomHeaders)
+		})
+	}
+}
+
+func TestValidateMapping(t *testing.T) {
+	require.NoError(t, ValidateMapping(nil))
+	require.NoError(t, ValidateMapping(&datastore.PubSubMessageMapping{
+		EventType: "attributes.type",
+		Data:      "body",
+		Headers:   map[string]string{"X-Id": "body.id"},
+	}))
+
+	err := ValidateMapping(&datastore.PubSubMessageMapping{EventType: "type"})
+	require.EqualError(t, err, "mapping path for event_type must start with body or attributes")
+
+	err = ValidateMapping(&datastore.PubSubMessageMapping{OwnerID: "bodyless.id"})
+	require.EqualError(t, err, "mapping path for owner_id must start with body or attributes")
+
+	err = ValidateMapping(&datastore.PubSubMessageMapping{Headers: map[string]string{"X-Id": ""}})
+	require.EqualError(t, err, "mapping path for header X-Id is required")
+}
@@ -0,0 +1,244 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/nats-io/nats.go"
+)
+
+var ErrInvalidCredentials = errors.New("your nats credentials are invalid. please verify you're providing the correct credentials")
+
+const (
+	dialTimeout     = 10 * time.Second
+	defaultPrefetch = 10
+
+	// fetchWait is how long a fetch waits for messages before it is
+	// retried, it bounds how long Stop waits for a worker
+	fetchWait = 5 * time.Second
+
+	// retryDelay is how long the server waits before redelivering a
+	// message whose job couldn't be enqueued, and how long to wait
+	// before reconnecting to the server
+	retryDelay = time.Second
+)
+
+type Nats struct {
+	Cfg     *datastore.NatsPubSubConfig
+	source  *datastore.Source
+	workers int
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	handler datastore.PubSubHandler
+	log     log.StdLogger
+}
+
+func New(source *datastore.Source, handler datastore.PubSubHandler, log log.StdLogger) *Nats {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Nats{
+		Cfg:     source.PubSub.Nats,
+		source:  source,
+		workers: source.PubSub.Workers,
+		ctx:     ctx,
+		cancel:  cancel,
+		handler: handler,
+		log:     log,
+	}
+}
+
+// Start runs a pull subscriber per worker, they share the durable
+// consumer so each message goes to one of them.
+func (n *Nats) Start() {
+	for i := 1; i <= n.workers; i++ {
+		n.wg.Add(1)
+		go func() {
+			defer n.wg.Done()
+			n.Consume()
+		}()
+	}
+}
+
+func (n *Nats) Stop() {
+	n.cancel()
+	n.wg.Wait()
+}
+
+// Verify ensures the nats credentials are correct and the stream exists,
+// without a subject the consumer has to exist too.
+func (n *Nats) Verify() error {
+	nc, err := n.connect()
+	if err != nil {
+		log.WithError(err).Error("failed to connect to server - nats")
+		return ErrInvalidCredentials
+	}
+	defer nc.Close()
+
+	js, err := nc.JetStream()
+	if err != nil {
+		log.WithError(err).Error("failed to create jetstream context - nats")
+		return ErrInvalidCredentials
+	}
+
+	_, err = js.StreamInfo(n.Cfg.Stream)
+	if err != nil {
+		log.WithError(err).Error("failed to fetch stream info - nats")
+		return fmt.Errorf("stream with name %s does not exist", n.Cfg.Stream)
+	}
+
+	if len(n.Cfg.Subject) == 0 {
+		_, err = js.ConsumerInfo(n.Cfg.Stream, n.Cfg.Consumer)
+		if err != nil {
+			log.WithError(err).Error("failed to fetch consumer info - nats")
+			return fmt.Errorf("consumer with name %s does not exist", n.Cfg.Consumer)
+		}
+	}
+
+	return nil
+}
+
+// Consume fetches messages from the stream until the consumer is stopped,
+// reconnecting whenever the subscription fails.
+func (n *Nats) Consume() {
+	defer n.handleError()
+
+	for {
+		err := n.consume()
+		if n.ctx.Err() != nil {
+			return
+		}
+
+		n.log.WithError(err).Error("lost connection to server, reconnecting - nats")
+
+		select {
+		case <-n.ctx.Done():
+			return
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+func (n *Nats) consume() error {
+	nc, err := n.connect()
+	if err != nil {
+		return err
+	}
+	defer nc.Close()
+
+	js, err := nc.JetStream()
+	if err != nil {
+		return err
+	}
+
+	sub, err := n.subscribe(js)
+	if err != nil {
+		return err
+	}
+
+	for {
+		ctx, cancel := context.WithTimeout(n.ctx, fetchWait)
+		msgs, err := sub.Fetch(n.prefetch(), nats.Context(ctx))
+		cancel()
+
+		if n.ctx.Err() != nil {
+			return nil
+		}
+
+		if err != nil && !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, nats.ErrTimeout) {
+			return err
+		}
+
+		for _, msg := range msgs {
+			n.handle(msg)
+		}
+	}
+}
+
+// subscribe creates the durable consumer for the subject if it doesn't
+// exist yet, without a subject it binds to an existing consumer.
+func (n *Nats) subscribe(js nats.JetStreamContext) (*nats.Subscription, error) {
+	if len(n.Cfg.Subject) == 0 {
+		return js.PullSubscribe("", n.Cfg.Consumer, nats.Bind(n.Cfg.Stream, n.Cfg.Consumer))
+	}
+
+	return js.PullSubscribe(n.Cfg.Subject, n.Cfg.Consumer, nats.BindStream(n.Cfg.Stream), nats.AckExplicit())
+}
+
+// handle acks a message once its job is enqueued. Malformed messages are
+// terminated so they are never redelivered, other failures are redelivered
+// after a delay.
+func (n *Nats) handle(msg *nats.Msg) {
+	deliveries := 1
+	if meta, err := msg.Metadata(); err == nil {
+		deliveries = int(meta.NumDelivered)
+	}
+
+	err := n.handler(n.ctx, n.source, &datastore.PubSubMessage{Data: string(msg.Data), Attributes: headers(msg.Header), Deliveries: deliveries})
+	if err == nil {
+		if err := msg.Ack(); err != nil {
+			n.log.WithError(err).Error("failed to ack message - nats")
+		}
+		return
+	}
+
+	if errors.Is(err, datastore.ErrInvalidPubSubMessage) {
+		n.log.WithError(err).Error("terminating malformed message - nats")
+		if err := msg.Term(); err != nil {
+			n.log.WithError(err).Error("failed to terminate message - nats")
+		}
+		return
+	}
+
+	n.log.WithError(err).Error("failed to write message to create event queue - nats")
+	if err := msg.NakWithDelay(retryDelay); err != nil {
+		n.log.WithError(err).Error("failed to nak message - nats")
+	}
+}
+
+// headers returns a message's headers as attributes for the mapping,
+// only the first value of a header is kept.
+func headers(h nats.Header) map[string]string {
+	attributes := make(map[string]string, len(h))
+	for k := range h {
+		attributes[k] = h.Get(k)
+	}
+
+	return attributes
+}
+
+func (n *Nats) connect() (*nats.Conn, error) {
+	opts := []nats.Option{
+		nats.Name("convoy"),
+		nats.Timeout(dialTimeout),
+	}
+
+	if len(n.Cfg.Username) > 0 {
+		opts = append(opts, nats.UserInfo(n.Cfg.Username, n.Cfg.Password))
+	}
+
+	if len(n.Cfg.Token) > 0 {
+		opts = append(opts, nats.Token(n.Cfg.Token))
+	}
+
+	return nats.Connect(n.Cfg.URL, opts...)
+}
+
+func (n *Nats) prefetch() int {
+	if n.Cfg.Prefetch > 0 {
+		return n.Cfg.Prefetch
+	}
+
+	return defaultPrefetch
+}
+
+func (n *Nats) handleError() {
+	if err := recover(); err != nil {
+		n.log.WithError(fmt.Errorf("sourceID: %s, Errror: %s", n.source.UID, err)).Error("nats pubsub source crashed")
+	}
+}
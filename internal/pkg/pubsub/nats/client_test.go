@@ -0,0 +1,144 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+)
+
+// runServer starts an in-process jetstream server with an events stream.
+func runServer(t *testing.T) string {
+	s, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      -1,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	require.NoError(t, err)
+
+	go s.Start()
+	t.Cleanup(s.Shutdown)
+	require.True(t, s.ReadyForConnections(5*time.Second))
+
+	nc, err := nats.Connect(s.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(nc.Close)
+
+	js, err := nc.JetStream()
+	require.NoError(t, err)
+
+	_, err = js.AddStream(&nats.StreamConfig{Name: "EVENTS", Subjects: []string{"events.>"}})
+	require.NoError(t, err)
+
+	return s.ClientURL()
+}
+
+func publish(t *testing.T, url string, msgs ...string) {
+	nc, err := nats.Connect(url)
+	require.NoError(t, err)
+	defer nc.Close()
+
+	js, err := nc.JetStream()
+	require.NoError(t, err)
+
+	for _, msg := range msgs {
+		_, err = js.Publish("events.created", []byte(msg))
+		require.NoError(t, err)
+	}
+}
+
+func TestNats_Consume(t *testing.T) {
+	url := runServer(t)
+	publish(t, url, "valid", "malformed", "flaky")
+
+	source := &datastore.Source{
+		UID: "source-1",
+		PubSub: &datastore.PubSubConfig{
+			Type:    datastore.NatsPubSub,
+			Workers: 2,
+			Nats: &datastore.NatsPubSubConfig{
+				URL:      url,
+				Stream:   "EVENTS",
+				Consumer: "convoy",
+				Subject:  "events.>",
+			},
+		},
+	}
+
+	var mu sync.Mutex
+	attempts := map[string]int{}
+	done := make(chan struct{})
+
+	handler := func(_ context.Context, _ *datastore.Source, msg *datastore.PubSubMessage) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		attempts[msg.Data]++
+		if msg.Deliveries != attempts[msg.Data] {
+			return fmt.Errorf("expected delivery %d, got %d", attempts[msg.Data], msg.Deliveries)
+		}
+
+		switch {
+		case msg.Data == "malformed":
+			return datastore.ErrInvalidPubSubMessage
+		case msg.Data == "flaky" && msg.Deliveries == 1:
+			return errors.New("queue is unavailable")
+		case msg.Data == "flaky":
+			close(done)
+		}
+
+		return nil
+	}
+
+	n := New(source, handler, log.NewLogger(io.Discard))
+	require.NoError(t, n.Verify())
+
+	n.Start()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("flaky message was not redelivered")
+	}
+
+	n.Stop()
+
+	require.Equal(t, map[string]int{"valid": 1, "malformed": 1, "flaky": 2}, attempts)
+
+	nc, err := nats.Connect(url)
+	require.NoError(t, err)
+	defer nc.Close()
+
+	js, err := nc.JetStream()
+	require.NoError(t, err)
+
+	info, err := js.ConsumerInfo("EVENTS", "convoy")
+	require.NoError(t, err)
+	require.Zero(t, info.NumPending)
+	require.Zero(t, info.NumAckPending)
+}
+
+func TestNats_Verify(t *testing.T) {
+	url := runServer(t)
+
+	n := &Nats{Cfg: &datastore.NatsPubSubConfig{URL: url, Stream: "ORDERS", Consumer: "convoy", Subject: "orders.>"}}
+	require.EqualError(t, n.Verify(), "stream with name ORDERS does not exist")
+
+	n.Cfg.Stream, n.Cfg.Subject = "EVENTS", ""
+	require.EqualError(t, n.Verify(), "consumer with name convoy does not exist")
+
+	n.Cfg.URL = "nats://127.0.0.1:1"
+	require.ErrorIs(t, n.Verify(), ErrInvalidCredentials)
+}
@@ -3,10 +3,15 @@ package pubsub
 import (
 	"crypto/md5"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub/amqp"
 	"github.com/frain-dev/convoy/internal/pkg/pubsub/google"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub/kafka"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub/nats"
 	"github.com/frain-dev/convoy/internal/pkg/pubsub/sqs"
 	"github.com/frain-dev/convoy/pkg/log"
 )
@@ -62,6 +67,31 @@ func (p *PubSubSource) getHash() string {
 		hash = fmt.Sprintf("%s,%s,%s,%v", gq.ServiceAccount, gq.ProjectID, gq.SubscriptionID, source.PubSub.Workers)
 	}
 
+	if source.PubSub.Type == datastore.KafkaPubSub {
+		kq := source.PubSub.Kafka
+		hash = fmt.Sprintf("%s,%s,%s,%v,%s,%v,%v", strings.Join(kq.Brokers, ","), kq.Topic, kq.ConsumerGroupID, kq.TLS, kq.CACert, kq.SASL, source.PubSub.Workers)
+	}
+
+	if source.PubSub.Type == datastore.AmqpPubSub {
+		aq := source.PubSub.Amqp
+		hash = fmt.Sprintf("%s,%s,%v,%v", aq.URL, aq.Queue, aq.Prefetch, source.PubSub.Workers)
+	}
+
+	if source.PubSub.Type == datastore.NatsPubSub {
+		nq := source.PubSub.Nats
+		hash = fmt.Sprintf("%s,%s,%s,%s,%v,%s,%s,%s,%v", nq.URL, nq.Stream, nq.Consumer, nq.Subject, nq.Prefetch, nq.Username, nq.Password, nq.Token, source.PubSub.Workers)
+	}
+
+	if source.PubSub.Mapping != nil {
+		m, _ := json.Marshal(source.PubSub.Mapping)
+		hash = fmt.Sprintf("%s,%s", hash, m)
+	}
+
+	if source.PubSub.DeadLetter != nil {
+		d, _ := json.Marshal(source.PubSub.DeadLetter)
+		hash = fmt.Sprintf("%s,%s", hash, d)
+	}
+
 	h := md5.Sum([]byte(hash))
 	hash = hex.EncodeToString(h[:])
 
@@ -120,5 +150,17 @@ func NewPubSubClient(source *datastore.Source, handler datastore.PubSubHandler,
 		return google.New(source, handler, log), nil
 	}
 
+	if source.PubSub.Type == datastore.KafkaPubSub {
+		return kafka.New(source, handler, log), nil
+	}
+
+	if source.PubSub.Type == datastore.AmqpPubSub {
+		return amqp.New(source, handler, log), nil
+	}
+
+	if source.PubSub.Type == datastore.NatsPubSub {
+		return nats.New(source, handler, log), nil
+	}
+
 	return nil, fmt.Errorf("pub sub type %s is not supported", source.PubSub.Type)
 }
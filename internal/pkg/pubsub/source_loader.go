@@ -6,9 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"strconv"
 	"time"
 
 	"github.com/frain-dev/convoy/internal/pkg/apm"
+	"github.com/frain-dev/convoy/internal/pkg/metrics"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub/google"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub/sqs"
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/datastore"
@@ -26,6 +30,8 @@ const (
 
 type SourceLoader struct {
 	endpointRepo datastore.EndpointRepository
+	eventRepo    datastore.EventRepository
+	deadLetters  datastore.SourceDeadLetterRepository
 	sourceRepo   datastore.SourceRepository
 	projectRepo  datastore.ProjectRepository
 	queue        queue.Queuer
@@ -33,9 +39,11 @@ type SourceLoader struct {
 	log          log.StdLogger
 }
 
-func NewSourceLoader(endpointRepo datastore.EndpointRepository, sourceRepo datastore.SourceRepository, projectRepo datastore.ProjectRepository, queue queue.Queuer, sourcePool *SourcePool, log log.StdLogger) *SourceLoader {
+func NewSourceLoader(endpointRepo datastore.EndpointRepository, eventRepo datastore.EventRepository, deadLetters datastore.SourceDeadLetterRepository, sourceRepo datastore.SourceRepository, projectRepo datastore.ProjectRepository, queue queue.Queuer, sourcePool *SourcePool, log log.StdLogger) *SourceLoader {
 	return &SourceLoader{
 		endpointRepo: endpointRepo,
+		eventRepo:    eventRepo,
+		deadLetters:  deadLetters,
 		sourceRepo:   sourceRepo,
 		projectRepo:  projectRepo,
 		queue:        queue,
@@ -89,7 +97,7 @@ func (s *SourceLoader) fetchSources(ctx context.Context, projectID string, curso
 	}
 
 	for _, source := range sources {
-		ps, err := NewPubSubSource(&source, s.handler, s.log)
+		ps, err := NewPubSubSource(&source, s.handle, s.log)
 		if err != nil {
 			s.log.WithError(err).Error("failed to create pub sub source")
 		}
@@ -121,22 +129,95 @@ func (s *SourceLoader) fetchProjectSources(ctx context.Context) error {
 	return nil
 }
 
-func (s *SourceLoader) handler(ctx context.Context, source *datastore.Source, msg string) error {
+// handle turns msg into an event and counts the outcome. Malformed messages,
+// and messages that still fail once they have been redelivered the
+// configured number of times, are moved to the source's dead letter
+// destination and reported as handled.
+func (s *SourceLoader) handle(ctx context.Context, source *datastore.Source, msg *datastore.PubSubMessage) error {
+	m := metrics.PubSub()
+	m.Consumed.WithLabelValues(source.ProjectID, source.UID).Inc()
+
+	err := s.handler(ctx, source, msg)
+	if err == nil {
+		return nil
+	}
+
+	m.Failed.WithLabelValues(source.ProjectID, source.UID).Inc()
+
+	var cfg *datastore.PubSubDeadLetterConfig
+	if source.PubSub != nil {
+		cfg = source.PubSub.DeadLetter
+	}
+
+	if cfg == nil {
+		return err
+	}
+
+	if !errors.Is(err, datastore.ErrInvalidPubSubMessage) && msg.Deliveries <= cfg.MaxRedeliveries {
+		return err
+	}
+
+	if dlErr := s.deadLetter(ctx, source, cfg, msg, err); dlErr != nil {
+		s.log.WithError(dlErr).Errorf("failed to dead letter message from source %s", source.UID)
+		return err
+	}
+
+	m.DeadLettered.WithLabelValues(source.ProjectID, source.UID).Inc()
+	return nil
+}
+
+func (s *SourceLoader) deadLetter(ctx context.Context, source *datastore.Source, cfg *datastore.PubSubDeadLetterConfig, msg *datastore.PubSubMessage, reason error) error {
+	attrs := map[string]string{
+		"convoy_source_id":  source.UID,
+		"convoy_reason":     reason.Error(),
+		"convoy_deliveries": strconv.Itoa(msg.Deliveries),
+	}
+
+	switch cfg.Type {
+	case datastore.SqsDeadLetter:
+		return sqs.Publish(ctx, cfg.Sqs, msg, attrs, nil)
+	case datastore.GoogleDeadLetter:
+		return google.Publish(ctx, cfg.Google, msg, attrs)
+	case datastore.TableDeadLetter:
+		attributes := datastore.M{}
+		for k, v := range msg.Attributes {
+			attributes[k] = v
+		}
+
+		return s.deadLetters.CreateSourceDeadLetter(ctx, &datastore.SourceDeadLetter{
+			UID:        ulid.Make().String(),
+			ProjectID:  source.ProjectID,
+			SourceID:   source.UID,
+			Data:       msg.Data,
+			Attributes: attributes,
+			Reason:     reason.Error(),
+			Deliveries: msg.Deliveries,
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		})
+	}
+
+	return fmt.Errorf("dead letter type %s is not supported", cfg.Type)
+}
+
+func (s *SourceLoader) handler(ctx context.Context, source *datastore.Source, msg *datastore.PubSubMessage) error {
 	txn, innerCtx := apm.StartTransaction(ctx, fmt.Sprintf("%v handler", source.Name))
 	defer txn.End()
 
-	ev := struct {
-		EndpointID    string            `json:"endpoint_id"`
-		OwnerID       string            `json:"owner_id"`
-		EventType     string            `json:"event_type"`
-		Data          json.RawMessage   `json:"data"`
-		CustomHeaders map[string]string `json:"custom_headers"`
-	}{}
+	var mapping *datastore.PubSubMessageMapping
+	if source.PubSub != nil {
+		mapping = source.PubSub.Mapping
+	}
 
-	if err := json.Unmarshal([]byte(msg), &ev); err != nil {
+	ev, err := ParseMessage(mapping, msg.Data, msg.Attributes)
+	if err != nil {
 		return err
 	}
 
+	if util.IsStringEmpty(ev.OwnerID) && util.IsStringEmpty(ev.EndpointID) {
+		return fmt.Errorf("%w: message has neither an owner id nor an endpoint id", datastore.ErrInvalidPubSubMessage)
+	}
+
 	var endpoints []string
 
 	if !util.IsStringEmpty(ev.OwnerID) {
@@ -161,17 +242,29 @@ func (s *SourceLoader) handler(ctx context.Context, source *datastore.Source, ms
 		endpoints = append(endpoints, endpoint.UID)
 	}
 
+	var isDuplicate bool
+	if !util.IsStringEmpty(ev.IdempotencyKey) {
+		events, err := s.eventRepo.FindEventsByIdempotencyKey(innerCtx, source.ProjectID, ev.IdempotencyKey)
+		if err != nil {
+			return err
+		}
+
+		isDuplicate = len(events) > 0
+	}
+
 	event := datastore.Event{
-		UID:       ulid.Make().String(),
-		EventType: datastore.EventType(ev.EventType),
-		SourceID:  source.UID,
-		ProjectID: source.ProjectID,
-		Raw:       string(ev.Data),
-		Data:      ev.Data,
-		Headers:   getCustomHeaders(ev.CustomHeaders),
-		Endpoints: endpoints,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		UID:              ulid.Make().String(),
+		EventType:        datastore.EventType(ev.EventType),
+		SourceID:         source.UID,
+		ProjectID:        source.ProjectID,
+		Raw:              string(ev.Data),
+		Data:             ev.Data,
+		Headers:          getCustomHeaders(ev.CustomHeaders),
+		IdempotencyKey:   ev.IdempotencyKey,
+		IsDuplicateEvent: isDuplicate,
+		Endpoints:        endpoints,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
 	}
 
 	createEvent := task.CreateEvent{
@@ -2,25 +2,30 @@ package pubsub
 
 import (
 	"context"
+	"errors"
 	"io"
 	"testing"
 
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/metrics"
 	"github.com/frain-dev/convoy/mocks"
 	"github.com/frain-dev/convoy/pkg/log"
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 )
 
 func provideSourceLoader(ctrl *gomock.Controller) *SourceLoader {
 	endpointRepo := mocks.NewMockEndpointRepository(ctrl)
+	eventRepo := mocks.NewMockEventRepository(ctrl)
+	deadLetterRepo := mocks.NewMockSourceDeadLetterRepository(ctrl)
 	sourceRepo := mocks.NewMockSourceRepository(ctrl)
 	projectRepo := mocks.NewMockProjectRepository(ctrl)
 	queue := mocks.NewMockQueuer(ctrl)
 	sourcePool := provideSourcePool()
 	logger := log.NewLogger(io.Discard)
 
-	sourceLoader := NewSourceLoader(endpointRepo, sourceRepo, projectRepo, queue, sourcePool, logger)
+	sourceLoader := NewSourceLoader(endpointRepo, eventRepo, deadLetterRepo, sourceRepo, projectRepo, queue, sourcePool, logger)
 	return sourceLoader
 }
 
@@ -140,3 +145,117 @@ func TestSourceLoader_FetchSources(t *testing.T) {
 		})
 	}
 }
+
+func TestSourceLoader_Handler_MalformedMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sourceLoader := provideSourceLoader(ctrl)
+
+	err := sourceLoader.handler(context.Background(), &datastore.Source{UID: "12345"}, &datastore.PubSubMessage{Data: "not json", Deliveries: 1})
+	require.ErrorIs(t, err, datastore.ErrInvalidPubSubMessage)
+}
+
+func TestSourceLoader_Handle(t *testing.T) {
+	malformed := &datastore.PubSubMessage{Data: "not json", Attributes: map[string]string{"tenant": "acme"}, Deliveries: 1}
+	failing := func(deliveries int) *datastore.PubSubMessage {
+		return &datastore.PubSubMessage{Data: `{"endpoint_id":"ep-1"}`, Deliveries: deliveries}
+	}
+
+	tests := []struct {
+		name             string
+		deadLetter       *datastore.PubSubDeadLetterConfig
+		msg              *datastore.PubSubMessage
+		dbFn             func(sourceLoader *SourceLoader)
+		wantErr          bool
+		wantDeadLettered float64
+	}{
+		{
+			name:    "should_return_error_without_dead_letter_config",
+			msg:     malformed,
+			wantErr: true,
+		},
+		{
+			name:       "should_dead_letter_malformed_message",
+			deadLetter: &datastore.PubSubDeadLetterConfig{Type: datastore.TableDeadLetter, MaxRedeliveries: 5},
+			msg:        malformed,
+			dbFn: func(sourceLoader *SourceLoader) {
+				dl, _ := sourceLoader.deadLetters.(*mocks.MockSourceDeadLetterRepository)
+				dl.EXPECT().CreateSourceDeadLetter(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, deadLetter *datastore.SourceDeadLetter) error {
+						require.Equal(t, "source-1", deadLetter.SourceID)
+						require.Equal(t, "not json", deadLetter.Data)
+						require.Equal(t, datastore.M{"tenant": "acme"}, deadLetter.Attributes)
+						require.Equal(t, 1, deadLetter.Deliveries)
+						return nil
+					})
+			},
+			wantDeadLettered: 1,
+		},
+		{
+			name:       "should_retry_until_max_redeliveries",
+			deadLetter: &datastore.PubSubDeadLetterConfig{Type: datastore.TableDeadLetter, MaxRedeliveries: 2},
+			msg:        failing(2),
+			dbFn: func(sourceLoader *SourceLoader) {
+				e, _ := sourceLoader.endpointRepo.(*mocks.MockEndpointRepository)
+				e.EXPECT().FindEndpointByID(gomock.Any(), "ep-1", "project-1").Return(nil, datastore.ErrEndpointNotFound)
+			},
+			wantErr: true,
+		},
+		{
+			name:       "should_dead_letter_after_max_redeliveries",
+			deadLetter: &datastore.PubSubDeadLetterConfig{Type: datastore.TableDeadLetter, MaxRedeliveries: 2},
+			msg:        failing(3),
+			dbFn: func(sourceLoader *SourceLoader) {
+				e, _ := sourceLoader.endpointRepo.(*mocks.MockEndpointRepository)
+				e.EXPECT().FindEndpointByID(gomock.Any(), "ep-1", "project-1").Return(nil, datastore.ErrEndpointNotFound)
+
+				dl, _ := sourceLoader.deadLetters.(*mocks.MockSourceDeadLetterRepository)
+				dl.EXPECT().CreateSourceDeadLetter(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantDeadLettered: 1,
+		},
+		{
+			name:       "should_return_error_when_dead_lettering_fails",
+			deadLetter: &datastore.PubSubDeadLetterConfig{Type: datastore.TableDeadLetter},
+			msg:        malformed,
+			dbFn: func(sourceLoader *SourceLoader) {
+				dl, _ := sourceLoader.deadLetters.(*mocks.MockSourceDeadLetterRepository)
+				dl.EXPECT().CreateSourceDeadLetter(gomock.Any(), gomock.Any()).Return(errors.New("database is down"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			metrics.Reset()
+			sourceLoader := provideSourceLoader(ctrl)
+
+			if tc.dbFn != nil {
+				tc.dbFn(sourceLoader)
+			}
+
+			source := &datastore.Source{
+				UID:       "source-1",
+				ProjectID: "project-1",
+				PubSub:    &datastore.PubSubConfig{Type: datastore.SqsPubSub, DeadLetter: tc.deadLetter},
+			}
+
+			err := sourceLoader.handle(context.Background(), source, tc.msg)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			m := metrics.PubSub()
+			require.Equal(t, float64(1), testutil.ToFloat64(m.Consumed.WithLabelValues("project-1", "source-1")))
+			require.Equal(t, float64(1), testutil.ToFloat64(m.Failed.WithLabelValues("project-1", "source-1")))
+			require.Equal(t, tc.wantDeadLettered, testutil.ToFloat64(m.DeadLettered.WithLabelValues("project-1", "source-1")))
+		})
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
 	"sync"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -16,6 +19,8 @@ import (
 
 var ErrInvalidCredentials = errors.New("your sqs credentials are invalid. please verify you're providing the correct credentials")
 
+const maxMessageAttributes = 10
+
 type Sqs struct {
 	Cfg     *datastore.SQSPubSubConfig
 	source  *datastore.Source
@@ -105,9 +110,11 @@ func (s *Sqs) Consume() {
 		}
 
 		output, err := svc.ReceiveMessage(&sqs.ReceiveMessageInput{
-			QueueUrl:            queueURL,
-			MaxNumberOfMessages: aws.Int64(10),
-			WaitTimeSeconds:     aws.Int64(1),
+			QueueUrl:              queueURL,
+			MaxNumberOfMessages:   aws.Int64(10),
+			WaitTimeSeconds:       aws.Int64(1),
+			MessageAttributeNames: []*string{aws.String(sqs.QueueAttributeNameAll)},
+			AttributeNames:        []*string{aws.String(sqs.MessageSystemAttributeNameApproximateReceiveCount)},
 		})
 
 		if err != nil {
@@ -122,7 +129,15 @@ func (s *Sqs) Consume() {
 
 				defer s.handleError()
 
-				if err := s.handler(context.Background(), s.source, *m.Body); err != nil {
+				msg := &datastore.PubSubMessage{Data: *m.Body, Attributes: attributes(m.MessageAttributes), Deliveries: receiveCount(m)}
+
+				err := s.handler(context.Background(), s.source, msg)
+				if errors.Is(err, datastore.ErrInvalidPubSubMessage) {
+					s.log.WithError(err).Error("dropping malformed message - sqs")
+					err = nil
+				}
+
+				if err != nil {
 					s.log.WithError(err).Error("failed to write message to create event queue")
 				} else {
 					_, err = svc.DeleteMessage(&sqs.DeleteMessageInput{
@@ -142,6 +157,34 @@ func (s *Sqs) Consume() {
 	}
 }
 
+// receiveCount returns how many times a message has been received.
+func receiveCount(m *sqs.Message) int {
+	v, ok := m.Attributes[sqs.MessageSystemAttributeNameApproximateReceiveCount]
+	if !ok || v == nil {
+		return 1
+	}
+
+	count, err := strconv.Atoi(*v)
+	if err != nil {
+		return 1
+	}
+
+	return count
+}
+
+// attributes returns a message's string and number attributes for the
+// mapping, binary attributes are skipped.
+func attributes(attrs map[string]*sqs.MessageAttributeValue) map[string]string {
+	a := make(map[string]string, len(attrs))
+	for k, v := range attrs {
+		if v != nil && v.StringValue != nil {
+			a[k] = *v.StringValue
+		}
+	}
+
+	return a
+}
+
 func (s *Sqs) Stop() {
 	close(s.done)
 }
@@ -151,3 +194,106 @@ func (s *Sqs) handleError() {
 		s.log.WithError(fmt.Errorf("sourceID: %s, Errror: %s", s.source.UID, err)).Error("sqs pubsub source crashed")
 	}
 }
+
+// Publish sends msg to the queue in cfg, it is used to dead letter
+// messages. Requests are sent with client, the default client is used
+// when it is nil.
+func Publish(ctx context.Context, cfg *datastore.SQSPubSubConfig, msg *datastore.PubSubMessage, attrs map[string]string, client *http.Client) error {
+	p, err := NewPublisher(cfg, client)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+
+	return p.Publish(ctx, msg, attrs)
+}
+
+// Publisher sends messages to a queue, its session and the queue's url
+// are reused between messages.
+type Publisher struct {
+	cfg *datastore.SQSPubSubConfig
+	svc *sqs.SQS
+
+	mu       sync.Mutex
+	queueURL *string
+}
+
+// NewPublisher returns a publisher for the queue in cfg. Requests are sent
+// with client, the default client is used when it is nil.
+func NewPublisher(cfg *datastore.SQSPubSubConfig, client *http.Client) (*Publisher, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String(cfg.DefaultRegion),
+		Credentials: credentials.NewStaticCredentials(cfg.AccessKeyID, cfg.SecretKey, ""),
+		HTTPClient:  client,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Publisher{cfg: cfg, svc: sqs.New(sess)}, nil
+}
+
+// url looks up the queue's url the first time a message is sent.
+func (p *Publisher) url(ctx context.Context) (*string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.queueURL != nil {
+		return p.queueURL, nil
+	}
+
+	out, err := p.svc.GetQueueUrlWithContext(ctx, &sqs.GetQueueUrlInput{QueueName: &p.cfg.QueueName})
+	if err != nil {
+		return nil, err
+	}
+
+	p.queueURL = out.QueueUrl
+	return p.queueURL, nil
+}
+
+// Close is a no-op, requests don't hold connections beyond the client's
+// idle connections.
+func (p *Publisher) Close() error {
+	return nil
+}
+
+// Publish sends msg to the queue. Message attributes are copied as
+// string attributes, sqs allows at most 10 per message so extra
+// attributes are dropped.
+func (p *Publisher) Publish(ctx context.Context, msg *datastore.PubSubMessage, attrs map[string]string) error {
+	url, err := p.url(ctx)
+	if err != nil {
+		return err
+	}
+
+	messageAttributes := map[string]*sqs.MessageAttributeValue{}
+	add := func(values map[string]string) {
+		keys := make([]string, 0, len(values))
+		for k := range values {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if len(messageAttributes) == maxMessageAttributes || len(values[k]) == 0 {
+				continue
+			}
+
+			messageAttributes[k] = &sqs.MessageAttributeValue{
+				DataType:    aws.String("String"),
+				StringValue: aws.String(values[k]),
+			}
+		}
+	}
+
+	add(attrs)
+	add(msg.Attributes)
+
+	_, err = p.svc.SendMessageWithContext(ctx, &sqs.SendMessageInput{
+		QueueUrl:          url,
+		MessageBody:       aws.String(msg.Data),
+		MessageAttributes: messageAttributes,
+	})
+
+	return err
+}
@@ -4,7 +4,10 @@ import (
 	"errors"
 
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub/amqp"
 	"github.com/frain-dev/convoy/internal/pkg/pubsub/google"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub/kafka"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub/nats"
 	"github.com/frain-dev/convoy/internal/pkg/pubsub/sqs"
 	"github.com/frain-dev/convoy/util"
 )
@@ -22,6 +25,24 @@ type SqsPubSub struct {
 	QueueName     string `json:"queue_name" valid:"required"`
 }
 
+type KafkaPubSub struct {
+	Topic           string `json:"topic" valid:"required~topic is required"`
+	ConsumerGroupID string `json:"consumer_group_id" valid:"required~consumer group id is required"`
+}
+
+type AmqpPubSub struct {
+	URL      string `json:"url" valid:"required~url is required"`
+	Queue    string `json:"queue" valid:"required~queue is required"`
+	Prefetch int    `json:"prefetch" valid:"range(0|65535)~prefetch must be between 0 and 65535"`
+}
+
+type NatsPubSub struct {
+	URL      string `json:"url" valid:"required~url is required"`
+	Stream   string `json:"stream" valid:"required~stream is required"`
+	Consumer string `json:"consumer" valid:"required~consumer is required"`
+	Prefetch int    `json:"prefetch" valid:"range(0|1000)~prefetch must be between 0 and 1000"`
+}
+
 type PS struct {
 	Type    datastore.PubSubType `json:"type" valid:"required~type is required,supported_pub_sub~unsupported pub sub type"`
 	Workers int                  `json:"workers" valid:"required"`
@@ -42,6 +63,16 @@ func Validate(cfg *datastore.PubSubConfig) error {
 		return err
 	}
 
+	err = ValidateMapping(cfg.Mapping)
+	if err != nil {
+		return err
+	}
+
+	err = validateDeadLetter(cfg.DeadLetter)
+	if err != nil {
+		return err
+	}
+
 	switch cfg.Type {
 	case datastore.GooglePubSub:
 		if cfg.Google == nil {
@@ -88,7 +119,131 @@ func Validate(cfg *datastore.PubSubConfig) error {
 
 		return nil
 
+	case datastore.KafkaPubSub:
+		if cfg.Kafka == nil {
+			return errors.New("kafka config is required")
+		}
+
+		if len(cfg.Kafka.Brokers) == 0 {
+			return errors.New("at least one broker is required")
+		}
+
+		kPubSub := &KafkaPubSub{
+			Topic:           cfg.Kafka.Topic,
+			ConsumerGroupID: cfg.Kafka.ConsumerGroupID,
+		}
+
+		if err := util.Validate(kPubSub); err != nil {
+			return err
+		}
+
+		k := &kafka.Kafka{Cfg: cfg.Kafka}
+		if err := k.Verify(); err != nil {
+			return err
+		}
+
+		return nil
+
+	case datastore.AmqpPubSub:
+		if cfg.Amqp == nil {
+			return errors.New("amqp config is required")
+		}
+
+		aPubSub := &AmqpPubSub{
+			URL:      cfg.Amqp.URL,
+			Queue:    cfg.Amqp.Queue,
+			Prefetch: cfg.Amqp.Prefetch,
+		}
+
+		if err := util.Validate(aPubSub); err != nil {
+			return err
+		}
+
+		a := &amqp.Amqp{Cfg: cfg.Amqp}
+		if err := a.Verify(); err != nil {
+			return err
+		}
+
+		return nil
+
+	case datastore.NatsPubSub:
+		if cfg.Nats == nil {
+			return errors.New("nats config is required")
+		}
+
+		nPubSub := &NatsPubSub{
+			URL:      cfg.Nats.URL,
+			Stream:   cfg.Nats.Stream,
+			Consumer: cfg.Nats.Consumer,
+			Prefetch: cfg.Nats.Prefetch,
+		}
+
+		if err := util.Validate(nPubSub); err != nil {
+			return err
+		}
+
+		n := &nats.Nats{Cfg: cfg.Nats}
+		if err := n.Verify(); err != nil {
+			return err
+		}
+
+		return nil
+
 	default:
 		return nil
 	}
 }
+
+type DeadLetter struct {
+	Type            datastore.PubSubDeadLetterType `json:"type" valid:"required~dead letter type is required,in(sqs|google|table)~unsupported dead letter type"`
+	MaxRedeliveries int                            `json:"max_redeliveries" valid:"range(0|1000)~max redeliveries must be between 0 and 1000"`
+}
+
+type GooglePubSubTopic struct {
+	ServiceAccount []byte `json:"service_account" valid:"required~service account is required"`
+	TopicID        string `json:"topic_id" valid:"required~topic id is required"`
+	ProjectID      string `json:"project_id" valid:"required~project id is required"`
+}
+
+func validateDeadLetter(cfg *datastore.PubSubDeadLetterConfig) error {
+	if cfg == nil {
+		return nil
+	}
+
+	err := util.Validate(&DeadLetter{Type: cfg.Type, MaxRedeliveries: cfg.MaxRedeliveries})
+	if err != nil {
+		return err
+	}
+
+	switch cfg.Type {
+	case datastore.SqsDeadLetter:
+		if cfg.Sqs == nil {
+			return errors.New("dead letter sqs config is required")
+		}
+
+		err = util.Validate(&SqsPubSub{
+			AccessKeyID:   cfg.Sqs.AccessKeyID,
+			SecretKey:     cfg.Sqs.SecretKey,
+			DefaultRegion: cfg.Sqs.DefaultRegion,
+			QueueName:     cfg.Sqs.QueueName,
+		})
+		if err != nil {
+			return err
+		}
+
+		return (&sqs.Sqs{Cfg: cfg.Sqs}).Verify()
+
+	case datastore.GoogleDeadLetter:
+		if cfg.Google == nil {
+			return errors.New("dead letter google pub sub config is required")
+		}
+
+		return util.Validate(&GooglePubSubTopic{
+			ServiceAccount: cfg.Google.ServiceAccount,
+			TopicID:        cfg.Google.TopicID,
+			ProjectID:      cfg.Google.ProjectID,
+		})
+	}
+
+	return nil
+}
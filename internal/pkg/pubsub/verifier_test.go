@@ -0,0 +1,28 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDeadLetter(t *testing.T) {
+	require.NoError(t, validateDeadLetter(nil))
+	require.NoError(t, validateDeadLetter(&datastore.PubSubDeadLetterConfig{Type: datastore.TableDeadLetter, MaxRedeliveries: 5}))
+
+	err := validateDeadLetter(&datastore.PubSubDeadLetterConfig{Type: "kafka"})
+	require.EqualError(t, err, "type:unsupported dead letter type")
+
+	err = validateDeadLetter(&datastore.PubSubDeadLetterConfig{Type: datastore.TableDeadLetter, MaxRedeliveries: 5000})
+	require.EqualError(t, err, "max_redeliveries:max redeliveries must be between 0 and 1000")
+
+	err = validateDeadLetter(&datastore.PubSubDeadLetterConfig{Type: datastore.SqsDeadLetter})
+	require.EqualError(t, err, "dead letter sqs config is required")
+
+	err = validateDeadLetter(&datastore.PubSubDeadLetterConfig{
+		Type:   datastore.GoogleDeadLetter,
+		Google: &datastore.GooglePubSubTopicConfig{ProjectID: "convoy", ServiceAccount: []byte("{}")},
+	})
+	require.EqualError(t, err, "topic_id:topic id is required")
+}
@@ -6,7 +6,7 @@ package mocks
 
 import (
 	context "context"
-	json "encoding/json"
+	io "io"
 	reflect "reflect"
 	time "time"
 
@@ -208,6 +208,21 @@ func (mr *MockEventDeliveryRepositoryMockRecorder) CountEventDeliveries(ctx, pro
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEventDeliveries", reflect.TypeOf((*MockEventDeliveryRepository)(nil).CountEventDeliveries), ctx, projectID, endpointIDs, eventID, status, params)
 }
 
+// CountPendingBatchEventDeliveries mocks base method.
+func (m *MockEventDeliveryRepository) CountPendingBatchEventDeliveries(ctx context.Context, projectID, subscriptionID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPendingBatchEventDeliveries", ctx, projectID, subscriptionID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPendingBatchEventDeliveries indicates an expected call of CountPendingBatchEventDeliveries.
+func (mr *MockEventDeliveryRepositoryMockRecorder) CountPendingBatchEventDeliveries(ctx, projectID, subscriptionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPendingBatchEventDeliveries", reflect.TypeOf((*MockEventDeliveryRepository)(nil).CountPendingBatchEventDeliveries), ctx, projectID, subscriptionID)
+}
+
 // CreateEventDelivery mocks base method.
 func (m *MockEventDeliveryRepository) CreateEventDelivery(arg0 context.Context, arg1 *datastore.EventDelivery) error {
 	m.ctrl.T.Helper()
@@ -222,6 +237,21 @@ func (mr *MockEventDeliveryRepositoryMockRecorder) CreateEventDelivery(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEventDelivery", reflect.TypeOf((*MockEventDeliveryRepository)(nil).CreateEventDelivery), arg0, arg1)
 }
 
+// CreateEventDeliveryBatch mocks base method.
+func (m *MockEventDeliveryRepository) CreateEventDeliveryBatch(ctx context.Context, projectID, subscriptionID, batchID string, config datastore.BatchConfiguration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEventDeliveryBatch", ctx, projectID, subscriptionID, batchID, config)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEventDeliveryBatch indicates an expected call of CreateEventDeliveryBatch.
+func (mr *MockEventDeliveryRepositoryMockRecorder) CreateEventDeliveryBatch(ctx, projectID, subscriptionID, batchID, config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEventDeliveryBatch", reflect.TypeOf((*MockEventDeliveryRepository)(nil).CreateEventDeliveryBatch), ctx, projectID, subscriptionID, batchID, config)
+}
+
 // DeleteProjectEventDeliveries mocks base method.
 func (m *MockEventDeliveryRepository) DeleteProjectEventDeliveries(ctx context.Context, projectID string, filter *datastore.EventDeliveryFilter, hardDelete bool) error {
 	m.ctrl.T.Helper()
@@ -236,6 +266,35 @@ func (mr *MockEventDeliveryRepositoryMockRecorder) DeleteProjectEventDeliveries(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProjectEventDeliveries", reflect.TypeOf((*MockEventDeliveryRepository)(nil).DeleteProjectEventDeliveries), ctx, projectID, filter, hardDelete)
 }
 
+// DiscardEventDeliveries mocks base method.
+func (m *MockEventDeliveryRepository) DiscardEventDeliveries(ctx context.Context, projectID string, ids []string, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardEventDeliveries", ctx, projectID, ids, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DiscardEventDeliveries indicates an expected call of DiscardEventDeliveries.
+func (mr *MockEventDeliveryRepositoryMockRecorder) DiscardEventDeliveries(ctx, projectID, ids, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardEventDeliveries", reflect.TypeOf((*MockEventDeliveryRepository)(nil).DiscardEventDeliveries), ctx, projectID, ids, reason)
+}
+
+// DiscardScheduledEventDeliveries mocks base method.
+func (m *MockEventDeliveryRepository) DiscardScheduledEventDeliveries(ctx context.Context, projectID, eventID, reason string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardScheduledEventDeliveries", ctx, projectID, eventID, reason)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscardScheduledEventDeliveries indicates an expected call of DiscardScheduledEventDeliveries.
+func (mr *MockEventDeliveryRepositoryMockRecorder) DiscardScheduledEventDeliveries(ctx, projectID, eventID, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardScheduledEventDeliveries", reflect.TypeOf((*MockEventDeliveryRepository)(nil).DiscardScheduledEventDeliveries), ctx, projectID, eventID, reason)
+}
+
 // FindDiscardedEventDeliveries mocks base method.
 func (m *MockEventDeliveryRepository) FindDiscardedEventDeliveries(ctx context.Context, projectID, deviceId string, params datastore.SearchParams) ([]datastore.EventDelivery, error) {
 	m.ctrl.T.Helper()
@@ -251,6 +310,21 @@ func (mr *MockEventDeliveryRepositoryMockRecorder) FindDiscardedEventDeliveries(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDiscardedEventDeliveries", reflect.TypeOf((*MockEventDeliveryRepository)(nil).FindDiscardedEventDeliveries), ctx, projectID, deviceId, params)
 }
 
+// FindEventDeliveriesByBatchID mocks base method.
+func (m *MockEventDeliveryRepository) FindEventDeliveriesByBatchID(ctx context.Context, projectID, batchID string) ([]datastore.EventDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEventDeliveriesByBatchID", ctx, projectID, batchID)
+	ret0, _ := ret[0].([]datastore.EventDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEventDeliveriesByBatchID indicates an expected call of FindEventDeliveriesByBatchID.
+func (mr *MockEventDeliveryRepositoryMockRecorder) FindEventDeliveriesByBatchID(ctx, projectID, batchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventDeliveriesByBatchID", reflect.TypeOf((*MockEventDeliveryRepository)(nil).FindEventDeliveriesByBatchID), ctx, projectID, batchID)
+}
+
 // FindEventDeliveriesByEventID mocks base method.
 func (m *MockEventDeliveryRepository) FindEventDeliveriesByEventID(ctx context.Context, projectID, id string) ([]datastore.EventDelivery, error) {
 	m.ctrl.T.Helper()
@@ -296,6 +370,21 @@ func (mr *MockEventDeliveryRepositoryMockRecorder) FindEventDeliveryByID(ctx, pr
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventDeliveryByID", reflect.TypeOf((*MockEventDeliveryRepository)(nil).FindEventDeliveryByID), ctx, projectID, id)
 }
 
+// FindPendingEventDeliveryBefore mocks base method.
+func (m *MockEventDeliveryRepository) FindPendingEventDeliveryBefore(ctx context.Context, projectID string, eventDelivery *datastore.EventDelivery) (*datastore.EventDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPendingEventDeliveryBefore", ctx, projectID, eventDelivery)
+	ret0, _ := ret[0].(*datastore.EventDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPendingEventDeliveryBefore indicates an expected call of FindPendingEventDeliveryBefore.
+func (mr *MockEventDeliveryRepositoryMockRecorder) FindPendingEventDeliveryBefore(ctx, projectID, eventDelivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPendingEventDeliveryBefore", reflect.TypeOf((*MockEventDeliveryRepository)(nil).FindPendingEventDeliveryBefore), ctx, projectID, eventDelivery)
+}
+
 // LoadEventDeliveriesIntervals mocks base method.
 func (m *MockEventDeliveryRepository) LoadEventDeliveriesIntervals(ctx context.Context, projectID string, params datastore.SearchParams, period datastore.Period) ([]datastore.EventInterval, error) {
 	m.ctrl.T.Helper()
@@ -327,6 +416,20 @@ func (mr *MockEventDeliveryRepositoryMockRecorder) LoadEventDeliveriesPaged(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEventDeliveriesPaged", reflect.TypeOf((*MockEventDeliveryRepository)(nil).LoadEventDeliveriesPaged), ctx, projectID, endpointIDs, eventID, status, params, pageable, idempotencyKey)
 }
 
+// UpdateEventDeliveryMetadata mocks base method.
+func (m *MockEventDeliveryRepository) UpdateEventDeliveryMetadata(ctx context.Context, projectID string, eventDelivery *datastore.EventDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEventDeliveryMetadata", ctx, projectID, eventDelivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEventDeliveryMetadata indicates an expected call of UpdateEventDeliveryMetadata.
+func (mr *MockEventDeliveryRepositoryMockRecorder) UpdateEventDeliveryMetadata(ctx, projectID, eventDelivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventDeliveryMetadata", reflect.TypeOf((*MockEventDeliveryRepository)(nil).UpdateEventDeliveryMetadata), ctx, projectID, eventDelivery)
+}
+
 // UpdateEventDeliveryWithAttempt mocks base method.
 func (m *MockEventDeliveryRepository) UpdateEventDeliveryWithAttempt(ctx context.Context, projectID string, eventDelivery datastore.EventDelivery, attempt datastore.DeliveryAttempt) error {
 	m.ctrl.T.Helper()
@@ -436,6 +539,20 @@ func (mr *MockEventRepositoryMockRecorder) CreateEvent(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockEventRepository)(nil).CreateEvent), arg0, arg1)
 }
 
+// CreateEvents mocks base method.
+func (m *MockEventRepository) CreateEvents(arg0 context.Context, arg1 []*datastore.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEvents", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEvents indicates an expected call of CreateEvents.
+func (mr *MockEventRepositoryMockRecorder) CreateEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvents", reflect.TypeOf((*MockEventRepository)(nil).CreateEvents), arg0, arg1)
+}
+
 // DeleteProjectEvents mocks base method.
 func (m *MockEventRepository) DeleteProjectEvents(ctx context.Context, projectID string, f *datastore.EventFilter, hardDelete bool) error {
 	m.ctrl.T.Helper()
@@ -526,6 +643,22 @@ func (mr *MockEventRepositoryMockRecorder) LoadEventsPaged(ctx, projectID, f int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEventsPaged", reflect.TypeOf((*MockEventRepository)(nil).LoadEventsPaged), ctx, projectID, f)
 }
 
+// LoadScheduledEventsPaged mocks base method.
+func (m *MockEventRepository) LoadScheduledEventsPaged(ctx context.Context, projectID string, pageable datastore.Pageable) ([]datastore.Event, datastore.PaginationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadScheduledEventsPaged", ctx, projectID, pageable)
+	ret0, _ := ret[0].([]datastore.Event)
+	ret1, _ := ret[1].(datastore.PaginationData)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LoadScheduledEventsPaged indicates an expected call of LoadScheduledEventsPaged.
+func (mr *MockEventRepositoryMockRecorder) LoadScheduledEventsPaged(ctx, projectID, pageable interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadScheduledEventsPaged", reflect.TypeOf((*MockEventRepository)(nil).LoadScheduledEventsPaged), ctx, projectID, pageable)
+}
+
 // MockProjectRepository is a mock of ProjectRepository interface.
 type MockProjectRepository struct {
 	ctrl     *gomock.Controller
@@ -2007,19 +2140,18 @@ func (m *MockExportRepository) EXPECT() *MockExportRepositoryMockRecorder {
 }
 
 // ExportRecords mocks base method.
-func (m *MockExportRepository) ExportRecords(ctx context.Context, tableName, projectID string, createdAt time.Time) (json.RawMessage, int64, error) {
+func (m *MockExportRepository) ExportRecords(ctx context.Context, tableName, projectID string, createdAt time.Time, w io.Writer) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ExportRecords", ctx, tableName, projectID, createdAt)
-	ret0, _ := ret[0].(json.RawMessage)
-	ret1, _ := ret[1].(int64)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "ExportRecords", ctx, tableName, projectID, createdAt, w)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // ExportRecords indicates an expected call of ExportRecords.
-func (mr *MockExportRepositoryMockRecorder) ExportRecords(ctx, tableName, projectID, createdAt interface{}) *gomock.Call {
+func (mr *MockExportRepositoryMockRecorder) ExportRecords(ctx, tableName, projectID, createdAt, w interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportRecords", reflect.TypeOf((*MockExportRepository)(nil).ExportRecords), ctx, tableName, projectID, createdAt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportRecords", reflect.TypeOf((*MockExportRepository)(nil).ExportRecords), ctx, tableName, projectID, createdAt, w)
 }
 
 // MockMetaEventRepository is a mock of MetaEventRepository interface.
@@ -2103,3 +2235,541 @@ func (mr *MockMetaEventRepositoryMockRecorder) UpdateMetaEvent(ctx, projectID, m
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetaEvent", reflect.TypeOf((*MockMetaEventRepository)(nil).UpdateMetaEvent), ctx, projectID, metaEvent)
 }
+
+// MockDeadLetterRepository is a mock of DeadLetterRepository interface.
+type MockDeadLetterRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeadLetterRepositoryMockRecorder
+}
+
+// MockDeadLetterRepositoryMockRecorder is the mock recorder for MockDeadLetterRepository.
+type MockDeadLetterRepositoryMockRecorder struct {
+	mock *MockDeadLetterRepository
+}
+
+// NewMockDeadLetterRepository creates a new mock instance.
+func NewMockDeadLetterRepository(ctrl *gomock.Controller) *MockDeadLetterRepository {
+	mock := &MockDeadLetterRepository{ctrl: ctrl}
+	mock.recorder = &MockDeadLetterRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeadLetterRepository) EXPECT() *MockDeadLetterRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateDeadLetter mocks base method.
+func (m *MockDeadLetterRepository) CreateDeadLetter(arg0 context.Context, arg1 *datastore.DeadLetter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeadLetter", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDeadLetter indicates an expected call of CreateDeadLetter.
+func (mr *MockDeadLetterRepositoryMockRecorder) CreateDeadLetter(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeadLetter", reflect.TypeOf((*MockDeadLetterRepository)(nil).CreateDeadLetter), arg0, arg1)
+}
+
+// DeleteDeadLetter mocks base method.
+func (m *MockDeadLetterRepository) DeleteDeadLetter(ctx context.Context, projectID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDeadLetter", ctx, projectID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDeadLetter indicates an expected call of DeleteDeadLetter.
+func (mr *MockDeadLetterRepositoryMockRecorder) DeleteDeadLetter(ctx, projectID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeadLetter", reflect.TypeOf((*MockDeadLetterRepository)(nil).DeleteDeadLetter), ctx, projectID, id)
+}
+
+// DeleteDeadLetters mocks base method.
+func (m *MockDeadLetterRepository) DeleteDeadLetters(ctx context.Context, projectID string, f *datastore.DeadLetterFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDeadLetters", ctx, projectID, f)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDeadLetters indicates an expected call of DeleteDeadLetters.
+func (mr *MockDeadLetterRepositoryMockRecorder) DeleteDeadLetters(ctx, projectID, f interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeadLetters", reflect.TypeOf((*MockDeadLetterRepository)(nil).DeleteDeadLetters), ctx, projectID, f)
+}
+
+// FindDeadLetterByID mocks base method.
+func (m *MockDeadLetterRepository) FindDeadLetterByID(ctx context.Context, projectID, id string) (*datastore.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeadLetterByID", ctx, projectID, id)
+	ret0, _ := ret[0].(*datastore.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeadLetterByID indicates an expected call of FindDeadLetterByID.
+func (mr *MockDeadLetterRepositoryMockRecorder) FindDeadLetterByID(ctx, projectID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeadLetterByID", reflect.TypeOf((*MockDeadLetterRepository)(nil).FindDeadLetterByID), ctx, projectID, id)
+}
+
+// LoadDeadLettersPaged mocks base method.
+func (m *MockDeadLetterRepository) LoadDeadLettersPaged(ctx context.Context, projectID string, f *datastore.DeadLetterFilter) ([]datastore.DeadLetter, datastore.PaginationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadDeadLettersPaged", ctx, projectID, f)
+	ret0, _ := ret[0].([]datastore.DeadLetter)
+	ret1, _ := ret[1].(datastore.PaginationData)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LoadDeadLettersPaged indicates an expected call of LoadDeadLettersPaged.
+func (mr *MockDeadLetterRepositoryMockRecorder) LoadDeadLettersPaged(ctx, projectID, f interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadDeadLettersPaged", reflect.TypeOf((*MockDeadLetterRepository)(nil).LoadDeadLettersPaged), ctx, projectID, f)
+}
+
+// MockSourceDeadLetterRepository is a mock of SourceDeadLetterRepository interface.
+type MockSourceDeadLetterRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSourceDeadLetterRepositoryMockRecorder
+}
+
+// MockSourceDeadLetterRepositoryMockRecorder is the mock recorder for MockSourceDeadLetterRepository.
+type MockSourceDeadLetterRepositoryMockRecorder struct {
+	mock *MockSourceDeadLetterRepository
+}
+
+// NewMockSourceDeadLetterRepository creates a new mock instance.
+func NewMockSourceDeadLetterRepository(ctrl *gomock.Controller) *MockSourceDeadLetterRepository {
+	mock := &MockSourceDeadLetterRepository{ctrl: ctrl}
+	mock.recorder = &MockSourceDeadLetterRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSourceDeadLetterRepository) EXPECT() *MockSourceDeadLetterRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateSourceDeadLetter mocks base method.
+func (m *MockSourceDeadLetterRepository) CreateSourceDeadLetter(arg0 context.Context, arg1 *datastore.SourceDeadLetter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSourceDeadLetter", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSourceDeadLetter indicates an expected call of CreateSourceDeadLetter.
+func (mr *MockSourceDeadLetterRepositoryMockRecorder) CreateSourceDeadLetter(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSourceDeadLetter", reflect.TypeOf((*MockSourceDeadLetterRepository)(nil).CreateSourceDeadLetter), arg0, arg1)
+}
+
+// LoadSourceDeadLettersPaged mocks base method.
+func (m *MockSourceDeadLetterRepository) LoadSourceDeadLettersPaged(ctx context.Context, projectID, sourceID string, pageable datastore.Pageable) ([]datastore.SourceDeadLetter, datastore.PaginationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadSourceDeadLettersPaged", ctx, projectID, sourceID, pageable)
+	ret0, _ := ret[0].([]datastore.SourceDeadLetter)
+	ret1, _ := ret[1].(datastore.PaginationData)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LoadSourceDeadLettersPaged indicates an expected call of LoadSourceDeadLettersPaged.
+func (mr *MockSourceDeadLetterRepositoryMockRecorder) LoadSourceDeadLettersPaged(ctx, projectID, sourceID, pageable interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadSourceDeadLettersPaged", reflect.TypeOf((*MockSourceDeadLetterRepository)(nil).LoadSourceDeadLettersPaged), ctx, projectID, sourceID, pageable)
+}
+
+// MockEventTypeRepository is a mock of EventTypeRepository interface.
+type MockEventTypeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventTypeRepositoryMockRecorder
+}
+
+// MockEventTypeRepositoryMockRecorder is the mock recorder for MockEventTypeRepository.
+type MockEventTypeRepositoryMockRecorder struct {
+	mock *MockEventTypeRepository
+}
+
+// NewMockEventTypeRepository creates a new mock instance.
+func NewMockEventTypeRepository(ctrl *gomock.Controller) *MockEventTypeRepository {
+	mock := &MockEventTypeRepository{ctrl: ctrl}
+	mock.recorder = &MockEventTypeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventTypeRepository) EXPECT() *MockEventTypeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateEventType mocks base method.
+func (m *MockEventTypeRepository) CreateEventType(arg0 context.Context, arg1 *datastore.ProjectEventType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEventType", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEventType indicates an expected call of CreateEventType.
+func (mr *MockEventTypeRepositoryMockRecorder) CreateEventType(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEventType", reflect.TypeOf((*MockEventTypeRepository)(nil).CreateEventType), arg0, arg1)
+}
+
+// DeleteEventType mocks base method.
+func (m *MockEventTypeRepository) DeleteEventType(ctx context.Context, projectID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEventType", ctx, projectID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEventType indicates an expected call of DeleteEventType.
+func (mr *MockEventTypeRepositoryMockRecorder) DeleteEventType(ctx, projectID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEventType", reflect.TypeOf((*MockEventTypeRepository)(nil).DeleteEventType), ctx, projectID, id)
+}
+
+// FindEventTypeByID mocks base method.
+func (m *MockEventTypeRepository) FindEventTypeByID(ctx context.Context, projectID, id string) (*datastore.ProjectEventType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEventTypeByID", ctx, projectID, id)
+	ret0, _ := ret[0].(*datastore.ProjectEventType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEventTypeByID indicates an expected call of FindEventTypeByID.
+func (mr *MockEventTypeRepositoryMockRecorder) FindEventTypeByID(ctx, projectID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventTypeByID", reflect.TypeOf((*MockEventTypeRepository)(nil).FindEventTypeByID), ctx, projectID, id)
+}
+
+// FindEventTypeByName mocks base method.
+func (m *MockEventTypeRepository) FindEventTypeByName(ctx context.Context, projectID, name string) (*datastore.ProjectEventType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEventTypeByName", ctx, projectID, name)
+	ret0, _ := ret[0].(*datastore.ProjectEventType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEventTypeByName indicates an expected call of FindEventTypeByName.
+func (mr *MockEventTypeRepositoryMockRecorder) FindEventTypeByName(ctx, projectID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventTypeByName", reflect.TypeOf((*MockEventTypeRepository)(nil).FindEventTypeByName), ctx, projectID, name)
+}
+
+// LoadEventTypesPaged mocks base method.
+func (m *MockEventTypeRepository) LoadEventTypesPaged(ctx context.Context, projectID string, pageable datastore.Pageable) ([]datastore.ProjectEventType, datastore.PaginationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadEventTypesPaged", ctx, projectID, pageable)
+	ret0, _ := ret[0].([]datastore.ProjectEventType)
+	ret1, _ := ret[1].(datastore.PaginationData)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LoadEventTypesPaged indicates an expected call of LoadEventTypesPaged.
+func (mr *MockEventTypeRepositoryMockRecorder) LoadEventTypesPaged(ctx, projectID, pageable interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEventTypesPaged", reflect.TypeOf((*MockEventTypeRepository)(nil).LoadEventTypesPaged), ctx, projectID, pageable)
+}
+
+// UpdateEventType mocks base method.
+func (m *MockEventTypeRepository) UpdateEventType(arg0 context.Context, arg1 *datastore.ProjectEventType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEventType", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEventType indicates an expected call of UpdateEventType.
+func (mr *MockEventTypeRepositoryMockRecorder) UpdateEventType(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventType", reflect.TypeOf((*MockEventTypeRepository)(nil).UpdateEventType), arg0, arg1)
+}
+
+// MockSigningKeyRepository is a mock of SigningKeyRepository interface.
+type MockSigningKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSigningKeyRepositoryMockRecorder
+}
+
+// MockSigningKeyRepositoryMockRecorder is the mock recorder for MockSigningKeyRepository.
+type MockSigningKeyRepositoryMockRecorder struct {
+	mock *MockSigningKeyRepository
+}
+
+// NewMockSigningKeyRepository creates a new mock instance.
+func NewMockSigningKeyRepository(ctrl *gomock.Controller) *MockSigningKeyRepository {
+	mock := &MockSigningKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockSigningKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSigningKeyRepository) EXPECT() *MockSigningKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateSigningKey mocks base method.
+func (m *MockSigningKeyRepository) CreateSigningKey(arg0 context.Context, arg1 *datastore.SigningKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSigningKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSigningKey indicates an expected call of CreateSigningKey.
+func (mr *MockSigningKeyRepositoryMockRecorder) CreateSigningKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSigningKey", reflect.TypeOf((*MockSigningKeyRepository)(nil).CreateSigningKey), arg0, arg1)
+}
+
+// LoadActiveSigningKeys mocks base method.
+func (m *MockSigningKeyRepository) LoadActiveSigningKeys(ctx context.Context, projectID string) ([]datastore.SigningKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadActiveSigningKeys", ctx, projectID)
+	ret0, _ := ret[0].([]datastore.SigningKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadActiveSigningKeys indicates an expected call of LoadActiveSigningKeys.
+func (mr *MockSigningKeyRepositoryMockRecorder) LoadActiveSigningKeys(ctx, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadActiveSigningKeys", reflect.TypeOf((*MockSigningKeyRepository)(nil).LoadActiveSigningKeys), ctx, projectID)
+}
+
+// UpdateSigningKey mocks base method.
+func (m *MockSigningKeyRepository) UpdateSigningKey(arg0 context.Context, arg1 *datastore.SigningKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSigningKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSigningKey indicates an expected call of UpdateSigningKey.
+func (mr *MockSigningKeyRepositoryMockRecorder) UpdateSigningKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSigningKey", reflect.TypeOf((*MockSigningKeyRepository)(nil).UpdateSigningKey), arg0, arg1)
+}
+
+// MockSCIMRepository is a mock of SCIMRepository interface.
+type MockSCIMRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSCIMRepositoryMockRecorder
+}
+
+// MockSCIMRepositoryMockRecorder is the mock recorder for MockSCIMRepository.
+type MockSCIMRepositoryMockRecorder struct {
+	mock *MockSCIMRepository
+}
+
+// NewMockSCIMRepository creates a new mock instance.
+func NewMockSCIMRepository(ctrl *gomock.Controller) *MockSCIMRepository {
+	mock := &MockSCIMRepository{ctrl: ctrl}
+	mock.recorder = &MockSCIMRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSCIMRepository) EXPECT() *MockSCIMRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateSCIMGroup mocks base method.
+func (m *MockSCIMRepository) CreateSCIMGroup(arg0 context.Context, arg1 *datastore.SCIMGroup) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSCIMGroup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSCIMGroup indicates an expected call of CreateSCIMGroup.
+func (mr *MockSCIMRepositoryMockRecorder) CreateSCIMGroup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSCIMGroup", reflect.TypeOf((*MockSCIMRepository)(nil).CreateSCIMGroup), arg0, arg1)
+}
+
+// CreateSCIMToken mocks base method.
+func (m *MockSCIMRepository) CreateSCIMToken(arg0 context.Context, arg1 *datastore.SCIMToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSCIMToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSCIMToken indicates an expected call of CreateSCIMToken.
+func (mr *MockSCIMRepositoryMockRecorder) CreateSCIMToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSCIMToken", reflect.TypeOf((*MockSCIMRepository)(nil).CreateSCIMToken), arg0, arg1)
+}
+
+// CreateSCIMUser mocks base method.
+func (m *MockSCIMRepository) CreateSCIMUser(arg0 context.Context, arg1 *datastore.SCIMUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSCIMUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSCIMUser indicates an expected call of CreateSCIMUser.
+func (mr *MockSCIMRepositoryMockRecorder) CreateSCIMUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSCIMUser", reflect.TypeOf((*MockSCIMRepository)(nil).CreateSCIMUser), arg0, arg1)
+}
+
+// DeleteSCIMGroup mocks base method.
+func (m *MockSCIMRepository) DeleteSCIMGroup(ctx context.Context, organisationID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSCIMGroup", ctx, organisationID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSCIMGroup indicates an expected call of DeleteSCIMGroup.
+func (mr *MockSCIMRepositoryMockRecorder) DeleteSCIMGroup(ctx, organisationID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSCIMGroup", reflect.TypeOf((*MockSCIMRepository)(nil).DeleteSCIMGroup), ctx, organisationID, id)
+}
+
+// DeleteSCIMUser mocks base method.
+func (m *MockSCIMRepository) DeleteSCIMUser(ctx context.Context, organisationID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSCIMUser", ctx, organisationID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSCIMUser indicates an expected call of DeleteSCIMUser.
+func (mr *MockSCIMRepositoryMockRecorder) DeleteSCIMUser(ctx, organisationID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSCIMUser", reflect.TypeOf((*MockSCIMRepository)(nil).DeleteSCIMUser), ctx, organisationID, userID)
+}
+
+// FindSCIMGroupByID mocks base method.
+func (m *MockSCIMRepository) FindSCIMGroupByID(ctx context.Context, organisationID, id string) (*datastore.SCIMGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSCIMGroupByID", ctx, organisationID, id)
+	ret0, _ := ret[0].(*datastore.SCIMGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSCIMGroupByID indicates an expected call of FindSCIMGroupByID.
+func (mr *MockSCIMRepositoryMockRecorder) FindSCIMGroupByID(ctx, organisationID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSCIMGroupByID", reflect.TypeOf((*MockSCIMRepository)(nil).FindSCIMGroupByID), ctx, organisationID, id)
+}
+
+// FindSCIMGroupsByUserID mocks base method.
+func (m *MockSCIMRepository) FindSCIMGroupsByUserID(ctx context.Context, organisationID, userID string) ([]datastore.SCIMGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSCIMGroupsByUserID", ctx, organisationID, userID)
+	ret0, _ := ret[0].([]datastore.SCIMGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSCIMGroupsByUserID indicates an expected call of FindSCIMGroupsByUserID.
+func (mr *MockSCIMRepositoryMockRecorder) FindSCIMGroupsByUserID(ctx, organisationID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSCIMGroupsByUserID", reflect.TypeOf((*MockSCIMRepository)(nil).FindSCIMGroupsByUserID), ctx, organisationID, userID)
+}
+
+// FindSCIMTokenByMaskID mocks base method.
+func (m *MockSCIMRepository) FindSCIMTokenByMaskID(ctx context.Context, maskID string) (*datastore.SCIMToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSCIMTokenByMaskID", ctx, maskID)
+	ret0, _ := ret[0].(*datastore.SCIMToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSCIMTokenByMaskID indicates an expected call of FindSCIMTokenByMaskID.
+func (mr *MockSCIMRepositoryMockRecorder) FindSCIMTokenByMaskID(ctx, maskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSCIMTokenByMaskID", reflect.TypeOf((*MockSCIMRepository)(nil).FindSCIMTokenByMaskID), ctx, maskID)
+}
+
+// FindSCIMUser mocks base method.
+func (m *MockSCIMRepository) FindSCIMUser(ctx context.Context, organisationID, userID string) (*datastore.SCIMUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSCIMUser", ctx, organisationID, userID)
+	ret0, _ := ret[0].(*datastore.SCIMUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSCIMUser indicates an expected call of FindSCIMUser.
+func (mr *MockSCIMRepositoryMockRecorder) FindSCIMUser(ctx, organisationID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSCIMUser", reflect.TypeOf((*MockSCIMRepository)(nil).FindSCIMUser), ctx, organisationID, userID)
+}
+
+// LoadSCIMGroups mocks base method.
+func (m *MockSCIMRepository) LoadSCIMGroups(ctx context.Context, organisationID string) ([]datastore.SCIMGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadSCIMGroups", ctx, organisationID)
+	ret0, _ := ret[0].([]datastore.SCIMGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadSCIMGroups indicates an expected call of LoadSCIMGroups.
+func (mr *MockSCIMRepositoryMockRecorder) LoadSCIMGroups(ctx, organisationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadSCIMGroups", reflect.TypeOf((*MockSCIMRepository)(nil).LoadSCIMGroups), ctx, organisationID)
+}
+
+// LoadSCIMUsers mocks base method.
+func (m *MockSCIMRepository) LoadSCIMUsers(ctx context.Context, organisationID string) ([]datastore.SCIMUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadSCIMUsers", ctx, organisationID)
+	ret0, _ := ret[0].([]datastore.SCIMUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadSCIMUsers indicates an expected call of LoadSCIMUsers.
+func (mr *MockSCIMRepositoryMockRecorder) LoadSCIMUsers(ctx, organisationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadSCIMUsers", reflect.TypeOf((*MockSCIMRepository)(nil).LoadSCIMUsers), ctx, organisationID)
+}
+
+// UpdateSCIMGroup mocks base method.
+func (m *MockSCIMRepository) UpdateSCIMGroup(arg0 context.Context, arg1 *datastore.SCIMGroup) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSCIMGroup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSCIMGroup indicates an expected call of UpdateSCIMGroup.
+func (mr *MockSCIMRepositoryMockRecorder) UpdateSCIMGroup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSCIMGroup", reflect.TypeOf((*MockSCIMRepository)(nil).UpdateSCIMGroup), arg0, arg1)
+}
+
+// UpdateSCIMUser mocks base method.
+func (m *MockSCIMRepository) UpdateSCIMUser(arg0 context.Context, arg1 *datastore.SCIMUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSCIMUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSCIMUser indicates an expected call of UpdateSCIMUser.
+func (mr *MockSCIMRepositoryMockRecorder) UpdateSCIMUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSCIMUser", reflect.TypeOf((*MockSCIMRepository)(nil).UpdateSCIMUser), arg0, arg1)
+}
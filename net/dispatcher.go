@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/pkg/compress"
 	"github.com/frain-dev/convoy/pkg/httpheader"
 	"github.com/frain-dev/convoy/pkg/log"
 	"github.com/frain-dev/convoy/util"
@@ -18,10 +19,17 @@ import (
 
 type Dispatcher struct {
 	client *http.Client
+	policy *EgressPolicy
+
+	// contentEncoding compresses request bodies when it's set
+	contentEncoding compress.Encoding
 }
 
-func NewDispatcher(timeout time.Duration, httpProxy string) (*Dispatcher, error) {
-	d := &Dispatcher{client: &http.Client{Timeout: timeout}}
+// NewDispatcher creates a dispatcher, deliveries are checked against
+// policy when it isn't nil. Addresses can only be checked at dial time
+// without a proxy, with one only the target url itself is checked.
+func NewDispatcher(timeout time.Duration, httpProxy string, policy *EgressPolicy) (*Dispatcher, error) {
+	d := &Dispatcher{client: &http.Client{Timeout: timeout}, policy: policy}
 
 	if len(httpProxy) > 0 {
 		proxyUrl, err := url.Parse(httpProxy)
@@ -30,21 +38,58 @@ func NewDispatcher(timeout time.Duration, httpProxy string) (*Dispatcher, error)
 		}
 
 		d.client.Transport = &http.Transport{Proxy: http.ProxyURL(proxyUrl)}
+	} else if policy != nil {
+		if t := policy.Transport(); t != nil {
+			d.client.Transport = t
+		}
 	}
 
 	return d, nil
 }
 
+// SetContentEncoding makes the dispatcher compress request bodies with
+// encoding. An endpoint that rejects the encoding with a 415 is sent the
+// request again, with an encoding from the Accept-Encoding header of its
+// response or uncompressed.
+func (d *Dispatcher) SetContentEncoding(encoding compress.Encoding) {
+	d.contentEncoding = encoding
+}
+
 func (d *Dispatcher) SendRequest(endpoint, method string, jsonData json.RawMessage, signatureHeader string, hmac string, maxResponseSize int64, headers httpheader.HTTPHeader, idempotencyKey string) (*Response, error) {
-	r := &Response{}
 	if util.IsStringEmpty(signatureHeader) || util.IsStringEmpty(hmac) {
 		err := errors.New("signature header and hmac are required")
 		log.WithError(err).Error("Dispatcher invalid arguments")
+		return &Response{Error: err.Error()}, err
+	}
+
+	encoding := d.contentEncoding
+	for {
+		r, err := d.sendRequest(endpoint, method, jsonData, encoding, signatureHeader, hmac, maxResponseSize, headers, idempotencyKey)
+		if err != nil || encoding == compress.None || r.StatusCode != http.StatusUnsupportedMediaType {
+			return r, err
+		}
+
+		next := compress.Negotiate(r.ResponseHeader.Get("Accept-Encoding"))
+		if next == encoding {
+			next = compress.None
+		}
+
+		log.Infof("%s does not accept %s encoded requests, retrying with %q", endpoint, encoding, next)
+		encoding = next
+	}
+}
+
+func (d *Dispatcher) sendRequest(endpoint, method string, jsonData json.RawMessage, encoding compress.Encoding, signatureHeader string, hmac string, maxResponseSize int64, headers httpheader.HTTPHeader, idempotencyKey string) (*Response, error) {
+	r := &Response{}
+
+	body, err := compress.Encode(encoding, jsonData)
+	if err != nil {
+		log.WithError(err).Error("error occurred while compressing request body")
 		r.Error = err.Error()
 		return r, err
 	}
 
-	req, err := http.NewRequest(method, endpoint, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequest(method, endpoint, bytes.NewBuffer(body))
 	if err != nil {
 		log.WithError(err).Error("error occurred while creating request")
 		return r, err
@@ -62,10 +107,25 @@ func (d *Dispatcher) SendRequest(endpoint, method string, jsonData json.RawMessa
 
 	req.Header = http.Header(header)
 
+	// the encoding is set after custom headers so they can't contradict
+	// the body that's actually sent
+	if encoding != compress.None {
+		req.Header.Set("Content-Encoding", encoding.String())
+	}
+
 	r.RequestHeader = req.Header
 	r.URL = req.URL
 	r.Method = req.Method
 
+	if d.policy != nil {
+		err = d.policy.CheckURL(req.URL)
+		if err != nil {
+			log.WithError(err).Error("delivery blocked by egress policy")
+			r.Error = err.Error()
+			return r, err
+		}
+	}
+
 	err = d.do(req, r, maxResponseSize)
 
 	return r, err
@@ -108,6 +168,12 @@ func (d *Dispatcher) do(req *http.Request, res *Response, maxResponseSize int64)
 	if err != nil {
 		log.WithError(err).Error("error sending request to API endpoint")
 		res.Error = err.Error()
+
+		var egressErr *EgressError
+		if errors.As(err, &egressErr) {
+			res.Error = egressErr.Error()
+		}
+
 		return err
 	}
 	updateDispatchHeaders(res, response)
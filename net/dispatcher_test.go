@@ -3,11 +3,14 @@ package net
 import (
 	"bytes"
 	"encoding/json"
+	"io"
 	"math/rand"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/compress"
 	"github.com/frain-dev/convoy/pkg/httpheader"
 	"github.com/jarcoal/httpmock"
 
@@ -292,3 +295,78 @@ func TestDispatcher_SendRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestDispatcher_SendRequest_ContentEncoding(t *testing.T) {
+	payload := json.RawMessage(`{"event": "payment.success"}`)
+
+	tests := []struct {
+		name          string
+		encoding      compress.Encoding
+		accepted      map[string]bool
+		acceptHeader  string
+		wantEncodings []string
+	}{
+		{
+			name:          "should_compress_request",
+			encoding:      compress.Gzip,
+			accepted:      map[string]bool{"gzip": true},
+			wantEncodings: []string{"gzip"},
+		},
+		{
+			name:          "should_switch_to_accepted_encoding",
+			encoding:      compress.Zstd,
+			accepted:      map[string]bool{"gzip": true},
+			acceptHeader:  "gzip",
+			wantEncodings: []string{"zstd", "gzip"},
+		},
+		{
+			name:          "should_fall_back_to_uncompressed",
+			encoding:      compress.Gzip,
+			accepted:      map[string]bool{"": true},
+			wantEncodings: []string{"gzip", ""},
+		},
+		{
+			name:          "should_not_compress_by_default",
+			accepted:      map[string]bool{"": true},
+			wantEncodings: []string{""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			var encodings []string
+			httpmock.RegisterResponder(http.MethodPost, "https://google.com",
+				func(req *http.Request) (*http.Response, error) {
+					encoding := req.Header.Get("Content-Encoding")
+					encodings = append(encodings, encoding)
+
+					if !tt.accepted[encoding] {
+						res := httpmock.NewStringResponse(http.StatusUnsupportedMediaType, "")
+						res.Header.Set("Accept-Encoding", tt.acceptHeader)
+						return res, nil
+					}
+
+					body, err := io.ReadAll(req.Body)
+					require.NoError(t, err)
+
+					body, err = compress.Decode(compress.Encoding(encoding), body)
+					require.NoError(t, err)
+					require.JSONEq(t, string(payload), string(body))
+
+					return httpmock.NewStringResponse(http.StatusOK, string(successBody)), nil
+				})
+
+			d, err := NewDispatcher(10*time.Second, "", nil)
+			require.NoError(t, err)
+			d.SetContentEncoding(tt.encoding)
+
+			res, err := d.SendRequest("https://google.com", http.MethodPost, payload, "X-Convoy-Signature", "hmac", config.MaxResponseSize, nil, "")
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, res.StatusCode)
+			require.Equal(t, tt.wantEncodings, encodings)
+		})
+	}
+}
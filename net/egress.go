@@ -0,0 +1,274 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	stdnet "net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ErrEgressBlocked is returned when the egress policy refuses a destination.
+var ErrEgressBlocked = errors.New("egress blocked")
+
+// EgressError describes why a destination was refused.
+type EgressError struct {
+	Host   string
+	Reason string
+}
+
+func (e *EgressError) Error() string {
+	return fmt.Sprintf("delivery to %s blocked by egress policy: %s", e.Host, e.Reason)
+}
+
+func (e *EgressError) Is(target error) bool {
+	return target == ErrEgressBlocked
+}
+
+// privateNetworks are blocked unless private networks are allowed. Loopback,
+// link-local and private ranges are checked with the net.IP helpers.
+var privateNetworks = []*stdnet.IPNet{
+	mustParseCIDR("0.0.0.0/8"),
+	mustParseCIDR("100.64.0.0/10"), // carrier-grade nat
+	mustParseCIDR("192.0.0.0/24"),
+	mustParseCIDR("198.18.0.0/15"),
+}
+
+// EgressPolicy decides which hosts deliveries may be sent to. Denied
+// entries always win, allowed entries are exceptions to the private
+// network block.
+type EgressPolicy struct {
+	allowPrivateNetworks bool
+
+	allowNets  []*stdnet.IPNet
+	denyNets   []*stdnet.IPNet
+	allowHosts []string
+	denyHosts  []string
+
+	once      sync.Once
+	transport *http.Transport
+}
+
+// NewEgressPolicy builds a policy from allow and deny lists. Entries are
+// CIDRs, IP addresses or hostnames, hostnames may start with a "*." wildcard.
+func NewEgressPolicy(allowPrivateNetworks bool, allowList, denyList []string) (*EgressPolicy, error) {
+	p := &EgressPolicy{allowPrivateNetworks: allowPrivateNetworks}
+
+	var err error
+	p.allowNets, p.allowHosts, err = parseEgressList(allowList)
+	if err != nil {
+		return nil, err
+	}
+
+	p.denyNets, p.denyHosts, err = parseEgressList(denyList)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// CheckURL checks a url against the policy without resolving its host,
+// hostnames are checked against the resolved addresses at dial time.
+func (p *EgressPolicy) CheckURL(u *url.URL) error {
+	return p.checkHost(u.Hostname())
+}
+
+// ValidateURL checks a url and the addresses its host currently resolves
+// to. Hosts that don't resolve are let through, they are checked again
+// when a delivery is sent.
+func (p *EgressPolicy) ValidateURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	host := normalizeHost(u.Hostname())
+	err = p.checkHost(host)
+	if err != nil {
+		return err
+	}
+
+	if stdnet.ParseIP(host) != nil || matchHost(p.allowHosts, host) {
+		return nil
+	}
+
+	addrs, err := stdnet.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil
+	}
+
+	for _, addr := range addrs {
+		err = p.checkIP(host, addr.IP)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DialContext dials addr once its host passes the policy. The resolved
+// address is checked right before connecting, so a host can't pass the
+// check and then be rebound to a blocked address.
+func (p *EgressPolicy) DialContext(ctx context.Context, network, addr string) (stdnet.Conn, error) {
+	host, _, err := stdnet.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	host = normalizeHost(host)
+	err = p.checkHost(host)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &stdnet.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if !matchHost(p.allowHosts, host) {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			ip, _, err := stdnet.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			return p.checkIP(host, stdnet.ParseIP(ip))
+		}
+	}
+
+	return dialer.DialContext(ctx, network, addr)
+}
+
+// Transport returns the policy's transport, it is shared by every
+// dispatcher using the policy so connections are reused. It is nil when
+// the default transport has been replaced, e.g. by a mock in tests.
+func (p *EgressPolicy) Transport() *http.Transport {
+	dt, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil
+	}
+
+	p.once.Do(func() {
+		p.transport = dt.Clone()
+		p.transport.DialContext = p.DialContext
+	})
+
+	return p.transport
+}
+
+func (p *EgressPolicy) checkHost(host string) error {
+	host = normalizeHost(host)
+	if matchHost(p.denyHosts, host) {
+		return &EgressError{Host: host, Reason: "host is denied"}
+	}
+
+	if ip := stdnet.ParseIP(host); ip != nil {
+		return p.checkIP(host, ip)
+	}
+
+	return nil
+}
+
+func (p *EgressPolicy) checkIP(host string, ip stdnet.IP) error {
+	if ip == nil {
+		return &EgressError{Host: host, Reason: "invalid address"}
+	}
+
+	if containsIP(p.denyNets, ip) {
+		return &EgressError{Host: host, Reason: fmt.Sprintf("address %s is denied", ip)}
+	}
+
+	if p.allowPrivateNetworks || containsIP(p.allowNets, ip) {
+		return nil
+	}
+
+	if isPrivateIP(ip) {
+		return &EgressError{Host: host, Reason: fmt.Sprintf("address %s is in a private network", ip)}
+	}
+
+	return nil
+}
+
+func isPrivateIP(ip stdnet.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		containsIP(privateNetworks, ip)
+}
+
+func containsIP(nets []*stdnet.IPNet, ip stdnet.IP) bool {
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchHost(hosts []string, host string) bool {
+	for _, h := range hosts {
+		if h == host {
+			return true
+		}
+
+		if strings.HasPrefix(h, "*.") && strings.HasSuffix(host, h[1:]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
+
+func parseEgressList(entries []string) ([]*stdnet.IPNet, []string, error) {
+	var nets []*stdnet.IPNet
+	var hosts []string
+
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+
+		if strings.Contains(entry, "/") {
+			_, n, err := stdnet.ParseCIDR(entry)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid egress cidr %s: %v", entry, err)
+			}
+
+			nets = append(nets, n)
+			continue
+		}
+
+		if ip := stdnet.ParseIP(entry); ip != nil {
+			bits := 8 * stdnet.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*stdnet.IPv4len
+			}
+
+			nets = append(nets, &stdnet.IPNet{IP: ip, Mask: stdnet.CIDRMask(bits, bits)})
+			continue
+		}
+
+		hosts = append(hosts, normalizeHost(entry))
+	}
+
+	return nets, hosts, nil
+}
+
+func mustParseCIDR(s string) *stdnet.IPNet {
+	_, n, err := stdnet.ParseCIDR(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return n
+}
@@ -0,0 +1,109 @@
+package net
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEgressPolicy_CheckURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		allowPrivate bool
+		allowList    []string
+		denyList     []string
+		url          string
+		wantBlocked  bool
+	}{
+		{name: "public address", url: "https://8.8.8.8/hook"},
+		{name: "hostname", url: "https://example.com/hook"},
+		{name: "loopback", url: "http://127.0.0.1:8080", wantBlocked: true},
+		{name: "ipv6 loopback", url: "http://[::1]:8080", wantBlocked: true},
+		{name: "cloud metadata", url: "http://169.254.169.254/latest", wantBlocked: true},
+		{name: "private range", url: "http://10.0.0.12", wantBlocked: true},
+		{name: "carrier-grade nat", url: "http://100.64.1.1", wantBlocked: true},
+		{name: "unspecified", url: "http://0.0.0.0", wantBlocked: true},
+		{name: "private networks allowed", allowPrivate: true, url: "http://10.0.0.12"},
+		{name: "allowed cidr", allowList: []string{"10.0.0.0/24"}, url: "http://10.0.0.12"},
+		{name: "allowed ip", allowList: []string{"192.168.1.4"}, url: "http://192.168.1.4"},
+		{name: "denied host", denyList: []string{"example.com"}, url: "https://EXAMPLE.com./hook", wantBlocked: true},
+		{name: "denied wildcard host", denyList: []string{"*.example.com"}, url: "https://api.example.com", wantBlocked: true},
+		{name: "wildcard doesn't match apex", denyList: []string{"*.example.com"}, url: "https://example.com"},
+		{name: "deny wins over allow", allowList: []string{"10.0.0.0/8"}, denyList: []string{"10.0.0.12"}, url: "http://10.0.0.12", wantBlocked: true},
+		{name: "denied public cidr", allowPrivate: true, denyList: []string{"8.8.8.0/24"}, url: "https://8.8.8.8", wantBlocked: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := NewEgressPolicy(tc.allowPrivate, tc.allowList, tc.denyList)
+			require.NoError(t, err)
+
+			u, err := url.Parse(tc.url)
+			require.NoError(t, err)
+
+			err = p.CheckURL(u)
+			if tc.wantBlocked {
+				require.ErrorIs(t, err, ErrEgressBlocked)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNewEgressPolicy_InvalidCIDR(t *testing.T) {
+	_, err := NewEgressPolicy(false, []string{"10.0.0.0/33"}, nil)
+	require.Error(t, err)
+}
+
+func TestDispatcher_EgressPolicy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	t.Run("should_block_loopback_address", func(t *testing.T) {
+		p, err := NewEgressPolicy(false, nil, nil)
+		require.NoError(t, err)
+
+		d, err := NewDispatcher(5*time.Second, "", p)
+		require.NoError(t, err)
+
+		res, err := d.SendRequest(srv.URL, http.MethodPost, []byte(`{}`), "X-Convoy-Signature", "hmac", 1024, nil, "")
+		require.ErrorIs(t, err, ErrEgressBlocked)
+		require.Contains(t, res.Error, "blocked by egress policy")
+	})
+
+	t.Run("should_block_hostname_resolving_to_loopback", func(t *testing.T) {
+		p, err := NewEgressPolicy(false, nil, nil)
+		require.NoError(t, err)
+
+		d, err := NewDispatcher(5*time.Second, "", p)
+		require.NoError(t, err)
+
+		res, err := d.SendRequest("http://localhost:"+u.Port(), http.MethodPost, []byte(`{}`), "X-Convoy-Signature", "hmac", 1024, nil, "")
+		require.True(t, errors.Is(err, ErrEgressBlocked))
+		require.Contains(t, res.Error, "delivery to localhost blocked by egress policy")
+	})
+
+	t.Run("should_send_to_allowed_address", func(t *testing.T) {
+		p, err := NewEgressPolicy(false, []string{"127.0.0.0/8"}, nil)
+		require.NoError(t, err)
+
+		d, err := NewDispatcher(5*time.Second, "", p)
+		require.NoError(t, err)
+
+		res, err := d.SendRequest(srv.URL, http.MethodPost, []byte(`{}`), "X-Convoy-Signature", "hmac", 1024, nil, "")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+	})
+}
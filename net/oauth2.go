@@ -0,0 +1,55 @@
+package net
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// OAuth2Token is an access token fetched with the client credentials grant.
+type OAuth2Token struct {
+	AccessToken string    `json:"access_token"`
+	TokenType   string    `json:"token_type"`
+	Expiry      time.Time `json:"expiry"`
+}
+
+// FetchOAuth2Token requests an access token from tokenURL using the
+// client credentials grant. The request is sent with the dispatcher's
+// client, so the token url is held to the same egress policy and timeout
+// as the deliveries.
+func (d *Dispatcher) FetchOAuth2Token(ctx context.Context, tokenURL, clientID, clientSecret, scope string) (*OAuth2Token, error) {
+	if d.policy != nil {
+		u, err := url.Parse(tokenURL)
+		if err != nil {
+			return nil, err
+		}
+
+		err = d.policy.CheckURL(u)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cfg := &clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     tokenURL,
+		Scopes:       strings.Fields(scope),
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, d.client)
+	token, err := cfg.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OAuth2Token{
+		AccessToken: token.AccessToken,
+		TokenType:   token.Type(),
+		Expiry:      token.Expiry,
+	}, nil
+}
@@ -0,0 +1,61 @@
+package net
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchOAuth2Token(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "client_credentials", r.Form.Get("grant_type"))
+		require.Equal(t, "events:write", r.Form.Get("scope"))
+
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "client-id" || secret != "client-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"token","token_type":"bearer","expires_in":3600}`))
+	}))
+	defer srv.Close()
+
+	d, err := NewDispatcher(5*time.Second, "", nil)
+	require.NoError(t, err)
+
+	token, err := d.FetchOAuth2Token(context.Background(), srv.URL, "client-id", "client-secret", "events:write")
+	require.NoError(t, err)
+	require.Equal(t, "token", token.AccessToken)
+	require.Equal(t, "Bearer", token.TokenType)
+	require.WithinDuration(t, time.Now().Add(time.Hour), token.Expiry, time.Minute)
+
+	_, err = d.FetchOAuth2Token(context.Background(), srv.URL, "client-id", "wrong-secret", "events:write")
+	require.Error(t, err)
+}
+
+func TestFetchOAuth2Token_EgressPolicy(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"token","token_type":"bearer"}`))
+	}))
+	defer srv.Close()
+
+	policy, err := NewEgressPolicy(false, nil, nil)
+	require.NoError(t, err)
+
+	d, err := NewDispatcher(5*time.Second, "", policy)
+	require.NoError(t, err)
+
+	_, err = d.FetchOAuth2Token(context.Background(), srv.URL, "client-id", "client-secret", "")
+	require.ErrorIs(t, err, ErrEgressBlocked)
+	require.Zero(t, requests)
+}
@@ -0,0 +1,152 @@
+package net
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// maxClientCertTransports bounds how many endpoints' transports are kept,
+// the least recently used have their connections closed.
+const maxClientCertTransports = 1000
+
+// clientCertTransports holds a transport per endpoint, so connections made
+// with an endpoint's client certificate are reused across deliveries. The
+// transport is replaced when the endpoint's certificate changes.
+var clientCertTransports = newTransportCache(maxClientCertTransports)
+
+// transportCache is a least recently used cache of transports keyed by
+// endpoint id, deleted endpoints' transports are evicted once enough
+// other endpoints have been used. load and store are called with mu held.
+type transportCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type clientCertTransport struct {
+	endpointID  string
+	fingerprint string
+	transport   *http.Transport
+}
+
+func newTransportCache(size int) *transportCache {
+	return &transportCache{size: size, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+func (c *transportCache) load(endpointID string) (*clientCertTransport, bool) {
+	e, ok := c.entries[endpointID]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(e)
+	return e.Value.(*clientCertTransport), true
+}
+
+// store replaces the endpoint's transport, the replaced and evicted
+// transports' idle connections are closed.
+func (c *transportCache) store(t *clientCertTransport) {
+	if e, ok := c.entries[t.endpointID]; ok {
+		e.Value.(*clientCertTransport).transport.CloseIdleConnections()
+		e.Value = t
+		c.order.MoveToFront(e)
+		return
+	}
+
+	c.entries[t.endpointID] = c.order.PushFront(t)
+
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+
+		evicted := oldest.Value.(*clientCertTransport)
+		delete(c.entries, evicted.endpointID)
+		evicted.transport.CloseIdleConnections()
+	}
+}
+
+func (c *transportCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// ClientTLSConfig builds the TLS config for mutual TLS from a PEM encoded
+// client certificate and key. caPEM optionally replaces the system roots
+// used to verify the server.
+func ClientTLSConfig(certPEM, keyPEM, caPEM string) (*tls.Config, error) {
+	cert, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if len(caPEM) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(caPEM)) {
+			return nil, errors.New("invalid ca certificate")
+		}
+
+		cfg.RootCAs = pool
+	}
+
+	return cfg, nil
+}
+
+// SetTLSConfig makes the dispatcher use cfg for its connections. The
+// default and egress policy transports are shared, so they are cloned
+// rather than modified. Every call creates a new transport, deliveries
+// use SetClientCertificate to reuse them.
+func (d *Dispatcher) SetTLSConfig(cfg *tls.Config) {
+	t := &http.Transport{}
+	if ct, ok := d.client.Transport.(*http.Transport); ok && ct != nil {
+		t = ct.Clone()
+	} else if dt, ok := http.DefaultTransport.(*http.Transport); ok {
+		t = dt.Clone()
+	}
+
+	t.TLSClientConfig = cfg
+	d.client.Transport = t
+}
+
+// SetClientCertificate makes the dispatcher present a PEM encoded client
+// certificate on its connections to endpointID. See ClientTLSConfig.
+func (d *Dispatcher) SetClientCertificate(endpointID, certPEM, keyPEM, caPEM string) error {
+	sum := sha256.Sum256([]byte(certPEM + "\n" + keyPEM + "\n" + caPEM))
+	fingerprint := hex.EncodeToString(sum[:])
+
+	clientCertTransports.mu.Lock()
+	defer clientCertTransports.mu.Unlock()
+
+	cached, ok := clientCertTransports.load(endpointID)
+	if ok && cached.fingerprint == fingerprint {
+		d.client.Transport = cached.transport
+		return nil
+	}
+
+	cfg, err := ClientTLSConfig(certPEM, keyPEM, caPEM)
+	if err != nil {
+		return err
+	}
+
+	d.SetTLSConfig(cfg)
+	clientCertTransports.store(&clientCertTransport{
+		endpointID:  endpointID,
+		fingerprint: fingerprint,
+		transport:   d.client.Transport.(*http.Transport),
+	})
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package net
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func generateClientCert(t *testing.T) (string, string, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "convoy"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return string(certPEM), string(keyPEM), cert
+}
+
+func TestDispatcher_MutualTLS(t *testing.T) {
+	certPEM, keyPEM, cert := generateClientCert(t)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(cert)
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	srv.StartTLS()
+	defer srv.Close()
+
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+
+	t.Run("should present the client certificate", func(t *testing.T) {
+		d, err := NewDispatcher(5*time.Second, "", nil)
+		require.NoError(t, err)
+		require.NoError(t, d.SetClientCertificate("endpoint-mtls", certPEM, keyPEM, caPEM))
+
+		resp, err := d.SendRequest(srv.URL, http.MethodPost, []byte(`{}`), "X-Convoy-Signature", "hmac", 1024, nil, "")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should fail without the client certificate", func(t *testing.T) {
+		pool := x509.NewCertPool()
+		pool.AddCert(srv.Certificate())
+
+		d, err := NewDispatcher(5*time.Second, "", nil)
+		require.NoError(t, err)
+		d.SetTLSConfig(&tls.Config{RootCAs: pool})
+
+		_, err = d.SendRequest(srv.URL, http.MethodPost, []byte(`{}`), "X-Convoy-Signature", "hmac", 1024, nil, "")
+		require.Error(t, err)
+	})
+}
+
+func TestDispatcher_SetClientCertificate(t *testing.T) {
+	certPEM, keyPEM, _ := generateClientCert(t)
+	rotatedCertPEM, rotatedKeyPEM, _ := generateClientCert(t)
+
+	transport := func(endpointID, certPEM, keyPEM string) http.RoundTripper {
+		d, err := NewDispatcher(5*time.Second, "", nil)
+		require.NoError(t, err)
+		require.NoError(t, d.SetClientCertificate(endpointID, certPEM, keyPEM, ""))
+		return d.client.Transport
+	}
+
+	first := transport("endpoint-1", certPEM, keyPEM)
+	require.Same(t, first, transport("endpoint-1", certPEM, keyPEM))
+	require.NotSame(t, first, transport("endpoint-2", certPEM, keyPEM))
+
+	rotated := transport("endpoint-1", rotatedCertPEM, rotatedKeyPEM)
+	require.NotSame(t, first, rotated)
+	require.Same(t, rotated, transport("endpoint-1", rotatedCertPEM, rotatedKeyPEM))
+
+	d, err := NewDispatcher(5*time.Second, "", nil)
+	require.NoError(t, err)
+	require.Error(t, d.SetClientCertificate("endpoint-3", certPEM, "invalid", ""))
+}
+
+func TestTransportCache(t *testing.T) {
+	c := newTransportCache(2)
+
+	entry := func(endpointID string) *clientCertTransport {
+		return &clientCertTransport{endpointID: endpointID, fingerprint: endpointID, transport: &http.Transport{}}
+	}
+
+	a, b, d := entry("a"), entry("b"), entry("d")
+	c.store(a)
+	c.store(b)
+
+	// using a makes b the least recently used
+	cached, ok := c.load("a")
+	require.True(t, ok)
+	require.Same(t, a, cached)
+
+	c.store(d)
+	require.Equal(t, 2, c.Len())
+
+	_, ok = c.load("b")
+	require.False(t, ok)
+
+	// storing an endpoint again replaces its transport
+	rotated := entry("d")
+	c.store(rotated)
+	require.Equal(t, 2, c.Len())
+
+	cached, ok = c.load("d")
+	require.True(t, ok)
+	require.Same(t, rotated, cached)
+}
+
+func TestClientTLSConfig(t *testing.T) {
+	certPEM, keyPEM, _ := generateClientCert(t)
+
+	_, err := ClientTLSConfig(certPEM, keyPEM, "")
+	require.NoError(t, err)
+
+	_, err = ClientTLSConfig(certPEM, "invalid", "")
+	require.Error(t, err)
+
+	_, err = ClientTLSConfig(certPEM, keyPEM, "invalid")
+	require.Error(t, err)
+}
@@ -4,9 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/frain-dev/convoy/pkg/flatten"
 )
 
 var ErrTrailingDollarOpNotAllowed = errors.New("invalid filter syntax, found trailing $")
@@ -15,20 +18,35 @@ type CompareFunc func(x, y interface{}) (bool, error)
 
 func defaultCompareMap() map[string]CompareFunc {
 	return map[string]CompareFunc{
-		"$gte":   gte,
-		"$gt":    gt,
-		"$lte":   lte,
-		"$lt":    lt,
-		"$in":    in,
-		"$nin":   nin,
-		"$eq":    eq,
-		"$neq":   neq,
-		"$or":    or,
-		"$and":   and,
-		"$exist": exist,
+		"$gte":       gte,
+		"$gt":        gt,
+		"$lte":       lte,
+		"$lt":        lt,
+		"$in":        in,
+		"$nin":       nin,
+		"$eq":        eq,
+		"$neq":       neq,
+		"$or":        or,
+		"$and":       and,
+		"$exist":     exist,
+		"$regex":     regex,
+		"$prefix":    prefix,
+		"$suffix":    suffix,
+		"$contains":  contains,
+		"$ieq":       ieq,
+		"$size":      size,
+		"$elemMatch": elemMatch,
+		"$not":       not,
 	}
 }
 
+// arrayOperators match against a whole array, arrays of objects are
+// flattened into indexed keys so they're rebuilt before being compared.
+var arrayOperators = map[string]bool{
+	"$size":      true,
+	"$elemMatch": true,
+}
+
 func Compare(payload map[string]interface{}, filter map[string]interface{}) (bool, error) {
 	return compare(payload, filter)
 }
@@ -87,7 +105,15 @@ func compare(payload map[string]interface{}, filter map[string]interface{}) (boo
 				pass = append(pass, check)
 			}
 
-			continue
+			ops, isMap := filterVal.(map[string]interface{})
+			if !isMap || !hasArrayOperator(ops) {
+				continue
+			}
+
+			payloadVal, ok = arrayElements(payload, key)
+			if !ok {
+				continue
+			}
 		}
 
 		switch v := filterVal.(type) {
@@ -104,7 +130,12 @@ func compare(payload map[string]interface{}, filter map[string]interface{}) (boo
 					continue
 				}
 
-				check, err := cmp[vk](payloadVal, vv)
+				fn, ok := cmp[vk]
+				if !ok {
+					return false, fmt.Errorf("unknown operator %s", vk)
+				}
+
+				check, err := fn(payloadVal, vv)
 				if err != nil {
 					return false, err
 				}
@@ -349,6 +380,232 @@ func exist(payload, filter interface{}) (bool, error) {
 	return b == want, nil
 }
 
+// regex matches a string against a regular expression, the expression is
+// either a pattern or one precompiled by Compile.
+func regex(payload, filter interface{}) (bool, error) {
+	p, ok := payload.(string)
+	if !ok {
+		return false, nil
+	}
+
+	switch f := filter.(type) {
+	case *regexp.Regexp:
+		return f.MatchString(p), nil
+	case string:
+		re, err := regexp.Compile(f)
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(p), nil
+	default:
+		return false, fmt.Errorf("filter %v is not a valid regular expression", filter)
+	}
+}
+
+func prefix(payload, filter interface{}) (bool, error) {
+	return matchString(payload, filter, strings.HasPrefix)
+}
+
+func suffix(payload, filter interface{}) (bool, error) {
+	return matchString(payload, filter, strings.HasSuffix)
+}
+
+func contains(payload, filter interface{}) (bool, error) {
+	return matchString(payload, filter, strings.Contains)
+}
+
+// ieq checks whether x, y are equal ignoring case, values that aren't
+// strings are compared like eq does.
+func ieq(x, y interface{}) (bool, error) {
+	sx, ok := x.(string)
+	if !ok {
+		return eq(x, y)
+	}
+
+	sy, ok := y.(string)
+	if !ok {
+		return false, nil
+	}
+
+	return strings.EqualFold(sx, sy), nil
+}
+
+func matchString(payload, filter interface{}, fn func(s, substr string) bool) (bool, error) {
+	p, ok := payload.(string)
+	if !ok {
+		return false, nil
+	}
+
+	f, ok := filter.(string)
+	if !ok {
+		return false, fmt.Errorf("filter %v is not a string", filter)
+	}
+
+	return fn(p, f), nil
+}
+
+// size matches the length of an array, the filter is either the length
+// or the operators the length is compared with e.g. {"$gte": 2}.
+func size(payload, filter interface{}) (bool, error) {
+	p, ok := payload.([]interface{})
+	if !ok {
+		return false, nil
+	}
+
+	n := float64(len(p))
+	ops, ok := filter.(map[string]interface{})
+	if !ok {
+		return eq(n, filter)
+	}
+
+	return matchOperators(n, ops)
+}
+
+// elemMatch matches when at least one element of an array matches the
+// filter. Objects are matched like payloads are, any other element is
+// matched against the filter's operators e.g. {"$gt": 5}.
+func elemMatch(payload, filter interface{}) (bool, error) {
+	p, ok := payload.([]interface{})
+	if !ok {
+		return false, nil
+	}
+
+	f, ok := filter.(*Filter)
+	if !ok {
+		fm, isMap := filter.(map[string]interface{})
+		if !isMap {
+			return false, fmt.Errorf("filter %v is not valid json", filter)
+		}
+
+		var err error
+		f, err = compile(fm)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	for _, el := range p {
+		var check bool
+		var err error
+
+		switch e := el.(type) {
+		case map[string]interface{}:
+			fe, ferr := flatten.Flatten(e)
+			if ferr != nil {
+				return false, ferr
+			}
+			check, err = f.Match(fe)
+		default:
+			if !f.operatorsOnly {
+				continue
+			}
+			check, err = matchOperators(e, f.filter)
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		if check {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// not negates the operators it is given e.g. {"$not": {"$regex": "^test"}}.
+func not(payload, filter interface{}) (bool, error) {
+	ops, ok := filter.(map[string]interface{})
+	if !ok {
+		return false, fmt.Errorf("filter %v is not valid json", filter)
+	}
+
+	chk, err := matchOperators(payload, ops)
+	return !chk, err
+}
+
+// matchOperators applies every operator in ops to a single value.
+func matchOperators(payload interface{}, ops map[string]interface{}) (bool, error) {
+	cmp := defaultCompareMap()
+	for op, arg := range ops {
+		fn, ok := cmp[op]
+		if !ok || op == "$or" || op == "$and" || op == "$exist" {
+			return false, fmt.Errorf("operator %s cannot be used here", op)
+		}
+
+		chk, err := fn(payload, arg)
+		if err != nil {
+			return false, err
+		}
+
+		if !chk {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func hasArrayOperator(ops map[string]interface{}) bool {
+	for op := range ops {
+		if arrayOperators[op] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// arrayElements rebuilds an array of objects from the indexed keys it was
+// flattened into, e.g. items.0.name and items.1.name become the elements
+// of items.
+func arrayElements(payload map[string]interface{}, key string) ([]interface{}, bool) {
+	elements := map[int]map[string]interface{}{}
+	last := -1
+
+	for k, v := range payload {
+		rest := strings.TrimPrefix(k, key+".")
+		if rest == k {
+			continue
+		}
+
+		idx, field, ok := strings.Cut(rest, ".")
+		if !ok {
+			continue
+		}
+
+		i, err := strconv.Atoi(idx)
+		if err != nil || i < 0 {
+			continue
+		}
+
+		if elements[i] == nil {
+			elements[i] = map[string]interface{}{}
+		}
+		elements[i][field] = v
+
+		if i > last {
+			last = i
+		}
+	}
+
+	if last < 0 {
+		return nil, false
+	}
+
+	arr := make([]interface{}, last+1)
+	for i := range arr {
+		if el, ok := elements[i]; ok {
+			arr[i] = el
+		} else {
+			arr[i] = map[string]interface{}{}
+		}
+	}
+
+	return arr, true
+}
+
 // toFloat64 converts interface{} value to float64 if value is numeric else return false
 func toFloat64(v interface{}) (float64, bool) {
 	var f float64
@@ -903,3 +903,136 @@ func jsonEqual(got, want interface{}) bool {
 	diff, _ := jsondiff.Compare(a, b, &jsondiff.Options{})
 	return diff == jsondiff.FullMatch
 }
+
+func TestCompareStringAndArrayOperators(t *testing.T) {
+	payload := map[string]interface{}{
+		"person": map[string]interface{}{
+			"name":  "Raymond",
+			"email": "raymond@getconvoy.io",
+			"tags":  []interface{}{"admin", "beta"},
+		},
+		"orders": []interface{}{
+			map[string]interface{}{"status": "paid", "amount": 20},
+			map[string]interface{}{"status": "refunded", "amount": 5},
+		},
+		"scores": []interface{}{3, 8, 11},
+	}
+
+	tests := []struct {
+		name   string
+		filter map[string]interface{}
+		want   bool
+	}{
+		{
+			name:   "regex",
+			filter: map[string]interface{}{"person": map[string]interface{}{"email": map[string]interface{}{"$regex": "@getconvoy\\.io$"}}},
+			want:   true,
+		},
+		{
+			name:   "regex - no match",
+			filter: map[string]interface{}{"person.email": map[string]interface{}{"$regex": "^admin@"}},
+			want:   false,
+		},
+		{
+			name:   "prefix",
+			filter: map[string]interface{}{"person.name": map[string]interface{}{"$prefix": "Ray"}},
+			want:   true,
+		},
+		{
+			name:   "suffix",
+			filter: map[string]interface{}{"person.email": map[string]interface{}{"$suffix": ".com"}},
+			want:   false,
+		},
+		{
+			name:   "contains",
+			filter: map[string]interface{}{"person.email": map[string]interface{}{"$contains": "convoy"}},
+			want:   true,
+		},
+		{
+			name:   "case insensitive equality",
+			filter: map[string]interface{}{"person.name": map[string]interface{}{"$ieq": "RAYMOND"}},
+			want:   true,
+		},
+		{
+			name:   "size of array of values",
+			filter: map[string]interface{}{"person.tags": map[string]interface{}{"$size": 2}},
+			want:   true,
+		},
+		{
+			name:   "size of array of objects",
+			filter: map[string]interface{}{"orders": map[string]interface{}{"$size": map[string]interface{}{"$gt": 2}}},
+			want:   false,
+		},
+		{
+			name: "elemMatch over objects",
+			filter: map[string]interface{}{"orders": map[string]interface{}{"$elemMatch": map[string]interface{}{
+				"status": "refunded",
+				"amount": map[string]interface{}{"$lt": 10},
+			}}},
+			want: true,
+		},
+		{
+			name: "elemMatch needs one element to match every condition",
+			filter: map[string]interface{}{"orders": map[string]interface{}{"$elemMatch": map[string]interface{}{
+				"status": "paid",
+				"amount": map[string]interface{}{"$lt": 10},
+			}}},
+			want: false,
+		},
+		{
+			name:   "elemMatch over values",
+			filter: map[string]interface{}{"scores": map[string]interface{}{"$elemMatch": map[string]interface{}{"$gt": 10}}},
+			want:   true,
+		},
+		{
+			name:   "not",
+			filter: map[string]interface{}{"person.email": map[string]interface{}{"$not": map[string]interface{}{"$suffix": "@example.com"}}},
+			want:   true,
+		},
+		{
+			name: "not inside or",
+			filter: map[string]interface{}{"$or": []interface{}{
+				map[string]interface{}{"person.name": map[string]interface{}{"$not": map[string]interface{}{"$prefix": "Ray"}}},
+				map[string]interface{}{"person.tags": map[string]interface{}{"$size": 3}},
+			}},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := flatten.Flatten(payload)
+			if err != nil {
+				t.Fatalf("failed to flatten JSON: %v", err)
+			}
+
+			f, err := flatten.Flatten(tt.filter)
+			if err != nil {
+				t.Fatalf("failed to flatten JSON: %v", err)
+			}
+
+			matched, err := Compare(p, f)
+			if err != nil {
+				t.Fatalf("failed to compare: %v", err)
+			}
+
+			if matched != tt.want {
+				t.Errorf("mismatch:\ngot:  %+v\nwant: %+v", matched, tt.want)
+			}
+
+			compiled, err := Compile(tt.filter)
+			if err != nil {
+				t.Fatalf("failed to compile: %v", err)
+			}
+
+			matched, err = compiled.Match(p)
+			if err != nil {
+				t.Fatalf("failed to match: %v", err)
+			}
+
+			if matched != tt.want {
+				t.Errorf("compiled mismatch:\ngot:  %+v\nwant: %+v", matched, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,378 @@
+package compare
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/frain-dev/convoy/pkg/flatten"
+)
+
+// FilterError reports where in a filter it is invalid, Path is the
+// dotted path of the offending key e.g. person.age.$gte.
+type FilterError struct {
+	Path   string
+	Reason string
+}
+
+func (e *FilterError) Error() string {
+	if e.Path == "" {
+		return e.Reason
+	}
+
+	return fmt.Sprintf("%s: %s", e.Path, e.Reason)
+}
+
+// Filter is a filter that has been validated, flattened and had its
+// operator arguments precompiled, so it can be matched against many
+// payloads without being parsed again.
+type Filter struct {
+	filter map[string]interface{}
+
+	// operatorsOnly is set for filters like {"$gt": 5} that match a value
+	// rather than an object, they're only used by $elemMatch.
+	operatorsOnly bool
+}
+
+// Compile validates filter and prepares it to be matched.
+func Compile(filter map[string]interface{}) (*Filter, error) {
+	err := Validate(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return compile(filter)
+}
+
+// Match reports whether a flattened payload matches the filter.
+func (f *Filter) Match(payload map[string]interface{}) (bool, error) {
+	if f.operatorsOnly {
+		return false, nil
+	}
+
+	return compare(payload, f.filter)
+}
+
+func compile(filter map[string]interface{}) (*Filter, error) {
+	if len(filter) > 0 && isOperatorMap(filter) {
+		ops, err := prepareOperators(filter)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Filter{filter: ops, operatorsOnly: true}, nil
+	}
+
+	flat, err := flatten.Flatten(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	prepared, err := prepare(flat)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Filter{filter: prepared}, nil
+}
+
+// prepare copies a flattened filter with the arguments of its operators
+// precompiled.
+func prepare(filter map[string]interface{}) (map[string]interface{}, error) {
+	prepared := make(map[string]interface{}, len(filter))
+	for key, value := range filter {
+		switch v := value.(type) {
+		case []interface{}:
+			if key != "$or" && key != "$and" {
+				prepared[key] = v
+				continue
+			}
+
+			conditions := make([]interface{}, len(v))
+			for i := range v {
+				m, ok := v[i].(map[string]interface{})
+				if !ok {
+					return nil, fmt.Errorf("filter %v is not valid json", v[i])
+				}
+
+				c, err := prepare(m)
+				if err != nil {
+					return nil, err
+				}
+				conditions[i] = c
+			}
+			prepared[key] = conditions
+
+		case map[string]interface{}:
+			if !isOperatorMap(v) {
+				prepared[key] = v
+				continue
+			}
+
+			ops, err := prepareOperators(v)
+			if err != nil {
+				return nil, err
+			}
+			prepared[key] = ops
+
+		default:
+			prepared[key] = v
+		}
+	}
+
+	return prepared, nil
+}
+
+func prepareOperators(ops map[string]interface{}) (map[string]interface{}, error) {
+	prepared := make(map[string]interface{}, len(ops))
+	for op, arg := range ops {
+		prepared[op] = arg
+
+		switch op {
+		case "$regex":
+			pattern, ok := arg.(string)
+			if !ok {
+				continue
+			}
+
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, err
+			}
+			prepared[op] = re
+
+		case "$elemMatch":
+			m, ok := arg.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			f, err := compile(m)
+			if err != nil {
+				return nil, err
+			}
+			prepared[op] = f
+
+		case "$not":
+			m, ok := arg.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			not, err := prepareOperators(m)
+			if err != nil {
+				return nil, err
+			}
+			prepared[op] = not
+		}
+	}
+
+	return prepared, nil
+}
+
+// Validate checks that every operator in filter is known and is given a
+// valid argument, the error is a *FilterError with the path of the first
+// invalid key.
+func Validate(filter map[string]interface{}) error {
+	return validate("", filter, false)
+}
+
+func validate(path string, value interface{}, inField bool) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedKeys(v) {
+			p := joinPath(path, key)
+
+			if isOperatorKey(key) {
+				if !inField && key != "$or" && key != "$and" {
+					return &FilterError{Path: p, Reason: fmt.Sprintf("%s must be applied to a field", key)}
+				}
+
+				err := validateOperator(p, key, v[key])
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			if strings.HasSuffix(key, ".$") || key == "$" {
+				return &FilterError{Path: p, Reason: ErrTrailingDollarOpNotAllowed.Error()}
+			}
+
+			err := validate(p, v[key], true)
+			if err != nil {
+				return err
+			}
+		}
+
+	case []interface{}:
+		for i := range v {
+			err := validate(joinPath(path, strconv.Itoa(i)), v[i], inField)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateOperator(path, op string, arg interface{}) error {
+	invalid := func(reason string) error {
+		return &FilterError{Path: path, Reason: reason}
+	}
+
+	switch op {
+	case "$gte", "$gt", "$lte", "$lt":
+		if _, ok := toFloat64(arg); !ok {
+			return invalid("value must be a number")
+		}
+
+	case "$in", "$nin":
+		if _, ok := arg.([]interface{}); !ok {
+			return invalid("value must be an array")
+		}
+
+	case "$eq", "$neq", "$ieq":
+
+	case "$or", "$and":
+		conditions, ok := arg.([]interface{})
+		if !ok {
+			return invalid("value must be an array of filters")
+		}
+
+		for i := range conditions {
+			if _, ok := conditions[i].(map[string]interface{}); !ok {
+				return &FilterError{Path: joinPath(path, strconv.Itoa(i)), Reason: "value must be a filter"}
+			}
+
+			err := validate(joinPath(path, strconv.Itoa(i)), conditions[i], false)
+			if err != nil {
+				return err
+			}
+		}
+
+	case "$exist":
+		if _, ok := arg.(bool); !ok {
+			return invalid("value must be a boolean")
+		}
+
+	case "$regex":
+		pattern, ok := arg.(string)
+		if !ok {
+			return invalid("value must be a string")
+		}
+
+		if _, err := regexp.Compile(pattern); err != nil {
+			return invalid(fmt.Sprintf("invalid regular expression: %v", err))
+		}
+
+	case "$prefix", "$suffix", "$contains":
+		if _, ok := arg.(string); !ok {
+			return invalid("value must be a string")
+		}
+
+	case "$size":
+		switch a := arg.(type) {
+		case map[string]interface{}:
+			if len(a) == 0 {
+				return invalid("value must not be empty")
+			}
+
+			for _, k := range sortedKeys(a) {
+				if !comparisonOperators[k] {
+					return &FilterError{Path: joinPath(path, k), Reason: fmt.Sprintf("%s cannot be used with $size", k)}
+				}
+
+				err := validateOperator(joinPath(path, k), k, a[k])
+				if err != nil {
+					return err
+				}
+			}
+		default:
+			n, ok := toFloat64(arg)
+			if !ok || n < 0 || n != float64(int64(n)) {
+				return invalid("value must be a non-negative integer or an object of comparison operators")
+			}
+		}
+
+	case "$elemMatch":
+		m, ok := arg.(map[string]interface{})
+		if !ok || len(m) == 0 {
+			return invalid("value must be a non-empty object")
+		}
+
+		return validate(path, m, true)
+
+	case "$not":
+		m, ok := arg.(map[string]interface{})
+		if !ok || len(m) == 0 {
+			return invalid("value must be a non-empty object of operators")
+		}
+
+		for _, k := range sortedKeys(m) {
+			p := joinPath(path, k)
+			if !isOperatorKey(k) || k == "$or" || k == "$and" || k == "$exist" {
+				return &FilterError{Path: p, Reason: fmt.Sprintf("%s cannot be used with $not", k)}
+			}
+
+			err := validateOperator(p, k, m[k])
+			if err != nil {
+				return err
+			}
+		}
+
+	default:
+		return invalid(fmt.Sprintf("unknown operator %s", op))
+	}
+
+	return nil
+}
+
+// comparisonOperators can be used to compare the length matched by $size.
+var comparisonOperators = map[string]bool{
+	"$gte": true,
+	"$gt":  true,
+	"$lte": true,
+	"$lt":  true,
+	"$eq":  true,
+	"$neq": true,
+	"$in":  true,
+	"$nin": true,
+}
+
+// isOperatorKey follows flatten, keys that start with $. are array
+// wildcards rather than operators.
+func isOperatorKey(key string) bool {
+	return strings.HasPrefix(key, "$") && !strings.HasPrefix(key, "$.")
+}
+
+func isOperatorMap(m map[string]interface{}) bool {
+	for k := range m {
+		if !isOperatorKey(k) || k == "$or" || k == "$and" {
+			return false
+		}
+	}
+
+	return len(m) > 0
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,112 @@
+package compare
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		err    string
+	}{
+		{
+			name:   "valid filter",
+			filter: `{"person": {"age": {"$gte": 18}, "name": {"$regex": "^ray", "$not": {"$ieq": "raymond"}}}, "$or": [{"tags": {"$size": {"$gt": 1}}}]}`,
+		},
+		{
+			name:   "unknown operator",
+			filter: `{"person": {"age": {"$gtee": 18}}}`,
+			err:    "person.age.$gtee: unknown operator $gtee",
+		},
+		{
+			name:   "invalid regex",
+			filter: `{"person": {"name": {"$regex": "[a-"}}}`,
+			err:    "person.name.$regex: invalid regular expression: error parsing regexp: missing closing ]: `[a-`",
+		},
+		{
+			name:   "comparison needs a number",
+			filter: `{"amount": {"$lt": "ten"}}`,
+			err:    "amount.$lt: value must be a number",
+		},
+		{
+			name:   "invalid operator inside or",
+			filter: `{"$or": [{"name": "a"}, {"name": {"$prefix": 1}}]}`,
+			err:    "$or.1.name.$prefix: value must be a string",
+		},
+		{
+			name:   "invalid operator inside elemMatch",
+			filter: `{"orders": {"$elemMatch": {"amount": {"$in": 5}}}}`,
+			err:    "orders.$elemMatch.amount.$in: value must be an array",
+		},
+		{
+			name:   "size needs an integer",
+			filter: `{"tags": {"$size": 1.5}}`,
+			err:    "tags.$size: value must be a non-negative integer or an object of comparison operators",
+		},
+		{
+			name:   "size with a string operator",
+			filter: `{"tags": {"$size": {"$regex": "1"}}}`,
+			err:    "tags.$size.$regex: $regex cannot be used with $size",
+		},
+		{
+			name:   "not with exist",
+			filter: `{"name": {"$not": {"$exist": true}}}`,
+			err:    "name.$not.$exist: $exist cannot be used with $not",
+		},
+		{
+			name:   "operator without a field",
+			filter: `{"$regex": "a"}`,
+			err:    "$regex: $regex must be applied to a field",
+		},
+		{
+			name:   "or needs an array",
+			filter: `{"$or": {"name": "a"}}`,
+			err:    "$or: value must be an array of filters",
+		},
+		{
+			name:   "trailing wildcard",
+			filter: `{"venues.$": "lagos"}`,
+			err:    "venues.$: invalid filter syntax, found trailing $",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var filter map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(tt.filter), &filter))
+
+			err := Validate(filter)
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.EqualError(t, err, tt.err)
+
+			var fe *FilterError
+			require.ErrorAs(t, err, &fe)
+		})
+	}
+}
+
+func TestCompile_PrecompilesOperators(t *testing.T) {
+	f, err := Compile(map[string]interface{}{
+		"name":   map[string]interface{}{"$not": map[string]interface{}{"$regex": "^test"}},
+		"orders": map[string]interface{}{"$elemMatch": map[string]interface{}{"status": "paid"}},
+	})
+	require.NoError(t, err)
+
+	require.IsType(t, &Filter{}, f.filter["orders"].(map[string]interface{})["$elemMatch"])
+
+	matched, err := f.Match(map[string]interface{}{"name": "raymond", "orders.0.status": "paid"})
+	require.NoError(t, err)
+	require.True(t, matched)
+
+	matched, err = f.Match(map[string]interface{}{"name": "test-raymond", "orders.0.status": "paid"})
+	require.NoError(t, err)
+	require.False(t, matched)
+}
@@ -0,0 +1,124 @@
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Encoding is a content coding payloads can be compressed with, its
+// values are the tokens used in Content-Encoding headers.
+type Encoding string
+
+const (
+	None Encoding = ""
+	Gzip Encoding = "gzip"
+	Zstd Encoding = "zstd"
+)
+
+func (e Encoding) IsValid() bool {
+	switch e {
+	case None, Gzip, Zstd:
+		return true
+	default:
+		return false
+	}
+}
+
+func (e Encoding) String() string {
+	return string(e)
+}
+
+// Encode compresses data with e, data is returned as it is for None.
+func Encode(e Encoding, data []byte) ([]byte, error) {
+	switch e {
+	case None:
+		return data, nil
+	case Gzip:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	case Zstd:
+		w, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, err
+		}
+		defer w.Close()
+
+		return w.EncodeAll(data, make([]byte, 0, len(data)/2)), nil
+	default:
+		return nil, fmt.Errorf("unsupported encoding %s", e)
+	}
+}
+
+// Decode decompresses data that was compressed with e.
+func Decode(e Encoding, data []byte) ([]byte, error) {
+	switch e {
+	case None:
+		return data, nil
+	case Gzip:
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+
+		return io.ReadAll(r)
+	case Zstd:
+		r, err := zstd.NewReader(nil)
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+
+		return r.DecodeAll(data, nil)
+	default:
+		return nil, fmt.Errorf("unsupported encoding %s", e)
+	}
+}
+
+// Negotiate picks the encoding to use from an Accept-Encoding header,
+// encodings are preferred by their q value and then by the order they're
+// listed in. None is returned when no supported encoding is accepted.
+func Negotiate(acceptEncoding string) Encoding {
+	best, bestQ := None, 0.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		fields := strings.Split(part, ";")
+		e := Encoding(strings.ToLower(strings.TrimSpace(fields[0])))
+		if e == None || !e.IsValid() {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			k, v, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.TrimSpace(k) != "q" {
+				continue
+			}
+
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err == nil {
+				q = parsed
+			}
+		}
+
+		if q > bestQ {
+			best, bestQ = e, q
+		}
+	}
+
+	return best
+}
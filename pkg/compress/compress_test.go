@@ -0,0 +1,75 @@
+package compress
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	data := []byte(`{"items": [` + strings.Repeat(`{"name": "convoy", "id": 1},`, 100) + `{}]}`)
+
+	for _, e := range []Encoding{None, Gzip, Zstd} {
+		t.Run("should_round_trip_"+string(e), func(t *testing.T) {
+			encoded, err := Encode(e, data)
+			require.NoError(t, err)
+
+			if e != None {
+				require.Less(t, len(encoded), len(data))
+			}
+
+			decoded, err := Decode(e, encoded)
+			require.NoError(t, err)
+			require.Equal(t, data, decoded)
+		})
+	}
+}
+
+func TestEncode_UnsupportedEncoding(t *testing.T) {
+	_, err := Encode("br", []byte("{}"))
+	require.EqualError(t, err, "unsupported encoding br")
+
+	_, err = Decode("br", []byte("{}"))
+	require.EqualError(t, err, "unsupported encoding br")
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		want           Encoding
+	}{
+		{
+			name:           "should_not_compress_without_header",
+			acceptEncoding: "",
+			want:           None,
+		},
+		{
+			name:           "should_pick_first_listed",
+			acceptEncoding: "zstd, gzip",
+			want:           Zstd,
+		},
+		{
+			name:           "should_pick_highest_q",
+			acceptEncoding: "zstd;q=0.5, gzip",
+			want:           Gzip,
+		},
+		{
+			name:           "should_skip_unsupported",
+			acceptEncoding: "br, deflate, GZIP",
+			want:           Gzip,
+		},
+		{
+			name:           "should_skip_refused",
+			acceptEncoding: "gzip;q=0, identity",
+			want:           None,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Negotiate(tt.acceptEncoding))
+		})
+	}
+}
@@ -0,0 +1,190 @@
+// Package eventtype matches event types against the patterns subscriptions
+// filter them with.
+//
+// Event types are split into segments on ".", a pattern segment of * matches
+// exactly one segment and ** matches any number of segments, including none.
+// So invoice.* matches invoice.paid but not invoice.paid.v1, *.deleted
+// matches user.deleted and order.** matches order, order.created and
+// order.created.v1. A pattern of just * keeps matching every event type.
+//
+// Patterns starting with ! exclude the event types they match, a set of
+// patterns matches an event type when none of its exclusions match it and
+// either one of its other patterns does or it only has exclusions.
+//
+// Event types stored before patterns were validated may not parse, they
+// only match event types equal to them.
+package eventtype
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// All matches every event type.
+	All = "*"
+
+	separator = "."
+	negation  = "!"
+	anyOne    = "*"
+	anyNumber = "**"
+)
+
+var ErrEmptyPattern = errors.New("event type pattern cannot be empty")
+
+// Pattern is a parsed event type pattern.
+type Pattern struct {
+	raw      string
+	segments []string
+	negated  bool
+	all      bool
+	exact    bool
+}
+
+// Parse validates and parses a single event type pattern.
+func Parse(pattern string) (*Pattern, error) {
+	p := &Pattern{raw: pattern}
+
+	s := pattern
+	if strings.HasPrefix(s, negation) {
+		p.negated = true
+		s = s[len(negation):]
+	}
+
+	if s == "" {
+		return nil, ErrEmptyPattern
+	}
+
+	if s == All {
+		p.all = true
+		return p, nil
+	}
+
+	p.segments = strings.Split(s, separator)
+	for _, seg := range p.segments {
+		switch {
+		case seg == "":
+			return nil, fmt.Errorf("event type pattern %q has an empty segment", pattern)
+		case strings.Contains(seg, negation):
+			return nil, fmt.Errorf("event type pattern %q can only be negated with a leading %s", pattern, negation)
+		case strings.Contains(seg, anyOne) && seg != anyOne && seg != anyNumber:
+			return nil, fmt.Errorf("event type pattern %q can only use %s and %s as whole segments", pattern, anyOne, anyNumber)
+		}
+	}
+
+	return p, nil
+}
+
+// Validate checks that every pattern in patterns is valid.
+func Validate(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := Parse(pattern); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *Pattern) String() string {
+	return p.raw
+}
+
+// Negated reports whether the pattern excludes the event types it matches.
+func (p *Pattern) Negated() bool {
+	return p.negated
+}
+
+// Match reports whether eventType matches the pattern, negation is ignored.
+func (p *Pattern) Match(eventType string) bool {
+	if p.all {
+		return true
+	}
+
+	if p.exact {
+		return eventType == p.raw
+	}
+
+	return matchSegments(p.segments, strings.Split(eventType, separator))
+}
+
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	switch pattern[0] {
+	case anyNumber:
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	case anyOne:
+		return len(segments) > 0 && matchSegments(pattern[1:], segments[1:])
+	default:
+		return len(segments) > 0 && pattern[0] == segments[0] && matchSegments(pattern[1:], segments[1:])
+	}
+}
+
+// Matcher matches event types against a subscription's set of patterns.
+type Matcher struct {
+	include []*Pattern
+	exclude []*Pattern
+}
+
+// NewMatcher parses patterns, an empty set of patterns matches nothing.
+// Patterns that don't parse are matched as exact event types.
+func NewMatcher(patterns []string) *Matcher {
+	m := &Matcher{}
+	for _, pattern := range patterns {
+		p, err := Parse(pattern)
+		if err != nil {
+			p = &Pattern{raw: pattern, exact: true}
+		}
+
+		if p.negated {
+			m.exclude = append(m.exclude, p)
+		} else {
+			m.include = append(m.include, p)
+		}
+	}
+
+	return m
+}
+
+// Match reports whether eventType matches the set of patterns.
+func (m *Matcher) Match(eventType string) bool {
+	if m.excludes(eventType) {
+		return false
+	}
+
+	if len(m.include) == 0 {
+		return len(m.exclude) > 0
+	}
+
+	for _, p := range m.include {
+		if p.Match(eventType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *Matcher) excludes(eventType string) bool {
+	for _, p := range m.exclude {
+		if p.Match(eventType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Match reports whether eventType matches patterns.
+func Match(patterns []string, eventType string) bool {
+	return NewMatcher(patterns).Match(eventType)
+}
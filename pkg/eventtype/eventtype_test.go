@@ -0,0 +1,73 @@
+package eventtype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		pattern string
+		err     string
+	}{
+		{pattern: "*"},
+		{pattern: "invoice.paid"},
+		{pattern: "invoice.*"},
+		{pattern: "*.deleted"},
+		{pattern: "order.**"},
+		{pattern: "!order.test"},
+		{pattern: "", err: "event type pattern cannot be empty"},
+		{pattern: "!", err: "event type pattern cannot be empty"},
+		{pattern: "order..created", err: `event type pattern "order..created" has an empty segment`},
+		{pattern: "order.!test", err: `event type pattern "order.!test" can only be negated with a leading !`},
+		{pattern: "invoice*", err: `event type pattern "invoice*" can only use * and ** as whole segments`},
+		{pattern: "order.***", err: `event type pattern "order.***" can only use * and ** as whole segments`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			_, err := Parse(tt.pattern)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		patterns  []string
+		eventType string
+		want      bool
+	}{
+		{name: "exact", patterns: []string{"invoice.paid"}, eventType: "invoice.paid", want: true},
+		{name: "exact_mismatch", patterns: []string{"invoice.paid"}, eventType: "invoice.created"},
+		{name: "all", patterns: []string{"*"}, eventType: "invoice.paid.v1", want: true},
+		{name: "single_segment", patterns: []string{"invoice.*"}, eventType: "invoice.paid", want: true},
+		{name: "single_segment_too_deep", patterns: []string{"invoice.*"}, eventType: "invoice.paid.v1"},
+		{name: "single_segment_missing", patterns: []string{"invoice.*"}, eventType: "invoice"},
+		{name: "leading_wildcard", patterns: []string{"*.deleted"}, eventType: "user.deleted", want: true},
+		{name: "any_number", patterns: []string{"order.**"}, eventType: "order.created.v1", want: true},
+		{name: "any_number_none", patterns: []string{"order.**"}, eventType: "order", want: true},
+		{name: "any_number_mismatch", patterns: []string{"order.**"}, eventType: "orders.created"},
+		{name: "any_number_middle", patterns: []string{"order.**.v1"}, eventType: "order.created.v1", want: true},
+		{name: "negation", patterns: []string{"order.**", "!order.test"}, eventType: "order.test"},
+		{name: "negation_other", patterns: []string{"order.**", "!order.test"}, eventType: "order.created", want: true},
+		{name: "only_negations", patterns: []string{"!order.test"}, eventType: "invoice.paid", want: true},
+		{name: "none", patterns: []string{}, eventType: "invoice.paid"},
+		{name: "invalid", patterns: []string{"invoice*"}, eventType: "invoice"},
+		{name: "invalid_exact", patterns: []string{"invoice*"}, eventType: "invoice*", want: true},
+		{name: "invalid_negation", patterns: []string{"order.**", "!order.!test"}, eventType: "order.test", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Match(tt.patterns, tt.eventType))
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+)
+
+var (
+	ErrNotBoolean = errors.New("expression must evaluate to a boolean")
+
+	envOnce sync.Once
+	env     *cel.Env
+	envErr  error
+)
+
+// Input is what an expression is evaluated over, its fields are
+// available to expressions as body, headers, event_type and source.
+type Input struct {
+	// Body is the decoded event payload
+	Body interface{}
+
+	// Headers are the event's headers, only the first value of each
+	// header is kept and names are lower cased e.g. headers['x-tier']
+	Headers map[string][]string
+
+	EventType string
+
+	// SourceID and SourceName are available as source.id and source.name
+	SourceID   string
+	SourceName string
+}
+
+func (in *Input) activation() map[string]interface{} {
+	headers := make(map[string]string, len(in.Headers))
+	for k, v := range in.Headers {
+		if len(v) > 0 {
+			headers[strings.ToLower(k)] = v[0]
+		}
+	}
+
+	body := in.Body
+	if body == nil {
+		body = map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"body":       body,
+		"headers":    headers,
+		"event_type": in.EventType,
+		"source": map[string]interface{}{
+			"id":   in.SourceID,
+			"name": in.SourceName,
+		},
+	}
+}
+
+// Program is a compiled and type checked expression.
+type Program struct {
+	expr string
+	prg  cel.Program
+}
+
+func environment() (*cel.Env, error) {
+	envOnce.Do(func() {
+		env, envErr = cel.NewEnv(
+			cel.Variable("body", cel.DynType),
+			cel.Variable("headers", cel.MapType(cel.StringType, cel.StringType)),
+			cel.Variable("event_type", cel.StringType),
+			cel.Variable("source", cel.MapType(cel.StringType, cel.StringType)),
+			cel.CrossTypeNumericComparisons(true),
+		)
+	})
+
+	return env, envErr
+}
+
+// Compile parses and type checks expr, the error points at the line and
+// column of every issue found.
+func Compile(expr string) (*Program, error) {
+	e, err := environment()
+	if err != nil {
+		return nil, err
+	}
+
+	ast, issues := e.Compile(expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+
+	if t := ast.OutputType(); t != cel.BoolType && t != cel.DynType {
+		return nil, fmt.Errorf("%w, got %s", ErrNotBoolean, t)
+	}
+
+	prg, err := e.Program(ast)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Program{expr: expr, prg: prg}, nil
+}
+
+func (p *Program) String() string {
+	return p.expr
+}
+
+// Eval evaluates the expression over in, a missing field or a value of
+// the wrong type is an error rather than a match.
+func (p *Program) Eval(in *Input) (bool, error) {
+	out, _, err := p.prg.Eval(in.activation())
+	if err != nil {
+		return false, err
+	}
+
+	matched, ok := out.Value().(bool)
+	if !ok {
+		return false, ErrNotBoolean
+	}
+
+	return matched, nil
+}
@@ -0,0 +1,119 @@
+package expression
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		err  string
+	}{
+		{
+			name: "should_compile",
+			expr: `body.amount > 100 && body.currency in ['USD', 'EUR'] || headers['x-tier'] == 'gold'`,
+		},
+		{
+			name: "should_compile_with_event_type_and_source",
+			expr: `event_type.startsWith('invoice.') && source.name == 'stripe'`,
+		},
+		{
+			name: "should_reject_syntax_errors",
+			expr: `body.amount >`,
+			err:  "ERROR: <input>:1:14: Syntax error: mismatched input '<EOF>' expecting {'[', '{', '(', '.', '-', '!', 'true', 'false', 'null', NUM_FLOAT, NUM_INT, NUM_UINT, STRING, BYTES, IDENTIFIER}\n | body.amount >\n | .............^",
+		},
+		{
+			name: "should_reject_undeclared_references",
+			expr: `amount > 100`,
+			err:  "ERROR: <input>:1:1: undeclared reference to 'amount' (in container '')\n | amount > 100\n | ^",
+		},
+		{
+			name: "should_reject_non_boolean_expressions",
+			expr: `event_type + '.v1'`,
+			err:  "expression must evaluate to a boolean, got string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile(tt.expr)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestProgram_Eval(t *testing.T) {
+	var body interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"amount": 250, "currency": "USD", "items": [{"sku": "a"}]}`), &body))
+
+	in := &Input{
+		Body:       body,
+		Headers:    map[string][]string{"X-Tier": {"gold"}},
+		EventType:  "invoice.paid",
+		SourceID:   "source-1",
+		SourceName: "stripe",
+	}
+
+	tests := []struct {
+		name    string
+		expr    string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "should_match_body",
+			expr: `body.amount > 100 && body.currency in ['USD', 'EUR']`,
+			want: true,
+		},
+		{
+			name: "should_match_lower_cased_headers",
+			expr: `body.amount > 1000 || headers['x-tier'] == 'gold'`,
+			want: true,
+		},
+		{
+			name: "should_match_event_type_and_source",
+			expr: `event_type == 'invoice.paid' && source.id == 'source-1' && source.name == 'stripe'`,
+			want: true,
+		},
+		{
+			name: "should_match_lists",
+			expr: `body.items.exists(i, i.sku == 'b')`,
+			want: false,
+		},
+		{
+			name: "should_check_for_missing_fields",
+			expr: `has(body.discount) && body.discount > 0`,
+			want: false,
+		},
+		{
+			name:    "should_error_for_missing_fields",
+			expr:    `body.discount > 0`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Compile(tt.expr)
+			require.NoError(t, err)
+
+			got, err := p.Eval(in)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -157,6 +157,14 @@ func isKeyValidOperator(op string) bool {
 		"$or",
 		"$and",
 		"$exist",
+		"$regex",
+		"$prefix",
+		"$suffix",
+		"$contains",
+		"$ieq",
+		"$size",
+		"$elemMatch",
+		"$not",
 	}
 
 	for _, o := range operators {
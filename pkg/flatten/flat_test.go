@@ -532,6 +532,29 @@ func TestFlattenWithOperator(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "string, array and negated operators",
+			given: `{
+				"person": {
+					"email": {"$not": {"$suffix": "@example.com"}},
+					"name": {"$regex": "^ray", "$ieq": "RAYMOND"}
+				},
+				"orders": {"$size": {"$gte": 1}, "$elemMatch": {"status": "paid"}}
+			}`,
+			want: map[string]interface{}{
+				"person.email": map[string]interface{}{
+					"$not": map[string]interface{}{"$suffix": "@example.com"},
+				},
+				"person.name": map[string]interface{}{
+					"$regex": "^ray",
+					"$ieq":   "RAYMOND",
+				},
+				"orders": map[string]interface{}{
+					"$size":      map[string]interface{}{"$gte": float64(1)},
+					"$elemMatch": map[string]interface{}{"status": "paid"},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,147 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// MaxSchemaSize is the largest json schema an event type can have.
+const MaxSchemaSize = 64 * 1024
+
+// schemaURL is the url a schema is compiled under, it only needs to be
+// an absolute url so relative refs within the schema can be resolved.
+const schemaURL = "convoy://event-type.json"
+
+var (
+	ErrSchemaTooLarge = fmt.Errorf("json schema cannot be larger than %d bytes", MaxSchemaSize)
+	ErrInvalidPayload = errors.New("payload is not valid json")
+)
+
+// ValidationError lists every way a payload failed to match a schema.
+type ValidationError struct {
+	Errors []string
+}
+
+func (v *ValidationError) Error() string {
+	return fmt.Sprintf("payload does not match schema: %s", strings.Join(v.Errors, "; "))
+}
+
+// IsEmpty reports whether there's no schema to validate against.
+func IsEmpty(schema json.RawMessage) bool {
+	s := bytes.TrimSpace(schema)
+	return len(s) == 0 || string(s) == "null"
+}
+
+// Compile parses a json schema, documents referenced by $ref are never
+// fetched, refs can only point within the schema itself.
+func Compile(schema json.RawMessage) (*jsonschema.Schema, error) {
+	if len(schema) > MaxSchemaSize {
+		return nil, ErrSchemaTooLarge
+	}
+
+	c := jsonschema.NewCompiler()
+	c.Draft = jsonschema.Draft2020
+	c.AssertFormat = true
+	c.LoadURL = func(s string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("loading %s is not allowed", s)
+	}
+
+	err := c.AddResource(schemaURL, bytes.NewReader(schema))
+	if err != nil {
+		return nil, errors.New("invalid json schema: schema is not valid json")
+	}
+
+	s, err := c.Compile(schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid json schema: %v", describe(err))
+	}
+
+	return s, nil
+}
+
+// Validate checks payload against schema, a payload that doesn't match
+// returns a *ValidationError. An empty schema matches every payload.
+func Validate(schema json.RawMessage, payload json.RawMessage) error {
+	if IsEmpty(schema) {
+		return nil
+	}
+
+	s, err := Compile(schema)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return ErrInvalidPayload
+	}
+
+	err = s.Validate(v)
+	if err == nil {
+		return nil
+	}
+
+	var verr *jsonschema.ValidationError
+	if !errors.As(err, &verr) {
+		return err
+	}
+
+	return &ValidationError{Errors: leaves(verr)}
+}
+
+// leaves flattens a validation error to the errors that caused it, the
+// wrapping errors only say that a subschema failed.
+func leaves(err *jsonschema.ValidationError) []string {
+	seen := map[string]struct{}{}
+	var walk func(e *jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) == 0 {
+			location := e.InstanceLocation
+			if location == "" {
+				location = "/"
+			}
+
+			seen[fmt.Sprintf("%s: %s", location, e.Message)] = struct{}{}
+			return
+		}
+
+		for _, cause := range e.Causes {
+			walk(cause)
+		}
+	}
+	walk(err)
+
+	errs := make([]string, 0, len(seen))
+	for e := range seen {
+		errs = append(errs, e)
+	}
+	sort.Strings(errs)
+
+	return errs
+}
+
+// describe drops the compiler's wrapping so errors don't mention the
+// internal url the schema was compiled under.
+func describe(err error) string {
+	var serr *jsonschema.SchemaError
+	if errors.As(err, &serr) && serr.Err != nil {
+		var verr *jsonschema.ValidationError
+		if errors.As(serr.Err, &verr) {
+			return strings.Join(leaves(verr), "; ")
+		}
+
+		return serr.Err.Error()
+	}
+
+	return err.Error()
+}
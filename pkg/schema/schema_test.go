@@ -0,0 +1,96 @@
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const userSchema = `{
+	"type": "object",
+	"properties": {
+		"id": {"type": "string"},
+		"email": {"type": "string", "format": "email"},
+		"age": {"type": "integer", "minimum": 0}
+	},
+	"required": ["id", "email"]
+}`
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   string
+		payload  string
+		wantErrs []string
+		wantErr  error
+	}{
+		{
+			name:    "should_match_schema",
+			schema:  userSchema,
+			payload: `{"id":"usr_1","email":"ada@example.com","age":36}`,
+		},
+		{
+			name:    "should_list_every_error",
+			schema:  userSchema,
+			payload: `{"id":1,"email":"ada","age":-1}`,
+			wantErrs: []string{
+				"/age: must be >= 0 but found -1",
+				"/email: 'ada' is not valid 'email'",
+				"/id: expected string, but got number",
+			},
+		},
+		{
+			name:     "should_report_missing_properties_at_root",
+			schema:   userSchema,
+			payload:  `{}`,
+			wantErrs: []string{"/: missing properties: 'id', 'email'"},
+		},
+		{
+			name:    "should_match_anything_without_schema",
+			schema:  `null`,
+			payload: `[1, 2, 3]`,
+		},
+		{
+			name:    "should_reject_invalid_payload",
+			schema:  userSchema,
+			payload: `{"id":`,
+			wantErr: ErrInvalidPayload,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(json.RawMessage(tc.schema), json.RawMessage(tc.payload))
+
+			switch {
+			case tc.wantErr != nil:
+				require.ErrorIs(t, err, tc.wantErr)
+			case tc.wantErrs != nil:
+				var verr *ValidationError
+				require.ErrorAs(t, err, &verr)
+				require.Equal(t, tc.wantErrs, verr.Errors)
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCompile(t *testing.T) {
+	_, err := Compile(json.RawMessage(`{"$schema":"http://json-schema.org/draft-07/schema#","type":"object"}`))
+	require.NoError(t, err)
+
+	_, err = Compile(json.RawMessage(`{"$defs":{"id":{"type":"string"}},"properties":{"id":{"$ref":"#/$defs/id"}}}`))
+	require.NoError(t, err)
+
+	_, err = Compile(json.RawMessage(`{"type":`))
+	require.EqualError(t, err, "invalid json schema: schema is not valid json")
+
+	_, err = Compile(json.RawMessage(`{"$ref":"file:///etc/passwd"}`))
+	require.EqualError(t, err, "invalid json schema: loading file:///etc/passwd is not allowed")
+
+	_, err = Compile(json.RawMessage(`{"description":"` + strings.Repeat("a", MaxSchemaSize) + `"}`))
+	require.ErrorIs(t, err, ErrSchemaTooLarge)
+}
@@ -0,0 +1,504 @@
+package scim
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Filter is a parsed SCIM filter, see RFC 7644 section 3.4.2.2.
+type Filter interface {
+	// Match reports whether the JSON form of a resource matches the filter.
+	Match(resource map[string]interface{}) bool
+}
+
+// ParseFilter parses a filter such as
+//
+//	userName eq "bjensen" and (emails co "example.com" or not (active pr))
+//
+// Every operator but sorting is supported, along with value paths like
+// emails[type eq "work"]. String comparisons are case insensitive.
+func ParseFilter(filter string) (Filter, error) {
+	tokens, err := lex(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, BadRequest(ErrInvalidFilter, "unexpected %q in filter", t.text)
+	}
+
+	return f, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenLParen
+	tokenRParen
+	tokenLBracket
+	tokenRBracket
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func lex(s string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")"})
+			i++
+		case c == '[':
+			tokens = append(tokens, token{kind: tokenLBracket, text: "["})
+			i++
+		case c == ']':
+			tokens = append(tokens, token{kind: tokenRBracket, text: "]"})
+			i++
+		case c == '"':
+			j := i + 1
+			for ; j < len(s) && s[j] != '"'; j++ {
+				if s[j] == '\\' {
+					j++
+				}
+			}
+
+			if j >= len(s) {
+				return nil, BadRequest(ErrInvalidFilter, "unterminated string in filter")
+			}
+
+			var v string
+			if err := json.Unmarshal([]byte(s[i:j+1]), &v); err != nil {
+				return nil, BadRequest(ErrInvalidFilter, "invalid string %s in filter", s[i:j+1])
+			}
+
+			tokens = append(tokens, token{kind: tokenString, text: v})
+			i = j + 1
+		default:
+			j := i
+			for ; j < len(s) && !strings.ContainsRune(" \t\n\r()[]\"", rune(s[j])); j++ {
+			}
+
+			tokens = append(tokens, token{kind: tokenWord, text: s[i:j]})
+			i = j
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) keyword(k string) bool {
+	t := p.peek()
+	return t.kind == tokenWord && strings.EqualFold(t.text, k)
+}
+
+func (p *parser) expect(kind tokenKind, text string) error {
+	if t := p.next(); t.kind != kind {
+		return BadRequest(ErrInvalidFilter, "expected %q in filter", text)
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("or") {
+		p.next()
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &orFilter{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Filter, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("and") {
+		p.next()
+
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &andFilter{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseNot() (Filter, error) {
+	if !p.keyword("not") {
+		return p.parseAtom()
+	}
+	p.next()
+
+	if err := p.expect(tokenLParen, "("); err != nil {
+		return nil, err
+	}
+
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(tokenRParen, ")"); err != nil {
+		return nil, err
+	}
+
+	return &notFilter{filter: f}, nil
+}
+
+func (p *parser) parseAtom() (Filter, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenLParen:
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(tokenRParen, ")"); err != nil {
+			return nil, err
+		}
+
+		return f, nil
+	case tokenWord:
+	default:
+		return nil, BadRequest(ErrInvalidFilter, "expected an attribute in filter, got %q", t.text)
+	}
+
+	path, err := parseAttrPath(t.text)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind == tokenLBracket {
+		p.next()
+
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(tokenRBracket, "]"); err != nil {
+			return nil, err
+		}
+
+		return &valuePathFilter{path: path, filter: f}, nil
+	}
+
+	op := p.next()
+	if op.kind != tokenWord {
+		return nil, BadRequest(ErrInvalidFilter, "expected an operator after %s in filter", t.text)
+	}
+
+	expr := &attrFilter{path: path, op: strings.ToLower(op.text)}
+	switch expr.op {
+	case "pr":
+		return expr, nil
+	case "eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le":
+	default:
+		return nil, BadRequest(ErrInvalidFilter, "unknown operator %q in filter", op.text)
+	}
+
+	v := p.next()
+	switch v.kind {
+	case tokenString:
+		expr.value = v.text
+	case tokenWord:
+		expr.value, err = parseLiteral(v.text)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, BadRequest(ErrInvalidFilter, "expected a value after %s %s in filter", t.text, op.text)
+	}
+
+	return expr, nil
+}
+
+func parseLiteral(s string) (interface{}, error) {
+	switch strings.ToLower(s) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, BadRequest(ErrInvalidFilter, "invalid value %q in filter", s)
+	}
+
+	return f, nil
+}
+
+// attrPath is an attribute and its sub-attributes, an extension attribute
+// is kept under its schema URN, the way it appears in the JSON form.
+type attrPath []string
+
+func parseAttrPath(s string) (attrPath, error) {
+	var path attrPath
+
+	if strings.HasPrefix(strings.ToLower(s), "urn:") {
+		i := strings.LastIndex(s, ":")
+		uri := s[:i]
+		s = s[i+1:]
+
+		if !strings.EqualFold(uri, UserSchema) && !strings.EqualFold(uri, GroupSchema) {
+			path = append(path, uri)
+		}
+	}
+
+	for _, seg := range strings.Split(s, ".") {
+		if seg == "" {
+			return nil, BadRequest(ErrInvalidPath, "invalid attribute path %q", s)
+		}
+		path = append(path, seg)
+	}
+
+	return path, nil
+}
+
+// lookup returns the values at path, multi-valued attributes along the
+// way are flattened.
+func lookup(v interface{}, path attrPath) []interface{} {
+	switch t := v.(type) {
+	case []interface{}:
+		var values []interface{}
+		for _, e := range t {
+			values = append(values, lookup(e, path)...)
+		}
+		return values
+	case map[string]interface{}:
+		if len(path) == 0 {
+			return []interface{}{t}
+		}
+
+		k, ok := findKey(t, path[0])
+		if !ok {
+			return nil
+		}
+
+		return lookup(t[k], path[1:])
+	default:
+		if len(path) == 0 {
+			return []interface{}{t}
+		}
+		return nil
+	}
+}
+
+func findKey(m map[string]interface{}, name string) (string, bool) {
+	if _, ok := m[name]; ok {
+		return name, true
+	}
+
+	for k := range m {
+		if strings.EqualFold(k, name) {
+			return k, true
+		}
+	}
+
+	return "", false
+}
+
+type andFilter struct {
+	left, right Filter
+}
+
+func (f *andFilter) Match(resource map[string]interface{}) bool {
+	return f.left.Match(resource) && f.right.Match(resource)
+}
+
+type orFilter struct {
+	left, right Filter
+}
+
+func (f *orFilter) Match(resource map[string]interface{}) bool {
+	return f.left.Match(resource) || f.right.Match(resource)
+}
+
+type notFilter struct {
+	filter Filter
+}
+
+func (f *notFilter) Match(resource map[string]interface{}) bool {
+	return !f.filter.Match(resource)
+}
+
+type valuePathFilter struct {
+	path   attrPath
+	filter Filter
+}
+
+func (f *valuePathFilter) Match(resource map[string]interface{}) bool {
+	for _, v := range lookup(resource, f.path) {
+		if m, ok := v.(map[string]interface{}); ok && f.filter.Match(m) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type attrFilter struct {
+	path  attrPath
+	op    string
+	value interface{}
+}
+
+func (f *attrFilter) Match(resource map[string]interface{}) bool {
+	values := lookup(resource, f.path)
+
+	if f.op == "eq" && f.value == nil {
+		return !(&attrFilter{path: f.path, op: "pr"}).Match(resource)
+	}
+
+	switch f.op {
+	case "pr":
+		for _, v := range values {
+			if present(v) {
+				return true
+			}
+		}
+		return false
+	case "ne":
+		return !(&attrFilter{path: f.path, op: "eq", value: f.value}).Match(resource)
+	}
+
+	for _, v := range values {
+		if compare(v, f.op, f.value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func present(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return false
+	case string:
+		return t != ""
+	case map[string]interface{}:
+		return len(t) > 0
+	default:
+		return true
+	}
+}
+
+// compare compares an attribute's value with a filter's value, a complex
+// value is compared by its value sub-attribute.
+func compare(v interface{}, op string, want interface{}) bool {
+	if m, ok := v.(map[string]interface{}); ok {
+		k, ok := findKey(m, "value")
+		if !ok {
+			return false
+		}
+		v = m[k]
+	}
+
+	switch w := want.(type) {
+	case bool:
+		b, ok := v.(bool)
+		return ok && op == "eq" && b == w
+	case float64:
+		n, ok := v.(float64)
+		if !ok {
+			return false
+		}
+
+		switch op {
+		case "eq":
+			return n == w
+		case "gt":
+			return n > w
+		case "ge":
+			return n >= w
+		case "lt":
+			return n < w
+		case "le":
+			return n <= w
+		}
+	case string:
+		s, ok := v.(string)
+		if !ok {
+			return false
+		}
+
+		s, w = strings.ToLower(s), strings.ToLower(w)
+		switch op {
+		case "eq":
+			return s == w
+		case "co":
+			return strings.Contains(s, w)
+		case "sw":
+			return strings.HasPrefix(s, w)
+		case "ew":
+			return strings.HasSuffix(s, w)
+		case "gt":
+			return s > w
+		case "ge":
+			return s >= w
+		case "lt":
+			return s < w
+		case "le":
+			return s <= w
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,100 @@
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testUser(t *testing.T) map[string]interface{} {
+	active := Boolean(true)
+	m, err := ToMap(&User{
+		Schemas:    []string{UserSchema},
+		ID:         "01H0000000000000000000000",
+		ExternalID: "00u1",
+		UserName:   "Barbara.Jensen@example.com",
+		Name:       &Name{GivenName: "Barbara", FamilyName: "Jensen"},
+		Emails: []Email{
+			{Value: "barbara.jensen@example.com", Type: "work", Primary: true},
+			{Value: "babs@home.example.org", Type: "home"},
+		},
+		Active: &active,
+		Groups: []Reference{{Value: "g1", Display: "Engineering"}},
+	})
+	require.NoError(t, err)
+
+	return m
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   bool
+		err    string
+	}{
+		{filter: `userName eq "barbara.jensen@example.com"`, want: true},
+		{filter: `USERNAME Eq "BARBARA.JENSEN@EXAMPLE.COM"`, want: true},
+		{filter: `urn:ietf:params:scim:schemas:core:2.0:User:userName sw "barbara"`, want: true},
+		{filter: `userName eq "bjensen"`},
+		{filter: `userName ne "bjensen"`, want: true},
+		{filter: `externalId eq "00u1"`, want: true},
+		{filter: `name.familyName co "ens"`, want: true},
+		{filter: `name.givenName ew "ara"`, want: true},
+		{filter: `emails co "home.example.org"`, want: true},
+		{filter: `emails.value ew "example.com"`, want: true},
+		{filter: `emails[type eq "work" and value co "@example.com"]`, want: true},
+		{filter: `emails[type eq "other"]`},
+		{filter: `groups[value eq "g1"]`, want: true},
+		{filter: `active eq true`, want: true},
+		{filter: `active eq false`},
+		{filter: `title pr`},
+		{filter: `not (title pr)`, want: true},
+		{filter: `displayName eq null`, want: true},
+		{filter: `userName eq "bjensen" or externalId eq "00u1"`, want: true},
+		{filter: `userName eq "bjensen" or externalId eq "00u1" and active eq false`},
+		{filter: `(userName eq "bjensen" or externalId eq "00u1") and active eq true`, want: true},
+		{filter: `id gt "01G" and id lt "01J"`, want: true},
+		{filter: `userName eq "with \"quotes\""`},
+		{filter: `userName`, err: "expected an operator after userName in filter"},
+		{filter: `userName eq`, err: "expected a value after userName eq in filter"},
+		{filter: `userName like "b"`, err: `unknown operator "like" in filter`},
+		{filter: `userName eq bjensen`, err: `invalid value "bjensen" in filter`},
+		{filter: `userName eq "bjensen`, err: "unterminated string in filter"},
+		{filter: `(userName pr`, err: `expected ")" in filter`},
+		{filter: `userName pr userName`, err: `unexpected "userName" in filter`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			f, err := ParseFilter(tt.filter)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+
+				var scimErr *Error
+				require.ErrorAs(t, err, &scimErr)
+				require.Equal(t, ErrInvalidFilter, scimErr.ScimType)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, f.Match(testUser(t)))
+		})
+	}
+}
+
+func TestNewListResponse(t *testing.T) {
+	resources := []interface{}{"a", "b", "c"}
+
+	l := NewListResponse(resources, 2, 1)
+	require.Equal(t, 3, l.TotalResults)
+	require.Equal(t, 2, l.StartIndex)
+	require.Equal(t, []interface{}{"b"}, l.Resources)
+
+	l = NewListResponse(resources, 0, 10)
+	require.Equal(t, 1, l.StartIndex)
+	require.Len(t, l.Resources, 3)
+
+	l = NewListResponse(resources, 5, 10)
+	require.Empty(t, l.Resources)
+	require.Equal(t, 0, l.ItemsPerPage)
+}
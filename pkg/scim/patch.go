@@ -0,0 +1,354 @@
+package scim
+
+import (
+	"reflect"
+	"strings"
+)
+
+const (
+	PatchAdd     = "add"
+	PatchRemove  = "remove"
+	PatchReplace = "replace"
+)
+
+// PatchRequest is a PATCH request body, see RFC 7644 section 3.5.2.
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+func (r *PatchRequest) Validate() error {
+	hasSchema := false
+	for _, s := range r.Schemas {
+		if strings.EqualFold(s, PatchOpSchema) {
+			hasSchema = true
+		}
+	}
+
+	if !hasSchema {
+		return BadRequest(ErrInvalidSyntax, "patch request must use the %s schema", PatchOpSchema)
+	}
+
+	if len(r.Operations) == 0 {
+		return BadRequest(ErrInvalidSyntax, "patch request has no operations")
+	}
+
+	for _, o := range r.Operations {
+		switch strings.ToLower(o.Op) {
+		case PatchAdd, PatchReplace:
+		case PatchRemove:
+			if o.Path == "" {
+				return BadRequest(ErrNoTarget, "remove operations need a path")
+			}
+		default:
+			return BadRequest(ErrInvalidSyntax, "unknown patch operation %q", o.Op)
+		}
+	}
+
+	return nil
+}
+
+// Apply applies the operations in order to the JSON form of a resource.
+// Read-only attributes aren't guarded here, callers decode the result back
+// into the resource and decide what may change.
+func (r *PatchRequest) Apply(resource map[string]interface{}) error {
+	for _, o := range r.Operations {
+		err := o.apply(resource)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o PatchOperation) apply(resource map[string]interface{}) error {
+	op := strings.ToLower(o.Op)
+
+	if o.Path == "" {
+		return applyValues(resource, op, o.Value)
+	}
+
+	attr, filterExpr, sub, err := splitPatchPath(o.Path)
+	if err != nil {
+		return err
+	}
+
+	path, err := parseAttrPath(attr)
+	if err != nil {
+		return err
+	}
+
+	parent, err := navigate(resource, path[:len(path)-1], op != PatchRemove)
+	if err != nil || parent == nil {
+		return err
+	}
+
+	key := path[len(path)-1]
+	if k, ok := findKey(parent, key); ok {
+		key = k
+	}
+
+	if filterExpr == "" {
+		if op == PatchRemove {
+			return removeValues(parent, key, o.Value)
+		}
+
+		return setValue(parent, key, o.Value, op)
+	}
+
+	filter, err := ParseFilter(filterExpr)
+	if err != nil {
+		return BadRequest(ErrInvalidPath, "invalid filter in path %q: %v", o.Path, err)
+	}
+
+	return applyFiltered(parent, key, filter, sub, op, o.Value)
+}
+
+// applyValues applies an operation without a path, the value holds the
+// attributes to add or replace.
+func applyValues(resource map[string]interface{}, op string, value interface{}) error {
+	values, ok := value.(map[string]interface{})
+	if !ok {
+		return BadRequest(ErrInvalidValue, "operations without a path need an object value")
+	}
+
+	for k, v := range values {
+		if strings.EqualFold(k, UserSchema) || strings.EqualFold(k, GroupSchema) {
+			err := applyValues(resource, op, v)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if strings.EqualFold(k, GroupExtensionSchema) {
+			err := setValue(resource, k, v, op)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		err := PatchOperation{Op: op, Path: k, Value: v}.apply(resource)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func splitPatchPath(p string) (attr string, filter string, sub string, err error) {
+	i := strings.Index(p, "[")
+	if i < 0 {
+		return p, "", "", nil
+	}
+
+	j := strings.LastIndex(p, "]")
+	if j < i {
+		return "", "", "", BadRequest(ErrInvalidPath, "invalid path %q", p)
+	}
+
+	rest := p[j+1:]
+	if rest != "" {
+		if !strings.HasPrefix(rest, ".") || len(rest) == 1 {
+			return "", "", "", BadRequest(ErrInvalidPath, "invalid path %q", p)
+		}
+		sub = rest[1:]
+	}
+
+	return p[:i], p[i+1 : j], sub, nil
+}
+
+// navigate walks path from m, creating missing complex attributes when
+// create is set, it returns nil when a parent is missing and create isn't.
+func navigate(m map[string]interface{}, path attrPath, create bool) (map[string]interface{}, error) {
+	for _, seg := range path {
+		k, ok := findKey(m, seg)
+		if !ok {
+			if !create {
+				return nil, nil
+			}
+
+			child := map[string]interface{}{}
+			m[seg] = child
+			m = child
+			continue
+		}
+
+		child, ok := m[k].(map[string]interface{})
+		if !ok {
+			return nil, BadRequest(ErrInvalidPath, "%s is not a complex attribute", seg)
+		}
+		m = child
+	}
+
+	return m, nil
+}
+
+// setValue adds or replaces m[key]. Values added to a multi-valued
+// attribute are appended unless already present, sub-attributes given for
+// a complex attribute are merged into it.
+func setValue(m map[string]interface{}, key string, value interface{}, op string) error {
+	if k, ok := findKey(m, key); ok {
+		key = k
+	}
+	existing := m[key]
+
+	if arr, ok := existing.([]interface{}); ok && op == PatchAdd {
+		values, ok := value.([]interface{})
+		if !ok {
+			values = []interface{}{value}
+		}
+
+		for _, v := range values {
+			if indexOf(arr, v) < 0 {
+				arr = append(arr, v)
+			}
+		}
+
+		m[key] = arr
+		return nil
+	}
+
+	if current, ok := existing.(map[string]interface{}); ok {
+		if values, ok := value.(map[string]interface{}); ok {
+			for k, v := range values {
+				err := setValue(current, k, v, op)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	m[key] = value
+	return nil
+}
+
+// removeValues removes m[key], or only the given values when key is a
+// multi-valued attribute.
+func removeValues(m map[string]interface{}, key string, value interface{}) error {
+	arr, ok := m[key].([]interface{})
+	if !ok || value == nil {
+		delete(m, key)
+		return nil
+	}
+
+	values, ok := value.([]interface{})
+	if !ok {
+		values = []interface{}{value}
+	}
+
+	kept := make([]interface{}, 0, len(arr))
+	for _, e := range arr {
+		if indexOf(values, e) < 0 {
+			kept = append(kept, e)
+		}
+	}
+
+	m[key] = kept
+	return nil
+}
+
+func applyFiltered(m map[string]interface{}, key string, filter Filter, sub string, op string, value interface{}) error {
+	arr, _ := m[key].([]interface{})
+
+	var matched []map[string]interface{}
+	kept := make([]interface{}, 0, len(arr))
+	for _, e := range arr {
+		elem, ok := e.(map[string]interface{})
+		if ok && filter.Match(elem) {
+			matched = append(matched, elem)
+			if op == PatchRemove && sub == "" {
+				continue
+			}
+		}
+		kept = append(kept, e)
+	}
+
+	if op == PatchRemove {
+		if sub == "" {
+			m[key] = kept
+			return nil
+		}
+
+		for _, elem := range matched {
+			if k, ok := findKey(elem, sub); ok {
+				delete(elem, k)
+			}
+		}
+		return nil
+	}
+
+	if len(matched) == 0 {
+		// emails[type eq "work"].value on a user without a work email adds
+		// one, other filters need something to match.
+		f, ok := filter.(*attrFilter)
+		if !ok || f.op != "eq" || len(f.path) != 1 {
+			return BadRequest(ErrNoTarget, "no values of %s match the path's filter", key)
+		}
+
+		elem := map[string]interface{}{f.path[0]: f.value}
+		m[key] = append(arr, elem)
+		matched = append(matched, elem)
+	}
+
+	for _, elem := range matched {
+		if sub != "" {
+			err := setValue(elem, sub, value, op)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		values, ok := value.(map[string]interface{})
+		if !ok {
+			return BadRequest(ErrInvalidValue, "values of %s must be objects", key)
+		}
+
+		for k, v := range values {
+			err := setValue(elem, k, v, op)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// indexOf finds v in values, complex values with a value sub-attribute
+// are compared by it alone.
+func indexOf(values []interface{}, v interface{}) int {
+	for i, e := range values {
+		if sameValue(e, v) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func sameValue(a, b interface{}) bool {
+	am, aok := a.(map[string]interface{})
+	bm, bok := b.(map[string]interface{})
+	if aok && bok {
+		ak, aok := findKey(am, "value")
+		bk, bok := findKey(bm, "value")
+		if aok && bok {
+			return reflect.DeepEqual(am[ak], bm[bk])
+		}
+	}
+
+	return reflect.DeepEqual(a, b)
+}
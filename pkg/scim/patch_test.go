@@ -0,0 +1,200 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		req  PatchRequest
+		err  string
+	}{
+		{
+			name: "valid",
+			req:  PatchRequest{Schemas: []string{PatchOpSchema}, Operations: []PatchOperation{{Op: "Replace", Path: "active", Value: false}}},
+		},
+		{
+			name: "missing_schema",
+			req:  PatchRequest{Operations: []PatchOperation{{Op: "add", Path: "active", Value: false}}},
+			err:  "patch request must use the urn:ietf:params:scim:api:messages:2.0:PatchOp schema",
+		},
+		{
+			name: "no_operations",
+			req:  PatchRequest{Schemas: []string{PatchOpSchema}},
+			err:  "patch request has no operations",
+		},
+		{
+			name: "unknown_operation",
+			req:  PatchRequest{Schemas: []string{PatchOpSchema}, Operations: []PatchOperation{{Op: "move", Path: "active"}}},
+			err:  `unknown patch operation "move"`,
+		},
+		{
+			name: "remove_without_path",
+			req:  PatchRequest{Schemas: []string{PatchOpSchema}, Operations: []PatchOperation{{Op: "remove"}}},
+			err:  "remove operations need a path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPatchRequest_Apply(t *testing.T) {
+	tests := []struct {
+		name       string
+		resource   string
+		operations string
+		want       string
+		err        string
+	}{
+		{
+			name:       "replace_attribute",
+			resource:   `{"userName":"bjensen","active":true}`,
+			operations: `[{"op":"Replace","path":"active","value":false}]`,
+			want:       `{"userName":"bjensen","active":false}`,
+		},
+		{
+			name:       "replace_without_path",
+			resource:   `{"userName":"bjensen","name":{"givenName":"Barbara","familyName":"Jensen"}}`,
+			operations: `[{"op":"replace","value":{"USERNAME":"babs","name.givenName":"Babs"}}]`,
+			want:       `{"userName":"babs","name":{"givenName":"Babs","familyName":"Jensen"}}`,
+		},
+		{
+			name:       "replace_merges_complex_attribute",
+			resource:   `{"name":{"givenName":"Barbara","familyName":"Jensen"}}`,
+			operations: `[{"op":"replace","path":"name","value":{"familyName":"Smith"}}]`,
+			want:       `{"name":{"givenName":"Barbara","familyName":"Smith"}}`,
+		},
+		{
+			name:       "add_sub_attribute",
+			resource:   `{"userName":"bjensen"}`,
+			operations: `[{"op":"add","path":"name.familyName","value":"Jensen"}]`,
+			want:       `{"userName":"bjensen","name":{"familyName":"Jensen"}}`,
+		},
+		{
+			name:       "add_members",
+			resource:   `{"displayName":"Engineering","members":[{"value":"u1"}]}`,
+			operations: `[{"op":"add","path":"members","value":[{"value":"u1"},{"value":"u2","display":"Babs"}]}]`,
+			want:       `{"displayName":"Engineering","members":[{"value":"u1"},{"value":"u2","display":"Babs"}]}`,
+		},
+		{
+			name:       "replace_members",
+			resource:   `{"displayName":"Engineering","members":[{"value":"u1"}]}`,
+			operations: `[{"op":"replace","path":"members","value":[{"value":"u2"}]}]`,
+			want:       `{"displayName":"Engineering","members":[{"value":"u2"}]}`,
+		},
+		{
+			name:       "remove_member_by_filter",
+			resource:   `{"members":[{"value":"u1"},{"value":"u2"}]}`,
+			operations: `[{"op":"remove","path":"members[value eq \"u1\"]"}]`,
+			want:       `{"members":[{"value":"u2"}]}`,
+		},
+		{
+			name:       "remove_member_by_value",
+			resource:   `{"members":[{"value":"u1"},{"value":"u2"}]}`,
+			operations: `[{"op":"remove","path":"members","value":[{"value":"u2"}]}]`,
+			want:       `{"members":[{"value":"u1"}]}`,
+		},
+		{
+			name:       "remove_attribute",
+			resource:   `{"userName":"bjensen","externalId":"00u1"}`,
+			operations: `[{"op":"remove","path":"externalId"}]`,
+			want:       `{"userName":"bjensen"}`,
+		},
+		{
+			name:       "replace_filtered_sub_attribute",
+			resource:   `{"emails":[{"value":"a@example.com","type":"work"},{"value":"b@example.com","type":"home"}]}`,
+			operations: `[{"op":"replace","path":"emails[type eq \"work\"].value","value":"c@example.com"}]`,
+			want:       `{"emails":[{"value":"c@example.com","type":"work"},{"value":"b@example.com","type":"home"}]}`,
+		},
+		{
+			name:       "add_filtered_sub_attribute_creates_value",
+			resource:   `{"userName":"bjensen"}`,
+			operations: `[{"op":"add","path":"emails[type eq \"work\"].value","value":"a@example.com"}]`,
+			want:       `{"userName":"bjensen","emails":[{"type":"work","value":"a@example.com"}]}`,
+		},
+		{
+			name:       "replace_extension_attribute",
+			resource:   `{"displayName":"Engineering","urn:convoy:scim:schemas:extension:2.0:Group":{"role":"member"}}`,
+			operations: `[{"op":"replace","path":"urn:convoy:scim:schemas:extension:2.0:Group:role","value":"admin"}]`,
+			want:       `{"displayName":"Engineering","urn:convoy:scim:schemas:extension:2.0:Group":{"role":"admin"}}`,
+		},
+		{
+			name:       "replace_extension_without_path",
+			resource:   `{"displayName":"Engineering"}`,
+			operations: `[{"op":"replace","value":{"urn:convoy:scim:schemas:extension:2.0:Group":{"role":"admin"}}}]`,
+			want:       `{"displayName":"Engineering","urn:convoy:scim:schemas:extension:2.0:Group":{"role":"admin"}}`,
+		},
+		{
+			name:       "core_schema_prefixed_path",
+			resource:   `{"userName":"bjensen"}`,
+			operations: `[{"op":"replace","path":"urn:ietf:params:scim:schemas:core:2.0:User:userName","value":"babs"}]`,
+			want:       `{"userName":"babs"}`,
+		},
+		{
+			name:       "no_target",
+			resource:   `{"members":[{"value":"u1"}]}`,
+			operations: `[{"op":"replace","path":"members[value co \"u2\"].display","value":"Babs"}]`,
+			err:        "no values of members match the path's filter",
+		},
+		{
+			name:       "invalid_path_filter",
+			resource:   `{"members":[{"value":"u1"}]}`,
+			operations: `[{"op":"remove","path":"members[value eq]"}]`,
+			err:        `invalid filter in path "members[value eq]": expected a value after value eq in filter`,
+		},
+		{
+			name:       "value_without_path_must_be_object",
+			resource:   `{"userName":"bjensen"}`,
+			operations: `[{"op":"add","value":"babs"}]`,
+			err:        "operations without a path need an object value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resource map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(tt.resource), &resource))
+
+			req := &PatchRequest{Schemas: []string{PatchOpSchema}}
+			require.NoError(t, json.Unmarshal([]byte(tt.operations), &req.Operations))
+
+			err := req.Apply(resource)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			got, err := json.Marshal(resource)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestBoolean_UnmarshalJSON(t *testing.T) {
+	var u User
+	require.NoError(t, json.Unmarshal([]byte(`{"userName":"bjensen","active":"False"}`), &u))
+	require.False(t, u.IsActive())
+
+	u = User{}
+	require.NoError(t, json.Unmarshal([]byte(`{"userName":"bjensen"}`), &u))
+	require.True(t, u.IsActive())
+
+	require.Error(t, json.Unmarshal([]byte(`{"active":"maybe"}`), &u))
+}
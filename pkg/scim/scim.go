@@ -0,0 +1,322 @@
+// Package scim holds the SCIM 2.0 (RFC 7643 and RFC 7644) resources Convoy
+// serves to identity providers, along with the filter and PATCH semantics
+// the protocol defines over them.
+//
+// Filters and PATCH operations work on the JSON form of a resource, a
+// map[string]interface{}, so they don't need to know about the resource's
+// Go type. Attribute names are matched case insensitively.
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	UserSchema                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	GroupSchema                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	GroupExtensionSchema        = "urn:convoy:scim:schemas:extension:2.0:Group"
+	ListResponseSchema          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	PatchOpSchema               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ErrorSchema                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	ServiceProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	ResourceTypeSchema          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+
+	// ContentType is the media type of SCIM requests and responses.
+	ContentType = "application/scim+json"
+
+	// MaxResults caps the number of resources returned in one list
+	// response.
+	MaxResults = 100
+)
+
+// Error types from RFC 7644 section 3.12.
+const (
+	ErrInvalidFilter = "invalidFilter"
+	ErrInvalidPath   = "invalidPath"
+	ErrInvalidSyntax = "invalidSyntax"
+	ErrInvalidValue  = "invalidValue"
+	ErrMutability    = "mutability"
+	ErrNoTarget      = "noTarget"
+	ErrUniqueness    = "uniqueness"
+)
+
+// Error is the SCIM error response, it is also returned as an error by the
+// parsers in this package and by the SCIM service.
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+func NewError(status int, scimType string, detail string) *Error {
+	return &Error{
+		Schemas:  []string{ErrorSchema},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	}
+}
+
+// BadRequest returns a 400 error of the given SCIM error type.
+func BadRequest(scimType string, format string, args ...interface{}) *Error {
+	return NewError(http.StatusBadRequest, scimType, fmt.Sprintf(format, args...))
+}
+
+func (e *Error) Error() string {
+	return e.Detail
+}
+
+// StatusCode returns the HTTP status of the error.
+func (e *Error) StatusCode() int {
+	code, err := strconv.Atoi(e.Status)
+	if err != nil {
+		return http.StatusInternalServerError
+	}
+
+	return code
+}
+
+type Meta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location,omitempty"`
+}
+
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type Email struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// Reference points at another resource, a group's members and a user's
+// groups are lists of references.
+type Reference struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// Boolean is a boolean that also decodes from "true" and "false" strings,
+// some identity providers send active that way.
+type Boolean bool
+
+func (b *Boolean) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return fmt.Errorf("%s is not a boolean", data)
+	}
+
+	*b = Boolean(v)
+	return nil
+}
+
+type User struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	ExternalID  string      `json:"externalId,omitempty"`
+	UserName    string      `json:"userName"`
+	Name        *Name       `json:"name,omitempty"`
+	DisplayName string      `json:"displayName,omitempty"`
+	Emails      []Email     `json:"emails,omitempty"`
+	Active      *Boolean    `json:"active,omitempty"`
+	Groups      []Reference `json:"groups,omitempty"`
+	Meta        *Meta       `json:"meta,omitempty"`
+}
+
+// IsActive reports whether the user is active, users are active unless
+// told otherwise.
+func (u *User) IsActive() bool {
+	return u.Active == nil || bool(*u.Active)
+}
+
+// GroupExtension maps a group to the role its members are given.
+type GroupExtension struct {
+	Role    string `json:"role,omitempty"`
+	Project string `json:"project,omitempty"`
+}
+
+type Group struct {
+	Schemas     []string        `json:"schemas"`
+	ID          string          `json:"id,omitempty"`
+	ExternalID  string          `json:"externalId,omitempty"`
+	DisplayName string          `json:"displayName"`
+	Members     []Reference     `json:"members,omitempty"`
+	Extension   *GroupExtension `json:"urn:convoy:scim:schemas:extension:2.0:Group,omitempty"`
+	Meta        *Meta           `json:"meta,omitempty"`
+}
+
+type ListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+// NewListResponse pages resources the way RFC 7644 section 3.4.2.4
+// describes, startIndex is 1-based and count is capped at MaxResults.
+func NewListResponse(resources []interface{}, startIndex int, count int) *ListResponse {
+	if startIndex < 1 {
+		startIndex = 1
+	}
+
+	if count < 0 {
+		count = 0
+	}
+
+	if count > MaxResults {
+		count = MaxResults
+	}
+
+	page := make([]interface{}, 0)
+	if start := startIndex - 1; start < len(resources) {
+		end := start + count
+		if end > len(resources) {
+			end = len(resources)
+		}
+		page = append(page, resources[start:end]...)
+	}
+
+	return &ListResponse{
+		Schemas:      []string{ListResponseSchema},
+		TotalResults: len(resources),
+		StartIndex:   startIndex,
+		ItemsPerPage: len(page),
+		Resources:    page,
+	}
+}
+
+// ToMap returns the JSON form of a resource.
+func ToMap(resource interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+	err = json.Unmarshal(b, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// FromMap decodes the JSON form of a resource into resource.
+func FromMap(m map[string]interface{}, resource interface{}) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(b, resource)
+	if err != nil {
+		return BadRequest(ErrInvalidValue, "%v", err)
+	}
+
+	return nil
+}
+
+type Supported struct {
+	Supported bool `json:"supported"`
+}
+
+type BulkConfig struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+type FilterConfig struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+type AuthenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary"`
+}
+
+type ServiceProviderConfig struct {
+	Schemas               []string               `json:"schemas"`
+	DocumentationURI      string                 `json:"documentationUri,omitempty"`
+	Patch                 Supported              `json:"patch"`
+	Bulk                  BulkConfig             `json:"bulk"`
+	Filter                FilterConfig           `json:"filter"`
+	ChangePassword        Supported              `json:"changePassword"`
+	Sort                  Supported              `json:"sort"`
+	ETag                  Supported              `json:"etag"`
+	AuthenticationSchemes []AuthenticationScheme `json:"authenticationSchemes"`
+	Meta                  *Meta                  `json:"meta,omitempty"`
+}
+
+// NewServiceProviderConfig describes what the server supports: filtering
+// and PATCH, but not bulk operations, sorting, etags or password changes.
+func NewServiceProviderConfig() *ServiceProviderConfig {
+	return &ServiceProviderConfig{
+		Schemas:          []string{ServiceProviderConfigSchema},
+		DocumentationURI: "https://docs.getconvoy.io",
+		Patch:            Supported{Supported: true},
+		Filter:           FilterConfig{Supported: true, MaxResults: MaxResults},
+		AuthenticationSchemes: []AuthenticationScheme{
+			{
+				Type:        "oauthbearertoken",
+				Name:        "Bearer Token",
+				Description: "Authentication with the organisation's SCIM token",
+				Primary:     true,
+			},
+		},
+	}
+}
+
+type SchemaExtension struct {
+	Schema   string `json:"schema"`
+	Required bool   `json:"required"`
+}
+
+type ResourceType struct {
+	Schemas          []string          `json:"schemas"`
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	Endpoint         string            `json:"endpoint"`
+	Schema           string            `json:"schema"`
+	SchemaExtensions []SchemaExtension `json:"schemaExtensions,omitempty"`
+}
+
+// ResourceTypes lists the resources the server provisions.
+func ResourceTypes() []ResourceType {
+	return []ResourceType{
+		{
+			Schemas:  []string{ResourceTypeSchema},
+			ID:       "User",
+			Name:     "User",
+			Endpoint: "/Users",
+			Schema:   UserSchema,
+		},
+		{
+			Schemas:          []string{ResourceTypeSchema},
+			ID:               "Group",
+			Name:             "Group",
+			Endpoint:         "/Groups",
+			Schema:           GroupSchema,
+			SchemaExtensions: []SchemaExtension{{Schema: GroupExtensionSchema}},
+		},
+	}
+}
@@ -0,0 +1,244 @@
+package signature
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Algorithms supported for asymmetric signing, named after their JWA
+// identifiers (RFC 7518) so they can be published as is in a JWKS.
+const (
+	EdDSAAlgorithm = "EdDSA"
+	PS256Algorithm = "PS256"
+
+	// JWSSignatureHeader holds the detached JWS of a delivery's payload.
+	JWSSignatureHeader = "X-Convoy-Signature-JWS"
+)
+
+const rsaKeySize = 2048
+
+var (
+	// ErrUnsupportedAlgorithm is returned for an unknown signing algorithm.
+	ErrUnsupportedAlgorithm = errors.New("Algorithm not supported")
+
+	// ErrInvalidJWS is returned when a detached JWS is malformed.
+	ErrInvalidJWS = errors.New("Invalid detached JWS")
+
+	// ErrUnknownKey is returned when a JWS was signed by a key that isn't in the key set.
+	ErrUnknownKey = errors.New("Signing key not found in key set")
+)
+
+// JWK is the public half of a signing key in the JSON Web Key format (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+
+	// Ed25519 keys (RFC 8037)
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+
+	// RSA keys
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+type jwsHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// GenerateKeyPair generates a key pair for alg. The private key is PKCS #8
+// and the public key PKIX, both DER encoded.
+func GenerateKeyPair(alg string) (privateKey []byte, publicKey []byte, err error) {
+	var priv crypto.Signer
+	switch alg {
+	case EdDSAAlgorithm:
+		_, priv, err = ed25519.GenerateKey(rand.Reader)
+	case PS256Algorithm:
+		priv, err = rsa.GenerateKey(rand.Reader, rsaKeySize)
+	default:
+		return nil, nil, ErrUnsupportedAlgorithm
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	privateKey, err = x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	publicKey, err = x509.MarshalPKIXPublicKey(priv.Public())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return privateKey, publicKey, nil
+}
+
+// SignDetachedJWS signs payload with a PKCS #8 private key and returns a
+// compact JWS with a detached payload (RFC 7515, Appendix F), that is
+// "<header>..<signature>". Receivers verify it against the raw request body.
+func SignDetachedJWS(alg, kid string, privateKey []byte, payload []byte) (string, error) {
+	key, err := x509.ParsePKCS8PrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	h, err := json.Marshal(jwsHeader{Alg: alg, Kid: kid})
+	if err != nil {
+		return "", err
+	}
+
+	protected := base64.RawURLEncoding.EncodeToString(h)
+	signingInput := protected + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	var sig []byte
+	switch alg {
+	case EdDSAAlgorithm:
+		k, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return "", fmt.Errorf("%s requires an ed25519 key", alg)
+		}
+		sig = ed25519.Sign(k, []byte(signingInput))
+	case PS256Algorithm:
+		k, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return "", fmt.Errorf("%s requires an rsa key", alg)
+		}
+
+		digest := sha256.Sum256([]byte(signingInput))
+		sig, err = rsa.SignPSS(rand.Reader, k, crypto.SHA256, digest[:], &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		if err != nil {
+			return "", err
+		}
+	default:
+		return "", ErrUnsupportedAlgorithm
+	}
+
+	return protected + ".." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// VerifyDetachedJWS verifies a detached JWS over payload with the key
+// named by its kid in keys.
+func VerifyDetachedJWS(jws string, payload []byte, keys JWKS) error {
+	parts := strings.Split(jws, ".")
+	if len(parts) != 3 || len(parts[1]) != 0 {
+		return ErrInvalidJWS
+	}
+
+	h, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return ErrInvalidJWS
+	}
+
+	var header jwsHeader
+	err = json.Unmarshal(h, &header)
+	if err != nil {
+		return ErrInvalidJWS
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return ErrInvalidJWS
+	}
+
+	var jwk *JWK
+	for i := range keys.Keys {
+		if keys.Keys[i].Kid == header.Kid {
+			jwk = &keys.Keys[i]
+			break
+		}
+	}
+
+	if jwk == nil || jwk.Alg != header.Alg {
+		return ErrUnknownKey
+	}
+
+	pub, err := jwk.publicKey()
+	if err != nil {
+		return err
+	}
+
+	signingInput := parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload)
+	switch k := pub.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, []byte(signingInput), sig) {
+			return ErrInvalidJWS
+		}
+	case *rsa.PublicKey:
+		digest := sha256.Sum256([]byte(signingInput))
+		err = rsa.VerifyPSS(k, crypto.SHA256, digest[:], sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		if err != nil {
+			return ErrInvalidJWS
+		}
+	}
+
+	return nil
+}
+
+// NewJWK builds the JWK of a PKIX DER encoded public key.
+func NewJWK(alg, kid string, publicKey []byte) (*JWK, error) {
+	key, err := x509.ParsePKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	jwk := &JWK{Use: "sig", Alg: alg, Kid: kid}
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(k)
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(k.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes())
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	return jwk, nil
+}
+
+func (j *JWK) publicKey() (crypto.PublicKey, error) {
+	switch j.Kty {
+	case "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(j.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid ed25519 key %s", j.Kid)
+		}
+		return ed25519.PublicKey(x), nil
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(j.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rsa key %s", j.Kid)
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(j.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rsa key %s", j.Kid)
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	}
+
+	return nil, ErrUnsupportedAlgorithm
+}
@@ -0,0 +1,57 @@
+package signature
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DetachedJWS(t *testing.T) {
+	payload := []byte(`{"event":"invoice.paid","amount":2000}`)
+
+	for _, alg := range []string{EdDSAAlgorithm, PS256Algorithm} {
+		t.Run(alg, func(t *testing.T) {
+			priv, pub, err := GenerateKeyPair(alg)
+			require.NoError(t, err)
+
+			jwk, err := NewJWK(alg, "key-1", pub)
+			require.NoError(t, err)
+			keys := JWKS{Keys: []JWK{*jwk}}
+
+			jws, err := SignDetachedJWS(alg, "key-1", priv, payload)
+			require.NoError(t, err)
+			require.Equal(t, 3, len(strings.Split(jws, ".")))
+			require.Contains(t, jws, "..")
+
+			require.NoError(t, VerifyDetachedJWS(jws, payload, keys))
+			require.ErrorIs(t, VerifyDetachedJWS(jws, []byte(`{"amount":1}`), keys), ErrInvalidJWS)
+			require.ErrorIs(t, VerifyDetachedJWS(jws, payload, JWKS{}), ErrUnknownKey)
+		})
+	}
+}
+
+func Test_NewJWK(t *testing.T) {
+	_, pub, err := GenerateKeyPair(EdDSAAlgorithm)
+	require.NoError(t, err)
+
+	jwk, err := NewJWK(EdDSAAlgorithm, "key-1", pub)
+	require.NoError(t, err)
+	require.Equal(t, "OKP", jwk.Kty)
+	require.Equal(t, "Ed25519", jwk.Crv)
+	require.Equal(t, "sig", jwk.Use)
+	require.NotEmpty(t, jwk.X)
+
+	_, pub, err = GenerateKeyPair(PS256Algorithm)
+	require.NoError(t, err)
+
+	jwk, err = NewJWK(PS256Algorithm, "key-2", pub)
+	require.NoError(t, err)
+	require.Equal(t, "RSA", jwk.Kty)
+	require.Equal(t, "AQAB", jwk.E)
+}
+
+func Test_GenerateKeyPair_UnsupportedAlgorithm(t *testing.T) {
+	_, _, err := GenerateKeyPair("HS256")
+	require.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+}
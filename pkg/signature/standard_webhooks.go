@@ -0,0 +1,99 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Header names defined by the Standard Webhooks spec
+// (https://www.standardwebhooks.com).
+const (
+	StandardIDHeader        = "webhook-id"
+	StandardTimestampHeader = "webhook-timestamp"
+	StandardSignatureHeader = "webhook-signature"
+
+	// StandardSecretPrefix marks a base64 encoded Standard Webhooks secret.
+	StandardSecretPrefix = "whsec_"
+)
+
+// ErrNoSecret is returned when there is no secret to sign a payload with.
+var ErrNoSecret = errors.New("Secret not provided")
+
+// StandardHeaders are the headers sent with a Standard Webhooks request.
+type StandardHeaders struct {
+	ID        string
+	Timestamp string
+	Signature string
+}
+
+// ComputeStandardHeaders signs the payload following the Standard Webhooks
+// spec. msgID must stay the same across retries of a message so receivers
+// can deduplicate it. Every secret of the latest scheme is used, so receivers
+// keep verifying requests while a secret is being rolled.
+func (s *Signature) ComputeStandardHeaders(msgID string) (*StandardHeaders, error) {
+	if len(s.Schemes) == 0 || len(s.Schemes[len(s.Schemes)-1].Secret) == 0 {
+		return nil, ErrNoSecret
+	}
+
+	var ts string
+	if s.generateTimestampFn != nil {
+		ts = s.generateTimestampFn()
+	} else {
+		ts = fmt.Sprintf("%d", time.Now().Unix())
+	}
+
+	// the payload is signed as is since it is sent as is, receivers
+	// verify against the raw request body.
+	signedContent := fmt.Sprintf("%s.%s.%s", msgID, ts, s.Payload)
+
+	sch := s.Schemes[len(s.Schemes)-1]
+	sigs := make([]string, 0, len(sch.Secret))
+	for _, sec := range sch.Secret {
+		key, err := DecodeStandardSecret(sec)
+		if err != nil {
+			return nil, err
+		}
+
+		h := hmac.New(sha256.New, key)
+		h.Write([]byte(signedContent))
+		sigs = append(sigs, "v1,"+base64.StdEncoding.EncodeToString(h.Sum(nil)))
+	}
+
+	return &StandardHeaders{
+		ID:        msgID,
+		Timestamp: ts,
+		Signature: strings.Join(sigs, " "),
+	}, nil
+}
+
+// DecodeStandardSecret returns the signing key for a secret. Secrets with the
+// whsec_ prefix are base64 encoded, any other secret is used as is.
+func DecodeStandardSecret(secret string) ([]byte, error) {
+	if !strings.HasPrefix(secret, StandardSecretPrefix) {
+		return []byte(secret), nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, StandardSecretPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s secret: %v", StandardSecretPrefix, err)
+	}
+
+	return key, nil
+}
+
+// GenerateStandardSecret generates a random whsec_ prefixed secret.
+func GenerateStandardSecret() (string, error) {
+	key := make([]byte, 24)
+	_, err := rand.Read(key)
+	if err != nil {
+		return "", err
+	}
+
+	return StandardSecretPrefix + base64.StdEncoding.EncodeToString(key), nil
+}
@@ -0,0 +1,84 @@
+package signature
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Standard_Signatures(t *testing.T) {
+	tests := map[string]struct {
+		signature *Signature
+		msgID     string
+		expected  string
+		wantErr   bool
+	}{
+		"should_match_the_spec_reference_signature": {
+			signature: &Signature{
+				Payload: json.RawMessage(`{"test": 2432232314}`),
+				Schemes: []Scheme{
+					{Secret: []string{"whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw"}},
+				},
+				generateTimestampFn: func() string {
+					return "1614265330"
+				},
+			},
+			msgID:    "msg_p5jXN8AQM9LWM0D4loKWxJek",
+			expected: "v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE=",
+		},
+		"should_generate_a_signature_per_rolled_secret": {
+			signature: &Signature{
+				Payload: json.RawMessage(`{"test": 2432232314}`),
+				Schemes: []Scheme{
+					{Secret: []string{"old-secret"}},
+					{Secret: []string{"expired-secret", "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw"}},
+				},
+				generateTimestampFn: func() string {
+					return "1614265330"
+				},
+			},
+			msgID:    "msg_p5jXN8AQM9LWM0D4loKWxJek",
+			expected: "v1,+vFHlv0+A8D9zE8uI6ODVDnGB8h/oSjlJ7pxO0Jd/dQ= v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE=",
+		},
+		"should_error_for_an_invalid_whsec_secret": {
+			signature: &Signature{
+				Payload: json.RawMessage(`{}`),
+				Schemes: []Scheme{{Secret: []string{"whsec_%%%"}}},
+			},
+			msgID:   "msg_1",
+			wantErr: true,
+		},
+		"should_error_without_secrets": {
+			signature: &Signature{Payload: json.RawMessage(`{}`)},
+			msgID:     "msg_1",
+			wantErr:   true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			headers, err := tc.signature.ComputeStandardHeaders(tc.msgID)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.msgID, headers.ID)
+			require.Equal(t, "1614265330", headers.Timestamp)
+			require.Equal(t, tc.expected, headers.Signature)
+		})
+	}
+}
+
+func Test_GenerateStandardSecret(t *testing.T) {
+	secret, err := GenerateStandardSecret()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(secret, StandardSecretPrefix))
+
+	key, err := DecodeStandardSecret(secret)
+	require.NoError(t, err)
+	require.Len(t, key, 24)
+}
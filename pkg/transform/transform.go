@@ -0,0 +1,331 @@
+package transform
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"runtime/metrics"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/frain-dev/convoy/pkg/httpheader"
+)
+
+// Limits a transform function runs under. goja can't cap allocations, so
+// the heap is sampled while the function runs and the function is stopped
+// once it has grown by more than MaxMemory. The heap is shared with the
+// rest of the worker, so the limit is generous.
+const (
+	Timeout          = 500 * time.Millisecond
+	MaxFunctionSize  = 64 * 1024
+	MaxPayloadSize   = 1024 * 1024
+	MaxOutputSize    = 1024 * 1024
+	MaxMemory        = 64 * 1024 * 1024
+	maxCallStackSize = 256
+	maxLogs          = 50
+	maxLogSize       = 1024
+
+	memoryCheckInterval = 10 * time.Millisecond
+	heapMetric          = "/memory/classes/heap/objects:bytes"
+)
+
+var (
+	ErrFunctionTooLarge   = fmt.Errorf("transform function cannot be larger than %d bytes", MaxFunctionSize)
+	ErrFunctionNotDefined = errors.New("transform function must define function transform(request)")
+	ErrTimeout            = errors.New("transform function timed out")
+	ErrMemoryLimit        = fmt.Errorf("transform function cannot allocate more than %d bytes", MaxMemory)
+	ErrPayloadTooLarge    = fmt.Errorf("payloads larger than %d bytes cannot be transformed", MaxPayloadSize)
+
+	// memoryLimit is MaxMemory, tests lower it so they don't depend on
+	// how fast the runtime allocates.
+	memoryLimit            uint64 = MaxMemory
+	ErrOutputTooLarge             = fmt.Errorf("transform function output cannot be larger than %d bytes", MaxOutputSize)
+	ErrInvalidOutput              = errors.New("transform function must return an object with a body")
+	ErrInvalidPath                = errors.New("transform function returned an invalid path, it must be absolute and cannot contain a host or query")
+	ErrInvalidHeaderValues        = errors.New("transform function returned invalid headers, values must be strings or arrays of strings")
+)
+
+// Request is what a transform function receives as its only argument and
+// returns, possibly modified. Header values are passed to the function as
+// strings, multiple values are joined with a comma.
+type Request struct {
+	Body    json.RawMessage
+	Headers httpheader.HTTPHeader
+	Path    string
+}
+
+// Result is a transformed request, with the lines the function logged.
+type Result struct {
+	Body    json.RawMessage       `json:"body"`
+	Headers httpheader.HTTPHeader `json:"headers"`
+	Path    string                `json:"path"`
+	Logs    []string              `json:"logs"`
+}
+
+type input struct {
+	Body    json.RawMessage   `json:"body"`
+	Headers map[string]string `json:"headers"`
+	Path    string            `json:"path"`
+}
+
+type output struct {
+	Body    json.RawMessage        `json:"body"`
+	Headers map[string]interface{} `json:"headers"`
+	Path    *string                `json:"path"`
+}
+
+// Validate checks a function compiles and defines transform, without
+// calling it.
+func Validate(function string) error {
+	_, _, err := load(function, &[]string{})
+	return err
+}
+
+// Transform runs function's transform(request) against r. The function
+// runs in a fresh runtime without any I/O, console.log lines are returned
+// in the result to help debug it.
+func Transform(function string, r *Request) (*Result, error) {
+	if len(r.Body) > MaxPayloadSize {
+		return nil, ErrPayloadTooLarge
+	}
+
+	res := &Result{Logs: []string{}}
+
+	vm, fn, err := load(function, &res.Logs)
+	if err != nil {
+		return nil, err
+	}
+
+	body := r.Body
+	if len(body) == 0 {
+		body = json.RawMessage("null")
+	}
+
+	headers := make(map[string]string, len(r.Headers))
+	for k, v := range r.Headers {
+		headers[k] = strings.Join(v, ",")
+	}
+
+	in, err := json.Marshal(input{Body: body, Headers: headers, Path: r.Path})
+	if err != nil {
+		return nil, err
+	}
+
+	var out string
+	err = run(vm, func() error {
+		arg, err := vm.RunString("(" + string(in) + ")")
+		if err != nil {
+			return err
+		}
+
+		v, err := fn(goja.Undefined(), arg)
+		if err != nil {
+			return err
+		}
+
+		if goja.IsUndefined(v) || goja.IsNull(v) {
+			return ErrInvalidOutput
+		}
+
+		stringify, _ := goja.AssertFunction(vm.Get("JSON").ToObject(vm).Get("stringify"))
+		s, err := stringify(goja.Undefined(), v)
+		if err != nil {
+			return err
+		}
+
+		out = s.String()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(out) > MaxOutputSize {
+		return nil, ErrOutputTooLarge
+	}
+
+	var o output
+	err = json.Unmarshal([]byte(out), &o)
+	if err != nil || len(o.Body) == 0 {
+		return nil, ErrInvalidOutput
+	}
+
+	res.Body = o.Body
+	res.Headers, err = parseHeaders(o.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Path = r.Path
+	if o.Path != nil {
+		res.Path, err = parsePath(*o.Path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// load evaluates function in a new runtime and returns its transform.
+func load(function string, logs *[]string) (*goja.Runtime, goja.Callable, error) {
+	if len(function) > MaxFunctionSize {
+		return nil, nil, ErrFunctionTooLarge
+	}
+
+	prog, err := goja.Compile("transform.js", function, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("transform function is invalid: %v", err)
+	}
+
+	vm := goja.New()
+	vm.SetMaxCallStackSize(maxCallStackSize)
+
+	console := vm.NewObject()
+	err = console.Set("log", func(call goja.FunctionCall) goja.Value {
+		if len(*logs) >= maxLogs {
+			return goja.Undefined()
+		}
+
+		args := make([]string, len(call.Arguments))
+		for i, arg := range call.Arguments {
+			args[i] = arg.String()
+		}
+
+		line := strings.Join(args, " ")
+		if len(line) > maxLogSize {
+			line = line[:maxLogSize]
+		}
+
+		*logs = append(*logs, line)
+		return goja.Undefined()
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = vm.Set("console", console)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = run(vm, func() error {
+		_, err := vm.RunProgram(prog)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fn, ok := goja.AssertFunction(vm.Get("transform"))
+	if !ok {
+		return nil, nil, ErrFunctionNotDefined
+	}
+
+	return vm, fn, nil
+}
+
+// run calls f, interrupting the runtime once it has taken longer than
+// Timeout or allocated more than MaxMemory.
+func run(vm *goja.Runtime, f func() error) error {
+	timer := time.AfterFunc(Timeout, func() {
+		vm.Interrupt(ErrTimeout)
+	})
+	defer timer.Stop()
+
+	done := make(chan struct{})
+	defer close(done)
+	go limitMemory(vm, heapSize()+memoryLimit, done)
+
+	err := f()
+	if err != nil {
+		var interrupted *goja.InterruptedError
+		if errors.As(err, &interrupted) {
+			if reason, ok := interrupted.Value().(error); ok {
+				return reason
+			}
+
+			return ErrTimeout
+		}
+
+		var exception *goja.Exception
+		if errors.As(err, &exception) {
+			return fmt.Errorf("transform function failed: %s", exception.Value().String())
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// limitMemory interrupts the runtime when the heap grows past limit
+// before done is closed.
+func limitMemory(vm *goja.Runtime, limit uint64, done <-chan struct{}) {
+	ticker := time.NewTicker(memoryCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if heapSize() > limit {
+				vm.Interrupt(ErrMemoryLimit)
+				return
+			}
+		}
+	}
+}
+
+func heapSize() uint64 {
+	sample := []metrics.Sample{{Name: heapMetric}}
+	metrics.Read(sample)
+
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+
+	return sample[0].Value.Uint64()
+}
+
+func parseHeaders(h map[string]interface{}) (httpheader.HTTPHeader, error) {
+	headers := httpheader.HTTPHeader{}
+	for k, v := range h {
+		switch val := v.(type) {
+		case string:
+			headers[k] = []string{val}
+		case []interface{}:
+			values := make([]string, 0, len(val))
+			for _, s := range val {
+				str, ok := s.(string)
+				if !ok {
+					return nil, ErrInvalidHeaderValues
+				}
+				values = append(values, str)
+			}
+			headers[k] = values
+		default:
+			return nil, ErrInvalidHeaderValues
+		}
+	}
+
+	return headers, nil
+}
+
+// parsePath makes sure a returned path can only change the path of the
+// endpoint's url, not where it is sent to.
+func parsePath(p string) (string, error) {
+	if !strings.HasPrefix(p, "/") || strings.HasPrefix(p, "//") {
+		return "", ErrInvalidPath
+	}
+
+	u, err := url.Parse(p)
+	if err != nil || len(u.Host) > 0 || len(u.RawQuery) > 0 || len(u.Fragment) > 0 || strings.Contains(p, "?") {
+		return "", ErrInvalidPath
+	}
+
+	return u.Path, nil
+}
@@ -0,0 +1,170 @@
+package transform
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransform(t *testing.T) {
+	tests := []struct {
+		name        string
+		function    string
+		request     *Request
+		wantBody    string
+		wantHeaders httpheader.HTTPHeader
+		wantPath    string
+		wantLogs    []string
+		wantErr     error
+		wantErrMsg  string
+	}{
+		{
+			name: "should_rewrite_body_headers_and_path",
+			function: `function transform(req) {
+				console.log("event", req.body.event);
+				req.body = { type: req.body.event, amount: req.body.data.amount / 100 };
+				req.headers["X-Tenant"] = "acme";
+				req.path = "/v2" + req.path;
+				return req;
+			}`,
+			request: &Request{
+				Body:    json.RawMessage(`{"event":"invoice.paid","data":{"amount":2000}}`),
+				Headers: httpheader.HTTPHeader{"X-Source": []string{"billing"}},
+				Path:    "/webhooks",
+			},
+			wantBody:    `{"type":"invoice.paid","amount":20}`,
+			wantHeaders: httpheader.HTTPHeader{"X-Source": []string{"billing"}, "X-Tenant": []string{"acme"}},
+			wantPath:    "/v2/webhooks",
+			wantLogs:    []string{"event invoice.paid"},
+		},
+		{
+			name:        "should_keep_path_when_not_returned",
+			function:    `function transform(req) { return { body: [1, 2], headers: { "X-Ids": ["1", "2"] } }; }`,
+			request:     &Request{Body: json.RawMessage(`{}`), Path: "/hook"},
+			wantBody:    `[1,2]`,
+			wantHeaders: httpheader.HTTPHeader{"X-Ids": []string{"1", "2"}},
+			wantPath:    "/hook",
+			wantLogs:    []string{},
+		},
+		{
+			name:     "should_error_without_transform_function",
+			function: `function handle(req) { return req; }`,
+			request:  &Request{Body: json.RawMessage(`{}`)},
+			wantErr:  ErrFunctionNotDefined,
+		},
+		{
+			name:     "should_error_for_missing_body",
+			function: `function transform(req) { return { headers: {} }; }`,
+			request:  &Request{Body: json.RawMessage(`{}`)},
+			wantErr:  ErrInvalidOutput,
+		},
+		{
+			name:     "should_error_for_nothing_returned",
+			function: `function transform(req) { req.body = 1; }`,
+			request:  &Request{Body: json.RawMessage(`{}`)},
+			wantErr:  ErrInvalidOutput,
+		},
+		{
+			name:     "should_error_for_path_with_host",
+			function: `function transform(req) { req.path = "//evil.com/x"; return req; }`,
+			request:  &Request{Body: json.RawMessage(`{}`), Path: "/"},
+			wantErr:  ErrInvalidPath,
+		},
+		{
+			name:     "should_error_for_invalid_header_values",
+			function: `function transform(req) { req.headers["X-Count"] = 1; return req; }`,
+			request:  &Request{Body: json.RawMessage(`{}`)},
+			wantErr:  ErrInvalidHeaderValues,
+		},
+		{
+			name:     "should_time_out",
+			function: `function transform(req) { while (true) {} }`,
+			request:  &Request{Body: json.RawMessage(`{}`)},
+			wantErr:  ErrTimeout,
+		},
+		{
+			name:       "should_surface_thrown_errors",
+			function:   `function transform(req) { throw new Error("missing amount"); }`,
+			request:    &Request{Body: json.RawMessage(`{}`)},
+			wantErrMsg: "transform function failed: Error: missing amount",
+		},
+		{
+			name:     "should_error_for_deep_recursion",
+			function: `function f(n) { return f(n + 1); } function transform(req) { return f(0); }`,
+			request:  &Request{Body: json.RawMessage(`{}`)},
+		},
+		{
+			name:     "should_error_for_large_output",
+			function: `function transform(req) { req.body = "x".repeat(2 * 1024 * 1024); return req; }`,
+			request:  &Request{Body: json.RawMessage(`{}`)},
+			wantErr:  ErrOutputTooLarge,
+		},
+		{
+			name:     "should_error_for_large_payload",
+			function: `function transform(req) { return req; }`,
+			request:  &Request{Body: json.RawMessage(`"` + strings.Repeat("x", MaxPayloadSize) + `"`)},
+			wantErr:  ErrPayloadTooLarge,
+		},
+		{
+			name:        "should_truncate_long_log_lines",
+			function:    `function transform(req) { console.log("x".repeat(2048)); return req; }`,
+			request:     &Request{Body: json.RawMessage(`{}`), Path: "/"},
+			wantBody:    `{}`,
+			wantHeaders: httpheader.HTTPHeader{},
+			wantPath:    "/",
+			wantLogs:    []string{strings.Repeat("x", maxLogSize)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := Transform(tc.function, tc.request)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			if len(tc.wantErrMsg) > 0 {
+				require.EqualError(t, err, tc.wantErrMsg)
+				return
+			}
+
+			if len(tc.wantBody) == 0 {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.JSONEq(t, tc.wantBody, string(res.Body))
+			require.Equal(t, tc.wantHeaders, res.Headers)
+			require.Equal(t, tc.wantPath, res.Path)
+			require.Equal(t, tc.wantLogs, res.Logs)
+		})
+	}
+}
+
+func TestTransform_MemoryLimit(t *testing.T) {
+	defer func(limit uint64) { memoryLimit = limit }(memoryLimit)
+	memoryLimit = 1024 * 1024
+
+	function := `function transform(req) {
+		var chunks = [];
+		while (true) { chunks.push("x".repeat(1024 * 1024) + chunks.length); }
+	}`
+
+	_, err := Transform(function, &Request{Body: json.RawMessage(`{}`)})
+	require.ErrorIs(t, err, ErrMemoryLimit)
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, Validate(`function transform(req) { return req; }`))
+	require.ErrorIs(t, Validate(`var x = 1;`), ErrFunctionNotDefined)
+	require.ErrorIs(t, Validate(strings.Repeat(" ", MaxFunctionSize+1)), ErrFunctionTooLarge)
+
+	err := Validate(`function transform(req) {`)
+	require.Error(t, err)
+	require.True(t, strings.HasPrefix(err.Error(), "transform function is invalid"), err.Error())
+}
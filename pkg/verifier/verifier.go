@@ -9,7 +9,11 @@ import (
 	"errors"
 	"hash"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/frain-dev/convoy/pkg/signature"
 )
 
 var (
@@ -25,6 +29,8 @@ var (
 	ErrInvalidHeaderStructure             = errors.New("Invalid header structure")
 	ErrInvalidAuthLength                  = errors.New("Invalid Basic Auth Length")
 	ErrInvalidEncoding                    = errors.New("Invalid header encoding")
+	ErrInvalidTimestamp                   = errors.New("Invalid timestamp header")
+	ErrTimestampOutOfTolerance            = errors.New("Timestamp is outside the tolerance window")
 )
 
 type Verifier interface {
@@ -265,6 +271,77 @@ func (tV *TwitterVerifier) getSignature(sig string) string {
 	return strings.Split(sig, "sha256=")[1]
 }
 
+// defaultStandardTolerance is how far the webhook-timestamp header may be
+// from the current time, to prevent replay attacks.
+const defaultStandardTolerance = 5 * time.Minute
+
+type StandardWebhooksVerifier struct {
+	secret    string
+	tolerance time.Duration
+
+	// This function is used to get the current time when checking
+	// the timestamp header. It is only added to aid testing.
+	nowFn func() time.Time
+}
+
+func NewStandardWebhooksVerifier(secret string) *StandardWebhooksVerifier {
+	return &StandardWebhooksVerifier{
+		secret:    secret,
+		tolerance: defaultStandardTolerance,
+		nowFn:     time.Now,
+	}
+}
+
+func (sV *StandardWebhooksVerifier) VerifyRequest(r *http.Request, payload []byte) error {
+	msgID := r.Header.Get(signature.StandardIDHeader)
+	ts := r.Header.Get(signature.StandardTimestampHeader)
+	sigs := r.Header.Get(signature.StandardSignatureHeader)
+
+	if len(strings.TrimSpace(msgID)) == 0 || len(strings.TrimSpace(ts)) == 0 || len(strings.TrimSpace(sigs)) == 0 {
+		return ErrSignatureCannotBeEmpty
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+
+	diff := sV.nowFn().Sub(time.Unix(unix, 0))
+	if diff > sV.tolerance || diff < -sV.tolerance {
+		return ErrTimestampOutOfTolerance
+	}
+
+	key, err := signature.DecodeStandardSecret(sV.secret)
+	if err != nil {
+		return err
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(msgID + "." + ts + "."))
+	mac.Write(payload)
+	computedMAC := mac.Sum(nil)
+
+	// the header holds a space delimited list of versioned signatures,
+	// one per secret the sender signed with.
+	for _, versionedSig := range strings.Fields(sigs) {
+		version, sig, found := strings.Cut(versionedSig, ",")
+		if !found || version != "v1" {
+			continue
+		}
+
+		sentMAC, err := base64.StdEncoding.DecodeString(sig)
+		if err != nil {
+			continue
+		}
+
+		if hmac.Equal(sentMAC, computedMAC) {
+			return nil
+		}
+	}
+
+	return ErrHashDoesNotMatch
+}
+
 type NoopVerifier struct{}
 
 func (nV *NoopVerifier) VerifyRequest(r *http.Request, payload []byte) error {
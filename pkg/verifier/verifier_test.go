@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -454,3 +455,104 @@ func Test_TwitterVerifier_VerifyRequest(t *testing.T) {
 		})
 	}
 }
+
+func Test_StandardWebhooksVerifier_VerifyRequest(t *testing.T) {
+	signedAt := time.Unix(1614265330, 0)
+
+	tests := map[string]struct {
+		secret        string
+		payload       []byte
+		now           time.Time
+		requestFn     func(t *testing.T) *http.Request
+		expectedError error
+	}{
+		"valid_signature": {
+			secret:  "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw",
+			payload: []byte(`{"test": 2432232314}`),
+			now:     signedAt.Add(time.Minute),
+			requestFn: func(t *testing.T) *http.Request {
+				req, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+				require.NoError(t, err)
+
+				req.Header.Add("webhook-id", "msg_p5jXN8AQM9LWM0D4loKWxJek")
+				req.Header.Add("webhook-timestamp", "1614265330")
+				req.Header.Add("webhook-signature", "v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE=")
+				return req
+			},
+			expectedError: nil,
+		},
+		"valid_signature_among_rolled_secrets": {
+			secret:  "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw",
+			payload: []byte(`{"test": 2432232314}`),
+			now:     signedAt,
+			requestFn: func(t *testing.T) *http.Request {
+				req, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+				require.NoError(t, err)
+
+				req.Header.Add("webhook-id", "msg_p5jXN8AQM9LWM0D4loKWxJek")
+				req.Header.Add("webhook-timestamp", "1614265330")
+				req.Header.Add("webhook-signature", "v1,+vFHlv0+A8D9zE8uI6ODVDnGB8h/oSjlJ7pxO0Jd/dQ= v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE=")
+				return req
+			},
+			expectedError: nil,
+		},
+		"invalid_signature": {
+			secret:  "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw",
+			payload: []byte(`{"test": 2432232315}`),
+			now:     signedAt,
+			requestFn: func(t *testing.T) *http.Request {
+				req, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+				require.NoError(t, err)
+
+				req.Header.Add("webhook-id", "msg_p5jXN8AQM9LWM0D4loKWxJek")
+				req.Header.Add("webhook-timestamp", "1614265330")
+				req.Header.Add("webhook-signature", "v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE=")
+				return req
+			},
+			expectedError: ErrHashDoesNotMatch,
+		},
+		"timestamp_out_of_tolerance": {
+			secret:  "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw",
+			payload: []byte(`{"test": 2432232314}`),
+			now:     signedAt.Add(10 * time.Minute),
+			requestFn: func(t *testing.T) *http.Request {
+				req, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+				require.NoError(t, err)
+
+				req.Header.Add("webhook-id", "msg_p5jXN8AQM9LWM0D4loKWxJek")
+				req.Header.Add("webhook-timestamp", "1614265330")
+				req.Header.Add("webhook-signature", "v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE=")
+				return req
+			},
+			expectedError: ErrTimestampOutOfTolerance,
+		},
+		"missing_headers": {
+			secret:  "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw",
+			payload: []byte(`{"test": 2432232314}`),
+			now:     signedAt,
+			requestFn: func(t *testing.T) *http.Request {
+				req, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+				require.NoError(t, err)
+
+				req.Header.Add("webhook-signature", "v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE=")
+				return req
+			},
+			expectedError: ErrSignatureCannotBeEmpty,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange.
+			v := NewStandardWebhooksVerifier(tc.secret)
+			v.nowFn = func() time.Time { return tc.now }
+			req := tc.requestFn(t)
+
+			// Assert.
+			err := v.VerifyRequest(req, tc.payload)
+
+			// Act.
+			require.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package retrystrategies
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+// BackoffRetryStrategy grows the interval exponentially from base up to max,
+// with optional full or decorrelated jitter.
+// See https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/
+type BackoffRetryStrategy struct {
+	base     time.Duration
+	max      time.Duration
+	jitter   datastore.JitterType
+	previous time.Duration
+
+	// randFn returns a random duration in [0, n)
+	randFn func(n int64) int64
+}
+
+func (r *BackoffRetryStrategy) NextDuration(attempts uint64) time.Duration {
+	switch r.jitter {
+	case datastore.FullJitter:
+		return time.Duration(r.randFn(int64(r.backoff(attempts)) + 1))
+	case datastore.DecorrelatedJitter:
+		previous := r.previous
+		if previous < r.base {
+			previous = r.base
+		}
+
+		upper := previous * 3
+		if upper > r.max || upper < previous {
+			upper = r.max
+		}
+
+		if upper <= r.base {
+			return upper
+		}
+
+		return r.base + time.Duration(r.randFn(int64(upper-r.base)+1))
+	default:
+		return r.backoff(attempts)
+	}
+}
+
+// backoff is base * 2^attempts, capped at max.
+func (r *BackoffRetryStrategy) backoff(attempts uint64) time.Duration {
+	d := float64(r.base) * math.Pow(2, float64(attempts))
+	if d >= float64(r.max) {
+		return r.max
+	}
+
+	return time.Duration(d)
+}
+
+func NewBackoff(base, max time.Duration, jitter datastore.JitterType, previous time.Duration) *BackoffRetryStrategy {
+	if max < base {
+		max = base
+	}
+
+	return &BackoffRetryStrategy{
+		base:     base,
+		max:      max,
+		jitter:   jitter,
+		previous: previous,
+		randFn:   rand.Int63n,
+	}
+}
+
+var _ RetryStrategy = (*BackoffRetryStrategy)(nil)
@@ -0,0 +1,118 @@
+package retrystrategies
+
+import (
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+func TestBackoffRetryStrategy(t *testing.T) {
+	tests := []struct {
+		name             string
+		expectedDuration time.Duration
+		attempts         uint64
+		base             time.Duration
+		max              time.Duration
+		jitter           datastore.JitterType
+		previous         time.Duration
+		randFn           func(int64) int64
+	}{
+		{
+			name:             "no-jitter-initial-attempt-is-base",
+			expectedDuration: 2 * time.Second,
+			attempts:         0,
+			base:             2 * time.Second,
+			max:              time.Minute,
+			jitter:           datastore.NoJitter,
+		},
+		{
+			name:             "no-jitter-doubles-per-attempt",
+			expectedDuration: 16 * time.Second,
+			attempts:         3,
+			base:             2 * time.Second,
+			max:              time.Minute,
+			jitter:           datastore.NoJitter,
+		},
+		{
+			name:             "no-jitter-is-capped",
+			expectedDuration: time.Minute,
+			attempts:         200,
+			base:             2 * time.Second,
+			max:              time.Minute,
+			jitter:           datastore.NoJitter,
+		},
+		{
+			name:             "full-jitter-upper-bound",
+			expectedDuration: 16 * time.Second,
+			attempts:         3,
+			base:             2 * time.Second,
+			max:              time.Minute,
+			jitter:           datastore.FullJitter,
+			randFn: func(n int64) int64 {
+				return n - 1
+			},
+		},
+		{
+			name:             "full-jitter-lower-bound",
+			expectedDuration: 0,
+			attempts:         3,
+			base:             2 * time.Second,
+			max:              time.Minute,
+			jitter:           datastore.FullJitter,
+			randFn: func(n int64) int64 {
+				return 0
+			},
+		},
+		{
+			name:             "decorrelated-jitter-upper-bound-is-thrice-previous",
+			expectedDuration: 30 * time.Second,
+			attempts:         3,
+			base:             2 * time.Second,
+			max:              time.Minute,
+			jitter:           datastore.DecorrelatedJitter,
+			previous:         10 * time.Second,
+			randFn: func(n int64) int64 {
+				return n - 1
+			},
+		},
+		{
+			name:             "decorrelated-jitter-lower-bound-is-base",
+			expectedDuration: 2 * time.Second,
+			attempts:         3,
+			base:             2 * time.Second,
+			max:              time.Minute,
+			jitter:           datastore.DecorrelatedJitter,
+			previous:         10 * time.Second,
+			randFn: func(n int64) int64 {
+				return 0
+			},
+		},
+		{
+			name:             "decorrelated-jitter-is-capped",
+			expectedDuration: time.Minute,
+			attempts:         3,
+			base:             2 * time.Second,
+			max:              time.Minute,
+			jitter:           datastore.DecorrelatedJitter,
+			previous:         time.Hour,
+			randFn: func(n int64) int64 {
+				return n - 1
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			retry := NewBackoff(tc.base, tc.max, tc.jitter, tc.previous)
+			if tc.randFn != nil {
+				retry.randFn = tc.randFn
+			}
+
+			got := retry.NextDuration(tc.attempts)
+			if got != tc.expectedDuration {
+				t.Errorf("Want duration '%v' for attempts '%d', got '%v'", tc.expectedDuration, tc.attempts, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package retrystrategies
+
+import (
+	"time"
+)
+
+// CustomRetryStrategy follows a user supplied schedule, the last interval
+// is reused once the schedule has been exhausted.
+type CustomRetryStrategy struct {
+	intervals []time.Duration
+}
+
+func (r *CustomRetryStrategy) NextDuration(attempts uint64) time.Duration {
+	if len(r.intervals) == 0 {
+		return 0
+	}
+
+	if int(attempts) >= len(r.intervals) {
+		attempts = uint64(len(r.intervals) - 1)
+	}
+
+	return r.intervals[attempts]
+}
+
+func NewCustom(intervalSeconds []uint64) *CustomRetryStrategy {
+	intervals := make([]time.Duration, len(intervalSeconds))
+	for i, s := range intervalSeconds {
+		intervals[i] = time.Duration(s) * time.Second
+	}
+
+	return &CustomRetryStrategy{intervals: intervals}
+}
+
+var _ RetryStrategy = (*CustomRetryStrategy)(nil)
@@ -0,0 +1,44 @@
+package retrystrategies
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCustomRetryStrategy(t *testing.T) {
+	tests := []struct {
+		name             string
+		expectedDuration time.Duration
+		attempts         uint64
+		intervals        []uint64
+	}{
+		{
+			name:             "follows-schedule",
+			expectedDuration: 60 * time.Second,
+			attempts:         2,
+			intervals:        []uint64{5, 30, 60, 600},
+		},
+		{
+			name:             "reuses-last-interval",
+			expectedDuration: 600 * time.Second,
+			attempts:         20,
+			intervals:        []uint64{5, 30, 60, 600},
+		},
+		{
+			name:             "empty-schedule",
+			expectedDuration: 0,
+			attempts:         1,
+			intervals:        []uint64{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			retry := NewCustom(tc.intervals)
+			got := retry.NextDuration(tc.attempts)
+			if got != tc.expectedDuration {
+				t.Errorf("Want duration '%v' for attempts '%d', got '%v'", tc.expectedDuration, tc.attempts, got)
+			}
+		})
+	}
+}
@@ -12,17 +12,38 @@ type RetryStrategy interface {
 }
 
 func NewRetryStrategyFromMetadata(m datastore.Metadata) RetryStrategy {
-	if string(m.Strategy) == string(datastore.ExponentialStrategyProvider) {
-		// 10 seconds to 15 mins
-		return NewExponential([]uint{
-			10000,  // 10 seconds
-			30000,  // 30 seconds
-			60000,  // 1 minute
-			180000, // 3 minutes
-			300000, // 5 minutes
-			600000, // 10 minutes
-			900000, // 15 minutes
-		})
+	switch m.Strategy {
+	case datastore.ExponentialStrategyProvider:
+		// strategies created before the backoff was configurable
+		// keep using the fixed schedule
+		if m.MaxIntervalSeconds == 0 && m.Jitter == "" {
+			// 10 seconds to 15 mins
+			return NewExponential([]uint{
+				10000,  // 10 seconds
+				30000,  // 30 seconds
+				60000,  // 1 minute
+				180000, // 3 minutes
+				300000, // 5 minutes
+				600000, // 10 minutes
+				900000, // 15 minutes
+			})
+		}
+
+		max := time.Duration(m.MaxIntervalSeconds) * time.Second
+		if max == 0 {
+			max = 15 * time.Minute
+		}
+
+		return NewBackoff(
+			time.Duration(m.IntervalSeconds)*time.Second,
+			max,
+			m.Jitter,
+			time.Duration(m.LastIntervalMillis)*time.Millisecond,
+		)
+	case datastore.CustomStrategyProvider:
+		if len(m.Intervals) > 0 {
+			return NewCustom(m.Intervals)
+		}
 	}
 
 	return NewDefault(m.IntervalSeconds)
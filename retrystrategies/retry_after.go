@@ -0,0 +1,54 @@
+package retrystrategies
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxRetryAfter bounds how far in the future an endpoint can push a retry.
+const MaxRetryAfter = 24 * time.Hour
+
+// ShouldHonorRetryAfter reports whether a Retry-After header
+// on a response with this status code should be considered.
+func ShouldHonorRetryAfter(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+}
+
+// ParseRetryAfter reads a Retry-After header given either as delay-seconds
+// or as an HTTP date. It returns false when the header is absent or invalid.
+func ParseRetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	v := strings.TrimSpace(header.Get("Retry-After"))
+	if v == "" {
+		return 0, false
+	}
+
+	var d time.Duration
+	if seconds, err := strconv.ParseInt(v, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		if seconds > int64(MaxRetryAfter/time.Second) {
+			return MaxRetryAfter, true
+		}
+		d = time.Duration(seconds) * time.Second
+	} else {
+		t, err := http.ParseTime(v)
+		if err != nil {
+			return 0, false
+		}
+
+		d = t.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+	}
+
+	if d > MaxRetryAfter {
+		d = MaxRetryAfter
+	}
+
+	return d, true
+}
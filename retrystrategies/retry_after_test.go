@@ -0,0 +1,76 @@
+package retrystrategies
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		value            string
+		expectedDuration time.Duration
+		expectedOk       bool
+	}{
+		{
+			name:             "delay-seconds",
+			value:            "120",
+			expectedDuration: 2 * time.Minute,
+			expectedOk:       true,
+		},
+		{
+			name:             "http-date",
+			value:            now.Add(90 * time.Second).Format(http.TimeFormat),
+			expectedDuration: 90 * time.Second,
+			expectedOk:       true,
+		},
+		{
+			name:             "http-date-in-the-past",
+			value:            now.Add(-time.Hour).Format(http.TimeFormat),
+			expectedDuration: 0,
+			expectedOk:       true,
+		},
+		{
+			name:             "capped-at-max",
+			value:            "999999999999",
+			expectedDuration: MaxRetryAfter,
+			expectedOk:       true,
+		},
+		{
+			name:       "missing-header",
+			value:      "",
+			expectedOk: false,
+		},
+		{
+			name:       "invalid-header",
+			value:      "soon",
+			expectedOk: false,
+		},
+		{
+			name:       "negative-seconds",
+			value:      "-5",
+			expectedOk: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := http.Header{}
+			if tc.value != "" {
+				h.Set("Retry-After", tc.value)
+			}
+
+			got, ok := ParseRetryAfter(h, now)
+			if ok != tc.expectedOk {
+				t.Fatalf("Want ok '%v', got '%v'", tc.expectedOk, ok)
+			}
+
+			if got != tc.expectedDuration {
+				t.Errorf("Want duration '%v' for header '%s', got '%v'", tc.expectedDuration, tc.value, got)
+			}
+		})
+	}
+}
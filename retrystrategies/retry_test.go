@@ -40,3 +40,57 @@ func TestRetry_FallsBackToDefault(t *testing.T) {
 	_, isDefault := r.(*DefaultRetryStrategy)
 	assert.True(t, isDefault)
 }
+
+func TestRetry_CreatesLegacyExponentialWithoutBackoffConfig(t *testing.T) {
+	m := datastore.Metadata{
+		Strategy:        "exponential",
+		RetryLimit:      20,
+		IntervalSeconds: 5,
+	}
+
+	var r RetryStrategy = NewRetryStrategyFromMetadata(m)
+
+	_, isExponential := r.(*ExponentialBackoffRetryStrategy)
+	assert.True(t, isExponential)
+}
+
+func TestRetry_CreatesBackoff(t *testing.T) {
+	m := datastore.Metadata{
+		Strategy:           "exponential",
+		RetryLimit:         20,
+		IntervalSeconds:    5,
+		MaxIntervalSeconds: 600,
+		Jitter:             datastore.FullJitter,
+	}
+
+	var r RetryStrategy = NewRetryStrategyFromMetadata(m)
+
+	_, isBackoff := r.(*BackoffRetryStrategy)
+	assert.True(t, isBackoff)
+}
+
+func TestRetry_CreatesCustom(t *testing.T) {
+	m := datastore.Metadata{
+		Strategy:   "custom",
+		RetryLimit: 3,
+		Intervals:  []uint64{5, 60, 600},
+	}
+
+	var r RetryStrategy = NewRetryStrategyFromMetadata(m)
+
+	_, isCustom := r.(*CustomRetryStrategy)
+	assert.True(t, isCustom)
+}
+
+func TestRetry_CustomWithoutIntervalsFallsBackToDefault(t *testing.T) {
+	m := datastore.Metadata{
+		Strategy:        "custom",
+		RetryLimit:      3,
+		IntervalSeconds: 5,
+	}
+
+	var r RetryStrategy = NewRetryStrategyFromMetadata(m)
+
+	_, isDefault := r.(*DefaultRetryStrategy)
+	assert.True(t, isDefault)
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/log"
+)
+
+var (
+	ErrEventNotScheduled   = errors.New("event is not scheduled")
+	ErrNoScheduledDelivery = errors.New("event has no scheduled deliveries to cancel")
+)
+
+type CancelScheduledEventService struct {
+	EventDeliveryRepo datastore.EventDeliveryRepository
+
+	Event *datastore.Event
+}
+
+// Run discards the deliveries of a scheduled event that haven't been sent,
+// deliveries that were already batched are sent as planned.
+func (c *CancelScheduledEventService) Run(ctx context.Context) error {
+	if !c.Event.IsScheduled() {
+		return &ServiceError{ErrMsg: ErrEventNotScheduled.Error()}
+	}
+
+	n, err := c.EventDeliveryRepo.DiscardScheduledEventDeliveries(ctx, c.Event.ProjectID, c.Event.UID, "scheduled event was cancelled")
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to cancel scheduled event")
+		return &ServiceError{ErrMsg: "failed to cancel scheduled event", Err: err}
+	}
+
+	if n == 0 {
+		return &ServiceError{ErrMsg: ErrNoScheduledDelivery.Error()}
+	}
+
+	return nil
+}
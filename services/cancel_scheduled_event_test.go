@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+)
+
+func provideCancelScheduledEventService(ctrl *gomock.Controller, event *datastore.Event) *CancelScheduledEventService {
+	return &CancelScheduledEventService{
+		EventDeliveryRepo: mocks.NewMockEventDeliveryRepository(ctrl),
+		Event:             event,
+	}
+}
+
+func TestCancelScheduledEventService_Run(t *testing.T) {
+	ctx := context.Background()
+	scheduled := &datastore.Event{UID: "123", ProjectID: "abc", DeliverAt: null.TimeFrom(time.Now().Add(time.Hour))}
+
+	tests := []struct {
+		name       string
+		event      *datastore.Event
+		dbFn       func(cs *CancelScheduledEventService)
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:  "should_cancel_scheduled_event",
+			event: scheduled,
+			dbFn: func(cs *CancelScheduledEventService) {
+				ed, _ := cs.EventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().DiscardScheduledEventDeliveries(gomock.Any(), "abc", "123", gomock.Any()).
+					Times(1).Return(int64(2), nil)
+			},
+		},
+		{
+			name:       "should_error_for_event_that_was_never_scheduled",
+			event:      &datastore.Event{UID: "123", ProjectID: "abc"},
+			wantErr:    true,
+			wantErrMsg: ErrEventNotScheduled.Error(),
+		},
+		{
+			name:       "should_error_for_event_that_is_past_its_delivery_time",
+			event:      &datastore.Event{UID: "123", ProjectID: "abc", DeliverAt: null.TimeFrom(time.Now().Add(-time.Minute))},
+			wantErr:    true,
+			wantErrMsg: ErrEventNotScheduled.Error(),
+		},
+		{
+			name:  "should_error_when_no_delivery_is_held",
+			event: scheduled,
+			dbFn: func(cs *CancelScheduledEventService) {
+				ed, _ := cs.EventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().DiscardScheduledEventDeliveries(gomock.Any(), "abc", "123", gomock.Any()).
+					Times(1).Return(int64(0), nil)
+			},
+			wantErr:    true,
+			wantErrMsg: ErrNoScheduledDelivery.Error(),
+		},
+		{
+			name:  "should_fail_to_discard_deliveries",
+			event: scheduled,
+			dbFn: func(cs *CancelScheduledEventService) {
+				ed, _ := cs.EventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().DiscardScheduledEventDeliveries(gomock.Any(), "abc", "123", gomock.Any()).
+					Times(1).Return(int64(0), errors.New("failed"))
+			},
+			wantErr:    true,
+			wantErrMsg: "failed to cancel scheduled event",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			cs := provideCancelScheduledEventService(ctrl, tc.event)
+
+			if tc.dbFn != nil {
+				tc.dbFn(cs)
+			}
+
+			err := cs.Run(ctx)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/util"
+)
+
+// bulkQueueConcurrency is how many events of a bulk request are sent to
+// the queue at once.
+const bulkQueueConcurrency = 10
+
+type CreateBulkEventService struct {
+	EndpointRepo  datastore.EndpointRepository
+	EventRepo     datastore.EventRepository
+	EventTypeRepo datastore.EventTypeRepository
+	Queue         queue.Queuer
+
+	// NewMessages are decoded one at a time so a malformed event only
+	// fails itself rather than the whole request
+	NewMessages []json.RawMessage
+	Project     *datastore.Project
+}
+
+type bulkEvent struct {
+	index              int
+	event              *datastore.Event
+	createSubscription bool
+}
+
+// Run validates every event and writes the valid ones in one transaction,
+// events that fail validation are reported in the results and skipped.
+func (b *CreateBulkEventService) Run(ctx context.Context) (*models.BulkEventResponse, error) {
+	if b.Project == nil {
+		return nil, &ServiceError{ErrMsg: "an error occurred while creating event - invalid project"}
+	}
+
+	if len(b.NewMessages) == 0 {
+		return nil, &ServiceError{ErrMsg: "please provide at least one event"}
+	}
+
+	if len(b.NewMessages) > models.MaxBulkEvents {
+		return nil, &ServiceError{ErrMsg: fmt.Sprintf("a bulk request cannot have more than %d events", models.MaxBulkEvents)}
+	}
+
+	if err := validateRetryStrategy(b.Project); err != nil {
+		return nil, err
+	}
+
+	resp := &models.BulkEventResponse{Results: make([]models.BulkEventResult, len(b.NewMessages))}
+	lookup := &endpointLookup{repo: b.EndpointRepo, endpoints: map[string][]datastore.Endpoint{}}
+	eventTypeRepo := &cachedEventTypeRepo{EventTypeRepository: b.EventTypeRepo, eventTypes: map[string]*datastore.ProjectEventType{}}
+	idempotencyKeys := map[string]bool{}
+
+	events := make([]*bulkEvent, 0, len(b.NewMessages))
+	for i, raw := range b.NewMessages {
+		resp.Results[i].Index = i
+
+		ev, err := b.buildEvent(ctx, raw, lookup, eventTypeRepo, idempotencyKeys)
+		if err != nil {
+			resp.Results[i].Error = err.Error()
+			continue
+		}
+
+		ev.index = i
+		events = append(events, ev)
+	}
+
+	if len(events) > 0 {
+		toCreate := make([]*datastore.Event, len(events))
+		for i := range events {
+			toCreate[i] = events[i].event
+		}
+
+		err := b.EventRepo.CreateEvents(ctx, toCreate)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Error("failed to create bulk events")
+			return nil, &ServiceError{ErrMsg: "failed to create events", Err: err}
+		}
+
+		b.queueEvents(ctx, events, resp)
+	}
+
+	for _, result := range resp.Results {
+		if util.IsStringEmpty(result.Error) {
+			resp.Created++
+		} else {
+			resp.Failed++
+		}
+	}
+
+	return resp, nil
+}
+
+func (b *CreateBulkEventService) buildEvent(ctx context.Context, raw json.RawMessage, lookup *endpointLookup, eventTypeRepo datastore.EventTypeRepository, idempotencyKeys map[string]bool) (*bulkEvent, error) {
+	var newMessage models.CreateEvent
+	if err := json.Unmarshal(raw, &newMessage); err != nil {
+		return nil, errors.New("event is not valid json")
+	}
+
+	if err := newMessage.Validate(); err != nil {
+		return nil, err
+	}
+
+	if util.IsStringEmpty(newMessage.AppID) && util.IsStringEmpty(newMessage.EndpointID) {
+		return nil, ErrInvalidEndpointID
+	}
+
+	now := time.Now()
+	deliverAt, err := newMessage.DeliveryTime(now)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt, err := newMessage.ExpiryTime(now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ValidateEventSchema(ctx, eventTypeRepo, b.Project, newMessage.EventType, newMessage.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints, err := lookup.find(ctx, b.Project, &newMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(endpoints) == 0 {
+		return nil, ErrNoValidEndpointFound
+	}
+
+	isDuplicate, err := b.isDuplicate(ctx, newMessage.IdempotencyKey, idempotencyKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := buildEvent(endpoints, &newEvent{
+		Data:           newMessage.Data,
+		EventType:      newMessage.EventType,
+		EndpointID:     newMessage.EndpointID,
+		Raw:            string(newMessage.Data),
+		CustomHeaders:  newMessage.CustomHeaders,
+		IdempotencyKey: newMessage.IdempotencyKey,
+		IsDuplicate:    isDuplicate,
+		DeliverAt:      deliverAt,
+		ExpiresAt:      expiresAt,
+	}, b.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bulkEvent{event: event, createSubscription: !util.IsStringEmpty(newMessage.EndpointID)}, nil
+}
+
+// isDuplicate checks the idempotency key against existing events and the
+// events before it in the same request.
+func (b *CreateBulkEventService) isDuplicate(ctx context.Context, key string, seen map[string]bool) (bool, error) {
+	if util.IsStringEmpty(key) {
+		return false, nil
+	}
+
+	if seen[key] {
+		return true, nil
+	}
+	seen[key] = true
+
+	events, err := b.EventRepo.FindEventsByIdempotencyKey(ctx, b.Project.UID, key)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to find events by idempotency key")
+		return false, errors.New("an error occurred while checking the idempotency key")
+	}
+
+	return len(events) > 0, nil
+}
+
+// queueEvents sends the created events to the queue, an event that can't be
+// queued has been created but its deliveries won't be sent until it's replayed.
+func (b *CreateBulkEventService) queueEvents(ctx context.Context, events []*bulkEvent, resp *models.BulkEventResponse) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, bulkQueueConcurrency)
+
+	for _, ev := range events {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(ev *bulkEvent) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			resp.Results[ev.index].EventID = ev.event.UID
+
+			err := queueEvent(ev.event, ev.createSubscription, b.Queue)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Errorf("failed to queue event %s", ev.event.UID)
+				resp.Results[ev.index].Error = "event was created but could not be queued, replay it to send it"
+			}
+		}(ev)
+	}
+
+	wg.Wait()
+}
+
+// endpointLookup memoizes endpoint lookups, events in a bulk request are
+// usually sent to a handful of endpoints.
+type endpointLookup struct {
+	repo      datastore.EndpointRepository
+	endpoints map[string][]datastore.Endpoint
+}
+
+func (l *endpointLookup) find(ctx context.Context, project *datastore.Project, newMessage *models.CreateEvent) ([]datastore.Endpoint, error) {
+	key := "app:" + newMessage.AppID
+	if !util.IsStringEmpty(newMessage.EndpointID) {
+		key = "endpoint:" + newMessage.EndpointID
+	}
+
+	if endpoints, ok := l.endpoints[key]; ok {
+		return endpoints, nil
+	}
+
+	var endpoints []datastore.Endpoint
+	if !util.IsStringEmpty(newMessage.EndpointID) {
+		endpoint, err := l.repo.FindEndpointByID(ctx, newMessage.EndpointID, project.UID)
+		if err != nil {
+			return nil, err
+		}
+
+		endpoints = append(endpoints, *endpoint)
+	} else {
+		var err error
+		endpoints, err = l.repo.FindEndpointsByAppID(ctx, newMessage.AppID, project.UID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	l.endpoints[key] = endpoints
+	return endpoints, nil
+}
+
+// cachedEventTypeRepo memoizes event type lookups by name for the length
+// of a bulk request.
+type cachedEventTypeRepo struct {
+	datastore.EventTypeRepository
+	eventTypes map[string]*datastore.ProjectEventType
+}
+
+func (c *cachedEventTypeRepo) FindEventTypeByName(ctx context.Context, projectID string, name string) (*datastore.ProjectEventType, error) {
+	if eventType, ok := c.eventTypes[name]; ok {
+		if eventType == nil {
+			return nil, datastore.ErrEventTypeNotFound
+		}
+
+		return eventType, nil
+	}
+
+	eventType, err := c.EventTypeRepository.FindEventTypeByName(ctx, projectID, name)
+	if err != nil && !errors.Is(err, datastore.ErrEventTypeNotFound) {
+		return nil, err
+	}
+
+	c.eventTypes[name] = eventType
+	return eventType, err
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func provideCreateBulkEventService(ctrl *gomock.Controller, newMessages []string, project *datastore.Project) *CreateBulkEventService {
+	raw := make([]json.RawMessage, len(newMessages))
+	for i := range newMessages {
+		raw[i] = json.RawMessage(newMessages[i])
+	}
+
+	return &CreateBulkEventService{
+		EndpointRepo:  mocks.NewMockEndpointRepository(ctrl),
+		EventRepo:     mocks.NewMockEventRepository(ctrl),
+		EventTypeRepo: mocks.NewMockEventTypeRepository(ctrl),
+		Queue:         mocks.NewMockQueuer(ctrl),
+		NewMessages:   raw,
+		Project:       project,
+	}
+}
+
+func TestCreateBulkEventService_Run(t *testing.T) {
+	ctx := context.Background()
+	project := &datastore.Project{
+		UID: "abc",
+		Config: &datastore.ProjectConfig{
+			Strategy: &datastore.StrategyConfiguration{Type: "linear", Duration: 1000, RetryCount: 10},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		newMessages []string
+		project     *datastore.Project
+		dbFn        func(bs *CreateBulkEventService)
+		wantResp    *models.BulkEventResponse
+		wantErrMsg  string
+	}{
+		{
+			name: "should_create_valid_events_and_report_invalid_ones",
+			newMessages: []string{
+				`{"endpoint_id":"123","event_type":"payment.created","data":{"amount":10}}`,
+				`{"endpoint_id":"123","event_type":"payment.created"}`,
+				`not json`,
+				`{"event_type":"payment.created","data":{}}`,
+				`{"endpoint_id":"123","event_type":"payment.failed","data":{"amount":5}}`,
+			},
+			project: project,
+			dbFn: func(bs *CreateBulkEventService) {
+				e, _ := bs.EndpointRepo.(*mocks.MockEndpointRepository)
+				e.EXPECT().FindEndpointByID(gomock.Any(), "123", "abc").
+					Times(1).Return(&datastore.Endpoint{UID: "123", ProjectID: "abc"}, nil)
+
+				ev, _ := bs.EventRepo.(*mocks.MockEventRepository)
+				ev.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, events []*datastore.Event) error {
+						require.Equal(t, 2, len(events))
+						require.Equal(t, datastore.EventType("payment.created"), events[0].EventType)
+						require.Equal(t, datastore.EventType("payment.failed"), events[1].EventType)
+						return nil
+					})
+
+				q, _ := bs.Queue.(*mocks.MockQueuer)
+				q.EXPECT().Write(convoy.CreateEventProcessor, convoy.CreateEventQueue, gomock.Any()).
+					Times(2).Return(nil)
+			},
+			wantResp: &models.BulkEventResponse{
+				Created: 2,
+				Failed:  3,
+				Results: []models.BulkEventResult{
+					{Index: 0},
+					{Index: 1, Error: "data:please provide your data"},
+					{Index: 2, Error: "event is not valid json"},
+					{Index: 3, Error: ErrInvalidEndpointID.Error()},
+					{Index: 4},
+				},
+			},
+		},
+		{
+			name: "should_mark_repeated_idempotency_keys_as_duplicates",
+			newMessages: []string{
+				`{"endpoint_id":"123","event_type":"payment.created","data":{},"idempotency_key":"key-1"}`,
+				`{"endpoint_id":"123","event_type":"payment.created","data":{},"idempotency_key":"key-1"}`,
+			},
+			project: project,
+			dbFn: func(bs *CreateBulkEventService) {
+				e, _ := bs.EndpointRepo.(*mocks.MockEndpointRepository)
+				e.EXPECT().FindEndpointByID(gomock.Any(), "123", "abc").
+					Times(1).Return(&datastore.Endpoint{UID: "123", ProjectID: "abc"}, nil)
+
+				ev, _ := bs.EventRepo.(*mocks.MockEventRepository)
+				ev.EXPECT().FindEventsByIdempotencyKey(gomock.Any(), "abc", "key-1").
+					Times(1).Return(nil, nil)
+				ev.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, events []*datastore.Event) error {
+						require.False(t, events[0].IsDuplicateEvent)
+						require.True(t, events[1].IsDuplicateEvent)
+						return nil
+					})
+
+				q, _ := bs.Queue.(*mocks.MockQueuer)
+				q.EXPECT().Write(convoy.CreateEventProcessor, convoy.CreateEventQueue, gomock.Any()).
+					Times(2).Return(nil)
+			},
+			wantResp: &models.BulkEventResponse{
+				Created: 2,
+				Results: []models.BulkEventResult{{Index: 0}, {Index: 1}},
+			},
+		},
+		{
+			name: "should_report_events_that_could_not_be_queued",
+			newMessages: []string{
+				`{"endpoint_id":"123","event_type":"payment.created","data":{}}`,
+			},
+			project: project,
+			dbFn: func(bs *CreateBulkEventService) {
+				e, _ := bs.EndpointRepo.(*mocks.MockEndpointRepository)
+				e.EXPECT().FindEndpointByID(gomock.Any(), "123", "abc").
+					Times(1).Return(&datastore.Endpoint{UID: "123", ProjectID: "abc"}, nil)
+
+				ev, _ := bs.EventRepo.(*mocks.MockEventRepository)
+				ev.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				q, _ := bs.Queue.(*mocks.MockQueuer)
+				q.EXPECT().Write(convoy.CreateEventProcessor, convoy.CreateEventQueue, gomock.Any()).
+					Times(1).Return(errors.New("failed"))
+			},
+			wantResp: &models.BulkEventResponse{
+				Failed: 1,
+				Results: []models.BulkEventResult{
+					{Index: 0, Error: "event was created but could not be queued, replay it to send it"},
+				},
+			},
+		},
+		{
+			name: "should_not_write_when_every_event_is_invalid",
+			newMessages: []string{
+				`{"endpoint_id":"123","data":{}}`,
+			},
+			project: project,
+			wantResp: &models.BulkEventResponse{
+				Failed:  1,
+				Results: []models.BulkEventResult{{Index: 0, Error: "event_type:please provide an event type"}},
+			},
+		},
+		{
+			name: "should_fail_to_write_events",
+			newMessages: []string{
+				`{"endpoint_id":"123","event_type":"payment.created","data":{}}`,
+			},
+			project: project,
+			dbFn: func(bs *CreateBulkEventService) {
+				e, _ := bs.EndpointRepo.(*mocks.MockEndpointRepository)
+				e.EXPECT().FindEndpointByID(gomock.Any(), "123", "abc").
+					Times(1).Return(&datastore.Endpoint{UID: "123", ProjectID: "abc"}, nil)
+
+				ev, _ := bs.EventRepo.(*mocks.MockEventRepository)
+				ev.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("failed"))
+			},
+			wantErrMsg: "failed to create events",
+		},
+		{
+			name:        "should_error_for_empty_request",
+			newMessages: []string{},
+			project:     project,
+			wantErrMsg:  "please provide at least one event",
+		},
+		{
+			name:        "should_error_for_invalid_strategy_config",
+			newMessages: []string{`{}`},
+			project:     &datastore.Project{UID: "abc"},
+			wantErrMsg:  "retry strategy not defined in configuration",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			bs := provideCreateBulkEventService(ctrl, tc.newMessages, tc.project)
+
+			if tc.dbFn != nil {
+				tc.dbFn(bs)
+			}
+
+			resp, err := bs.Run(ctx)
+			if tc.wantErrMsg != "" {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.wantResp.Created, resp.Created)
+			require.Equal(t, tc.wantResp.Failed, resp.Failed)
+			require.Equal(t, len(tc.wantResp.Results), len(resp.Results))
+
+			for i, want := range tc.wantResp.Results {
+				got := resp.Results[i]
+				require.Equal(t, want.Index, got.Index)
+				require.Equal(t, want.Error, got.Error)
+
+				if want.Error == "" {
+					require.NotEmpty(t, got.EventID)
+				}
+			}
+		})
+	}
+}
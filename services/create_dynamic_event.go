@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/api/models"
@@ -28,6 +29,29 @@ func (e *CreateDynamicEventService) Run(ctx context.Context) error {
 
 	e.DynamicEvent.Event.ProjectID = e.Project.UID
 
+	// the delay and ttl are resolved now, they'd otherwise start counting
+	// from when the worker picks the event up
+	now := time.Now()
+	deliverAt, err := e.DynamicEvent.Event.DeliveryTime(now)
+	if err != nil {
+		return &ServiceError{ErrMsg: err.Error()}
+	}
+
+	expiresAt, err := e.DynamicEvent.Event.ExpiryTime(now)
+	if err != nil {
+		return &ServiceError{ErrMsg: err.Error()}
+	}
+
+	if !deliverAt.IsZero() {
+		e.DynamicEvent.Event.DeliverAt = &deliverAt
+		e.DynamicEvent.Event.Delay = ""
+	}
+
+	if !expiresAt.IsZero() {
+		e.DynamicEvent.Event.ExpiresAt = &expiresAt
+		e.DynamicEvent.Event.TTL = ""
+	}
+
 	taskName := convoy.CreateDynamicEventProcessor
 
 	eventByte, err := json.Marshal(e.DynamicEvent)
@@ -4,14 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
 	"time"
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub/destination"
+	"github.com/frain-dev/convoy/net"
 	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/signature"
 	"github.com/frain-dev/convoy/util"
 	"github.com/oklog/ulid/v2"
 )
@@ -27,12 +30,19 @@ type CreateEndpointService struct {
 }
 
 func (a *CreateEndpointService) Run(ctx context.Context) (*datastore.Endpoint, error) {
-	url, err := util.CleanEndpoint(a.E.URL)
+	kind := a.E.Kind
+	if util.IsStringEmpty(string(kind)) {
+		kind = datastore.HTTPEndpointKind
+	}
+
+	dest := a.E.Destination.Transform()
+	url, err := endpointTarget(ctx, kind, a.E.URL, dest)
 	if err != nil {
-		return nil, &ServiceError{ErrMsg: err.Error()}
+		return nil, err
 	}
 
 	a.E.URL = url
+
 	if a.E.RateLimit == 0 {
 		a.E.RateLimit = convoy.RATE_LIMIT
 	}
@@ -76,6 +86,11 @@ func (a *CreateEndpointService) Run(ctx context.Context) (*datastore.Endpoint, e
 		AdvancedSignatures: a.E.AdvancedSignatures,
 		AppID:              a.E.AppID,
 		RateLimitDuration:  duration.String(),
+		OrderedDelivery:    a.E.OrderedDelivery,
+		PartitionKeyPath:   a.E.PartitionKeyPath,
+		Kind:               kind,
+		Destination:        dest,
+		ContentEncoding:    a.E.ContentEncoding,
 		Status:             datastore.ActiveEndpointStatus,
 		CreatedAt:          time.Now(),
 		UpdatedAt:          time.Now(),
@@ -86,7 +101,7 @@ func (a *CreateEndpointService) Run(ctx context.Context) (*datastore.Endpoint, e
 	}
 
 	if util.IsStringEmpty(a.E.Secret) {
-		sc, err := util.GenerateSecret()
+		sc, err := generateEndpointSecret(project)
 		if err != nil {
 			return nil, &ServiceError{ErrMsg: "could not generate secret", Err: err}
 		}
@@ -108,11 +123,16 @@ func (a *CreateEndpointService) Run(ctx context.Context) (*datastore.Endpoint, e
 		})
 	}
 
-	auth, err := ValidateEndpointAuthentication(a.E.Authentication.Transform())
+	auth, err := models.ValidateEndpointAuthentication(a.E.Authentication.Transform())
 	if err != nil {
 		return nil, &ServiceError{ErrMsg: err.Error()}
 	}
 
+	err = encryptEndpointAuthentication(auth)
+	if err != nil {
+		return nil, err
+	}
+
 	endpoint.Authentication = auth
 	err = a.EndpointRepo.CreateEndpoint(ctx, endpoint, a.ProjectID)
 	if err != nil {
@@ -161,18 +181,94 @@ func (a *CreateEndpointService) Run(ctx context.Context) (*datastore.Endpoint, e
 	return endpoint, nil
 }
 
-func ValidateEndpointAuthentication(auth *datastore.EndpointAuthentication) (*datastore.EndpointAuthentication, error) {
-	if auth != nil && !util.IsStringEmpty(string(auth.Type)) {
-		if err := util.Validate(auth); err != nil {
-			return nil, err
+// generateEndpointSecret generates a whsec_ secret for projects signing with
+// Standard Webhooks, since receivers expect their secrets in that format.
+func generateEndpointSecret(project *datastore.Project) (string, error) {
+	if project.Config != nil && project.Config.GetSignatureConfig().IsStandardWebhooks() {
+		return signature.GenerateStandardSecret()
+	}
+
+	return util.GenerateSecret()
+}
+
+// endpointTarget returns the endpoint's target url, for http endpoints
+// it is the cleaned url, other kinds get their queue's url.
+func endpointTarget(ctx context.Context, kind datastore.EndpointKind, url string, dest *datastore.EndpointDestination) (string, error) {
+	if kind == datastore.HTTPEndpointKind {
+		url, err := util.CleanEndpoint(url)
+		if err != nil {
+			return "", &ServiceError{ErrMsg: err.Error()}
 		}
 
-		if auth == nil && auth.Type == datastore.APIKeyAuthentication {
-			return nil, util.NewServiceError(http.StatusBadRequest, errors.New("api key field is required"))
+		err = validateEndpointEgress(ctx, url)
+		if err != nil {
+			return "", err
 		}
 
-		return auth, nil
+		return url, nil
+	}
+
+	policy, err := egressPolicy()
+	if err != nil {
+		return "", err
+	}
+
+	err = destination.Validate(kind, dest, policy)
+	if err != nil {
+		return "", &ServiceError{ErrMsg: err.Error()}
+	}
+
+	d, err := destination.New(kind, dest, nil)
+	if err != nil {
+		return "", &ServiceError{ErrMsg: err.Error()}
+	}
+
+	return d.URL(), nil
+}
+
+// validateEndpointEgress rejects endpoint urls the egress policy would
+// block, so they are caught before any delivery is attempted.
+func validateEndpointEgress(ctx context.Context, url string) error {
+	policy, err := egressPolicy()
+	if err != nil {
+		return err
+	}
+
+	err = policy.ValidateURL(ctx, url)
+	if err != nil {
+		return &ServiceError{ErrMsg: err.Error()}
+	}
+
+	return nil
+}
+
+// encryptEndpointAuthentication encrypts the endpoint's mtls client key
+// and oauth2 client secret with the configured encryption key.
+func encryptEndpointAuthentication(auth *datastore.EndpointAuthentication) error {
+	cfg, err := config.Get()
+	if err != nil {
+		return &ServiceError{ErrMsg: "failed to load configuration", Err: err}
+	}
+
+	err = models.EncryptEndpointAuthentication(auth, cfg.EncryptionKey)
+	if err != nil {
+		return &ServiceError{ErrMsg: err.Error()}
+	}
+
+	return nil
+}
+
+// egressPolicy builds the egress policy endpoints are checked against.
+func egressPolicy() (*net.EgressPolicy, error) {
+	cfg, err := config.Get()
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: "failed to load configuration", Err: err}
+	}
+
+	policy, err := net.NewEgressPolicy(cfg.Egress.AllowPrivateNetworks, cfg.Egress.AllowList, cfg.Egress.DenyList)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: "failed to load egress policy", Err: err}
 	}
 
-	return nil, nil
+	return policy, nil
 }
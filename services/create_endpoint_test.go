@@ -5,12 +5,14 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
 )
 
 func provideCreateEndpointService(ctrl *gomock.Controller, e models.CreateEndpoint, projectID string) *CreateEndpointService {
@@ -81,6 +83,7 @@ func TestCreateEndpointService_Run(t *testing.T) {
 				RateLimit:         5000,
 				Status:            datastore.ActiveEndpointStatus,
 				RateLimitDuration: "1m0s",
+				Kind:              datastore.HTTPEndpointKind,
 			},
 			wantErr: false,
 		},
@@ -128,6 +131,7 @@ func TestCreateEndpointService_Run(t *testing.T) {
 				RateLimit:         100,
 				Status:            datastore.ActiveEndpointStatus,
 				RateLimitDuration: "1m0s",
+				Kind:              datastore.HTTPEndpointKind,
 				Authentication: &datastore.EndpointAuthentication{
 					Type: datastore.APIKeyAuthentication,
 					ApiKey: &datastore.ApiKey{
@@ -155,6 +159,139 @@ func TestCreateEndpointService_Run(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: `an error occurred parsing the rate limit duration: time: invalid duration "m"`,
 		},
+		{
+			name: "should_error_for_private_network_url",
+			args: args{
+				ctx: ctx,
+				e: models.CreateEndpoint{
+					Name:        "test_endpoint",
+					Secret:      "1234",
+					URL:         "http://169.254.169.254/latest/meta-data",
+					Description: "test_endpoint",
+				},
+				g: project,
+			},
+			wantErr:    true,
+			wantErrMsg: "delivery to 169.254.169.254 blocked by egress policy: address 169.254.169.254 is in a private network",
+		},
+		{
+			name: "should_error_for_invalid_mtls_client_certificate",
+			args: args{
+				ctx: ctx,
+				e: models.CreateEndpoint{
+					Name:              "test_endpoint",
+					Secret:            "1234",
+					RateLimit:         100,
+					RateLimitDuration: "1m",
+					URL:               "https://google.com",
+					Authentication: &models.EndpointAuthentication{
+						Type: datastore.MTLSAuthentication,
+						MTLS: &models.MTLSClientCert{
+							ClientCert: "invalid-cert",
+							ClientKey:  "invalid-key",
+						},
+					},
+				},
+				g: project,
+			},
+			dbFn: func(app *CreateEndpointService) {
+				p, _ := app.ProjectRepo.(*mocks.MockProjectRepository)
+				p.EXPECT().FetchProjectByID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(project, nil)
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid mtls client certificate: tls: failed to find any PEM data in certificate input",
+		},
+		{
+			name: "should_error_for_missing_oauth2_config",
+			args: args{
+				ctx: ctx,
+				e: models.CreateEndpoint{
+					Name:              "test_endpoint",
+					Secret:            "1234",
+					RateLimit:         100,
+					RateLimitDuration: "1m",
+					URL:               "https://google.com",
+					Authentication: &models.EndpointAuthentication{
+						Type: datastore.OAuth2Authentication,
+					},
+				},
+				g: project,
+			},
+			dbFn: func(app *CreateEndpointService) {
+				p, _ := app.ProjectRepo.(*mocks.MockProjectRepository)
+				p.EXPECT().FetchProjectByID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(project, nil)
+			},
+			wantErr:    true,
+			wantErrMsg: "oauth2 field is required",
+		},
+		{
+			name: "should_create_google_pub_sub_endpoint",
+			args: args{
+				ctx: ctx,
+				e: models.CreateEndpoint{
+					Name:   "endpoint",
+					Secret: "1234",
+					Kind:   datastore.GoogleEndpointKind,
+					Destination: &models.EndpointDestination{
+						Google: &models.GooglePubSubTopicConfig{
+							ProjectID:      "convoy",
+							TopicID:        "events",
+							ServiceAccount: []byte("{}"),
+						},
+					},
+				},
+				g: project,
+			},
+			dbFn: func(app *CreateEndpointService) {
+				p, _ := app.ProjectRepo.(*mocks.MockProjectRepository)
+				p.EXPECT().FetchProjectByID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(project, nil)
+
+				a, _ := app.EndpointRepo.(*mocks.MockEndpointRepository)
+				a.EXPECT().CreateEndpoint(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				c, _ := app.Cache.(*mocks.MockCache)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+			},
+			wantEndpoint: &datastore.Endpoint{
+				ProjectID: project.UID,
+				Title:     "endpoint",
+				Secrets: []datastore.Secret{
+					{Value: "1234"},
+				},
+				TargetURL:         "google://convoy/events",
+				RateLimit:         5000,
+				Status:            datastore.ActiveEndpointStatus,
+				RateLimitDuration: "1m0s",
+				Kind:              datastore.GoogleEndpointKind,
+				Destination: &datastore.EndpointDestination{
+					Google: &datastore.GooglePubSubTopicConfig{
+						ProjectID:      "convoy",
+						TopicID:        "events",
+						ServiceAccount: []byte("{}"),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_fail_to_create_endpoint_without_destination",
+			args: args{
+				ctx: ctx,
+				e: models.CreateEndpoint{
+					Name: "endpoint",
+					Kind: datastore.KafkaEndpointKind,
+				},
+				g: project,
+			},
+			wantErr:    true,
+			wantErrMsg: "kafka destination config is required",
+		},
 		{
 			name: "should_fail_to_create_endpoint",
 			args: args{
@@ -186,6 +323,10 @@ func TestCreateEndpointService_Run(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
+
+			err := config.LoadConfig("./testdata/basic-config.json")
+			require.NoError(t, err)
+
 			as := provideCreateEndpointService(ctrl, tc.args.e, tc.args.g.UID)
 
 			// Arrange Expectations
@@ -211,3 +352,65 @@ func TestCreateEndpointService_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateEndpointService_Run_EncryptsAuthentication(t *testing.T) {
+	project := &datastore.Project{UID: "1234567890", Type: datastore.OutgoingProject}
+	e := models.CreateEndpoint{
+		Name:   "endpoint",
+		Secret: "1234",
+		URL:    "https://google.com",
+		Authentication: &models.EndpointAuthentication{
+			Type: datastore.OAuth2Authentication,
+			OAuth2: &models.OAuth2{
+				TokenURL:     "https://auth.example.com/token",
+				ClientID:     "client-id",
+				ClientSecret: "client-secret",
+			},
+		},
+	}
+
+	t.Run("should_encrypt_oauth2_client_secret", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		t.Setenv("CONVOY_ENCRYPTION_KEY", "encryption-key")
+		err := config.LoadConfig("./testdata/basic-config.json")
+		require.NoError(t, err)
+
+		as := provideCreateEndpointService(ctrl, e, project.UID)
+
+		p, _ := as.ProjectRepo.(*mocks.MockProjectRepository)
+		p.EXPECT().FetchProjectByID(gomock.Any(), gomock.Any()).Times(1).Return(project, nil)
+
+		a, _ := as.EndpointRepo.(*mocks.MockEndpointRepository)
+		a.EXPECT().CreateEndpoint(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		c, _ := as.Cache.(*mocks.MockCache)
+		c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+
+		endpoint, err := as.Run(context.Background())
+		require.NoError(t, err)
+		require.NotEqual(t, "client-secret", endpoint.Authentication.OAuth2.ClientSecret)
+
+		secret, err := util.Decrypt(endpoint.Authentication.OAuth2.ClientSecret, "encryption-key")
+		require.NoError(t, err)
+		require.Equal(t, "client-secret", string(secret))
+	})
+
+	t.Run("should_error_without_encryption_key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		t.Setenv("CONVOY_ENCRYPTION_KEY", "")
+		err := config.LoadConfig("./testdata/basic-config.json")
+		require.NoError(t, err)
+
+		as := provideCreateEndpointService(ctrl, e, project.UID)
+
+		p, _ := as.ProjectRepo.(*mocks.MockProjectRepository)
+		p.EXPECT().FetchProjectByID(gomock.Any(), gomock.Any()).Times(1).Return(project, nil)
+
+		_, err = as.Run(context.Background())
+		require.EqualError(t, err, "an encryption key must be configured to store mtls and oauth2 credentials")
+	})
+}
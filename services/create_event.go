@@ -15,6 +15,7 @@ import (
 	"github.com/frain-dev/convoy/util"
 	"github.com/frain-dev/convoy/worker/task"
 	"github.com/oklog/ulid/v2"
+	"gopkg.in/guregu/null.v4"
 )
 
 var (
@@ -25,9 +26,10 @@ var (
 )
 
 type CreateEventService struct {
-	EndpointRepo datastore.EndpointRepository
-	EventRepo    datastore.EventRepository
-	Queue        queue.Queuer
+	EndpointRepo  datastore.EndpointRepository
+	EventRepo     datastore.EventRepository
+	EventTypeRepo datastore.EventTypeRepository
+	Queue         queue.Queuer
 
 	NewMessage *models.CreateEvent
 	Project    *datastore.Project
@@ -41,6 +43,8 @@ type newEvent struct {
 	CustomHeaders  map[string]string
 	IdempotencyKey string
 	IsDuplicate    bool
+	DeliverAt      time.Time
+	ExpiresAt      time.Time
 }
 
 func (c *CreateEventService) Run(ctx context.Context) (*datastore.Event, error) {
@@ -62,6 +66,22 @@ func (c *CreateEventService) Run(ctx context.Context) (*datastore.Event, error)
 		return nil, &ServiceError{ErrMsg: ErrInvalidEndpointID.Error()}
 	}
 
+	now := time.Now()
+	deliverAt, err := c.NewMessage.DeliveryTime(now)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error()}
+	}
+
+	expiresAt, err := c.NewMessage.ExpiryTime(now)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error()}
+	}
+
+	err = ValidateEventSchema(ctx, c.EventTypeRepo, c.Project, c.NewMessage.EventType, c.NewMessage.Data)
+	if err != nil {
+		return nil, err
+	}
+
 	endpoints, err := c.FindEndpoints(ctx, c.NewMessage, c.Project)
 	if err != nil {
 		log.FromContext(ctx).WithError(err).Error("failed to find endpoints")
@@ -80,6 +100,8 @@ func (c *CreateEventService) Run(ctx context.Context) (*datastore.Event, error)
 		CustomHeaders:  c.NewMessage.CustomHeaders,
 		IdempotencyKey: c.NewMessage.IdempotencyKey,
 		IsDuplicate:    isDuplicate,
+		DeliverAt:      deliverAt,
+		ExpiresAt:      expiresAt,
 	}
 
 	event, err := createEvent(ctx, endpoints, newEvent, c.Project, c.Queue)
@@ -105,13 +127,31 @@ func getCustomHeaders(customHeaders map[string]string) httpheader.HTTPHeader {
 }
 
 func createEvent(ctx context.Context, endpoints []datastore.Endpoint, newMessage *newEvent, g *datastore.Project, queuer queue.Queuer) (*datastore.Event, error) {
+	event, err := buildEvent(endpoints, newMessage, g)
+	if err != nil {
+		return nil, err
+	}
+
+	err = queueEvent(event, !util.IsStringEmpty(newMessage.EndpointID), queuer)
+	if err != nil {
+		log.FromContext(ctx).Errorf("Error occurred sending new event to the queue %s", err)
+	}
+
+	return event, nil
+}
+
+func buildEvent(endpoints []datastore.Endpoint, newMessage *newEvent, g *datastore.Project) (*datastore.Event, error) {
 	var endpointIDs []string
 
 	for _, endpoint := range endpoints {
 		endpointIDs = append(endpointIDs, endpoint.UID)
 	}
 
-	event := &datastore.Event{
+	if err := validateRetryStrategy(g); err != nil {
+		return nil, err
+	}
+
+	return &datastore.Event{
 		UID:              ulid.Make().String(),
 		EventType:        datastore.EventType(newMessage.EventType),
 		Data:             newMessage.Data,
@@ -123,38 +163,41 @@ func createEvent(ctx context.Context, endpoints []datastore.Endpoint, newMessage
 		UpdatedAt:        time.Now(),
 		Endpoints:        endpointIDs,
 		ProjectID:        g.UID,
-	}
+		DeliverAt:        null.NewTime(newMessage.DeliverAt, !newMessage.DeliverAt.IsZero()),
+		ExpiresAt:        null.NewTime(newMessage.ExpiresAt, !newMessage.ExpiresAt.IsZero()),
+	}, nil
+}
 
+func validateRetryStrategy(g *datastore.Project) error {
 	if (g.Config == nil || g.Config.Strategy == nil) ||
-		(g.Config.Strategy != nil && g.Config.Strategy.Type != datastore.LinearStrategyProvider && g.Config.Strategy.Type != datastore.ExponentialStrategyProvider) {
-		return nil, &ServiceError{ErrMsg: "retry strategy not defined in configuration"}
+		(g.Config.Strategy != nil && g.Config.Strategy.Type != datastore.LinearStrategyProvider &&
+			g.Config.Strategy.Type != datastore.ExponentialStrategyProvider && g.Config.Strategy.Type != datastore.CustomStrategyProvider) {
+		return &ServiceError{ErrMsg: "retry strategy not defined in configuration"}
 	}
 
-	taskName := convoy.CreateEventProcessor
+	return nil
+}
 
+// queueEvent sends the event to be created along with its deliveries, the
+// worker skips creating events that were already written.
+func queueEvent(event *datastore.Event, createSubscription bool, queuer queue.Queuer) error {
 	createEvent := task.CreateEvent{
 		Event:              *event,
-		CreateSubscription: !util.IsStringEmpty(newMessage.EndpointID),
+		CreateSubscription: createSubscription,
 	}
 
 	eventByte, err := json.Marshal(createEvent)
 	if err != nil {
-		return nil, &ServiceError{ErrMsg: err.Error()}
+		return err
 	}
 
-	payload := json.RawMessage(eventByte)
-
 	job := &queue.Job{
 		ID:      event.UID,
-		Payload: payload,
+		Payload: json.RawMessage(eventByte),
 		Delay:   0,
 	}
-	err = queuer.Write(taskName, convoy.CreateEventQueue, job)
-	if err != nil {
-		log.FromContext(ctx).Errorf("Error occurred sending new event to the queue %s", err)
-	}
 
-	return event, nil
+	return queuer.Write(convoy.CreateEventProcessor, convoy.CreateEventQueue, job)
 }
 
 func (c *CreateEventService) FindEndpoints(ctx context.Context, newMessage *models.CreateEvent, project *datastore.Project) ([]datastore.Endpoint, error) {
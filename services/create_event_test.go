@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/config"
@@ -11,6 +12,7 @@ import (
 	"github.com/frain-dev/convoy/pkg/httpheader"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/datastore"
@@ -18,16 +20,18 @@ import (
 
 func provideCreateEventService(ctrl *gomock.Controller, event *models.CreateEvent, project *datastore.Project) *CreateEventService {
 	return &CreateEventService{
-		EndpointRepo: mocks.NewMockEndpointRepository(ctrl),
-		EventRepo:    mocks.NewMockEventRepository(ctrl),
-		Queue:        mocks.NewMockQueuer(ctrl),
-		NewMessage:   event,
-		Project:      project,
+		EndpointRepo:  mocks.NewMockEndpointRepository(ctrl),
+		EventRepo:     mocks.NewMockEventRepository(ctrl),
+		EventTypeRepo: mocks.NewMockEventTypeRepository(ctrl),
+		Queue:         mocks.NewMockQueuer(ctrl),
+		NewMessage:    event,
+		Project:       project,
 	}
 }
 
 func TestCreateEventService_Run(t *testing.T) {
 	ctx := context.Background()
+	deliverAt := time.Now().Add(time.Hour)
 	type args struct {
 		ctx        context.Context
 		newMessage *models.CreateEvent
@@ -282,6 +286,86 @@ func TestCreateEventService_Run(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: datastore.ErrEndpointNotFound.Error(),
 		},
+		{
+			name: "should_error_for_data_not_matching_event_type_schema",
+			dbFn: func(es *CreateEventService) {
+				et, _ := es.EventTypeRepo.(*mocks.MockEventTypeRepository)
+				et.EXPECT().FindEventTypeByName(gomock.Any(), "abc", "payment.created").
+					Times(1).Return(&datastore.ProjectEventType{
+					Name:       "payment.created",
+					JSONSchema: []byte(`{"type":"object","required":["amount"]}`),
+				}, nil)
+			},
+			args: args{
+				ctx: ctx,
+				newMessage: &models.CreateEvent{
+					EndpointID: "123",
+					EventType:  "payment.created",
+					Data:       bytes.NewBufferString(`{"name":"convoy"}`).Bytes(),
+				},
+				g: &datastore.Project{
+					UID:    "abc",
+					Config: &datastore.ProjectConfig{IsSchemaValidationEnabled: true},
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid data for event type payment.created: payload does not match schema: /: missing properties: 'amount'",
+		},
+
+		{
+			name: "should_create_scheduled_event",
+			dbFn: func(es *CreateEventService) {
+				a, _ := es.EndpointRepo.(*mocks.MockEndpointRepository)
+				a.EXPECT().FindEndpointByID(gomock.Any(), gomock.Any(), "abc").
+					Times(1).Return(&datastore.Endpoint{UID: "123", ProjectID: "abc"}, nil)
+
+				eq, _ := es.Queue.(*mocks.MockQueuer)
+				eq.EXPECT().Write(convoy.CreateEventProcessor, convoy.CreateEventQueue, gomock.Any()).
+					Times(1).Return(nil)
+			},
+			args: args{
+				ctx: ctx,
+				newMessage: &models.CreateEvent{
+					EndpointID: "123",
+					EventType:  "payment.created",
+					Data:       bytes.NewBufferString(`{"name":"convoy"}`).Bytes(),
+					Schedule:   models.Schedule{DeliverAt: &deliverAt},
+				},
+				g: &datastore.Project{
+					UID: "abc",
+					Config: &datastore.ProjectConfig{
+						Strategy: &datastore.StrategyConfiguration{
+							Type:       "linear",
+							Duration:   1000,
+							RetryCount: 10,
+						},
+					},
+				},
+			},
+			wantEvent: &datastore.Event{
+				EventType: datastore.EventType("payment.created"),
+				Raw:       `{"name":"convoy"}`,
+				Data:      bytes.NewBufferString(`{"name":"convoy"}`).Bytes(),
+				Endpoints: []string{"123"},
+				ProjectID: "abc",
+				DeliverAt: null.TimeFrom(deliverAt),
+			},
+		},
+		{
+			name: "should_error_for_deliver_at_and_delay",
+			args: args{
+				ctx: ctx,
+				newMessage: &models.CreateEvent{
+					EndpointID: "123",
+					EventType:  "payment.created",
+					Data:       bytes.NewBufferString(`{"name":"convoy"}`).Bytes(),
+					Schedule:   models.Schedule{DeliverAt: &deliverAt, Delay: "1h"},
+				},
+				g: &datastore.Project{UID: "abc"},
+			},
+			wantErr:    true,
+			wantErrMsg: "only one of deliver_at and delay can be set",
+		},
 
 		{
 			name: "should_fail_to_create_event",
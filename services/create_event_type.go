@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/schema"
+	"github.com/oklog/ulid/v2"
+)
+
+type CreateEventTypeService struct {
+	EventTypeRepo datastore.EventTypeRepository
+	NewEventType  *models.CreateEventType
+	Project       *datastore.Project
+}
+
+func (c *CreateEventTypeService) Run(ctx context.Context) (*datastore.ProjectEventType, error) {
+	if !schema.IsEmpty(c.NewEventType.JSONSchema) {
+		if _, err := schema.Compile(c.NewEventType.JSONSchema); err != nil {
+			return nil, &ServiceError{ErrMsg: err.Error()}
+		}
+	}
+
+	eventType := &datastore.ProjectEventType{
+		UID:         ulid.Make().String(),
+		ProjectID:   c.Project.UID,
+		Name:        c.NewEventType.Name,
+		Description: c.NewEventType.Description,
+		Version:     c.NewEventType.Version,
+		JSONSchema:  c.NewEventType.JSONSchema,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	err := c.EventTypeRepo.CreateEventType(ctx, eventType)
+	if err != nil {
+		if errors.Is(err, datastore.ErrDuplicateEventType) {
+			return nil, &ServiceError{ErrMsg: err.Error(), Err: err}
+		}
+
+		log.FromContext(ctx).WithError(err).Error("failed to create event type")
+		return nil, &ServiceError{ErrMsg: "an error occurred while creating event type", Err: err}
+	}
+
+	return eventType, nil
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func provideCreateEventTypeService(ctrl *gomock.Controller, newEventType *models.CreateEventType) *CreateEventTypeService {
+	return &CreateEventTypeService{
+		EventTypeRepo: mocks.NewMockEventTypeRepository(ctrl),
+		NewEventType:  newEventType,
+		Project:       &datastore.Project{UID: "abc"},
+	}
+}
+
+func TestCreateEventTypeService_Run(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		newEventType *models.CreateEventType
+		dbFn         func(es *CreateEventTypeService)
+		wantErrMsg   string
+	}{
+		{
+			name: "should_create_event_type",
+			newEventType: &models.CreateEventType{
+				Name:       "user.created",
+				Version:    "v1",
+				JSONSchema: []byte(`{"type":"object"}`),
+			},
+			dbFn: func(es *CreateEventTypeService) {
+				et, _ := es.EventTypeRepo.(*mocks.MockEventTypeRepository)
+				et.EXPECT().CreateEventType(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+		},
+		{
+			name:         "should_create_event_type_without_schema",
+			newEventType: &models.CreateEventType{Name: "user.created"},
+			dbFn: func(es *CreateEventTypeService) {
+				et, _ := es.EventTypeRepo.(*mocks.MockEventTypeRepository)
+				et.EXPECT().CreateEventType(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+		},
+		{
+			name: "should_error_for_invalid_schema",
+			newEventType: &models.CreateEventType{
+				Name:       "user.created",
+				JSONSchema: []byte(`{"type":"objec"}`),
+			},
+			wantErrMsg: `invalid json schema: /type: expected array, but got string; /type: value must be one of "array", "boolean", "integer", "null", "number", "object", "string"`,
+		},
+		{
+			name: "should_error_for_remote_ref",
+			newEventType: &models.CreateEventType{
+				Name:       "user.created",
+				JSONSchema: []byte(`{"$ref":"https://example.com/user.json"}`),
+			},
+			wantErrMsg: "invalid json schema: loading https://example.com/user.json is not allowed",
+		},
+		{
+			name:         "should_error_for_duplicate_name",
+			newEventType: &models.CreateEventType{Name: "user.created"},
+			dbFn: func(es *CreateEventTypeService) {
+				et, _ := es.EventTypeRepo.(*mocks.MockEventTypeRepository)
+				et.EXPECT().CreateEventType(gomock.Any(), gomock.Any()).Times(1).Return(datastore.ErrDuplicateEventType)
+			},
+			wantErrMsg: datastore.ErrDuplicateEventType.Error(),
+		},
+		{
+			name:         "should_fail_to_create_event_type",
+			newEventType: &models.CreateEventType{Name: "user.created"},
+			dbFn: func(es *CreateEventTypeService) {
+				et, _ := es.EventTypeRepo.(*mocks.MockEventTypeRepository)
+				et.EXPECT().CreateEventType(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("failed"))
+			},
+			wantErrMsg: "an error occurred while creating event type",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			es := provideCreateEventTypeService(ctrl, tc.newEventType)
+
+			if tc.dbFn != nil {
+				tc.dbFn(es)
+			}
+
+			eventType, err := es.Run(ctx)
+			if tc.wantErrMsg != "" {
+				require.Error(t, err)
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotEmpty(t, eventType.UID)
+			require.Equal(t, "abc", eventType.ProjectID)
+			require.Equal(t, tc.newEventType.Name, eventType.Name)
+			require.False(t, eventType.Deprecated)
+		})
+	}
+}
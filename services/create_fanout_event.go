@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"errors"
+	"time"
+
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/queue"
@@ -12,6 +14,7 @@ import (
 type CreateFanoutEventService struct {
 	EndpointRepo   datastore.EndpointRepository
 	EventRepo      datastore.EventRepository
+	EventTypeRepo  datastore.EventTypeRepository
 	PortalLinkRepo datastore.PortalLinkRepository
 	Queue          queue.Queuer
 
@@ -28,6 +31,21 @@ func (e *CreateFanoutEventService) Run(ctx context.Context) (*datastore.Event, e
 		return nil, &ServiceError{ErrMsg: err.Error()}
 	}
 
+	now := time.Now()
+	deliverAt, err := e.NewMessage.DeliveryTime(now)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error()}
+	}
+
+	expiresAt, err := e.NewMessage.ExpiryTime(now)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error()}
+	}
+
+	if err := ValidateEventSchema(ctx, e.EventTypeRepo, e.Project, e.NewMessage.EventType, e.NewMessage.Data); err != nil {
+		return nil, err
+	}
+
 	var isDuplicate bool
 	if !util.IsStringEmpty(e.NewMessage.IdempotencyKey) {
 		events, err := e.EventRepo.FindEventsByIdempotencyKey(ctx, e.Project.UID, e.NewMessage.IdempotencyKey)
@@ -61,6 +79,8 @@ func (e *CreateFanoutEventService) Run(ctx context.Context) (*datastore.Event, e
 		Raw:            string(e.NewMessage.Data),
 		CustomHeaders:  e.NewMessage.CustomHeaders,
 		IsDuplicate:    isDuplicate,
+		DeliverAt:      deliverAt,
+		ExpiresAt:      expiresAt,
 	}
 
 	event, err := createEvent(ctx, endpoints, ev, e.Project, e.Queue)
@@ -54,13 +54,20 @@ func (m *MetaEvent) Run(eventType string, projectID string, data interface{}) er
 	}
 
 	metaData := &datastore.Metadata{
-		NumTrials:       0,
-		RetryLimit:      project.Config.Strategy.RetryCount,
-		Data:            mpByte,
-		Raw:             string(mpByte),
-		IntervalSeconds: project.Config.Strategy.Duration,
-		Strategy:        project.Config.Strategy.Type,
-		NextSendTime:    time.Now(),
+		NumTrials:          0,
+		RetryLimit:         project.Config.Strategy.RetryCount,
+		Data:               mpByte,
+		Raw:                string(mpByte),
+		IntervalSeconds:    project.Config.Strategy.Duration,
+		Strategy:           project.Config.Strategy.Type,
+		MaxIntervalSeconds: project.Config.Strategy.MaxDuration,
+		Jitter:             project.Config.Strategy.Jitter,
+		HonorRetryAfter:    project.Config.Strategy.HonorRetryAfter,
+		NextSendTime:       time.Now(),
+	}
+
+	for _, interval := range project.Config.Strategy.Intervals {
+		metaData.Intervals = append(metaData.Intervals, uint64(interval))
 	}
 
 	metaEvent := &datastore.MetaEvent{
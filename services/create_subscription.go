@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -11,13 +12,19 @@ import (
 
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/compare"
+	"github.com/frain-dev/convoy/pkg/eventtype"
+	"github.com/frain-dev/convoy/pkg/expression"
 	"github.com/frain-dev/convoy/pkg/log"
 	"github.com/frain-dev/convoy/util"
+	"gopkg.in/guregu/null.v4"
 )
 
 var (
 	ErrInvalidSubscriptionFilterFormat = errors.New("invalid subscription filter format")
 	ErrCreateSubscriptionError         = errors.New("failed to create subscription")
+	ErrBatchedSubscriptionFunction     = errors.New("a subscription with batched delivery cannot have a transform function")
+	ErrFilterWithExpression            = errors.New("a subscription filter and filter expression cannot both be set")
 )
 
 type CreateSubcriptionService struct {
@@ -64,6 +71,11 @@ func (s *CreateSubcriptionService) Run(ctx context.Context) (*datastore.Subscrip
 		return nil, util.NewServiceError(http.StatusBadRequest, err)
 	}
 
+	ttl, err := models.SubscriptionTTL(s.NewSubscription.TTL)
+	if err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
 	subscription := &datastore.Subscription{
 		UID:        ulid.Make().String(),
 		ProjectID:  s.Project.UID,
@@ -76,11 +88,18 @@ func (s *CreateSubcriptionService) Run(ctx context.Context) (*datastore.Subscrip
 		AlertConfig:     s.NewSubscription.AlertConfig.Transform(),
 		FilterConfig:    s.NewSubscription.FilterConfig.Transform(),
 		RateLimitConfig: s.NewSubscription.RateLimitConfig.Transform(),
+		BatchConfig:     s.NewSubscription.BatchConfig.Transform(),
+		Function:        null.NewString(s.NewSubscription.Function, !util.IsStringEmpty(s.NewSubscription.Function)),
+		TTL:             ttl,
 
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
+	if subscription.BatchConfig != nil && subscription.Function.Valid {
+		return nil, &ServiceError{ErrMsg: ErrBatchedSubscriptionFunction.Error()}
+	}
+
 	if subscription.FilterConfig == nil {
 		subscription.FilterConfig = &datastore.FilterConfiguration{}
 	}
@@ -89,6 +108,16 @@ func (s *CreateSubcriptionService) Run(ctx context.Context) (*datastore.Subscrip
 		subscription.FilterConfig.EventTypes = []string{"*"}
 	}
 
+	err = validateEventTypes(subscription.FilterConfig.EventTypes)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error(), Err: err}
+	}
+
+	err = validateFilterExpression(subscription.FilterConfig)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: err.Error(), Err: err}
+	}
+
 	if len(subscription.FilterConfig.Filter.Body) == 0 && len(subscription.FilterConfig.Filter.Headers) == 0 {
 		subscription.FilterConfig.Filter = datastore.FilterSchema{
 			Headers: datastore.M{},
@@ -101,6 +130,11 @@ func (s *CreateSubcriptionService) Run(ctx context.Context) (*datastore.Subscrip
 			log.FromContext(ctx).WithError(err).Error(ErrInvalidSubscriptionFilterFormat.Error())
 			return nil, &ServiceError{ErrMsg: ErrInvalidSubscriptionFilterFormat.Error()}
 		}
+
+		err = validateFilterSchema(subscription.FilterConfig.Filter)
+		if err != nil {
+			return nil, &ServiceError{ErrMsg: err.Error(), Err: err}
+		}
 	}
 
 	err = s.SubRepo.CreateSubscription(ctx, s.Project.UID, subscription)
@@ -139,3 +173,49 @@ func (s *CreateSubcriptionService) findEndpoint(ctx context.Context, appID, endp
 
 	return endpoint, nil
 }
+
+// validateFilterSchema checks the operators used in the body and header
+// filters, the error says which filter is invalid and where.
+func validateFilterSchema(fs datastore.FilterSchema) error {
+	err := compare.Validate(fs.Body.Map())
+	if err != nil {
+		return fmt.Errorf("invalid body filter: %w", err)
+	}
+
+	err = compare.Validate(fs.Headers.Map())
+	if err != nil {
+		return fmt.Errorf("invalid headers filter: %w", err)
+	}
+
+	return nil
+}
+
+// validateEventTypes checks the event type patterns, e.g. invoice.* or
+// !order.test, are valid.
+func validateEventTypes(eventTypes []string) error {
+	err := eventtype.Validate(eventTypes)
+	if err != nil {
+		return fmt.Errorf("invalid event types: %w", err)
+	}
+
+	return nil
+}
+
+// validateFilterExpression compiles the filter expression when one is
+// set, it can't be combined with a body or headers filter.
+func validateFilterExpression(fc *datastore.FilterConfiguration) error {
+	if fc.Expression == "" {
+		return nil
+	}
+
+	if len(fc.Filter.Body) > 0 || len(fc.Filter.Headers) > 0 {
+		return ErrFilterWithExpression
+	}
+
+	_, err := expression.Compile(fc.Expression)
+	if err != nil {
+		return fmt.Errorf("invalid filter expression: %w", err)
+	}
+
+	return nil
+}
@@ -338,6 +338,127 @@ func TestCreateSubcriptionService_Run(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: "failed to create subscription",
 		},
+		{
+			name: "should fail for invalid filter",
+			args: args{
+				ctx: ctx,
+				newSubscription: &models.CreateSubscription{
+					Name:       "sub 1",
+					SourceID:   "source-id-1",
+					EndpointID: "endpoint-id-1",
+					FilterConfig: &models.FilterConfiguration{
+						Filter: models.FS{
+							Body: datastore.M{"person": map[string]interface{}{"name": map[string]interface{}{"$regex": "[a-"}}},
+						},
+					},
+				},
+				project: &datastore.Project{
+					UID: "12345",
+				},
+			},
+			dbFn: func(ss *CreateSubcriptionService) {
+				a, _ := ss.EndpointRepo.(*mocks.MockEndpointRepository)
+				a.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-id-1", gomock.Any()).
+					Times(1).Return(
+					&datastore.Endpoint{
+						ProjectID: "12345",
+					},
+					nil,
+				)
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid body filter: person.name.$regex: invalid regular expression: error parsing regexp: missing closing ]: `[a-`",
+		},
+		{
+			name: "should fail for invalid filter expression",
+			args: args{
+				ctx: ctx,
+				newSubscription: &models.CreateSubscription{
+					Name:       "sub 1",
+					SourceID:   "source-id-1",
+					EndpointID: "endpoint-id-1",
+					FilterConfig: &models.FilterConfiguration{
+						Expression: `amount > 100`,
+					},
+				},
+				project: &datastore.Project{
+					UID: "12345",
+				},
+			},
+			dbFn: func(ss *CreateSubcriptionService) {
+				a, _ := ss.EndpointRepo.(*mocks.MockEndpointRepository)
+				a.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-id-1", gomock.Any()).
+					Times(1).Return(
+					&datastore.Endpoint{
+						ProjectID: "12345",
+					},
+					nil,
+				)
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid filter expression: ERROR: <input>:1:1: undeclared reference to 'amount' (in container '')\n | amount > 100\n | ^",
+		},
+		{
+			name: "should fail for filter and filter expression",
+			args: args{
+				ctx: ctx,
+				newSubscription: &models.CreateSubscription{
+					Name:       "sub 1",
+					SourceID:   "source-id-1",
+					EndpointID: "endpoint-id-1",
+					FilterConfig: &models.FilterConfiguration{
+						Expression: `body.amount > 100`,
+						Filter: models.FS{
+							Body: datastore.M{"amount": 100},
+						},
+					},
+				},
+				project: &datastore.Project{
+					UID: "12345",
+				},
+			},
+			dbFn: func(ss *CreateSubcriptionService) {
+				a, _ := ss.EndpointRepo.(*mocks.MockEndpointRepository)
+				a.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-id-1", gomock.Any()).
+					Times(1).Return(
+					&datastore.Endpoint{
+						ProjectID: "12345",
+					},
+					nil,
+				)
+			},
+			wantErr:    true,
+			wantErrMsg: "a subscription filter and filter expression cannot both be set",
+		},
+		{
+			name: "should fail for invalid event types",
+			args: args{
+				ctx: ctx,
+				newSubscription: &models.CreateSubscription{
+					Name:       "sub 1",
+					SourceID:   "source-id-1",
+					EndpointID: "endpoint-id-1",
+					FilterConfig: &models.FilterConfiguration{
+						EventTypes: []string{"invoice.*", "order..created"},
+					},
+				},
+				project: &datastore.Project{
+					UID: "12345",
+				},
+			},
+			dbFn: func(ss *CreateSubcriptionService) {
+				a, _ := ss.EndpointRepo.(*mocks.MockEndpointRepository)
+				a.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-id-1", gomock.Any()).
+					Times(1).Return(
+					&datastore.Endpoint{
+						ProjectID: "12345",
+					},
+					nil,
+				)
+			},
+			wantErr:    true,
+			wantErrMsg: `invalid event types: event type pattern "order..created" has an empty segment`,
+		},
 		{
 			name: "create subscription for outgoing project - should set default event types array",
 			args: args{
@@ -75,7 +75,7 @@ func (a *ExpireSecretService) Run(ctx context.Context) (*datastore.Endpoint, err
 	// Generate new secret.
 	newSecret := a.S.Secret
 	if len(newSecret) == 0 {
-		newSecret, err = util.GenerateSecret()
+		newSecret, err = generateEndpointSecret(a.Project)
 		if err != nil {
 			return nil, util.NewServiceError(http.StatusBadRequest, fmt.Errorf(fmt.Sprintf("could not generate secret...%v", err.Error())))
 		}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/queue"
+)
+
+type RedriveDeadLetterService struct {
+	DeadLetterRepo    datastore.DeadLetterRepository
+	EventDeliveryRepo datastore.EventDeliveryRepository
+	EndpointRepo      datastore.EndpointRepository
+	Queue             queue.Queuer
+
+	DeadLetter *datastore.DeadLetter
+	Project    *datastore.Project
+}
+
+func (r *RedriveDeadLetterService) Run(ctx context.Context) error {
+	eventDelivery, err := r.EventDeliveryRepo.FindEventDeliveryByID(ctx, r.Project.UID, r.DeadLetter.EventDeliveryID)
+	if err != nil {
+		return &ServiceError{ErrMsg: datastore.ErrEventDeliveryNotFound.Error(), Err: err}
+	}
+
+	if eventDelivery.Status != datastore.FailureEventStatus {
+		return &ServiceError{ErrMsg: "only failed event deliveries can be redriven"}
+	}
+
+	// the redriven delivery starts over with a fresh retry budget
+	eventDelivery.Metadata.NumTrials = 0
+	eventDelivery.Metadata.NextSendTime = time.Now()
+
+	err = r.EventDeliveryRepo.UpdateEventDeliveryMetadata(ctx, r.Project.UID, eventDelivery)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to reset event delivery retry budget")
+		return &ServiceError{ErrMsg: "failed to reset event delivery retry budget", Err: err}
+	}
+
+	rs := RetryEventDeliveryService{
+		EventDeliveryRepo: r.EventDeliveryRepo,
+		EndpointRepo:      r.EndpointRepo,
+		Queue:             r.Queue,
+		EventDelivery:     eventDelivery,
+		Project:           r.Project,
+	}
+
+	err = rs.Run(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = r.DeadLetterRepo.DeleteDeadLetter(ctx, r.Project.UID, r.DeadLetter.UID)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to remove redriven dead letter")
+		return &ServiceError{ErrMsg: "failed to remove redriven dead letter", Err: err}
+	}
+
+	return nil
+}
+
+type BatchRedriveDeadLetterService struct {
+	DeadLetterRepo    datastore.DeadLetterRepository
+	EventDeliveryRepo datastore.EventDeliveryRepository
+	EndpointRepo      datastore.EndpointRepository
+	Queue             queue.Queuer
+
+	Filter  *datastore.DeadLetterFilter
+	Project *datastore.Project
+}
+
+func (b *BatchRedriveDeadLetterService) Run(ctx context.Context) (int, int, error) {
+	deadLetters, _, err := b.DeadLetterRepo.LoadDeadLettersPaged(ctx, b.Project.UID, b.Filter)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to fetch dead letters")
+		return 0, 0, &ServiceError{ErrMsg: "failed to fetch dead letters", Err: err}
+	}
+
+	r := RedriveDeadLetterService{
+		DeadLetterRepo:    b.DeadLetterRepo,
+		EventDeliveryRepo: b.EventDeliveryRepo,
+		EndpointRepo:      b.EndpointRepo,
+		Queue:             b.Queue,
+		Project:           b.Project,
+	}
+
+	failures := 0
+	for i := range deadLetters {
+		r.DeadLetter = &deadLetters[i]
+		err := r.Run(ctx)
+		if err != nil {
+			failures++
+			log.FromContext(ctx).WithError(err).Error("an item in the batch redrive failed")
+		}
+	}
+
+	successes := len(deadLetters) - failures
+	return successes, failures, nil
+}
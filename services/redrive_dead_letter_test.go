@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func provideRedriveDeadLetterService(ctrl *gomock.Controller, deadLetter *datastore.DeadLetter, project *datastore.Project) *RedriveDeadLetterService {
+	return &RedriveDeadLetterService{
+		DeadLetterRepo:    mocks.NewMockDeadLetterRepository(ctrl),
+		EventDeliveryRepo: mocks.NewMockEventDeliveryRepository(ctrl),
+		EndpointRepo:      mocks.NewMockEndpointRepository(ctrl),
+		Queue:             mocks.NewMockQueuer(ctrl),
+		DeadLetter:        deadLetter,
+		Project:           project,
+	}
+}
+
+func TestRedriveDeadLetterService_Run(t *testing.T) {
+	ctx := context.Background()
+	type args struct {
+		deadLetter *datastore.DeadLetter
+		project    *datastore.Project
+	}
+	tests := []struct {
+		name       string
+		dbFn       func(rs *RedriveDeadLetterService)
+		args       args
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name: "should_redrive_dead_letter_with_fresh_retry_budget",
+			dbFn: func(rs *RedriveDeadLetterService) {
+				ed, _ := rs.EventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().FindEventDeliveryByID(gomock.Any(), "abc", "ed-1").
+					Times(1).Return(&datastore.EventDelivery{
+					UID:        "ed-1",
+					EndpointID: "ep-1",
+					Status:     datastore.FailureEventStatus,
+					Metadata:   &datastore.Metadata{NumTrials: 3, RetryLimit: 3},
+				}, nil)
+
+				ed.EXPECT().UpdateEventDeliveryMetadata(gomock.Any(), "abc", gomock.Any()).
+					Times(1).DoAndReturn(func(_ context.Context, _ string, delivery *datastore.EventDelivery) error {
+					require.Equal(t, uint64(0), delivery.Metadata.NumTrials)
+					return nil
+				})
+
+				a, _ := rs.EndpointRepo.(*mocks.MockEndpointRepository)
+				a.EXPECT().FindEndpointByID(gomock.Any(), "ep-1", "abc").
+					Times(1).Return(&datastore.Endpoint{Status: datastore.ActiveEndpointStatus}, nil)
+
+				ed.EXPECT().UpdateStatusOfEventDelivery(gomock.Any(), gomock.Any(), gomock.Any(), datastore.ScheduledEventStatus)
+
+				q, _ := rs.Queue.(*mocks.MockQueuer)
+				q.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).Return(nil)
+
+				dl, _ := rs.DeadLetterRepo.(*mocks.MockDeadLetterRepository)
+				dl.EXPECT().DeleteDeadLetter(gomock.Any(), "abc", "dl-1").
+					Times(1).Return(nil)
+			},
+			args: args{
+				deadLetter: &datastore.DeadLetter{UID: "dl-1", EventDeliveryID: "ed-1"},
+				project:    &datastore.Project{UID: "abc"},
+			},
+		},
+		{
+			name: "should_error_for_event_delivery_that_is_no_longer_failed",
+			dbFn: func(rs *RedriveDeadLetterService) {
+				ed, _ := rs.EventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().FindEventDeliveryByID(gomock.Any(), "abc", "ed-1").
+					Times(1).Return(&datastore.EventDelivery{
+					UID:      "ed-1",
+					Status:   datastore.SuccessEventStatus,
+					Metadata: &datastore.Metadata{},
+				}, nil)
+			},
+			args: args{
+				deadLetter: &datastore.DeadLetter{UID: "dl-1", EventDeliveryID: "ed-1"},
+				project:    &datastore.Project{UID: "abc"},
+			},
+			wantErr:    true,
+			wantErrMsg: "only failed event deliveries can be redriven",
+		},
+		{
+			name: "should_fail_to_find_event_delivery",
+			dbFn: func(rs *RedriveDeadLetterService) {
+				ed, _ := rs.EventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().FindEventDeliveryByID(gomock.Any(), "abc", "ed-1").
+					Times(1).Return(nil, datastore.ErrEventDeliveryNotFound)
+			},
+			args: args{
+				deadLetter: &datastore.DeadLetter{UID: "dl-1", EventDeliveryID: "ed-1"},
+				project:    &datastore.Project{UID: "abc"},
+			},
+			wantErr:    true,
+			wantErrMsg: "event delivery not found",
+		},
+		{
+			name: "should_fail_to_reset_retry_budget",
+			dbFn: func(rs *RedriveDeadLetterService) {
+				ed, _ := rs.EventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().FindEventDeliveryByID(gomock.Any(), "abc", "ed-1").
+					Times(1).Return(&datastore.EventDelivery{
+					UID:      "ed-1",
+					Status:   datastore.FailureEventStatus,
+					Metadata: &datastore.Metadata{NumTrials: 3},
+				}, nil)
+
+				ed.EXPECT().UpdateEventDeliveryMetadata(gomock.Any(), "abc", gomock.Any()).
+					Times(1).Return(errors.New("failed"))
+			},
+			args: args{
+				deadLetter: &datastore.DeadLetter{UID: "dl-1", EventDeliveryID: "ed-1"},
+				project:    &datastore.Project{UID: "abc"},
+			},
+			wantErr:    true,
+			wantErrMsg: "failed to reset event delivery retry budget",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			err := config.LoadConfig("./testdata/basic-config.json")
+			require.NoError(t, err)
+
+			rs := provideRedriveDeadLetterService(ctrl, tc.args.deadLetter, tc.args.project)
+
+			if tc.dbFn != nil {
+				tc.dbFn(rs)
+			}
+
+			err = rs.Run(ctx)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+		})
+	}
+}
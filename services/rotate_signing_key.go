@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"time"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/signature"
+	"github.com/frain-dev/convoy/util"
+	"github.com/oklog/ulid/v2"
+	"gopkg.in/guregu/null.v4"
+)
+
+type RotateSigningKeyService struct {
+	SigningKeyRepo datastore.SigningKeyRepository
+	EncryptionKey  string
+
+	R       *models.RotateSigningKey
+	Project *datastore.Project
+}
+
+// Run generates a new signing key for the project. The keys in use are
+// given an expiry instead of being removed right away, so receivers can
+// still verify in-flight deliveries with them until they expire.
+func (r *RotateSigningKeyService) Run(ctx context.Context) (*datastore.SigningKey, error) {
+	if util.IsStringEmpty(r.EncryptionKey) {
+		return nil, &ServiceError{ErrMsg: "an encryption key must be configured to store signing keys"}
+	}
+
+	alg := r.R.Algorithm
+	if len(alg) == 0 {
+		alg = datastore.Ed25519SigningKeyAlgorithm
+	}
+
+	if !alg.IsValid() {
+		return nil, &ServiceError{ErrMsg: signature.ErrUnsupportedAlgorithm.Error()}
+	}
+
+	privateKey, publicKey, err := signature.GenerateKeyPair(string(alg))
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: "failed to generate signing key", Err: err}
+	}
+
+	encrypted, err := util.Encrypt(privateKey, r.EncryptionKey)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: "failed to encrypt signing key", Err: err}
+	}
+
+	keys, err := r.SigningKeyRepo.LoadActiveSigningKeys(ctx, r.Project.UID)
+	if err != nil {
+		return nil, &ServiceError{ErrMsg: "failed to load signing keys", Err: err}
+	}
+
+	expiresAt := null.TimeFrom(time.Now().Add(time.Hour * time.Duration(r.R.Expiration)))
+	for i := range keys {
+		if !keys[i].ExpiresAt.IsZero() {
+			continue
+		}
+
+		keys[i].ExpiresAt = expiresAt
+		err = r.SigningKeyRepo.UpdateSigningKey(ctx, &keys[i])
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Error("failed to expire signing key")
+			return nil, &ServiceError{ErrMsg: "failed to expire signing key", Err: err}
+		}
+	}
+
+	key := &datastore.SigningKey{
+		UID:        ulid.Make().String(),
+		ProjectID:  r.Project.UID,
+		Algorithm:  alg,
+		PublicKey:  base64.StdEncoding.EncodeToString(publicKey),
+		PrivateKey: encrypted,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	err = r.SigningKeyRepo.CreateSigningKey(ctx, key)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to create signing key")
+		return nil, &ServiceError{ErrMsg: "failed to create signing key", Err: err}
+	}
+
+	return key, nil
+}
+
+// BuildJWKS returns the public key set of a project's active signing keys.
+func BuildJWKS(keys []datastore.SigningKey) (*signature.JWKS, error) {
+	jwks := &signature.JWKS{Keys: make([]signature.JWK, 0, len(keys))}
+	for i := range keys {
+		jwk, err := keys[i].JWK()
+		if err != nil {
+			return nil, &ServiceError{ErrMsg: "failed to build key set", Err: err}
+		}
+
+		jwks.Keys = append(jwks.Keys, *jwk)
+	}
+
+	return jwks, nil
+}
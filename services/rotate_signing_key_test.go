@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/pkg/signature"
+	"github.com/frain-dev/convoy/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+)
+
+func provideRotateSigningKeyService(ctrl *gomock.Controller, r *models.RotateSigningKey, encryptionKey string) *RotateSigningKeyService {
+	return &RotateSigningKeyService{
+		SigningKeyRepo: mocks.NewMockSigningKeyRepository(ctrl),
+		EncryptionKey:  encryptionKey,
+		R:              r,
+		Project:        &datastore.Project{UID: "project-1"},
+	}
+}
+
+func TestRotateSigningKeyService_Run(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		r             *models.RotateSigningKey
+		encryptionKey string
+		dbFn          func(rs *RotateSigningKeyService)
+		wantAlgorithm datastore.SigningKeyAlgorithm
+		wantErr       bool
+		wantErrMsg    string
+	}{
+		{
+			name:          "should_create_the_first_signing_key",
+			r:             &models.RotateSigningKey{},
+			encryptionKey: "encryption-key",
+			dbFn: func(rs *RotateSigningKeyService) {
+				repo := rs.SigningKeyRepo.(*mocks.MockSigningKeyRepository)
+				repo.EXPECT().LoadActiveSigningKeys(gomock.Any(), "project-1").Times(1).Return([]datastore.SigningKey{}, nil)
+				repo.EXPECT().CreateSigningKey(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			wantAlgorithm: datastore.Ed25519SigningKeyAlgorithm,
+		},
+		{
+			name:          "should_expire_the_current_key",
+			r:             &models.RotateSigningKey{Algorithm: datastore.RSAPSSSigningKeyAlgorithm, Expiration: 24},
+			encryptionKey: "encryption-key",
+			dbFn: func(rs *RotateSigningKeyService) {
+				repo := rs.SigningKeyRepo.(*mocks.MockSigningKeyRepository)
+				repo.EXPECT().LoadActiveSigningKeys(gomock.Any(), "project-1").Times(1).Return([]datastore.SigningKey{
+					{UID: "key-2"},
+					{UID: "key-1", ExpiresAt: null.TimeFrom(time.Now().Add(time.Hour))},
+				}, nil)
+
+				repo.EXPECT().UpdateSigningKey(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, key *datastore.SigningKey) error {
+						require.Equal(t, "key-2", key.UID)
+						require.WithinDuration(t, time.Now().Add(24*time.Hour), key.ExpiresAt.Time, time.Minute)
+						return nil
+					})
+				repo.EXPECT().CreateSigningKey(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			wantAlgorithm: datastore.RSAPSSSigningKeyAlgorithm,
+		},
+		{
+			name:       "should_error_without_an_encryption_key",
+			r:          &models.RotateSigningKey{},
+			wantErr:    true,
+			wantErrMsg: "an encryption key must be configured to store signing keys",
+		},
+		{
+			name:          "should_fail_to_create_signing_key",
+			r:             &models.RotateSigningKey{},
+			encryptionKey: "encryption-key",
+			dbFn: func(rs *RotateSigningKeyService) {
+				repo := rs.SigningKeyRepo.(*mocks.MockSigningKeyRepository)
+				repo.EXPECT().LoadActiveSigningKeys(gomock.Any(), "project-1").Times(1).Return([]datastore.SigningKey{}, nil)
+				repo.EXPECT().CreateSigningKey(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("failed"))
+			},
+			wantErr:    true,
+			wantErrMsg: "failed to create signing key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			rs := provideRotateSigningKeyService(ctrl, tt.r, tt.encryptionKey)
+
+			if tt.dbFn != nil {
+				tt.dbFn(rs)
+			}
+
+			key, err := rs.Run(ctx)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tt.wantErrMsg, err.(*ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tt.wantAlgorithm, key.Algorithm)
+
+			// the stored private key must only be usable with the encryption key
+			privateKey, err := util.Decrypt(key.PrivateKey, tt.encryptionKey)
+			require.NoError(t, err)
+
+			jws, err := signature.SignDetachedJWS(string(key.Algorithm), key.UID, privateKey, []byte(`{}`))
+			require.NoError(t, err)
+
+			jwks, err := BuildJWKS([]datastore.SigningKey{*key})
+			require.NoError(t, err)
+			require.NoError(t, signature.VerifyDetachedJWS(jws, []byte(`{}`), *jwks))
+		})
+	}
+}
@@ -0,0 +1,778 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/frain-dev/convoy/auth"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/scim"
+	"github.com/frain-dev/convoy/util"
+	"github.com/oklog/ulid/v2"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+var ErrInvalidSCIMToken = errors.New("invalid scim token")
+
+// SCIMService provisions an organisation's users and groups from its
+// identity provider.
+//
+// A SCIM user is a Convoy user linked to the organisation, an active user
+// is one with an organisation membership. A SCIM group carries a role in
+// the organisation and its members' roles follow the groups they are in.
+type SCIMService struct {
+	scimRepo      datastore.SCIMRepository
+	orgRepo       datastore.OrganisationRepository
+	orgMemberRepo datastore.OrganisationMemberRepository
+	userRepo      datastore.UserRepository
+	projectRepo   datastore.ProjectRepository
+}
+
+func NewSCIMService(scimRepo datastore.SCIMRepository, orgRepo datastore.OrganisationRepository,
+	orgMemberRepo datastore.OrganisationMemberRepository, userRepo datastore.UserRepository,
+	projectRepo datastore.ProjectRepository,
+) *SCIMService {
+	return &SCIMService{
+		scimRepo:      scimRepo,
+		orgRepo:       orgRepo,
+		orgMemberRepo: orgMemberRepo,
+		userRepo:      userRepo,
+		projectRepo:   projectRepo,
+	}
+}
+
+// GenerateToken creates a new SCIM token for the organisation, revoking
+// its previous one. The token is only ever returned here.
+func (s *SCIMService) GenerateToken(ctx context.Context, org *datastore.Organisation) (string, error) {
+	maskID, key := util.GenerateAPIKey()
+
+	salt, err := util.GenerateSecret()
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to generate salt")
+		return "", util.NewServiceError(http.StatusBadRequest, errors.New("failed to generate scim token"))
+	}
+
+	dk := pbkdf2.Key([]byte(key), []byte(salt), 4096, 32, sha256.New)
+
+	token := &datastore.SCIMToken{
+		UID:            ulid.Make().String(),
+		OrganisationID: org.UID,
+		MaskID:         maskID,
+		Hash:           base64.URLEncoding.EncodeToString(dk),
+		Salt:           salt,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	err = s.scimRepo.CreateSCIMToken(ctx, token)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to create scim token")
+		return "", util.NewServiceError(http.StatusBadRequest, errors.New("failed to generate scim token"))
+	}
+
+	return key, nil
+}
+
+// Authenticate returns the organisation a SCIM token belongs to.
+func (s *SCIMService) Authenticate(ctx context.Context, key string) (*datastore.Organisation, error) {
+	keySplit := strings.Split(key, ".")
+	if len(keySplit) != 3 {
+		return nil, ErrInvalidSCIMToken
+	}
+
+	token, err := s.scimRepo.FindSCIMTokenByMaskID(ctx, keySplit[1])
+	if err != nil {
+		if !errors.Is(err, datastore.ErrSCIMTokenNotFound) {
+			log.FromContext(ctx).WithError(err).Error("failed to find scim token")
+		}
+		return nil, ErrInvalidSCIMToken
+	}
+
+	decodedKey, err := base64.URLEncoding.DecodeString(token.Hash)
+	if err != nil {
+		return nil, ErrInvalidSCIMToken
+	}
+
+	dk := pbkdf2.Key([]byte(key), []byte(token.Salt), 4096, 32, sha256.New)
+	if !bytes.Equal(dk, decodedKey) {
+		return nil, ErrInvalidSCIMToken
+	}
+
+	org, err := s.orgRepo.FetchOrganisationByID(ctx, token.OrganisationID)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to find scim token organisation")
+		return nil, ErrInvalidSCIMToken
+	}
+
+	return org, nil
+}
+
+func (s *SCIMService) ListUsers(ctx context.Context, org *datastore.Organisation, filter scim.Filter) ([]*scim.User, error) {
+	users, err := s.scimRepo.LoadSCIMUsers(ctx, org.UID)
+	if err != nil {
+		return nil, scimInternalError(ctx, err, "failed to load users")
+	}
+
+	groups, err := s.scimRepo.LoadSCIMGroups(ctx, org.UID)
+	if err != nil {
+		return nil, scimInternalError(ctx, err, "failed to load groups")
+	}
+
+	resources := make([]*scim.User, 0, len(users))
+	for i := range users {
+		u := toSCIMUser(&users[i], groups)
+
+		ok, err := matchFilter(filter, u)
+		if err != nil {
+			return nil, scimInternalError(ctx, err, "failed to filter users")
+		}
+
+		if ok {
+			resources = append(resources, u)
+		}
+	}
+
+	return resources, nil
+}
+
+func (s *SCIMService) GetUser(ctx context.Context, org *datastore.Organisation, id string) (*scim.User, error) {
+	user, err := s.scimRepo.FindSCIMUser(ctx, org.UID, id)
+	if err != nil {
+		if errors.Is(err, datastore.ErrSCIMUserNotFound) {
+			return nil, scim.NewError(http.StatusNotFound, "", fmt.Sprintf("user %s not found", id))
+		}
+		return nil, scimInternalError(ctx, err, "failed to find user")
+	}
+
+	groups, err := s.scimRepo.FindSCIMGroupsByUserID(ctx, org.UID, id)
+	if err != nil {
+		return nil, scimInternalError(ctx, err, "failed to find user groups")
+	}
+
+	return toSCIMUser(user, groups), nil
+}
+
+// CreateUser provisions a user, userName is their email. A user who
+// already has a Convoy account is adopted as they are, otherwise an
+// account is created with a password they'll have to reset.
+func (s *SCIMService) CreateUser(ctx context.Context, org *datastore.Organisation, u *scim.User) (*scim.User, error) {
+	email := strings.TrimSpace(u.UserName)
+	if email == "" {
+		return nil, scim.BadRequest(scim.ErrInvalidValue, "userName is required")
+	}
+
+	scimUser := &datastore.SCIMUser{OrganisationID: org.UID, ExternalID: u.ExternalID}
+
+	user, err := s.userRepo.FindUserByEmail(ctx, email)
+	switch {
+	case err == nil:
+		_, err = s.scimRepo.FindSCIMUser(ctx, org.UID, user.UID)
+		if err == nil {
+			return nil, scim.NewError(http.StatusConflict, scim.ErrUniqueness, fmt.Sprintf("user %s already exists", email))
+		}
+
+		if !errors.Is(err, datastore.ErrSCIMUserNotFound) {
+			return nil, scimInternalError(ctx, err, "failed to find user")
+		}
+	case errors.Is(err, datastore.ErrUserNotFound):
+		user, err = s.createUser(ctx, email, u.Name)
+		if err != nil {
+			return nil, scimInternalError(ctx, err, "failed to create user")
+		}
+		scimUser.Provisioned = true
+	default:
+		return nil, scimInternalError(ctx, err, "failed to find user")
+	}
+
+	scimUser.UserID = user.UID
+	err = s.scimRepo.CreateSCIMUser(ctx, scimUser)
+	if err != nil {
+		return nil, scimInternalError(ctx, err, "failed to create user")
+	}
+
+	if u.IsActive() {
+		err = s.activate(ctx, org, user.UID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetUser(ctx, org, user.UID)
+}
+
+func (s *SCIMService) createUser(ctx context.Context, email string, name *scim.Name) (*datastore.User, error) {
+	secret, err := util.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	p := datastore.Password{Plaintext: secret}
+	err = p.GenerateHash()
+	if err != nil {
+		return nil, err
+	}
+
+	user := &datastore.User{
+		UID:           ulid.Make().String(),
+		Email:         email,
+		Password:      string(p.Hash),
+		EmailVerified: true,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+
+	if name != nil {
+		user.FirstName = name.GivenName
+		user.LastName = name.FamilyName
+	}
+
+	err = s.userRepo.CreateUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ReplaceUser updates a user. Only users the organisation provisioned have
+// their userName and name changed, other users own their accounts so those
+// changes are ignored.
+func (s *SCIMService) ReplaceUser(ctx context.Context, org *datastore.Organisation, id string, u *scim.User) (*scim.User, error) {
+	scimUser, err := s.scimRepo.FindSCIMUser(ctx, org.UID, id)
+	if err != nil {
+		if errors.Is(err, datastore.ErrSCIMUserNotFound) {
+			return nil, scim.NewError(http.StatusNotFound, "", fmt.Sprintf("user %s not found", id))
+		}
+		return nil, scimInternalError(ctx, err, "failed to find user")
+	}
+
+	email := strings.TrimSpace(u.UserName)
+	if email == "" {
+		return nil, scim.BadRequest(scim.ErrInvalidValue, "userName is required")
+	}
+
+	firstName, lastName := scimUser.FirstName, scimUser.LastName
+	if u.Name != nil {
+		firstName, lastName = u.Name.GivenName, u.Name.FamilyName
+	}
+
+	changed := !strings.EqualFold(email, scimUser.Email) || firstName != scimUser.FirstName || lastName != scimUser.LastName
+	if changed && scimUser.Provisioned {
+		err = s.updateUser(ctx, id, email, firstName, lastName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if u.ExternalID != scimUser.ExternalID {
+		scimUser.ExternalID = u.ExternalID
+		err = s.scimRepo.UpdateSCIMUser(ctx, scimUser)
+		if err != nil {
+			return nil, scimInternalError(ctx, err, "failed to update user")
+		}
+	}
+
+	if u.IsActive() {
+		err = s.activate(ctx, org, id)
+	} else {
+		err = s.deactivate(ctx, org, id)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetUser(ctx, org, id)
+}
+
+func (s *SCIMService) updateUser(ctx context.Context, id, email, firstName, lastName string) error {
+	user, err := s.userRepo.FindUserByID(ctx, id)
+	if err != nil {
+		return scimInternalError(ctx, err, "failed to find user")
+	}
+
+	if !strings.EqualFold(email, user.Email) {
+		existing, err := s.userRepo.FindUserByEmail(ctx, email)
+		if err == nil && existing.UID != user.UID {
+			return scim.NewError(http.StatusConflict, scim.ErrUniqueness, fmt.Sprintf("user %s already exists", email))
+		}
+
+		if err != nil && !errors.Is(err, datastore.ErrUserNotFound) {
+			return scimInternalError(ctx, err, "failed to find user")
+		}
+	}
+
+	user.Email = email
+	user.FirstName = firstName
+	user.LastName = lastName
+	user.UpdatedAt = time.Now()
+
+	err = s.userRepo.UpdateUser(ctx, user)
+	if err != nil {
+		return scimInternalError(ctx, err, "failed to update user")
+	}
+
+	return nil
+}
+
+func (s *SCIMService) PatchUser(ctx context.Context, org *datastore.Organisation, id string, req *scim.PatchRequest) (*scim.User, error) {
+	current, err := s.GetUser(ctx, org, id)
+	if err != nil {
+		return nil, err
+	}
+
+	u := &scim.User{}
+	err = applyPatch(req, current, u)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ReplaceUser(ctx, org, id, u)
+}
+
+// DeleteUser deactivates the user and unlinks them from the organisation,
+// their Convoy account is kept since it may belong to other organisations.
+func (s *SCIMService) DeleteUser(ctx context.Context, org *datastore.Organisation, id string) error {
+	_, err := s.scimRepo.FindSCIMUser(ctx, org.UID, id)
+	if err != nil {
+		if errors.Is(err, datastore.ErrSCIMUserNotFound) {
+			return scim.NewError(http.StatusNotFound, "", fmt.Sprintf("user %s not found", id))
+		}
+		return scimInternalError(ctx, err, "failed to find user")
+	}
+
+	err = s.deactivate(ctx, org, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.scimRepo.DeleteSCIMUser(ctx, org.UID, id)
+	if err != nil {
+		return scimInternalError(ctx, err, "failed to delete user")
+	}
+
+	return nil
+}
+
+// activate makes the user an organisation member with the role their
+// groups give them, existing members are left as they are.
+func (s *SCIMService) activate(ctx context.Context, org *datastore.Organisation, userID string) error {
+	_, err := s.orgMemberRepo.FetchOrganisationMemberByUserID(ctx, userID, org.UID)
+	if err == nil {
+		return nil
+	}
+
+	if !errors.Is(err, datastore.ErrOrgMemberNotFound) {
+		return scimInternalError(ctx, err, "failed to find organisation member")
+	}
+
+	groups, err := s.scimRepo.FindSCIMGroupsByUserID(ctx, org.UID, userID)
+	if err != nil {
+		return scimInternalError(ctx, err, "failed to find user groups")
+	}
+
+	member := &datastore.OrganisationMember{
+		UID:            ulid.Make().String(),
+		OrganisationID: org.UID,
+		UserID:         userID,
+		Role:           resolveSCIMRole(groups),
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	err = s.orgMemberRepo.CreateOrganisationMember(ctx, member)
+	if err != nil {
+		return scimInternalError(ctx, err, "failed to create organisation member")
+	}
+
+	return nil
+}
+
+func (s *SCIMService) deactivate(ctx context.Context, org *datastore.Organisation, userID string) error {
+	if userID == org.OwnerID {
+		return scim.BadRequest(scim.ErrMutability, "the organisation owner cannot be deactivated")
+	}
+
+	member, err := s.orgMemberRepo.FetchOrganisationMemberByUserID(ctx, userID, org.UID)
+	if err != nil {
+		if errors.Is(err, datastore.ErrOrgMemberNotFound) {
+			return nil
+		}
+		return scimInternalError(ctx, err, "failed to find organisation member")
+	}
+
+	err = s.orgMemberRepo.DeleteOrganisationMember(ctx, member.UID, org.UID)
+	if err != nil {
+		return scimInternalError(ctx, err, "failed to delete organisation member")
+	}
+
+	return nil
+}
+
+func (s *SCIMService) ListGroups(ctx context.Context, org *datastore.Organisation, filter scim.Filter) ([]*scim.Group, error) {
+	groups, err := s.scimRepo.LoadSCIMGroups(ctx, org.UID)
+	if err != nil {
+		return nil, scimInternalError(ctx, err, "failed to load groups")
+	}
+
+	resources := make([]*scim.Group, 0, len(groups))
+	for i := range groups {
+		g := toSCIMGroup(&groups[i])
+
+		ok, err := matchFilter(filter, g)
+		if err != nil {
+			return nil, scimInternalError(ctx, err, "failed to filter groups")
+		}
+
+		if ok {
+			resources = append(resources, g)
+		}
+	}
+
+	return resources, nil
+}
+
+func (s *SCIMService) GetGroup(ctx context.Context, org *datastore.Organisation, id string) (*scim.Group, error) {
+	group, err := s.findGroup(ctx, org, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSCIMGroup(group), nil
+}
+
+func (s *SCIMService) findGroup(ctx context.Context, org *datastore.Organisation, id string) (*datastore.SCIMGroup, error) {
+	group, err := s.scimRepo.FindSCIMGroupByID(ctx, org.UID, id)
+	if err != nil {
+		if errors.Is(err, datastore.ErrSCIMGroupNotFound) {
+			return nil, scim.NewError(http.StatusNotFound, "", fmt.Sprintf("group %s not found", id))
+		}
+		return nil, scimInternalError(ctx, err, "failed to find group")
+	}
+
+	return group, nil
+}
+
+func (s *SCIMService) CreateGroup(ctx context.Context, org *datastore.Organisation, g *scim.Group) (*scim.Group, error) {
+	group := &datastore.SCIMGroup{
+		UID:            ulid.Make().String(),
+		OrganisationID: org.UID,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	err := s.fillGroup(ctx, org, group, g)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.scimRepo.CreateSCIMGroup(ctx, group)
+	if err != nil {
+		return nil, s.groupWriteError(ctx, err, g)
+	}
+
+	err = s.syncRoles(ctx, org, group.Members)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetGroup(ctx, org, group.UID)
+}
+
+func (s *SCIMService) ReplaceGroup(ctx context.Context, org *datastore.Organisation, id string, g *scim.Group) (*scim.Group, error) {
+	group, err := s.findGroup(ctx, org, id)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := group.Members
+
+	err = s.fillGroup(ctx, org, group, g)
+	if err != nil {
+		return nil, err
+	}
+
+	group.UpdatedAt = time.Now()
+	err = s.scimRepo.UpdateSCIMGroup(ctx, group)
+	if err != nil {
+		return nil, s.groupWriteError(ctx, err, g)
+	}
+
+	err = s.syncRoles(ctx, org, append(previous, group.Members...))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetGroup(ctx, org, id)
+}
+
+func (s *SCIMService) PatchGroup(ctx context.Context, org *datastore.Organisation, id string, req *scim.PatchRequest) (*scim.Group, error) {
+	current, err := s.GetGroup(ctx, org, id)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &scim.Group{}
+	err = applyPatch(req, current, g)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ReplaceGroup(ctx, org, id, g)
+}
+
+func (s *SCIMService) DeleteGroup(ctx context.Context, org *datastore.Organisation, id string) error {
+	group, err := s.findGroup(ctx, org, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.scimRepo.DeleteSCIMGroup(ctx, org.UID, id)
+	if err != nil {
+		return scimInternalError(ctx, err, "failed to delete group")
+	}
+
+	return s.syncRoles(ctx, org, group.Members)
+}
+
+// fillGroup validates g and copies it into group. Groups give their
+// members the member role unless the Convoy group extension says
+// otherwise, a role scoped to a project needs a project of the
+// organisation.
+func (s *SCIMService) fillGroup(ctx context.Context, org *datastore.Organisation, group *datastore.SCIMGroup, g *scim.Group) error {
+	displayName := strings.TrimSpace(g.DisplayName)
+	if displayName == "" {
+		return scim.BadRequest(scim.ErrInvalidValue, "displayName is required")
+	}
+
+	role := auth.Role{Type: auth.RoleMember}
+	if g.Extension != nil {
+		if g.Extension.Role != "" {
+			role.Type = auth.RoleType(g.Extension.Role)
+		}
+		role.Project = g.Extension.Project
+	}
+
+	switch role.Type {
+	case auth.RoleSuperUser, auth.RoleAdmin, auth.RoleMember:
+	default:
+		return scim.BadRequest(scim.ErrInvalidValue, "invalid role %q, expected one of %s, %s or %s",
+			role.Type, auth.RoleSuperUser, auth.RoleAdmin, auth.RoleMember)
+	}
+
+	if role.Project != "" {
+		project, err := s.projectRepo.FetchProjectByID(ctx, role.Project)
+		if err != nil && !errors.Is(err, datastore.ErrProjectNotFound) {
+			return scimInternalError(ctx, err, "failed to find project")
+		}
+
+		if err != nil || project.OrganisationID != org.UID {
+			return scim.BadRequest(scim.ErrInvalidValue, "project %s not found", role.Project)
+		}
+	}
+
+	members := make([]string, 0, len(g.Members))
+	seen := map[string]bool{}
+	for _, m := range g.Members {
+		if seen[m.Value] {
+			continue
+		}
+		seen[m.Value] = true
+
+		_, err := s.scimRepo.FindSCIMUser(ctx, org.UID, m.Value)
+		if err != nil {
+			if errors.Is(err, datastore.ErrSCIMUserNotFound) {
+				return scim.BadRequest(scim.ErrInvalidValue, "member %s is not a user of this organisation", m.Value)
+			}
+			return scimInternalError(ctx, err, "failed to find group member")
+		}
+
+		members = append(members, m.Value)
+	}
+
+	group.DisplayName = displayName
+	group.ExternalID = g.ExternalID
+	group.Role = role
+	group.Members = members
+
+	return nil
+}
+
+func (s *SCIMService) groupWriteError(ctx context.Context, err error, g *scim.Group) error {
+	if errors.Is(err, datastore.ErrDuplicateSCIMGroup) {
+		return scim.NewError(http.StatusConflict, scim.ErrUniqueness, fmt.Sprintf("group %s already exists", g.DisplayName))
+	}
+
+	return scimInternalError(ctx, err, "failed to save group")
+}
+
+// syncRoles gives each active user the role their groups resolve to, the
+// organisation owner keeps theirs.
+func (s *SCIMService) syncRoles(ctx context.Context, org *datastore.Organisation, userIDs []string) error {
+	seen := map[string]bool{}
+	for _, userID := range userIDs {
+		if seen[userID] || userID == org.OwnerID {
+			continue
+		}
+		seen[userID] = true
+
+		member, err := s.orgMemberRepo.FetchOrganisationMemberByUserID(ctx, userID, org.UID)
+		if err != nil {
+			if errors.Is(err, datastore.ErrOrgMemberNotFound) {
+				continue
+			}
+			return scimInternalError(ctx, err, "failed to find organisation member")
+		}
+
+		groups, err := s.scimRepo.FindSCIMGroupsByUserID(ctx, org.UID, userID)
+		if err != nil {
+			return scimInternalError(ctx, err, "failed to find user groups")
+		}
+
+		role := resolveSCIMRole(groups)
+		if member.Role == role {
+			continue
+		}
+
+		member.Role = role
+		member.UpdatedAt = time.Now()
+		err = s.orgMemberRepo.UpdateOrganisationMember(ctx, member)
+		if err != nil {
+			return scimInternalError(ctx, err, "failed to update organisation member")
+		}
+	}
+
+	return nil
+}
+
+var scimRoleRanks = map[auth.RoleType]int{
+	auth.RoleMember:    1,
+	auth.RoleAdmin:     2,
+	auth.RoleSuperUser: 3,
+}
+
+// resolveSCIMRole picks the highest ranked role of a user's groups,
+// super_user over admin over member, with an organisation wide role
+// outranking the same role scoped to a project. Users in no group are
+// members.
+func resolveSCIMRole(groups []datastore.SCIMGroup) auth.Role {
+	role := auth.Role{Type: auth.RoleMember}
+	best := 0
+
+	for _, g := range groups {
+		rank := scimRoleRanks[g.Role.Type] * 2
+		if g.Role.Project == "" {
+			rank++
+		}
+
+		if rank > best {
+			best = rank
+			role = auth.Role{Type: g.Role.Type, Project: g.Role.Project}
+		}
+	}
+
+	return role
+}
+
+func toSCIMUser(u *datastore.SCIMUser, groups []datastore.SCIMGroup) *scim.User {
+	active := scim.Boolean(u.MemberID != "")
+
+	user := &scim.User{
+		Schemas:     []string{scim.UserSchema},
+		ID:          u.UserID,
+		ExternalID:  u.ExternalID,
+		UserName:    u.Email,
+		Name:        &scim.Name{GivenName: u.FirstName, FamilyName: u.LastName},
+		DisplayName: strings.TrimSpace(u.FirstName + " " + u.LastName),
+		Emails:      []scim.Email{{Value: u.Email, Type: "work", Primary: true}},
+		Active:      &active,
+		Meta: &scim.Meta{
+			ResourceType: "User",
+			Created:      u.CreatedAt,
+			LastModified: u.UpdatedAt,
+		},
+	}
+
+	user.Name.Formatted = user.DisplayName
+
+	for _, g := range groups {
+		for _, m := range g.Members {
+			if m == u.UserID {
+				user.Groups = append(user.Groups, scim.Reference{Value: g.UID, Display: g.DisplayName})
+				break
+			}
+		}
+	}
+
+	return user
+}
+
+func toSCIMGroup(g *datastore.SCIMGroup) *scim.Group {
+	group := &scim.Group{
+		Schemas:     []string{scim.GroupSchema, scim.GroupExtensionSchema},
+		ID:          g.UID,
+		ExternalID:  g.ExternalID,
+		DisplayName: g.DisplayName,
+		Extension: &scim.GroupExtension{
+			Role:    string(g.Role.Type),
+			Project: g.Role.Project,
+		},
+		Meta: &scim.Meta{
+			ResourceType: "Group",
+			Created:      g.CreatedAt,
+			LastModified: g.UpdatedAt,
+		},
+	}
+
+	for _, m := range g.Members {
+		group.Members = append(group.Members, scim.Reference{Value: m})
+	}
+
+	return group
+}
+
+func matchFilter(filter scim.Filter, resource interface{}) (bool, error) {
+	if filter == nil {
+		return true, nil
+	}
+
+	m, err := scim.ToMap(resource)
+	if err != nil {
+		return false, err
+	}
+
+	return filter.Match(m), nil
+}
+
+// applyPatch applies req to current and decodes the result into patched.
+func applyPatch(req *scim.PatchRequest, current interface{}, patched interface{}) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	m, err := scim.ToMap(current)
+	if err != nil {
+		return err
+	}
+
+	err = req.Apply(m)
+	if err != nil {
+		return err
+	}
+
+	return scim.FromMap(m, patched)
+}
+
+func scimInternalError(ctx context.Context, err error, msg string) error {
+	log.FromContext(ctx).WithError(err).Error(msg)
+	return scim.NewError(http.StatusInternalServerError, "", msg)
+}
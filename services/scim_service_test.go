@@ -0,0 +1,528 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/frain-dev/convoy/auth"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/pkg/scim"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+func provideSCIMService(ctrl *gomock.Controller) *SCIMService {
+	return NewSCIMService(
+		mocks.NewMockSCIMRepository(ctrl),
+		mocks.NewMockOrganisationRepository(ctrl),
+		mocks.NewMockOrganisationMemberRepository(ctrl),
+		mocks.NewMockUserRepository(ctrl),
+		mocks.NewMockProjectRepository(ctrl),
+	)
+}
+
+func requireSCIMError(t *testing.T, err error, status int, scimType string, detail string) {
+	t.Helper()
+
+	var scimErr *scim.Error
+	require.ErrorAs(t, err, &scimErr)
+	require.Equal(t, status, scimErr.StatusCode())
+	require.Equal(t, scimType, scimErr.ScimType)
+	require.Equal(t, detail, scimErr.Detail)
+}
+
+func TestSCIMService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := provideSCIMService(ctrl)
+	scimRepo, _ := s.scimRepo.(*mocks.MockSCIMRepository)
+	orgRepo, _ := s.orgRepo.(*mocks.MockOrganisationRepository)
+
+	key := "CO.mask.secret"
+	dk := pbkdf2.Key([]byte(key), []byte("salt"), 4096, 32, sha256.New)
+	token := &datastore.SCIMToken{OrganisationID: "org-1", MaskID: "mask", Hash: base64.URLEncoding.EncodeToString(dk), Salt: "salt"}
+
+	scimRepo.EXPECT().FindSCIMTokenByMaskID(gomock.Any(), "mask").Times(2).Return(token, nil)
+	orgRepo.EXPECT().FetchOrganisationByID(gomock.Any(), "org-1").Times(1).Return(&datastore.Organisation{UID: "org-1"}, nil)
+
+	org, err := s.Authenticate(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, "org-1", org.UID)
+
+	_, err = s.Authenticate(ctx, "CO.mask.wrong")
+	require.ErrorIs(t, err, ErrInvalidSCIMToken)
+
+	_, err = s.Authenticate(ctx, "not-a-token")
+	require.ErrorIs(t, err, ErrInvalidSCIMToken)
+}
+
+func TestSCIMService_GenerateToken(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := provideSCIMService(ctrl)
+	scimRepo, _ := s.scimRepo.(*mocks.MockSCIMRepository)
+
+	var token *datastore.SCIMToken
+	scimRepo.EXPECT().CreateSCIMToken(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, t *datastore.SCIMToken) error {
+			token = t
+			return nil
+		})
+
+	key, err := s.GenerateToken(ctx, &datastore.Organisation{UID: "org-1"})
+	require.NoError(t, err)
+	require.Equal(t, "org-1", token.OrganisationID)
+
+	dk := pbkdf2.Key([]byte(key), []byte(token.Salt), 4096, 32, sha256.New)
+	require.Equal(t, token.Hash, base64.URLEncoding.EncodeToString(dk))
+	require.Contains(t, key, token.MaskID)
+}
+
+func TestSCIMService_CreateUser(t *testing.T) {
+	ctx := context.Background()
+	org := &datastore.Organisation{UID: "org-1", OwnerID: "owner"}
+
+	tests := []struct {
+		name        string
+		user        *scim.User
+		dbFn        func(s *SCIMService)
+		wantErr     bool
+		wantErrCode int
+		wantErrType string
+		wantErrMsg  string
+	}{
+		{
+			name: "should_provision_new_user",
+			user: &scim.User{UserName: "bjensen@example.com", ExternalID: "00u1", Name: &scim.Name{GivenName: "Barbara", FamilyName: "Jensen"}},
+			dbFn: func(s *SCIMService) {
+				u, _ := s.userRepo.(*mocks.MockUserRepository)
+				u.EXPECT().FindUserByEmail(gomock.Any(), "bjensen@example.com").Times(1).Return(nil, datastore.ErrUserNotFound)
+				u.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, user *datastore.User) error {
+						require.Equal(t, "Barbara", user.FirstName)
+						require.True(t, user.EmailVerified)
+						require.NotEmpty(t, user.Password)
+						user.UID = "user-1"
+						return nil
+					})
+
+				sr, _ := s.scimRepo.(*mocks.MockSCIMRepository)
+				sr.EXPECT().CreateSCIMUser(gomock.Any(), &datastore.SCIMUser{
+					OrganisationID: "org-1", UserID: "user-1", ExternalID: "00u1", Provisioned: true,
+				}).Times(1).Return(nil)
+				sr.EXPECT().FindSCIMGroupsByUserID(gomock.Any(), "org-1", "user-1").Times(2).Return(nil, nil)
+				sr.EXPECT().FindSCIMUser(gomock.Any(), "org-1", "user-1").Times(1).
+					Return(&datastore.SCIMUser{UserID: "user-1", Email: "bjensen@example.com", MemberID: "member-1"}, nil)
+
+				om, _ := s.orgMemberRepo.(*mocks.MockOrganisationMemberRepository)
+				om.EXPECT().FetchOrganisationMemberByUserID(gomock.Any(), "user-1", "org-1").Times(1).Return(nil, datastore.ErrOrgMemberNotFound)
+				om.EXPECT().CreateOrganisationMember(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, m *datastore.OrganisationMember) error {
+						require.Equal(t, auth.Role{Type: auth.RoleMember}, m.Role)
+						return nil
+					})
+			},
+		},
+		{
+			name: "should_adopt_existing_member",
+			user: &scim.User{UserName: "bjensen@example.com"},
+			dbFn: func(s *SCIMService) {
+				u, _ := s.userRepo.(*mocks.MockUserRepository)
+				u.EXPECT().FindUserByEmail(gomock.Any(), "bjensen@example.com").Times(1).Return(&datastore.User{UID: "user-1"}, nil)
+
+				sr, _ := s.scimRepo.(*mocks.MockSCIMRepository)
+				gomock.InOrder(
+					sr.EXPECT().FindSCIMUser(gomock.Any(), "org-1", "user-1").Times(1).Return(nil, datastore.ErrSCIMUserNotFound),
+					sr.EXPECT().FindSCIMUser(gomock.Any(), "org-1", "user-1").Times(1).
+						Return(&datastore.SCIMUser{UserID: "user-1", Email: "bjensen@example.com", MemberID: "member-1"}, nil),
+				)
+				sr.EXPECT().CreateSCIMUser(gomock.Any(), &datastore.SCIMUser{OrganisationID: "org-1", UserID: "user-1"}).Times(1).Return(nil)
+				sr.EXPECT().FindSCIMGroupsByUserID(gomock.Any(), "org-1", "user-1").Times(1).Return(nil, nil)
+
+				om, _ := s.orgMemberRepo.(*mocks.MockOrganisationMemberRepository)
+				om.EXPECT().FetchOrganisationMemberByUserID(gomock.Any(), "user-1", "org-1").Times(1).
+					Return(&datastore.OrganisationMember{UID: "member-1"}, nil)
+			},
+		},
+		{
+			name: "should_fail_for_existing_scim_user",
+			user: &scim.User{UserName: "bjensen@example.com"},
+			dbFn: func(s *SCIMService) {
+				u, _ := s.userRepo.(*mocks.MockUserRepository)
+				u.EXPECT().FindUserByEmail(gomock.Any(), "bjensen@example.com").Times(1).Return(&datastore.User{UID: "user-1"}, nil)
+
+				sr, _ := s.scimRepo.(*mocks.MockSCIMRepository)
+				sr.EXPECT().FindSCIMUser(gomock.Any(), "org-1", "user-1").Times(1).Return(&datastore.SCIMUser{}, nil)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusConflict,
+			wantErrType: scim.ErrUniqueness,
+			wantErrMsg:  "user bjensen@example.com already exists",
+		},
+		{
+			name:        "should_fail_without_user_name",
+			user:        &scim.User{},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrType: scim.ErrInvalidValue,
+			wantErrMsg:  "userName is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := provideSCIMService(ctrl)
+			if tt.dbFn != nil {
+				tt.dbFn(s)
+			}
+
+			user, err := s.CreateUser(ctx, org, tt.user)
+			if tt.wantErr {
+				requireSCIMError(t, err, tt.wantErrCode, tt.wantErrType, tt.wantErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "user-1", user.ID)
+			require.True(t, user.IsActive())
+		})
+	}
+}
+
+func TestSCIMService_PatchUser(t *testing.T) {
+	ctx := context.Background()
+	org := &datastore.Organisation{UID: "org-1", OwnerID: "owner"}
+	deactivate := &scim.PatchRequest{
+		Schemas:    []string{scim.PatchOpSchema},
+		Operations: []scim.PatchOperation{{Op: "Replace", Path: "active", Value: "False"}},
+	}
+
+	tests := []struct {
+		name        string
+		id          string
+		req         *scim.PatchRequest
+		dbFn        func(s *SCIMService)
+		wantActive  bool
+		wantErr     bool
+		wantErrCode int
+		wantErrType string
+		wantErrMsg  string
+	}{
+		{
+			name: "should_deactivate_user",
+			id:   "user-1",
+			req:  deactivate,
+			dbFn: func(s *SCIMService) {
+				sr, _ := s.scimRepo.(*mocks.MockSCIMRepository)
+				gomock.InOrder(
+					sr.EXPECT().FindSCIMUser(gomock.Any(), "org-1", "user-1").Times(2).
+						Return(&datastore.SCIMUser{UserID: "user-1", Email: "bjensen@example.com", MemberID: "member-1"}, nil),
+					sr.EXPECT().FindSCIMUser(gomock.Any(), "org-1", "user-1").Times(1).
+						Return(&datastore.SCIMUser{UserID: "user-1", Email: "bjensen@example.com"}, nil),
+				)
+				sr.EXPECT().FindSCIMGroupsByUserID(gomock.Any(), "org-1", "user-1").Times(2).Return(nil, nil)
+
+				om, _ := s.orgMemberRepo.(*mocks.MockOrganisationMemberRepository)
+				om.EXPECT().FetchOrganisationMemberByUserID(gomock.Any(), "user-1", "org-1").Times(1).
+					Return(&datastore.OrganisationMember{UID: "member-1"}, nil)
+				om.EXPECT().DeleteOrganisationMember(gomock.Any(), "member-1", "org-1").Times(1).Return(nil)
+			},
+		},
+		{
+			name: "should_not_deactivate_owner",
+			id:   "owner",
+			req:  deactivate,
+			dbFn: func(s *SCIMService) {
+				sr, _ := s.scimRepo.(*mocks.MockSCIMRepository)
+				sr.EXPECT().FindSCIMUser(gomock.Any(), "org-1", "owner").Times(2).
+					Return(&datastore.SCIMUser{UserID: "owner", Email: "owner@example.com", MemberID: "member-1"}, nil)
+				sr.EXPECT().FindSCIMGroupsByUserID(gomock.Any(), "org-1", "owner").Times(1).Return(nil, nil)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrType: scim.ErrMutability,
+			wantErrMsg:  "the organisation owner cannot be deactivated",
+		},
+		{
+			name: "should_rename_provisioned_user",
+			id:   "user-1",
+			req: &scim.PatchRequest{
+				Schemas:    []string{scim.PatchOpSchema},
+				Operations: []scim.PatchOperation{{Op: "replace", Path: "name.familyName", Value: "Smith"}},
+			},
+			dbFn: func(s *SCIMService) {
+				sr, _ := s.scimRepo.(*mocks.MockSCIMRepository)
+				sr.EXPECT().FindSCIMUser(gomock.Any(), "org-1", "user-1").Times(3).
+					Return(&datastore.SCIMUser{UserID: "user-1", Email: "bjensen@example.com", LastName: "Jensen", MemberID: "member-1", Provisioned: true}, nil)
+				sr.EXPECT().FindSCIMGroupsByUserID(gomock.Any(), "org-1", "user-1").Times(2).Return(nil, nil)
+
+				u, _ := s.userRepo.(*mocks.MockUserRepository)
+				u.EXPECT().FindUserByID(gomock.Any(), "user-1").Times(1).Return(&datastore.User{UID: "user-1", Email: "bjensen@example.com"}, nil)
+				u.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, user *datastore.User) error {
+						require.Equal(t, "Smith", user.LastName)
+						return nil
+					})
+
+				om, _ := s.orgMemberRepo.(*mocks.MockOrganisationMemberRepository)
+				om.EXPECT().FetchOrganisationMemberByUserID(gomock.Any(), "user-1", "org-1").Times(1).
+					Return(&datastore.OrganisationMember{UID: "member-1"}, nil)
+			},
+			wantActive: true,
+		},
+		{
+			name: "should_ignore_rename_of_adopted_user",
+			id:   "user-1",
+			req: &scim.PatchRequest{
+				Schemas:    []string{scim.PatchOpSchema},
+				Operations: []scim.PatchOperation{{Op: "replace", Path: "name.familyName", Value: "Smith"}},
+			},
+			dbFn: func(s *SCIMService) {
+				sr, _ := s.scimRepo.(*mocks.MockSCIMRepository)
+				sr.EXPECT().FindSCIMUser(gomock.Any(), "org-1", "user-1").Times(3).
+					Return(&datastore.SCIMUser{UserID: "user-1", Email: "bjensen@example.com", LastName: "Jensen", MemberID: "member-1"}, nil)
+				sr.EXPECT().FindSCIMGroupsByUserID(gomock.Any(), "org-1", "user-1").Times(2).Return(nil, nil)
+
+				om, _ := s.orgMemberRepo.(*mocks.MockOrganisationMemberRepository)
+				om.EXPECT().FetchOrganisationMemberByUserID(gomock.Any(), "user-1", "org-1").Times(1).
+					Return(&datastore.OrganisationMember{UID: "member-1"}, nil)
+			},
+			wantActive: true,
+		},
+		{
+			name: "should_fail_for_unknown_user",
+			id:   "user-2",
+			req:  deactivate,
+			dbFn: func(s *SCIMService) {
+				sr, _ := s.scimRepo.(*mocks.MockSCIMRepository)
+				sr.EXPECT().FindSCIMUser(gomock.Any(), "org-1", "user-2").Times(1).Return(nil, datastore.ErrSCIMUserNotFound)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusNotFound,
+			wantErrMsg:  "user user-2 not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := provideSCIMService(ctrl)
+			tt.dbFn(s)
+
+			user, err := s.PatchUser(ctx, org, tt.id, tt.req)
+			if tt.wantErr {
+				requireSCIMError(t, err, tt.wantErrCode, tt.wantErrType, tt.wantErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantActive, user.IsActive())
+		})
+	}
+}
+
+func TestSCIMService_CreateGroup(t *testing.T) {
+	ctx := context.Background()
+	org := &datastore.Organisation{UID: "org-1", OwnerID: "owner"}
+
+	tests := []struct {
+		name        string
+		group       *scim.Group
+		dbFn        func(s *SCIMService)
+		wantErr     bool
+		wantErrCode int
+		wantErrType string
+		wantErrMsg  string
+	}{
+		{
+			name: "should_create_group_and_sync_member_roles",
+			group: &scim.Group{
+				DisplayName: "Engineering",
+				Members:     []scim.Reference{{Value: "user-1"}, {Value: "owner"}, {Value: "user-1"}},
+				Extension:   &scim.GroupExtension{Role: "admin", Project: "project-1"},
+			},
+			dbFn: func(s *SCIMService) {
+				p, _ := s.projectRepo.(*mocks.MockProjectRepository)
+				p.EXPECT().FetchProjectByID(gomock.Any(), "project-1").Times(1).
+					Return(&datastore.Project{UID: "project-1", OrganisationID: "org-1"}, nil)
+
+				role := auth.Role{Type: auth.RoleAdmin, Project: "project-1"}
+
+				sr, _ := s.scimRepo.(*mocks.MockSCIMRepository)
+				sr.EXPECT().FindSCIMUser(gomock.Any(), "org-1", "user-1").Times(1).Return(&datastore.SCIMUser{}, nil)
+				sr.EXPECT().FindSCIMUser(gomock.Any(), "org-1", "owner").Times(1).Return(&datastore.SCIMUser{}, nil)
+				sr.EXPECT().CreateSCIMGroup(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, g *datastore.SCIMGroup) error {
+						require.Equal(t, role, g.Role)
+						require.Equal(t, []string{"user-1", "owner"}, g.Members)
+						return nil
+					})
+				sr.EXPECT().FindSCIMGroupsByUserID(gomock.Any(), "org-1", "user-1").Times(1).
+					Return([]datastore.SCIMGroup{{Role: role}}, nil)
+				sr.EXPECT().FindSCIMGroupByID(gomock.Any(), "org-1", gomock.Any()).Times(1).
+					Return(&datastore.SCIMGroup{UID: "group-1", DisplayName: "Engineering", Role: role, Members: []string{"user-1", "owner"}}, nil)
+
+				om, _ := s.orgMemberRepo.(*mocks.MockOrganisationMemberRepository)
+				om.EXPECT().FetchOrganisationMemberByUserID(gomock.Any(), "user-1", "org-1").Times(1).
+					Return(&datastore.OrganisationMember{UID: "member-1", Role: auth.Role{Type: auth.RoleMember}}, nil)
+				om.EXPECT().UpdateOrganisationMember(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, m *datastore.OrganisationMember) error {
+						require.Equal(t, role, m.Role)
+						return nil
+					})
+			},
+		},
+		{
+			name:        "should_fail_for_invalid_role",
+			group:       &scim.Group{DisplayName: "Engineering", Extension: &scim.GroupExtension{Role: "api"}},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrType: scim.ErrInvalidValue,
+			wantErrMsg:  `invalid role "api", expected one of super_user, admin or member`,
+		},
+		{
+			name:  "should_fail_for_project_of_another_organisation",
+			group: &scim.Group{DisplayName: "Engineering", Extension: &scim.GroupExtension{Project: "project-2"}},
+			dbFn: func(s *SCIMService) {
+				p, _ := s.projectRepo.(*mocks.MockProjectRepository)
+				p.EXPECT().FetchProjectByID(gomock.Any(), "project-2").Times(1).
+					Return(&datastore.Project{UID: "project-2", OrganisationID: "org-2"}, nil)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrType: scim.ErrInvalidValue,
+			wantErrMsg:  "project project-2 not found",
+		},
+		{
+			name:  "should_fail_for_unknown_member",
+			group: &scim.Group{DisplayName: "Engineering", Members: []scim.Reference{{Value: "user-2"}}},
+			dbFn: func(s *SCIMService) {
+				sr, _ := s.scimRepo.(*mocks.MockSCIMRepository)
+				sr.EXPECT().FindSCIMUser(gomock.Any(), "org-1", "user-2").Times(1).Return(nil, datastore.ErrSCIMUserNotFound)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrType: scim.ErrInvalidValue,
+			wantErrMsg:  "member user-2 is not a user of this organisation",
+		},
+		{
+			name:  "should_fail_for_duplicate_group",
+			group: &scim.Group{DisplayName: "Engineering"},
+			dbFn: func(s *SCIMService) {
+				sr, _ := s.scimRepo.(*mocks.MockSCIMRepository)
+				sr.EXPECT().CreateSCIMGroup(gomock.Any(), gomock.Any()).Times(1).Return(datastore.ErrDuplicateSCIMGroup)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusConflict,
+			wantErrType: scim.ErrUniqueness,
+			wantErrMsg:  "group Engineering already exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := provideSCIMService(ctrl)
+			if tt.dbFn != nil {
+				tt.dbFn(s)
+			}
+
+			group, err := s.CreateGroup(ctx, org, tt.group)
+			if tt.wantErr {
+				requireSCIMError(t, err, tt.wantErrCode, tt.wantErrType, tt.wantErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "admin", group.Extension.Role)
+			require.Len(t, group.Members, 2)
+		})
+	}
+}
+
+func TestSCIMService_DeleteGroup(t *testing.T) {
+	ctx := context.Background()
+	org := &datastore.Organisation{UID: "org-1", OwnerID: "owner"}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := provideSCIMService(ctrl)
+
+	sr, _ := s.scimRepo.(*mocks.MockSCIMRepository)
+	sr.EXPECT().FindSCIMGroupByID(gomock.Any(), "org-1", "group-1").Times(1).
+		Return(&datastore.SCIMGroup{UID: "group-1", Role: auth.Role{Type: auth.RoleAdmin}, Members: []string{"user-1"}}, nil)
+	sr.EXPECT().DeleteSCIMGroup(gomock.Any(), "org-1", "group-1").Times(1).Return(nil)
+	sr.EXPECT().FindSCIMGroupsByUserID(gomock.Any(), "org-1", "user-1").Times(1).Return(nil, nil)
+
+	om, _ := s.orgMemberRepo.(*mocks.MockOrganisationMemberRepository)
+	om.EXPECT().FetchOrganisationMemberByUserID(gomock.Any(), "user-1", "org-1").Times(1).
+		Return(&datastore.OrganisationMember{UID: "member-1", Role: auth.Role{Type: auth.RoleAdmin}}, nil)
+	om.EXPECT().UpdateOrganisationMember(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, m *datastore.OrganisationMember) error {
+			require.Equal(t, auth.Role{Type: auth.RoleMember}, m.Role)
+			return nil
+		})
+
+	require.NoError(t, s.DeleteGroup(ctx, org, "group-1"))
+}
+
+func Test_resolveSCIMRole(t *testing.T) {
+	tests := []struct {
+		name   string
+		groups []datastore.SCIMGroup
+		want   auth.Role
+	}{
+		{
+			name: "no_groups",
+			want: auth.Role{Type: auth.RoleMember},
+		},
+		{
+			name: "highest_role_wins",
+			groups: []datastore.SCIMGroup{
+				{Role: auth.Role{Type: auth.RoleMember}},
+				{Role: auth.Role{Type: auth.RoleSuperUser}},
+				{Role: auth.Role{Type: auth.RoleAdmin}},
+			},
+			want: auth.Role{Type: auth.RoleSuperUser},
+		},
+		{
+			name: "organisation_wide_role_beats_project_role",
+			groups: []datastore.SCIMGroup{
+				{Role: auth.Role{Type: auth.RoleAdmin, Project: "project-1"}},
+				{Role: auth.Role{Type: auth.RoleAdmin}},
+			},
+			want: auth.Role{Type: auth.RoleAdmin},
+		},
+		{
+			name: "project_admin_beats_member",
+			groups: []datastore.SCIMGroup{
+				{Role: auth.Role{Type: auth.RoleMember}},
+				{Role: auth.Role{Type: auth.RoleAdmin, Project: "project-1"}},
+			},
+			want: auth.Role{Type: auth.RoleAdmin, Project: "project-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, resolveSCIMRole(tt.groups))
+		})
+	}
+}
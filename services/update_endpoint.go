@@ -26,20 +26,27 @@ type UpdateEndpointService struct {
 }
 
 func (a *UpdateEndpointService) Run(ctx context.Context) (*datastore.Endpoint, error) {
-	url, err := util.CleanEndpoint(a.E.URL)
+	endpoint := a.Endpoint
+
+	endpoint, err := a.EndpointRepo.FindEndpointByID(ctx, endpoint.UID, a.Project.UID)
 	if err != nil {
 		return nil, &ServiceError{ErrMsg: err.Error()}
 	}
 
-	a.E.URL = url
-
-	endpoint := a.Endpoint
+	kind, dest := updatedEndpointKind(endpoint, a.E)
+	if kind == datastore.HTTPEndpointKind && util.IsStringEmpty(a.E.URL) {
+		return nil, &ServiceError{ErrMsg: "please provide a url for your endpoint"}
+	}
 
-	endpoint, err = a.EndpointRepo.FindEndpointByID(ctx, endpoint.UID, a.Project.UID)
+	url, err := endpointTarget(ctx, kind, a.E.URL, dest)
 	if err != nil {
-		return nil, &ServiceError{ErrMsg: err.Error()}
+		return nil, err
 	}
 
+	a.E.URL = url
+	endpoint.Kind = kind
+	endpoint.Destination = dest
+
 	endpoint, err = updateEndpoint(endpoint, a.E, a.Project)
 	if err != nil {
 		return nil, &ServiceError{ErrMsg: err.Error()}
@@ -62,6 +69,32 @@ func (a *UpdateEndpointService) Run(ctx context.Context) (*datastore.Endpoint, e
 	return endpoint, nil
 }
 
+// updatedEndpointKind returns the endpoint's kind and destination after
+// the update, both are kept when no kind is given and the destination
+// is kept when the kind is unchanged and no destination is given.
+func updatedEndpointKind(endpoint *datastore.Endpoint, e models.UpdateEndpoint) (datastore.EndpointKind, *datastore.EndpointDestination) {
+	current := endpoint.Kind
+	if util.IsStringEmpty(string(current)) {
+		current = datastore.HTTPEndpointKind
+	}
+
+	kind := e.Kind
+	if util.IsStringEmpty(string(kind)) {
+		kind = current
+	}
+
+	if kind == datastore.HTTPEndpointKind {
+		return kind, nil
+	}
+
+	dest := e.Destination.Transform()
+	if dest == nil && kind == current {
+		dest = endpoint.Destination
+	}
+
+	return kind, dest
+}
+
 func updateEndpoint(endpoint *datastore.Endpoint, e models.UpdateEndpoint, project *datastore.Project) (*datastore.Endpoint, error) {
 	endpoint.TargetURL = e.URL
 	endpoint.Description = e.Description
@@ -101,7 +134,24 @@ func updateEndpoint(endpoint *datastore.Endpoint, e models.UpdateEndpoint, proje
 		endpoint.OwnerID = e.OwnerID
 	}
 
-	auth, err := ValidateEndpointAuthentication(e.Authentication.Transform())
+	if e.OrderedDelivery != nil {
+		endpoint.OrderedDelivery = *e.OrderedDelivery
+	}
+
+	if e.PartitionKeyPath != nil {
+		endpoint.PartitionKeyPath = *e.PartitionKeyPath
+	}
+
+	if e.ContentEncoding != nil {
+		endpoint.ContentEncoding = *e.ContentEncoding
+	}
+
+	auth, err := models.ValidateEndpointAuthentication(e.Authentication.Transform())
+	if err != nil {
+		return nil, err
+	}
+
+	err = encryptEndpointAuthentication(auth)
 	if err != nil {
 		return nil, err
 	}
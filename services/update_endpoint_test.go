@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
@@ -63,6 +64,7 @@ func TestUpdateEndpointService_Run(t *testing.T) {
 				RateLimit:         10000,
 				RateLimitDuration: "1m0s",
 				HttpTimeout:       "20s",
+				Kind:              datastore.HTTPEndpointKind,
 			},
 			dbFn: func(as *UpdateEndpointService) {
 				a, _ := as.EndpointRepo.(*mocks.MockEndpointRepository)
@@ -154,6 +156,10 @@ func TestUpdateEndpointService_Run(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
+
+			err := config.LoadConfig("./testdata/basic-config.json")
+			require.NoError(t, err)
+
 			as := provideUpdateEndpointService(ctrl, tc.args.e, tc.args.endpoint, tc.args.project)
 
 			// Arrange Expectations
@@ -176,3 +182,31 @@ func TestUpdateEndpointService_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdatedEndpointKind(t *testing.T) {
+	dest := &datastore.EndpointDestination{Sqs: &datastore.SQSPubSubConfig{QueueName: "events"}}
+	sqsEndpoint := &datastore.Endpoint{Kind: datastore.SqsEndpointKind, Destination: dest}
+
+	kind, d := updatedEndpointKind(&datastore.Endpoint{}, models.UpdateEndpoint{})
+	require.Equal(t, datastore.HTTPEndpointKind, kind)
+	require.Nil(t, d)
+
+	kind, d = updatedEndpointKind(sqsEndpoint, models.UpdateEndpoint{})
+	require.Equal(t, datastore.SqsEndpointKind, kind)
+	require.Equal(t, dest, d)
+
+	kind, d = updatedEndpointKind(sqsEndpoint, models.UpdateEndpoint{Kind: datastore.HTTPEndpointKind})
+	require.Equal(t, datastore.HTTPEndpointKind, kind)
+	require.Nil(t, d)
+
+	kind, d = updatedEndpointKind(sqsEndpoint, models.UpdateEndpoint{Kind: datastore.KafkaEndpointKind})
+	require.Equal(t, datastore.KafkaEndpointKind, kind)
+	require.Nil(t, d)
+
+	kind, d = updatedEndpointKind(sqsEndpoint, models.UpdateEndpoint{
+		Kind:        datastore.AmqpEndpointKind,
+		Destination: &models.EndpointDestination{Amqp: &models.AmqpExchangeConfig{URL: "amqp://localhost", RoutingKey: "events"}},
+	})
+	require.Equal(t, datastore.AmqpEndpointKind, kind)
+	require.Equal(t, &datastore.EndpointDestination{Amqp: &datastore.AmqpExchangeConfig{URL: "amqp://localhost", RoutingKey: "events"}}, d)
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/schema"
+)
+
+type UpdateEventTypeService struct {
+	EventTypeRepo datastore.EventTypeRepository
+	Update        *models.UpdateEventType
+	EventType     *datastore.ProjectEventType
+}
+
+// Run applies the update, the name can't change since events already
+// reference the type by it.
+func (u *UpdateEventTypeService) Run(ctx context.Context) (*datastore.ProjectEventType, error) {
+	if u.Update.JSONSchema != nil {
+		if !schema.IsEmpty(u.Update.JSONSchema) {
+			if _, err := schema.Compile(u.Update.JSONSchema); err != nil {
+				return nil, &ServiceError{ErrMsg: err.Error()}
+			}
+		}
+
+		u.EventType.JSONSchema = u.Update.JSONSchema
+	}
+
+	if u.Update.Description != nil {
+		u.EventType.Description = *u.Update.Description
+	}
+
+	if u.Update.Version != nil {
+		u.EventType.Version = *u.Update.Version
+	}
+
+	if u.Update.Deprecated != nil {
+		u.EventType.Deprecated = *u.Update.Deprecated
+	}
+
+	err := u.EventTypeRepo.UpdateEventType(ctx, u.EventType)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to update event type")
+		return nil, &ServiceError{ErrMsg: "an error occurred while updating event type", Err: err}
+	}
+
+	return u.EventType, nil
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frain-dev/convoy/api/models"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateEventTypeService_Run(t *testing.T) {
+	ctx := context.Background()
+	boolPtr := func(b bool) *bool { return &b }
+	strPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name       string
+		update     *models.UpdateEventType
+		dbFn       func(repo *mocks.MockEventTypeRepository)
+		want       *datastore.ProjectEventType
+		wantErrMsg string
+	}{
+		{
+			name: "should_update_event_type",
+			update: &models.UpdateEventType{
+				Version:    strPtr("v2"),
+				JSONSchema: []byte(`{"type":"object","required":["id"]}`),
+				Deprecated: boolPtr(true),
+			},
+			dbFn: func(repo *mocks.MockEventTypeRepository) {
+				repo.EXPECT().UpdateEventType(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			want: &datastore.ProjectEventType{
+				UID:         "123",
+				Name:        "user.created",
+				Description: "a user signed up",
+				Version:     "v2",
+				JSONSchema:  []byte(`{"type":"object","required":["id"]}`),
+				Deprecated:  true,
+			},
+		},
+		{
+			name:   "should_remove_schema",
+			update: &models.UpdateEventType{JSONSchema: []byte(`null`)},
+			dbFn: func(repo *mocks.MockEventTypeRepository) {
+				repo.EXPECT().UpdateEventType(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			want: &datastore.ProjectEventType{
+				UID:         "123",
+				Name:        "user.created",
+				Description: "a user signed up",
+				Version:     "v1",
+				JSONSchema:  []byte(`null`),
+			},
+		},
+		{
+			name:       "should_error_for_invalid_schema",
+			update:     &models.UpdateEventType{JSONSchema: []byte(`{"required":"id"}`)},
+			wantErrMsg: "invalid json schema: /required: expected array, but got string",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockEventTypeRepository(ctrl)
+			if tc.dbFn != nil {
+				tc.dbFn(repo)
+			}
+
+			us := &UpdateEventTypeService{
+				EventTypeRepo: repo,
+				Update:        tc.update,
+				EventType: &datastore.ProjectEventType{
+					UID:         "123",
+					Name:        "user.created",
+					Description: "a user signed up",
+					Version:     "v1",
+					JSONSchema:  []byte(`{"type":"object"}`),
+				},
+			}
+
+			eventType, err := us.Run(ctx)
+			if tc.wantErrMsg != "" {
+				require.Error(t, err)
+				require.Equal(t, tc.wantErrMsg, err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, eventType)
+		})
+	}
+}
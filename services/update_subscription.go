@@ -9,6 +9,7 @@ import (
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/pkg/log"
 	"github.com/frain-dev/convoy/util"
+	"gopkg.in/guregu/null.v4"
 )
 
 var (
@@ -97,19 +98,70 @@ func (s *UpdateSubscriptionService) Run(ctx context.Context) (*datastore.Subscri
 		subscription.RetryConfig.RetryCount = s.Update.RetryConfig.RetryCount
 	}
 
+	if s.Update.RetryConfig != nil {
+		if subscription.RetryConfig == nil {
+			subscription.RetryConfig = &datastore.RetryConfiguration{}
+		}
+
+		if !util.IsStringEmpty(s.Update.RetryConfig.MaxDuration) {
+			subscription.RetryConfig.MaxDuration = retryConfig.MaxDuration
+		}
+
+		if !util.IsStringEmpty(string(s.Update.RetryConfig.Jitter)) {
+			subscription.RetryConfig.Jitter = s.Update.RetryConfig.Jitter
+		}
+
+		if len(s.Update.RetryConfig.Intervals) > 0 {
+			subscription.RetryConfig.Intervals = s.Update.RetryConfig.Intervals
+		}
+
+		if s.Update.RetryConfig.HonorRetryAfter != nil {
+			subscription.RetryConfig.HonorRetryAfter = *s.Update.RetryConfig.HonorRetryAfter
+		}
+
+		err = models.ValidateRetryConfig(subscription.RetryConfig)
+		if err != nil {
+			return nil, &ServiceError{ErrMsg: err.Error(), Err: err}
+		}
+	}
+
 	if s.Update.FilterConfig != nil {
 		if len(s.Update.FilterConfig.EventTypes) > 0 {
+			err := validateEventTypes(s.Update.FilterConfig.EventTypes)
+			if err != nil {
+				return nil, &ServiceError{ErrMsg: err.Error(), Err: err}
+			}
+
 			subscription.FilterConfig.EventTypes = s.Update.FilterConfig.EventTypes
 		}
 
-		if len(s.Update.FilterConfig.Filter.Body) > 0 || len(s.Update.FilterConfig.Filter.Headers) > 0 {
+		if s.Update.FilterConfig.Expression != "" {
+			fc := s.Update.FilterConfig.Transform()
+			err := validateFilterExpression(fc)
+			if err != nil {
+				return nil, &ServiceError{ErrMsg: err.Error(), Err: err}
+			}
+
+			subscription.FilterConfig.Expression = fc.Expression
+			subscription.FilterConfig.Filter = datastore.FilterSchema{
+				Headers: datastore.M{},
+				Body:    datastore.M{},
+			}
+		} else if len(s.Update.FilterConfig.Filter.Body) > 0 || len(s.Update.FilterConfig.Filter.Headers) > 0 {
 			// validate that the filter is a json string
 			_, err := json.Marshal(s.Update.FilterConfig.Filter)
 			if err != nil {
 				log.FromContext(ctx).WithError(err).Error(ErrInvalidSubscriptionFilterFormat.Error())
 				return nil, &ServiceError{ErrMsg: ErrInvalidSubscriptionFilterFormat.Error(), Err: err}
 			}
-			subscription.FilterConfig.Filter = s.Update.FilterConfig.Filter.Transform()
+
+			filter := s.Update.FilterConfig.Filter.Transform()
+			err = validateFilterSchema(filter)
+			if err != nil {
+				return nil, &ServiceError{ErrMsg: err.Error(), Err: err}
+			}
+			subscription.FilterConfig.Filter = filter
+			subscription.FilterConfig.Expression = ""
 		}
 	}
 
@@ -127,6 +179,25 @@ func (s *UpdateSubscriptionService) Run(ctx context.Context) (*datastore.Subscri
 		subscription.RateLimitConfig.Duration = s.Update.RateLimitConfig.Duration
 	}
 
+	if s.Update.BatchConfig != nil {
+		subscription.BatchConfig = s.Update.BatchConfig.Transform()
+	}
+
+	if s.Update.Function != nil {
+		subscription.Function = null.NewString(*s.Update.Function, !util.IsStringEmpty(*s.Update.Function))
+	}
+
+	if s.Update.TTL != nil {
+		subscription.TTL, err = models.SubscriptionTTL(*s.Update.TTL)
+		if err != nil {
+			return nil, &ServiceError{ErrMsg: err.Error()}
+		}
+	}
+
+	if subscription.BatchConfig != nil && subscription.Function.Valid {
+		return nil, &ServiceError{ErrMsg: ErrBatchedSubscriptionFunction.Error()}
+	}
+
 	err = s.SubRepo.UpdateSubscription(ctx, s.ProjectId, subscription)
 	if err != nil {
 		log.FromContext(ctx).WithError(err).Error(ErrUpdateSubscriptionError.Error())
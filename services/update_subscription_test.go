@@ -8,6 +8,7 @@ import (
 	"github.com/frain-dev/convoy/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/datastore"
@@ -27,6 +28,8 @@ func provideUpdateSubscriptionService(ctrl *gomock.Controller, projectID string,
 func TestUpdateSubscriptionService_Run(t *testing.T) {
 	ctx := context.Background()
 
+	function := "function transform(req) { return req; }"
+
 	type args struct {
 		ctx            context.Context
 		project        *datastore.Project
@@ -72,6 +75,215 @@ func TestUpdateSubscriptionService_Run(t *testing.T) {
 					Return(nil)
 			},
 		},
+		{
+			name: "should turn on batching",
+			args: args{
+				ctx: ctx,
+				update: &models.UpdateSubscription{
+					Name: "sub 1",
+					BatchConfig: &models.BatchConfiguration{
+						MaxCount: 50,
+						MaxBytes: 1024,
+						MaxWait:  "1m",
+					},
+				},
+				project: &datastore.Project{UID: "12345"},
+			},
+			wantSubscription: &datastore.Subscription{
+				Name: "sub 1",
+				Type: datastore.SubscriptionTypeAPI,
+				BatchConfig: &datastore.BatchConfiguration{
+					MaxCount: 50,
+					MaxBytes: 1024,
+					MaxWait:  60,
+				},
+			},
+			dbFn: func(ss *UpdateSubscriptionService) {
+				s, _ := ss.SubRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).Return(&datastore.Subscription{
+					UID:  "sub-uid-1",
+					Type: datastore.SubscriptionTypeAPI,
+				}, nil)
+
+				s.EXPECT().UpdateSubscription(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+		},
+		{
+			name: "should turn off batching",
+			args: args{
+				ctx: ctx,
+				update: &models.UpdateSubscription{
+					Name:        "sub 1",
+					BatchConfig: &models.BatchConfiguration{},
+				},
+				project: &datastore.Project{UID: "12345"},
+			},
+			wantSubscription: &datastore.Subscription{
+				Name: "sub 1",
+				Type: datastore.SubscriptionTypeAPI,
+			},
+			dbFn: func(ss *UpdateSubscriptionService) {
+				s, _ := ss.SubRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).Return(&datastore.Subscription{
+					UID:         "sub-uid-1",
+					Type:        datastore.SubscriptionTypeAPI,
+					BatchConfig: &datastore.BatchConfiguration{MaxCount: 10, MaxWait: 30},
+				}, nil)
+
+				s.EXPECT().UpdateSubscription(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+		},
+		{
+			name: "should set transform function",
+			args: args{
+				ctx: ctx,
+				update: &models.UpdateSubscription{
+					Name:     "sub 1",
+					Function: &function,
+				},
+				project: &datastore.Project{UID: "12345"},
+			},
+			wantSubscription: &datastore.Subscription{
+				Name:     "sub 1",
+				Type:     datastore.SubscriptionTypeAPI,
+				Function: null.StringFrom(function),
+			},
+			dbFn: func(ss *UpdateSubscriptionService) {
+				s, _ := ss.SubRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).Return(&datastore.Subscription{
+					UID:  "sub-uid-1",
+					Type: datastore.SubscriptionTypeAPI,
+				}, nil)
+
+				s.EXPECT().UpdateSubscription(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+		},
+		{
+			name: "should error for transform function on batched subscription",
+			args: args{
+				ctx: ctx,
+				update: &models.UpdateSubscription{
+					Name:     "sub 1",
+					Function: &function,
+				},
+				project: &datastore.Project{UID: "12345"},
+			},
+			dbFn: func(ss *UpdateSubscriptionService) {
+				s, _ := ss.SubRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).Return(&datastore.Subscription{
+					UID:         "sub-uid-1",
+					Type:        datastore.SubscriptionTypeAPI,
+					BatchConfig: &datastore.BatchConfiguration{MaxCount: 10, MaxWait: 30},
+				}, nil)
+			},
+			wantErr:    true,
+			wantErrMsg: "a subscription with batched delivery cannot have a transform function",
+		},
+		{
+			name: "should keep honor retry after when it is left out",
+			args: args{
+				ctx: ctx,
+				update: &models.UpdateSubscription{
+					Name:        "sub 1",
+					RetryConfig: &models.RetryConfiguration{Jitter: datastore.FullJitter},
+				},
+				project: &datastore.Project{UID: "12345"},
+			},
+			wantSubscription: &datastore.Subscription{
+				Name: "sub 1",
+				Type: datastore.SubscriptionTypeAPI,
+				RetryConfig: &datastore.RetryConfiguration{
+					Type:            datastore.ExponentialStrategyProvider,
+					Duration:        10,
+					Jitter:          datastore.FullJitter,
+					HonorRetryAfter: true,
+				},
+			},
+			dbFn: func(ss *UpdateSubscriptionService) {
+				s, _ := ss.SubRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).Return(&datastore.Subscription{
+					UID:  "sub-uid-1",
+					Type: datastore.SubscriptionTypeAPI,
+					RetryConfig: &datastore.RetryConfiguration{
+						Type:            datastore.ExponentialStrategyProvider,
+						Duration:        10,
+						HonorRetryAfter: true,
+					},
+				}, nil)
+
+				s.EXPECT().UpdateSubscription(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+		},
+		{
+			name: "should turn off honor retry after",
+			args: args{
+				ctx: ctx,
+				update: &models.UpdateSubscription{
+					Name:        "sub 1",
+					RetryConfig: &models.RetryConfiguration{HonorRetryAfter: boolPtr(false)},
+				},
+				project: &datastore.Project{UID: "12345"},
+			},
+			wantSubscription: &datastore.Subscription{
+				Name: "sub 1",
+				Type: datastore.SubscriptionTypeAPI,
+				RetryConfig: &datastore.RetryConfiguration{
+					Type:     datastore.LinearStrategyProvider,
+					Duration: 10,
+				},
+			},
+			dbFn: func(ss *UpdateSubscriptionService) {
+				s, _ := ss.SubRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).Return(&datastore.Subscription{
+					UID:  "sub-uid-1",
+					Type: datastore.SubscriptionTypeAPI,
+					RetryConfig: &datastore.RetryConfiguration{
+						Type:            datastore.LinearStrategyProvider,
+						Duration:        10,
+						HonorRetryAfter: true,
+					},
+				}, nil)
+
+				s.EXPECT().UpdateSubscription(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+		},
+		{
+			name: "should error for jitter without a duration",
+			args: args{
+				ctx: ctx,
+				update: &models.UpdateSubscription{
+					Name:        "sub 1",
+					RetryConfig: &models.RetryConfiguration{Jitter: datastore.FullJitter},
+				},
+				project: &datastore.Project{UID: "12345"},
+			},
+			dbFn: func(ss *UpdateSubscriptionService) {
+				s, _ := ss.SubRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).Return(&datastore.Subscription{
+					UID:  "sub-uid-1",
+					Type: datastore.SubscriptionTypeAPI,
+				}, nil)
+			},
+			wantErr:    true,
+			wantErrMsg: "please provide a duration greater than zero to use jitter or a max duration",
+		},
 		{
 			name: "should fail to update subscription",
 			args: args{
@@ -121,6 +333,9 @@ func TestUpdateSubscriptionService_Run(t *testing.T) {
 
 			require.Equal(t, subscription.Name, tc.wantSubscription.Name)
 			require.Equal(t, subscription.Type, tc.wantSubscription.Type)
+			require.Equal(t, tc.wantSubscription.BatchConfig, subscription.BatchConfig)
+			require.Equal(t, tc.wantSubscription.Function, subscription.Function)
+			require.Equal(t, tc.wantSubscription.RetryConfig, subscription.RetryConfig)
 		})
 	}
 }
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/schema"
+)
+
+// ValidateEventSchema checks an event's data against the json schema of
+// its type when the project has schema validation enabled. Types missing
+// from the catalog, or without a schema, are not validated.
+func ValidateEventSchema(ctx context.Context, eventTypeRepo datastore.EventTypeRepository, project *datastore.Project, eventType string, data json.RawMessage) error {
+	if project.Config == nil || !project.Config.IsSchemaValidationEnabled {
+		return nil
+	}
+
+	et, err := eventTypeRepo.FindEventTypeByName(ctx, project.UID, eventType)
+	if err != nil {
+		if errors.Is(err, datastore.ErrEventTypeNotFound) {
+			return nil
+		}
+
+		log.FromContext(ctx).WithError(err).Error("failed to find event type")
+		return &ServiceError{ErrMsg: "an error occurred while validating event data", Err: err}
+	}
+
+	err = schema.Validate(et.JSONSchema, data)
+	if err != nil {
+		return &ServiceError{ErrMsg: fmt.Sprintf("invalid data for event type %s: %s", et.Name, err.Error()), Err: err}
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEventSchema(t *testing.T) {
+	ctx := context.Background()
+	enabled := &datastore.Project{UID: "abc", Config: &datastore.ProjectConfig{IsSchemaValidationEnabled: true}}
+	userCreated := &datastore.ProjectEventType{
+		Name:       "user.created",
+		JSONSchema: []byte(`{"type":"object","properties":{"id":{"type":"string"}},"required":["id"]}`),
+	}
+
+	tests := []struct {
+		name       string
+		project    *datastore.Project
+		data       string
+		dbFn       func(repo *mocks.MockEventTypeRepository)
+		wantErrMsg string
+	}{
+		{
+			name:    "should_skip_validation_when_disabled",
+			project: &datastore.Project{UID: "abc", Config: &datastore.ProjectConfig{}},
+			data:    `{"id":1}`,
+		},
+		{
+			name:    "should_accept_matching_data",
+			project: enabled,
+			data:    `{"id":"usr_1"}`,
+			dbFn: func(repo *mocks.MockEventTypeRepository) {
+				repo.EXPECT().FindEventTypeByName(gomock.Any(), "abc", "user.created").Return(userCreated, nil)
+			},
+		},
+		{
+			name:    "should_reject_data_not_matching_schema",
+			project: enabled,
+			data:    `{"id":1}`,
+			dbFn: func(repo *mocks.MockEventTypeRepository) {
+				repo.EXPECT().FindEventTypeByName(gomock.Any(), "abc", "user.created").Return(userCreated, nil)
+			},
+			wantErrMsg: "invalid data for event type user.created: payload does not match schema: /id: expected string, but got number",
+		},
+		{
+			name:    "should_skip_types_missing_from_the_catalog",
+			project: enabled,
+			data:    `{"id":1}`,
+			dbFn: func(repo *mocks.MockEventTypeRepository) {
+				repo.EXPECT().FindEventTypeByName(gomock.Any(), "abc", "user.created").Return(nil, datastore.ErrEventTypeNotFound)
+			},
+		},
+		{
+			name:    "should_skip_types_without_a_schema",
+			project: enabled,
+			data:    `{"id":1}`,
+			dbFn: func(repo *mocks.MockEventTypeRepository) {
+				repo.EXPECT().FindEventTypeByName(gomock.Any(), "abc", "user.created").
+					Return(&datastore.ProjectEventType{Name: "user.created", JSONSchema: []byte("null")}, nil)
+			},
+		},
+		{
+			name:    "should_fail_to_find_event_type",
+			project: enabled,
+			data:    `{"id":"usr_1"}`,
+			dbFn: func(repo *mocks.MockEventTypeRepository) {
+				repo.EXPECT().FindEventTypeByName(gomock.Any(), "abc", "user.created").Return(nil, errors.New("failed"))
+			},
+			wantErrMsg: "an error occurred while validating event data",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockEventTypeRepository(ctrl)
+			if tc.dbFn != nil {
+				tc.dbFn(repo)
+			}
+
+			err := ValidateEventSchema(ctx, repo, tc.project, "user.created", []byte(tc.data))
+			if tc.wantErrMsg != "" {
+				require.Error(t, err)
+				require.Equal(t, tc.wantErrMsg, err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
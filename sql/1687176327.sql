@@ -0,0 +1,27 @@
+-- +migrate Up
+ALTER TABLE convoy.project_configurations
+    ADD COLUMN IF NOT EXISTS strategy_max_duration INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS strategy_jitter TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS strategy_intervals INTEGER[],
+    ADD COLUMN IF NOT EXISTS strategy_honor_retry_after BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +migrate Up
+ALTER TABLE convoy.subscriptions
+    ADD COLUMN IF NOT EXISTS retry_config_max_duration INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS retry_config_jitter TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS retry_config_intervals INTEGER[],
+    ADD COLUMN IF NOT EXISTS retry_config_honor_retry_after BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +migrate Down
+ALTER TABLE convoy.project_configurations
+    DROP COLUMN IF EXISTS strategy_max_duration,
+    DROP COLUMN IF EXISTS strategy_jitter,
+    DROP COLUMN IF EXISTS strategy_intervals,
+    DROP COLUMN IF EXISTS strategy_honor_retry_after;
+
+-- +migrate Down
+ALTER TABLE convoy.subscriptions
+    DROP COLUMN IF EXISTS retry_config_max_duration,
+    DROP COLUMN IF EXISTS retry_config_jitter,
+    DROP COLUMN IF EXISTS retry_config_intervals,
+    DROP COLUMN IF EXISTS retry_config_honor_retry_after;
@@ -0,0 +1,31 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS convoy.dead_letters (
+    id CHAR(26) PRIMARY KEY,
+
+    project_id CHAR(26) NOT NULL REFERENCES convoy.projects (id),
+    event_delivery_id CHAR(26) NOT NULL REFERENCES convoy.event_deliveries (id),
+    event_id CHAR(26) NOT NULL REFERENCES convoy.events (id),
+    endpoint_id CHAR(26) REFERENCES convoy.endpoints (id),
+    event_type TEXT NOT NULL,
+    reason TEXT,
+    attempt JSONB,
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+-- +migrate Up
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dead_letters_event_delivery_id ON convoy.dead_letters (event_delivery_id) WHERE deleted_at IS NULL;
+
+-- +migrate Up
+CREATE INDEX IF NOT EXISTS idx_dead_letters_project_id_created_at ON convoy.dead_letters (project_id, created_at);
+
+-- +migrate Down
+DROP INDEX IF EXISTS convoy.idx_dead_letters_project_id_created_at;
+
+-- +migrate Down
+DROP INDEX IF EXISTS convoy.idx_dead_letters_event_delivery_id;
+
+-- +migrate Down
+DROP TABLE IF EXISTS convoy.dead_letters;
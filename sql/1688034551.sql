@@ -0,0 +1,24 @@
+-- +migrate Up
+ALTER TABLE convoy.endpoints
+    ADD COLUMN IF NOT EXISTS ordered_delivery BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS partition_key_path TEXT;
+
+-- +migrate Up
+ALTER TABLE convoy.event_deliveries
+    ADD COLUMN IF NOT EXISTS partition_key TEXT;
+
+-- +migrate Up
+CREATE INDEX IF NOT EXISTS idx_event_deliveries_partition_key ON convoy.event_deliveries (endpoint_id, partition_key, event_id, id)
+    WHERE partition_key IS NOT NULL AND deleted_at IS NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS convoy.idx_event_deliveries_partition_key;
+
+-- +migrate Down
+ALTER TABLE convoy.event_deliveries
+    DROP COLUMN IF EXISTS partition_key;
+
+-- +migrate Down
+ALTER TABLE convoy.endpoints
+    DROP COLUMN IF EXISTS ordered_delivery,
+    DROP COLUMN IF EXISTS partition_key_path;
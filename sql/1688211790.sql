@@ -0,0 +1,26 @@
+-- +migrate Up
+ALTER TABLE convoy.subscriptions
+    ADD COLUMN IF NOT EXISTS batch_config_max_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS batch_config_max_bytes INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS batch_config_max_wait INTEGER NOT NULL DEFAULT 0;
+
+-- +migrate Up
+ALTER TABLE convoy.event_deliveries
+    ADD COLUMN IF NOT EXISTS batch_id TEXT;
+
+-- +migrate Up
+CREATE INDEX IF NOT EXISTS idx_event_deliveries_batch_id ON convoy.event_deliveries (batch_id)
+    WHERE batch_id IS NOT NULL AND deleted_at IS NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS convoy.idx_event_deliveries_batch_id;
+
+-- +migrate Down
+ALTER TABLE convoy.event_deliveries
+    DROP COLUMN IF EXISTS batch_id;
+
+-- +migrate Down
+ALTER TABLE convoy.subscriptions
+    DROP COLUMN IF EXISTS batch_config_max_count,
+    DROP COLUMN IF EXISTS batch_config_max_bytes,
+    DROP COLUMN IF EXISTS batch_config_max_wait;
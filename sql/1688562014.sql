@@ -0,0 +1,23 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS convoy.signing_keys (
+    id CHAR(26) PRIMARY KEY,
+
+    project_id CHAR(26) NOT NULL REFERENCES convoy.projects (id),
+    algorithm TEXT NOT NULL,
+    public_key TEXT NOT NULL,
+    private_key TEXT NOT NULL,
+    expires_at TIMESTAMPTZ,
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+-- +migrate Up
+CREATE INDEX IF NOT EXISTS idx_signing_keys_project_id ON convoy.signing_keys (project_id) WHERE deleted_at IS NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS convoy.idx_signing_keys_project_id;
+
+-- +migrate Down
+DROP TABLE IF EXISTS convoy.signing_keys;
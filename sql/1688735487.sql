@@ -0,0 +1,19 @@
+-- +migrate Up
+ALTER TABLE convoy.endpoints
+    ADD COLUMN IF NOT EXISTS authentication_type_mtls_client_cert TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS authentication_type_mtls_client_key TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS authentication_type_mtls_ca_cert TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS authentication_type_oauth2_token_url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS authentication_type_oauth2_client_id TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS authentication_type_oauth2_client_secret TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS authentication_type_oauth2_scope TEXT NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE convoy.endpoints
+    DROP COLUMN IF EXISTS authentication_type_mtls_client_cert,
+    DROP COLUMN IF EXISTS authentication_type_mtls_client_key,
+    DROP COLUMN IF EXISTS authentication_type_mtls_ca_cert,
+    DROP COLUMN IF EXISTS authentication_type_oauth2_token_url,
+    DROP COLUMN IF EXISTS authentication_type_oauth2_client_id,
+    DROP COLUMN IF EXISTS authentication_type_oauth2_client_secret,
+    DROP COLUMN IF EXISTS authentication_type_oauth2_scope;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE convoy.subscriptions
+    ADD COLUMN IF NOT EXISTS function TEXT;
+
+-- +migrate Down
+ALTER TABLE convoy.subscriptions
+    DROP COLUMN IF EXISTS function;
@@ -0,0 +1,24 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS convoy.source_dead_letters (
+    id CHAR(26) PRIMARY KEY,
+
+    project_id CHAR(26) NOT NULL REFERENCES convoy.projects (id),
+    source_id CHAR(26) NOT NULL REFERENCES convoy.sources (id),
+    data TEXT NOT NULL,
+    attributes JSONB,
+    reason TEXT,
+    deliveries INTEGER NOT NULL DEFAULT 1,
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+-- +migrate Up
+CREATE INDEX IF NOT EXISTS idx_source_dead_letters_source_id ON convoy.source_dead_letters (project_id, source_id);
+
+-- +migrate Down
+DROP INDEX IF EXISTS convoy.idx_source_dead_letters_source_id;
+
+-- +migrate Down
+DROP TABLE IF EXISTS convoy.source_dead_letters;
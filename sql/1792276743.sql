@@ -0,0 +1,32 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS convoy.event_types (
+    id CHAR(26) PRIMARY KEY,
+
+    project_id CHAR(26) NOT NULL REFERENCES convoy.projects (id),
+    name TEXT NOT NULL,
+    description TEXT,
+    version TEXT,
+    json_schema JSONB,
+    deprecated BOOLEAN NOT NULL DEFAULT FALSE,
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+-- +migrate Up
+CREATE UNIQUE INDEX IF NOT EXISTS idx_event_types_project_id_name ON convoy.event_types (project_id, name) WHERE deleted_at IS NULL;
+
+-- +migrate Up
+ALTER TABLE convoy.project_configurations
+    ADD COLUMN IF NOT EXISTS schema_validation_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +migrate Down
+ALTER TABLE convoy.project_configurations
+    DROP COLUMN IF EXISTS schema_validation_enabled;
+
+-- +migrate Down
+DROP INDEX IF EXISTS convoy.idx_event_types_project_id_name;
+
+-- +migrate Down
+DROP TABLE IF EXISTS convoy.event_types;
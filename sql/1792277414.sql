@@ -0,0 +1,11 @@
+-- +migrate Up
+ALTER TABLE convoy.events ADD COLUMN IF NOT EXISTS deliver_at TIMESTAMPTZ;
+
+-- +migrate Up
+CREATE INDEX IF NOT EXISTS idx_events_project_id_deliver_at ON convoy.events (project_id, deliver_at) WHERE deliver_at IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS convoy.idx_events_project_id_deliver_at;
+
+-- +migrate Down
+ALTER TABLE convoy.events DROP COLUMN IF EXISTS deliver_at;
@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE convoy.endpoints
+    ADD COLUMN IF NOT EXISTS kind TEXT NOT NULL DEFAULT 'http',
+    ADD COLUMN IF NOT EXISTS destination JSONB;
+
+-- +migrate Down
+ALTER TABLE convoy.endpoints
+    DROP COLUMN IF EXISTS kind,
+    DROP COLUMN IF EXISTS destination;
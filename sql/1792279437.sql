@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE convoy.events ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
+ALTER TABLE convoy.subscriptions ADD COLUMN IF NOT EXISTS ttl INTEGER NOT NULL DEFAULT 0;
+
+-- +migrate Down
+ALTER TABLE convoy.events DROP COLUMN IF EXISTS expires_at;
+ALTER TABLE convoy.subscriptions DROP COLUMN IF EXISTS ttl;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE convoy.endpoints ADD COLUMN IF NOT EXISTS content_encoding TEXT NOT NULL DEFAULT '';
+ALTER TABLE convoy.events ADD COLUMN IF NOT EXISTS payload JSONB;
+
+-- +migrate Down
+ALTER TABLE convoy.endpoints DROP COLUMN IF EXISTS content_encoding;
+ALTER TABLE convoy.events DROP COLUMN IF EXISTS payload;
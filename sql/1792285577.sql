@@ -0,0 +1,79 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS convoy.scim_tokens (
+    id CHAR(26) PRIMARY KEY,
+
+    organisation_id CHAR(26) NOT NULL REFERENCES convoy.organisations (id),
+    mask_id TEXT NOT NULL,
+    hash TEXT NOT NULL,
+    salt TEXT NOT NULL,
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+-- +migrate Up
+CREATE UNIQUE INDEX IF NOT EXISTS idx_scim_tokens_mask_id ON convoy.scim_tokens (mask_id) WHERE deleted_at IS NULL;
+
+-- +migrate Up
+CREATE UNIQUE INDEX IF NOT EXISTS idx_scim_tokens_organisation_id ON convoy.scim_tokens (organisation_id) WHERE deleted_at IS NULL;
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS convoy.scim_users (
+    organisation_id CHAR(26) NOT NULL REFERENCES convoy.organisations (id),
+    user_id CHAR(26) NOT NULL REFERENCES convoy.users (id),
+    external_id TEXT NOT NULL DEFAULT '',
+    provisioned BOOLEAN NOT NULL DEFAULT FALSE,
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (organisation_id, user_id)
+);
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS convoy.scim_groups (
+    id CHAR(26) PRIMARY KEY,
+
+    organisation_id CHAR(26) NOT NULL REFERENCES convoy.organisations (id),
+    display_name TEXT NOT NULL,
+    external_id TEXT NOT NULL DEFAULT '',
+    role_type TEXT NOT NULL,
+    role_project TEXT NOT NULL DEFAULT '',
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+-- +migrate Up
+CREATE UNIQUE INDEX IF NOT EXISTS idx_scim_groups_organisation_id_display_name ON convoy.scim_groups (organisation_id, display_name) WHERE deleted_at IS NULL;
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS convoy.scim_group_members (
+    group_id CHAR(26) NOT NULL REFERENCES convoy.scim_groups (id),
+    user_id CHAR(26) NOT NULL REFERENCES convoy.users (id),
+
+    PRIMARY KEY (group_id, user_id)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS convoy.scim_group_members;
+
+-- +migrate Down
+DROP INDEX IF EXISTS convoy.idx_scim_groups_organisation_id_display_name;
+
+-- +migrate Down
+DROP TABLE IF EXISTS convoy.scim_groups;
+
+-- +migrate Down
+DROP TABLE IF EXISTS convoy.scim_users;
+
+-- +migrate Down
+DROP INDEX IF EXISTS convoy.idx_scim_tokens_organisation_id;
+
+-- +migrate Down
+DROP INDEX IF EXISTS convoy.idx_scim_tokens_mask_id;
+
+-- +migrate Down
+DROP TABLE IF EXISTS convoy.scim_tokens;
@@ -83,6 +83,8 @@ func GetVersionFromFS(fs embed.FS) string {
 
 const (
 	EventProcessor               TaskName = "EventProcessor"
+	BatchEventProcessor          TaskName = "BatchEventProcessor"
+	FlushEventBatchProcessor     TaskName = "FlushEventBatchProcessor"
 	DeadLetterProcessor          TaskName = "DeadLetterProcessor"
 	CreateEventProcessor         TaskName = "CreateEventProcessor"
 	CreateDynamicEventProcessor  TaskName = "CreateDynamicEventProcessor"
@@ -104,6 +106,8 @@ const (
 	TokenCacheKey              CacheKey = "tokens"
 	SourceCacheKey             CacheKey = "sources"
 	IdempotencyCacheKey        CacheKey = "dedup"
+	OAuth2TokenCacheKey        CacheKey = "oauth2_tokens"
+	SubscriptionsCacheKey      CacheKey = "subscriptions"
 )
 
 // queues
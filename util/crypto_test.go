@@ -268,3 +268,27 @@ func Test_computeJSONHmac_DifferentHashes(t *testing.T) {
 		})
 	}
 }
+
+func Test_EncryptDecrypt(t *testing.T) {
+	ciphertext, err := Encrypt([]byte("private-key"), "encryption-key")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	plaintext, err := Decrypt(ciphertext, "encryption-key")
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+
+	if string(plaintext) != "private-key" {
+		t.Errorf("Decrypt() got = %s, want private-key", plaintext)
+	}
+
+	if _, err = Decrypt(ciphertext, "another-key"); err == nil {
+		t.Errorf("Decrypt() with the wrong key should fail")
+	}
+
+	if _, err = Encrypt([]byte("private-key"), ""); err == nil {
+		t.Errorf("Encrypt() without a key should fail")
+	}
+}
@@ -73,6 +73,7 @@ func init() {
 		encoders := map[string]bool{
 			string(datastore.LinearStrategyProvider):      true,
 			string(datastore.ExponentialStrategyProvider): true,
+			string(datastore.CustomStrategyProvider):      true,
 		}
 
 		if _, ok := encoders[encoder]; !ok {
@@ -105,6 +106,9 @@ func init() {
 		pubsubs := map[string]bool{
 			string(datastore.SqsPubSub):    true,
 			string(datastore.GooglePubSub): true,
+			string(datastore.KafkaPubSub):  true,
+			string(datastore.AmqpPubSub):   true,
+			string(datastore.NatsPubSub):   true,
 		}
 
 		if _, ok := pubsubs[pubsub]; !ok {
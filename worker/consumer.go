@@ -30,6 +30,15 @@ func NewConsumer(q queue.Queuer, lo log.StdLogger) *Consumer {
 				if _, ok := err.(*task.RateLimitError); ok {
 					return false
 				}
+				if _, ok := err.(*task.CircuitBreakerError); ok {
+					return false
+				}
+				if _, ok := err.(*task.OrderedDeliveryError); ok {
+					return false
+				}
+				if _, ok := err.(*task.ScheduledDeliveryError); ok {
+					return false
+				}
 				return true
 			},
 			RetryDelayFunc: task.GetRetryDelay,
@@ -0,0 +1,176 @@
+package task
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub/destination"
+	"github.com/frain-dev/convoy/net"
+	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/frain-dev/convoy/pkg/log"
+)
+
+const publishMethod = "PUBLISH"
+
+// maxDestinations bounds how many endpoints' destinations a worker keeps
+// open, the least recently used are closed.
+const maxDestinations = 1000
+
+// newDestination is replaced in tests
+var newDestination = destination.New
+
+// destinations holds the destinations of the endpoints published to most
+// recently, so their clients and the connections they hold to the queue
+// are reused across deliveries.
+var destinations = newDestinationCache(maxDestinations)
+
+// destinationCache is a least recently used cache of destinations keyed by
+// endpoint id. A destination is replaced when its endpoint's destination
+// config or the egress policy changes, replaced and evicted destinations
+// are closed.
+type destinationCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type destinationCacheEntry struct {
+	endpointID  string
+	fingerprint string
+	policy      *net.EgressPolicy
+	destination destination.Destination
+}
+
+func newDestinationCache(size int) *destinationCache {
+	return &destinationCache{size: size, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+// Get returns the endpoint's destination, it is created if the endpoint
+// has none or its config has changed since it was created.
+func (c *destinationCache) Get(endpoint *datastore.Endpoint, policy *net.EgressPolicy) (destination.Destination, error) {
+	b, err := json.Marshal(endpoint.Destination)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(append([]byte(endpoint.Kind+"\n"), b...))
+	fingerprint := hex.EncodeToString(sum[:])
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[endpoint.UID]
+	if ok {
+		cached := e.Value.(*destinationCacheEntry)
+		if cached.fingerprint == fingerprint && cached.policy == policy {
+			c.order.MoveToFront(e)
+			return cached.destination, nil
+		}
+	}
+
+	d, err := newDestination(endpoint.Kind, endpoint.Destination, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		c.order.Remove(e)
+		closeDestination(e.Value.(*destinationCacheEntry).destination)
+	}
+
+	c.entries[endpoint.UID] = c.order.PushFront(&destinationCacheEntry{
+		endpointID:  endpoint.UID,
+		fingerprint: fingerprint,
+		policy:      policy,
+		destination: d,
+	})
+
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+
+		evicted := oldest.Value.(*destinationCacheEntry)
+		delete(c.entries, evicted.endpointID)
+		closeDestination(evicted.destination)
+	}
+
+	return d, nil
+}
+
+func (c *destinationCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// closeDestination closes d without holding up deliveries, it can wait
+// for messages still being published with it.
+func closeDestination(d destination.Destination) {
+	go func() {
+		err := d.Close()
+		if err != nil {
+			log.WithError(err).Errorf("failed to close destination %s", d.URL())
+		}
+	}()
+}
+
+// publishDelivery publishes a signed payload to a non http endpoint's
+// queue, the signature and headers are sent as message attributes. The
+// response mirrors a http response so the delivery is retried and
+// recorded like http deliveries, a published message counts as a 200.
+// Connections to the queue's brokers are checked against policy.
+func publishDelivery(ctx context.Context, endpoint *datastore.Endpoint, policy *net.EgressPolicy, timeout time.Duration, payload json.RawMessage, signatureHeader, hmac string, headers httpheader.HTTPHeader, idempotencyKey string) (*net.Response, error) {
+	attrs := map[string]string{signatureHeader: hmac}
+	if len(idempotencyKey) > 0 {
+		attrs["X-Convoy-Idempotency-Key"] = idempotencyKey
+	}
+
+	for k, v := range headers {
+		if len(v) > 0 {
+			attrs[k] = strings.Join(v, ",")
+		}
+	}
+
+	r := &net.Response{Method: publishMethod, RequestHeader: http.Header{}}
+	for k, v := range attrs {
+		r.RequestHeader.Set(k, v)
+	}
+
+	target, err := url.Parse(endpoint.TargetURL)
+	if err != nil || len(endpoint.TargetURL) == 0 {
+		target = &url.URL{Scheme: string(endpoint.Kind)}
+	}
+	r.URL = target
+
+	d, err := destinations.Get(endpoint, policy)
+	if err != nil {
+		log.WithError(err).Error("failed to load endpoint destination")
+		r.Error = err.Error()
+		return r, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err = d.Publish(ctx, payload, attrs)
+	if err != nil {
+		log.WithError(err).Errorf("failed to publish to %s", d.URL())
+		r.Error = err.Error()
+		return r, err
+	}
+
+	r.Status = "200 OK"
+	r.StatusCode = http.StatusOK
+	return r, nil
+}
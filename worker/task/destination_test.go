@@ -0,0 +1,151 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub/destination"
+	"github.com/frain-dev/convoy/net"
+	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDestination struct {
+	data   []byte
+	attrs  map[string]string
+	err    error
+	closed chan struct{}
+}
+
+func (f *fakeDestination) URL() string {
+	return "kafka://localhost:9092/events"
+}
+
+func (f *fakeDestination) Publish(_ context.Context, data []byte, attrs map[string]string) error {
+	f.data = data
+	f.attrs = attrs
+	return f.err
+}
+
+func (f *fakeDestination) Close() error {
+	if f.closed != nil {
+		close(f.closed)
+	}
+
+	return nil
+}
+
+func stubDestination(t *testing.T, d destination.Destination) {
+	newDestination = func(datastore.EndpointKind, *datastore.EndpointDestination, *net.EgressPolicy) (destination.Destination, error) {
+		return d, nil
+	}
+	destinations = newDestinationCache(maxDestinations)
+
+	t.Cleanup(func() {
+		newDestination = destination.New
+		destinations = newDestinationCache(maxDestinations)
+	})
+}
+
+func TestPublishDelivery(t *testing.T) {
+	endpoint := &datastore.Endpoint{
+		UID:       "endpoint-1",
+		TargetURL: "kafka://localhost:9092/events",
+		Kind:      datastore.KafkaEndpointKind,
+	}
+
+	t.Run("should_publish_signed_payload", func(t *testing.T) {
+		d := &fakeDestination{}
+		stubDestination(t, d)
+
+		headers := httpheader.HTTPHeader{"X-Tenant": []string{"acme"}}
+		resp, err := publishDelivery(context.Background(), endpoint, nil, time.Second, []byte(`{"id":1}`), "X-Convoy-Signature", "t=1,v1=abc", headers, "key-1")
+		require.NoError(t, err)
+
+		require.Equal(t, `{"id":1}`, string(d.data))
+		require.Equal(t, map[string]string{
+			"X-Convoy-Signature":       "t=1,v1=abc",
+			"X-Convoy-Idempotency-Key": "key-1",
+			"X-Tenant":                 "acme",
+		}, d.attrs)
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, publishMethod, resp.Method)
+		require.Equal(t, endpoint.TargetURL, resp.URL.String())
+		require.Equal(t, "acme", resp.RequestHeader.Get("X-Tenant"))
+	})
+
+	t.Run("should_record_publish_error", func(t *testing.T) {
+		stubDestination(t, &fakeDestination{err: errors.New("broker unavailable")})
+
+		resp, err := publishDelivery(context.Background(), endpoint, nil, time.Second, []byte(`{}`), "X-Convoy-Signature", "abc", nil, "")
+		require.EqualError(t, err, "broker unavailable")
+
+		require.Equal(t, 0, resp.StatusCode)
+		require.Equal(t, "broker unavailable", resp.Error)
+		require.NotNil(t, resp.URL)
+	})
+
+	t.Run("should_fail_without_destination", func(t *testing.T) {
+		resp, err := publishDelivery(context.Background(), &datastore.Endpoint{Kind: datastore.SqsEndpointKind}, nil, time.Second, []byte(`{}`), "X-Convoy-Signature", "abc", nil, "")
+		require.EqualError(t, err, "sqs destination config is required")
+		require.Equal(t, "sqs:", resp.URL.String())
+	})
+}
+
+func TestDestinationCache(t *testing.T) {
+	var created []*fakeDestination
+	newDestination = func(datastore.EndpointKind, *datastore.EndpointDestination, *net.EgressPolicy) (destination.Destination, error) {
+		d := &fakeDestination{closed: make(chan struct{})}
+		created = append(created, d)
+		return d, nil
+	}
+	t.Cleanup(func() { newDestination = destination.New })
+
+	endpoint := func(uid string, topic string) *datastore.Endpoint {
+		return &datastore.Endpoint{
+			UID:         uid,
+			Kind:        datastore.KafkaEndpointKind,
+			Destination: &datastore.EndpointDestination{Kafka: &datastore.KafkaTopicConfig{Brokers: []string{"localhost:9092"}, Topic: topic}},
+		}
+	}
+
+	c := newDestinationCache(2)
+
+	// the destination is reused while the endpoint's config is unchanged
+	d, err := c.Get(endpoint("endpoint-1", "events"), nil)
+	require.NoError(t, err)
+
+	same, err := c.Get(endpoint("endpoint-1", "events"), nil)
+	require.NoError(t, err)
+	require.Same(t, d, same)
+
+	// a changed config replaces the destination and closes the old one
+	changed, err := c.Get(endpoint("endpoint-1", "orders"), nil)
+	require.NoError(t, err)
+	require.NotSame(t, d, changed)
+	<-created[0].closed
+
+	// so does a changed egress policy
+	policy, err := net.NewEgressPolicy(false, nil, nil)
+	require.NoError(t, err)
+
+	_, err = c.Get(endpoint("endpoint-1", "orders"), policy)
+	require.NoError(t, err)
+	<-created[1].closed
+
+	// the least recently used destination is closed once the cache is full
+	_, err = c.Get(endpoint("endpoint-2", "events"), nil)
+	require.NoError(t, err)
+
+	_, err = c.Get(endpoint("endpoint-3", "events"), nil)
+	require.NoError(t, err)
+	<-created[2].closed
+
+	require.Equal(t, 2, c.Len())
+	require.Len(t, created, 5)
+}
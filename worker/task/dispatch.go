@@ -0,0 +1,199 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/limiter"
+	"github.com/frain-dev/convoy/net"
+	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/url"
+	"github.com/frain-dev/convoy/util"
+)
+
+// dispatchRequest is a delivery, or a batch of them, that has been
+// signed and authenticated and is ready to be sent.
+type dispatchRequest struct {
+	dispatch        *net.Dispatcher
+	policy          *net.EgressPolicy
+	timeout         time.Duration
+	targetURL       string
+	payload         json.RawMessage
+	signatureHeader string
+	signature       string
+	headers         httpheader.HTTPHeader
+
+	rateLimiter  limiter.RateLimiter
+	rateLimitKey string
+	rateLimit    *RateLimitConfig
+}
+
+// dispatchDeps are the repositories and clients prepareDispatch uses.
+type dispatchDeps struct {
+	cfg               config.Configuration
+	cache             cache.Cache
+	rateLimiter       limiter.RateLimiter
+	eventDeliveryRepo datastore.EventDeliveryRepository
+	signingKeyRepo    datastore.SigningKeyRepository
+}
+
+// prepareDispatch runs the steps single and batched deliveries go through
+// before they are sent. The deliveries are checked against the endpoint's
+// ordering and rate limit, then their payload is built, signed and
+// authenticated. The rate limit is only checked here, takeRateLimit takes
+// from it once the request is about to be sent. A batch is sent as a JSON array with the headers and
+// query params of its oldest delivery, a single delivery is transformed
+// by its subscription's function. Errors are returned ready to be
+// returned by the task, delay is how long a failed step waits to retry.
+func prepareDispatch(ctx context.Context, d *dispatchDeps, p *datastore.Project, endpoint *datastore.Endpoint, subscription *datastore.Subscription, batchID string, deliveries []datastore.EventDelivery, delay time.Duration) (*dispatchRequest, error) {
+	head := &deliveries[0]
+
+	msgID := head.UID
+	if len(batchID) > 0 {
+		msgID = batchID
+	}
+
+	err := checkBatchDeliveryOrder(ctx, d.eventDeliveryRepo, deliveries)
+	if err != nil {
+		log.FromContext(ctx).Debugf("%s is waiting on an earlier delivery to endpoint %s", msgID, endpoint.UID)
+		return nil, err
+	}
+
+	ec := &EventDeliveryConfig{subscription: subscription, project: p}
+	rlc := ec.rateLimitConfig()
+
+	err = checkRateLimit(ctx, d.rateLimiter, endpoint.TargetURL, rlc, delay)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload json.RawMessage
+	var headers httpheader.HTTPHeader
+	targetURL := endpoint.TargetURL
+	if len(batchID) > 0 {
+		payload, err = batchPayload(deliveries)
+		headers = head.Headers
+	} else {
+		payload, headers, targetURL, err = transformDelivery(subscription, head, endpoint.TargetURL)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Errorf("failed to transform event delivery %s", head.UID)
+		}
+	}
+	if err != nil {
+		return nil, &EndpointError{Err: err, delay: delay}
+	}
+
+	sig := newSignature(endpoint, p, payload)
+	sigHeader, sigValue, headers, err := signRequest(sig, p, msgID, headers)
+	if err != nil {
+		return nil, &EndpointError{Err: err, delay: delay}
+	}
+
+	headers, err = addJWSHeader(ctx, d.signingKeyRepo, p, d.cfg.EncryptionKey, sig.Payload, headers)
+	if err != nil {
+		return nil, &EndpointError{Err: err, delay: delay}
+	}
+
+	timeout, err := endpointTimeout(endpoint)
+	if err != nil {
+		log.WithError(err).Errorf("failed to parse endpoint duration")
+		return nil, &EndpointError{Err: err, delay: delay}
+	}
+
+	policy, err := egressPolicy(d.cfg)
+	if err != nil {
+		return nil, &EndpointError{Err: err, delay: delay}
+	}
+
+	dispatch, err := net.NewDispatcher(timeout, d.cfg.Server.HTTP.HttpProxy, policy)
+	if err != nil {
+		return nil, &EndpointError{Err: err, delay: delay}
+	}
+	dispatch.SetContentEncoding(endpoint.ContentEncoding)
+
+	if endpoint.IsHTTP() {
+		headers, err = authenticateRequest(ctx, d.cache, endpoint, dispatch, headers, d.cfg.EncryptionKey)
+		if err != nil {
+			return nil, &EndpointError{Err: err, delay: delay}
+		}
+
+		if !util.IsStringEmpty(head.URLQueryParams) {
+			targetURL, err = url.ConcatQueryParams(targetURL, head.URLQueryParams)
+			if err != nil {
+				log.WithError(err).Error("failed to concat url query params")
+				return nil, &EndpointError{Err: err, delay: delay}
+			}
+		}
+	}
+
+	return &dispatchRequest{
+		dispatch:        dispatch,
+		policy:          policy,
+		timeout:         timeout,
+		targetURL:       targetURL,
+		payload:         sig.Payload,
+		signatureHeader: sigHeader,
+		signature:       sigValue,
+		headers:         headers,
+		rateLimiter:     d.rateLimiter,
+		rateLimitKey:    endpoint.TargetURL,
+		rateLimit:       rlc,
+	}, nil
+}
+
+// takeRateLimit takes one request from the endpoint's rate limit, a batch
+// counts as a single request. It's called after the circuit breaker lets
+// the request through, so requests the breaker rejects don't use it up.
+func (r *dispatchRequest) takeRateLimit(ctx context.Context, delay time.Duration) error {
+	_, err := r.rateLimiter.Allow(ctx, r.rateLimitKey, r.rateLimit.Count, int(r.rateLimit.Duration))
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to take endpoint rate limit")
+		return &EndpointError{Err: err, delay: delay}
+	}
+
+	return nil
+}
+
+// send sends the request to an http endpoint, or publishes it to the
+// endpoint's broker.
+func (r *dispatchRequest) send(ctx context.Context, endpoint *datastore.Endpoint, maxResponseSize int64, idempotencyKey string) (*net.Response, error) {
+	if endpoint.IsHTTP() {
+		return r.dispatch.SendRequest(r.targetURL, string(convoy.HttpPost), r.payload, r.signatureHeader, r.signature, maxResponseSize, r.headers, idempotencyKey)
+	}
+
+	return publishDelivery(ctx, endpoint, r.policy, r.timeout, r.payload, r.signatureHeader, r.signature, r.headers, idempotencyKey)
+}
+
+// checkRateLimit returns a RateLimitError when the endpoint's rate limit
+// has been reached, it doesn't take from the limit.
+func checkRateLimit(ctx context.Context, rateLimiter limiter.RateLimiter, key string, rlc *RateLimitConfig, delay time.Duration) error {
+	res, err := rateLimiter.ShouldAllow(ctx, key, rlc.Count, int(rlc.Duration))
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to check endpoint rate limit")
+		return &EndpointError{Err: err, delay: delay}
+	}
+
+	if res.Remaining <= 0 {
+		err := fmt.Errorf("too many events to %s, limit of %v would be reached", key, res.Limit)
+		log.WithError(ErrRateLimit).Error(err.Error())
+
+		return &RateLimitError{Err: ErrRateLimit, delay: delay}
+	}
+
+	return nil
+}
+
+func endpointTimeout(endpoint *datastore.Endpoint) (time.Duration, error) {
+	if util.IsStringEmpty(endpoint.HttpTimeout) {
+		return time.ParseDuration(convoy.HTTP_TIMEOUT)
+	}
+
+	return time.ParseDuration(endpoint.HttpTimeout)
+}
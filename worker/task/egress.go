@@ -0,0 +1,34 @@
+package task
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/net"
+)
+
+var (
+	egressMu     sync.Mutex
+	egress       *net.EgressPolicy
+	egressConfig config.EgressConfiguration
+)
+
+// egressPolicy returns the egress policy of cfg. It is only rebuilt when
+// the configuration changes, so dispatchers keep sharing its connections.
+func egressPolicy(cfg config.Configuration) (*net.EgressPolicy, error) {
+	egressMu.Lock()
+	defer egressMu.Unlock()
+
+	if egress != nil && reflect.DeepEqual(egressConfig, cfg.Egress) {
+		return egress, nil
+	}
+
+	p, err := net.NewEgressPolicy(cfg.Egress.AllowPrivateNetworks, cfg.Egress.AllowList, cfg.Egress.DenyList)
+	if err != nil {
+		return nil, err
+	}
+
+	egress, egressConfig = p, cfg.Egress
+	return egress, nil
+}
@@ -0,0 +1,132 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/net"
+	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/util"
+)
+
+const (
+	// oauth2TokenExpiryDelta is how long before its expiry a cached token
+	// is refreshed, so it doesn't expire while a request is in flight.
+	oauth2TokenExpiryDelta = time.Minute
+
+	// defaultOAuth2TokenTTL is used for tokens without an expiry.
+	defaultOAuth2TokenTTL = time.Hour
+)
+
+// authenticateRequest applies an endpoint's mtls or oauth2 authentication
+// to a dispatch. Api keys are already part of the delivery's headers, the
+// mtls client key and oauth2 client secret are decrypted with
+// encryptionKey.
+func authenticateRequest(ctx context.Context, c cache.Cache, endpoint *datastore.Endpoint, dispatch *net.Dispatcher, headers httpheader.HTTPHeader, encryptionKey string) (httpheader.HTTPHeader, error) {
+	if endpoint.Authentication == nil {
+		return headers, nil
+	}
+
+	switch endpoint.Authentication.Type {
+	case datastore.MTLSAuthentication:
+		mc := endpoint.Authentication.MTLS
+		if mc == nil {
+			return nil, errors.New("endpoint mtls config is missing")
+		}
+
+		clientKey, err := util.Decrypt(mc.ClientKey, encryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt mtls client key: %v", err)
+		}
+
+		err = dispatch.SetClientCertificate(endpoint.UID, mc.ClientCert, string(clientKey), mc.CACert)
+		if err != nil {
+			return nil, err
+		}
+	case datastore.OAuth2Authentication:
+		if endpoint.Authentication.OAuth2 == nil {
+			return nil, errors.New("endpoint oauth2 config is missing")
+		}
+
+		token, err := oauth2Token(ctx, c, endpoint, dispatch, encryptionKey)
+		if err != nil {
+			return nil, err
+		}
+
+		h := httpheader.HTTPHeader{"Authorization": []string{token.TokenType + " " + token.AccessToken}}
+		h.MergeHeaders(headers)
+
+		return h, nil
+	}
+
+	return headers, nil
+}
+
+// oauth2Token returns the endpoint's cached token, a new one is fetched
+// with dispatch when there is none or it is about to expire.
+func oauth2Token(ctx context.Context, c cache.Cache, endpoint *datastore.Endpoint, dispatch *net.Dispatcher, encryptionKey string) (*net.OAuth2Token, error) {
+	o := endpoint.Authentication.OAuth2
+	key := oauth2TokenCacheKey(endpoint)
+
+	var token *net.OAuth2Token
+	err := c.Get(ctx, key, &token)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to fetch oauth2 token from cache")
+	}
+
+	if token != nil && len(token.AccessToken) > 0 &&
+		(token.Expiry.IsZero() || time.Until(token.Expiry) > oauth2TokenExpiryDelta) {
+		return token, nil
+	}
+
+	clientSecret, err := util.Decrypt(o.ClientSecret, encryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt oauth2 client secret: %v", err)
+	}
+
+	token, err = dispatch.FetchOAuth2Token(ctx, o.TokenURL, o.ClientID, string(clientSecret), o.Scope)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := defaultOAuth2TokenTTL
+	if !token.Expiry.IsZero() {
+		ttl = time.Until(token.Expiry) - oauth2TokenExpiryDelta
+	}
+
+	if ttl > 0 {
+		err = c.Set(ctx, key, token, ttl)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Error("failed to cache oauth2 token")
+		}
+	}
+
+	return token, nil
+}
+
+// invalidateOAuth2Token drops the endpoint's cached token once the endpoint
+// rejects it, the next attempt fetches a new one.
+func invalidateOAuth2Token(ctx context.Context, c cache.Cache, endpoint *datastore.Endpoint, statusCode int) {
+	if statusCode != http.StatusUnauthorized || endpoint.Authentication == nil ||
+		endpoint.Authentication.Type != datastore.OAuth2Authentication || endpoint.Authentication.OAuth2 == nil {
+		return
+	}
+
+	err := c.Delete(ctx, oauth2TokenCacheKey(endpoint))
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Error("failed to invalidate oauth2 token")
+	}
+}
+
+// tokens are cached per client id too, so updating the credentials
+// doesn't reuse a token issued for the old ones.
+func oauth2TokenCacheKey(endpoint *datastore.Endpoint) string {
+	return convoy.OAuth2TokenCacheKey.Get(endpoint.UID).Get(endpoint.Authentication.OAuth2.ClientID).String()
+}
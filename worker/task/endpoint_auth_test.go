@@ -0,0 +1,139 @@
+package task
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/net"
+	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/frain-dev/convoy/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticateRequest_OAuth2(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		// the stored client secret is decrypted before it is sent
+		_, secret, ok := r.BasicAuth()
+		if !ok {
+			secret = r.FormValue("client_secret")
+		}
+
+		if secret != "client-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"fresh-token","token_type":"bearer","expires_in":3600}`))
+	}))
+	defer srv.Close()
+
+	encryptionKey := "encryption-key"
+	clientSecret, err := util.Encrypt([]byte("client-secret"), encryptionKey)
+	require.NoError(t, err)
+
+	endpoint := &datastore.Endpoint{
+		UID: "endpoint-1",
+		Authentication: &datastore.EndpointAuthentication{
+			Type: datastore.OAuth2Authentication,
+			OAuth2: &datastore.OAuth2{
+				TokenURL:     srv.URL,
+				ClientID:     "client-id",
+				ClientSecret: clientSecret,
+			},
+		},
+	}
+	headers := httpheader.HTTPHeader{"X-Custom": []string{"value"}}
+
+	tests := []struct {
+		name         string
+		cacheFn      func(c *mocks.MockCache)
+		wantToken    string
+		wantRequests int
+	}{
+		{
+			name: "should use the cached token",
+			cacheFn: func(c *mocks.MockCache) {
+				c.EXPECT().Get(gomock.Any(), "oauth2_tokens:endpoint-1:client-id", gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, _ string, data interface{}) error {
+						*data.(**net.OAuth2Token) = &net.OAuth2Token{AccessToken: "cached-token", TokenType: "Bearer", Expiry: time.Now().Add(time.Hour)}
+						return nil
+					})
+			},
+			wantToken: "cached-token",
+		},
+		{
+			name: "should fetch a token when none is cached",
+			cacheFn: func(c *mocks.MockCache) {
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				c.EXPECT().Set(gomock.Any(), "oauth2_tokens:endpoint-1:client-id", gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			wantToken:    "fresh-token",
+			wantRequests: 1,
+		},
+		{
+			name: "should refresh a token that is about to expire",
+			cacheFn: func(c *mocks.MockCache) {
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, _ string, data interface{}) error {
+						*data.(**net.OAuth2Token) = &net.OAuth2Token{AccessToken: "stale-token", TokenType: "Bearer", Expiry: time.Now().Add(10 * time.Second)}
+						return nil
+					})
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			wantToken:    "fresh-token",
+			wantRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			requests = 0
+			c := mocks.NewMockCache(ctrl)
+			tt.cacheFn(c)
+
+			dispatch, err := net.NewDispatcher(5*time.Second, "", nil)
+			require.NoError(t, err)
+
+			h, err := authenticateRequest(context.Background(), c, endpoint, dispatch, headers, encryptionKey)
+			require.NoError(t, err)
+			require.Equal(t, []string{"Bearer " + tt.wantToken}, h["Authorization"])
+			require.Equal(t, []string{"value"}, h["X-Custom"])
+			require.Equal(t, tt.wantRequests, requests)
+
+			// the delivery's own headers are left untouched
+			require.Len(t, headers, 1)
+		})
+	}
+}
+
+func TestInvalidateOAuth2Token(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	endpoint := &datastore.Endpoint{
+		UID: "endpoint-1",
+		Authentication: &datastore.EndpointAuthentication{
+			Type:   datastore.OAuth2Authentication,
+			OAuth2: &datastore.OAuth2{ClientID: "client-id"},
+		},
+	}
+
+	c := mocks.NewMockCache(ctrl)
+	c.EXPECT().Delete(gomock.Any(), "oauth2_tokens:endpoint-1:client-id").Times(1).Return(nil)
+
+	invalidateOAuth2Token(context.Background(), c, endpoint, http.StatusOK)
+	invalidateOAuth2Token(context.Background(), c, endpoint, http.StatusUnauthorized)
+}
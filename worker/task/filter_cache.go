@@ -0,0 +1,68 @@
+package task
+
+import (
+	"container/list"
+	"sync"
+)
+
+// maxCompiledFilters bounds how many subscriptions' compiled filters a
+// worker keeps, the least recently used are compiled again when needed.
+const maxCompiledFilters = 10000
+
+// filterCache is a least recently used cache of compiled filters keyed by
+// subscription id. Deleted subscriptions are never looked up again, so
+// they're evicted once enough other subscriptions have been used.
+type filterCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type filterCacheEntry struct {
+	id     string
+	filter *compiledFilter
+}
+
+func newFilterCache(size int) *filterCache {
+	return &filterCache{size: size, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+func (c *filterCache) Load(id string) (*compiledFilter, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[id]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(e)
+	return e.Value.(*filterCacheEntry).filter, true
+}
+
+func (c *filterCache) Store(id string, cf *compiledFilter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[id]; ok {
+		e.Value.(*filterCacheEntry).filter = cf
+		c.order.MoveToFront(e)
+		return
+	}
+
+	c.entries[id] = c.order.PushFront(&filterCacheEntry{id: id, filter: cf})
+
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*filterCacheEntry).id)
+	}
+}
+
+func (c *filterCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
@@ -0,0 +1,39 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterCache(t *testing.T) {
+	c := newFilterCache(2)
+
+	a, b, d := &compiledFilter{schema: "a"}, &compiledFilter{schema: "b"}, &compiledFilter{schema: "d"}
+	c.Store("a", a)
+	c.Store("b", b)
+
+	// using a makes b the least recently used
+	cf, ok := c.Load("a")
+	require.True(t, ok)
+	require.Same(t, a, cf)
+
+	c.Store("d", d)
+	require.Equal(t, 2, c.Len())
+
+	_, ok = c.Load("b")
+	require.False(t, ok)
+
+	cf, ok = c.Load("a")
+	require.True(t, ok)
+	require.Same(t, a, cf)
+
+	// storing a subscription again replaces its filter
+	changed := &compiledFilter{schema: "d2"}
+	c.Store("d", changed)
+	require.Equal(t, 2, c.Len())
+
+	cf, ok = c.Load("d")
+	require.True(t, ok)
+	require.Same(t, changed, cf)
+}
@@ -0,0 +1,86 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/tidwall/gjson"
+)
+
+var ErrOrderedDeliveryBlocked = errors.New("an earlier event delivery to this endpoint is still pending")
+
+// orderedDeliveryDelay is how long a delivery waits before checking again
+// whether the delivery ahead of it has completed.
+const orderedDeliveryDelay = 5 * time.Second
+
+// partitionKey returns the key event deliveries to the endpoint are ordered
+// by, it is empty when the endpoint doesn't require ordered delivery.
+func partitionKey(endpoint *datastore.Endpoint, data []byte) string {
+	if endpoint == nil || !endpoint.OrderedDelivery {
+		return ""
+	}
+
+	if len(endpoint.PartitionKeyPath) == 0 {
+		return endpoint.UID
+	}
+
+	return fmt.Sprintf("%s:%s", endpoint.UID, gjson.GetBytes(data, endpoint.PartitionKeyPath).String())
+}
+
+// checkDeliveryOrder returns an OrderedDeliveryError when an earlier delivery
+// in the same partition has neither succeeded nor been dead-lettered.
+func checkDeliveryOrder(ctx context.Context, eventDeliveryRepo datastore.EventDeliveryRepository, ed *datastore.EventDelivery) error {
+	if len(ed.PartitionKey) == 0 {
+		return nil
+	}
+
+	head, err := eventDeliveryRepo.FindPendingEventDeliveryBefore(ctx, ed.ProjectID, ed)
+	if err != nil {
+		if errors.Is(err, datastore.ErrEventDeliveryNotFound) {
+			return nil
+		}
+
+		return &EndpointError{Err: err, delay: orderedDeliveryDelay}
+	}
+
+	delay := orderedDeliveryDelay
+	if head.Metadata != nil {
+		if untilNextSend := time.Until(head.Metadata.NextSendTime); untilNextSend > delay {
+			delay = untilNextSend
+		}
+	}
+
+	return &OrderedDeliveryError{Err: ErrOrderedDeliveryBlocked, delay: delay}
+}
+
+// checkBatchDeliveryOrder checks the ordering of the first delivery of
+// every partition in deliveries, the partition's other deliveries are
+// sent after it in the same request.
+func checkBatchDeliveryOrder(ctx context.Context, eventDeliveryRepo datastore.EventDeliveryRepository, deliveries []datastore.EventDelivery) error {
+	first := map[string]*datastore.EventDelivery{}
+	for i := range deliveries {
+		ed := &deliveries[i]
+		if len(ed.PartitionKey) == 0 {
+			continue
+		}
+
+		// deliveries are ordered the way FindPendingEventDeliveryBefore
+		// orders them, by event then delivery id
+		f, ok := first[ed.PartitionKey]
+		if !ok || ed.EventID < f.EventID || (ed.EventID == f.EventID && ed.UID < f.UID) {
+			first[ed.PartitionKey] = ed
+		}
+	}
+
+	for _, ed := range first {
+		err := checkDeliveryOrder(ctx, eventDeliveryRepo, ed)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitionKey(t *testing.T) {
+	data := []byte(`{"customer": {"id": "cus_123"}, "amount": 100}`)
+
+	tests := []struct {
+		name     string
+		endpoint *datastore.Endpoint
+		expected string
+	}{
+		{
+			name:     "unordered endpoint",
+			endpoint: &datastore.Endpoint{UID: "ep-1"},
+			expected: "",
+		},
+		{
+			name:     "no endpoint",
+			expected: "",
+		},
+		{
+			name:     "ordered endpoint without partition key path",
+			endpoint: &datastore.Endpoint{UID: "ep-1", OrderedDelivery: true},
+			expected: "ep-1",
+		},
+		{
+			name:     "ordered endpoint with partition key path",
+			endpoint: &datastore.Endpoint{UID: "ep-1", OrderedDelivery: true, PartitionKeyPath: "customer.id"},
+			expected: "ep-1:cus_123",
+		},
+		{
+			name:     "partition key path missing from payload",
+			endpoint: &datastore.Endpoint{UID: "ep-1", OrderedDelivery: true, PartitionKeyPath: "order.id"},
+			expected: "ep-1:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, partitionKey(tt.endpoint, data))
+		})
+	}
+}
+
+func TestCheckDeliveryOrder(t *testing.T) {
+	tests := []struct {
+		name          string
+		ed            *datastore.EventDelivery
+		dbFn          func(m *mocks.MockEventDeliveryRepository)
+		expectBlocked bool
+		expectErr     bool
+	}{
+		{
+			name: "unordered delivery is never blocked",
+			ed:   &datastore.EventDelivery{UID: "ed-2"},
+		},
+		{
+			name: "no earlier pending delivery",
+			ed:   &datastore.EventDelivery{UID: "ed-2", PartitionKey: "ep-1"},
+			dbFn: func(m *mocks.MockEventDeliveryRepository) {
+				m.EXPECT().FindPendingEventDeliveryBefore(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, datastore.ErrEventDeliveryNotFound)
+			},
+		},
+		{
+			name: "earlier pending delivery blocks",
+			ed:   &datastore.EventDelivery{UID: "ed-2", PartitionKey: "ep-1"},
+			dbFn: func(m *mocks.MockEventDeliveryRepository) {
+				m.EXPECT().FindPendingEventDeliveryBefore(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&datastore.EventDelivery{
+						UID:      "ed-1",
+						Status:   datastore.RetryEventStatus,
+						Metadata: &datastore.Metadata{NextSendTime: time.Now().Add(time.Minute)},
+					}, nil)
+			},
+			expectBlocked: true,
+		},
+		{
+			name: "lookup error is retried",
+			ed:   &datastore.EventDelivery{UID: "ed-2", PartitionKey: "ep-1"},
+			dbFn: func(m *mocks.MockEventDeliveryRepository) {
+				m.EXPECT().FindPendingEventDeliveryBefore(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("failed"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockEventDeliveryRepository(ctrl)
+			if tt.dbFn != nil {
+				tt.dbFn(repo)
+			}
+
+			err := checkDeliveryOrder(context.Background(), repo, tt.ed)
+
+			switch {
+			case tt.expectBlocked:
+				var orderedErr *OrderedDeliveryError
+				require.ErrorAs(t, err, &orderedErr)
+				require.Greater(t, orderedErr.Delay(), orderedDeliveryDelay)
+			case tt.expectErr:
+				var endpointErr *EndpointError
+				require.ErrorAs(t, err, &endpointErr)
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
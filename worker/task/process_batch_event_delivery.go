@@ -0,0 +1,401 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/circuitbreaker"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/notifications"
+	"github.com/frain-dev/convoy/limiter"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/retrystrategies"
+	"github.com/hibiken/asynq"
+	"github.com/oklog/ulid/v2"
+)
+
+var ErrBatchDeliveryAttemptFailed = errors.New("error sending event batch")
+
+// defaultBatchConfig is used to drain deliveries that were queued before
+// batching was turned off on their subscription.
+var defaultBatchConfig = datastore.BatchConfiguration{MaxCount: 100}
+
+type EventBatch struct {
+	BatchID        string
+	SubscriptionID string
+	ProjectID      string
+}
+
+// scheduleBatchFlush queues a flush for the subscription the delivery belongs
+// to. Every delivery schedules its own flush MaxWait seconds out, so the oldest
+// pending delivery never waits longer than that. The flush runs right away
+// once MaxCount deliveries are pending.
+func scheduleBatchFlush(ctx context.Context, eventDeliveryRepo datastore.EventDeliveryRepository, eventQueue queue.Queuer, s *datastore.Subscription, ed *datastore.EventDelivery) error {
+	bc := s.GetBatchConfig()
+	delay := time.Duration(bc.MaxWait) * time.Second
+
+	count, err := eventDeliveryRepo.CountPendingBatchEventDeliveries(ctx, ed.ProjectID, s.UID)
+	if err != nil {
+		return err
+	}
+
+	if uint64(count) >= bc.MaxCount {
+		delay = 0
+	}
+
+	// a scheduled delivery can't be claimed by a batch until it's due, so
+	// there's no point flushing before then
+	if ed.Metadata != nil {
+		if untilNextSend := time.Until(ed.Metadata.NextSendTime); untilNextSend > delay {
+			delay = untilNextSend
+		}
+	}
+
+	data, err := json.Marshal(EventBatch{SubscriptionID: s.UID, ProjectID: ed.ProjectID})
+	if err != nil {
+		return err
+	}
+
+	job := &queue.Job{
+		ID:      "flush:" + ed.UID,
+		Payload: data,
+		Delay:   delay,
+	}
+
+	return eventQueue.Write(convoy.FlushEventBatchProcessor, convoy.EventQueue, job)
+}
+
+// FlushEventBatch groups the pending deliveries of a subscription into
+// batches and queues each batch to be sent.
+func FlushEventBatch(eventDeliveryRepo datastore.EventDeliveryRepository, subRepo datastore.SubscriptionRepository, eventQueue queue.Queuer) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		var data EventBatch
+
+		err := json.Unmarshal(t.Payload(), &data)
+		if err != nil {
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		subscription, err := subRepo.FindSubscriptionByID(ctx, data.ProjectID, data.SubscriptionID)
+		if err != nil {
+			if errors.Is(err, datastore.ErrSubscriptionNotFound) {
+				return nil
+			}
+
+			return &EndpointError{Err: err, delay: 10 * time.Second}
+		}
+
+		bc := defaultBatchConfig
+		if subscription.BatchConfig != nil {
+			bc = *subscription.BatchConfig
+		}
+
+		for {
+			batchID := ulid.Make().String()
+			n, err := eventDeliveryRepo.CreateEventDeliveryBatch(ctx, data.ProjectID, subscription.UID, batchID, bc)
+			if err != nil {
+				return &EndpointError{Err: err, delay: 10 * time.Second}
+			}
+
+			if n == 0 {
+				return nil
+			}
+
+			payload, err := json.Marshal(EventBatch{BatchID: batchID, SubscriptionID: subscription.UID, ProjectID: data.ProjectID})
+			if err != nil {
+				return &EndpointError{Err: err, delay: 10 * time.Second}
+			}
+
+			job := &queue.Job{
+				ID:      batchID,
+				Payload: payload,
+				Delay:   1 * time.Second,
+			}
+
+			err = eventQueue.Write(convoy.BatchEventProcessor, convoy.EventQueue, job)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Errorf("[asynq]: an error occurred sending event batch %s to be dispatched", batchID)
+			}
+		}
+	}
+}
+
+// ProcessEventBatch sends the members of a batch as a single JSON array.
+// The batch shares one signature and one delivery attempt, and is retried
+// as a unit using the retry strategy of its oldest delivery.
+func ProcessEventBatch(endpointRepo datastore.EndpointRepository, eventDeliveryRepo datastore.EventDeliveryRepository, projectRepo datastore.ProjectRepository, deadLetterRepo datastore.DeadLetterRepository, subRepo datastore.SubscriptionRepository, signingKeyRepo datastore.SigningKeyRepository, cache cache.Cache, breaker circuitbreaker.CircuitBreaker, rateLimiter limiter.RateLimiter, notificationQueue queue.Queuer) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		var data EventBatch
+
+		err := json.Unmarshal(t.Payload(), &data)
+		if err != nil {
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		cfg, err := config.Get()
+		if err != nil {
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		deliveries, err := eventDeliveryRepo.FindEventDeliveriesByBatchID(ctx, data.ProjectID, data.BatchID)
+		if err != nil {
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		// members that expired on an earlier attempt are no longer sent
+		// with the rest of the batch
+		deliveries = withoutDiscarded(deliveries)
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		switch deliveries[0].Status {
+		case datastore.ProcessingEventStatus,
+			datastore.SuccessEventStatus,
+			datastore.FailureEventStatus,
+			datastore.DiscardedEventStatus:
+			return nil
+		}
+
+		deliveries, err = discardExpired(ctx, eventDeliveryRepo, data.ProjectID, deliveries, time.Now())
+		if err != nil {
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		if len(deliveries) == 0 {
+			log.FromContext(ctx).Debugf("every delivery in batch %s expired before it was sent", data.BatchID)
+			return nil
+		}
+
+		head := &deliveries[0]
+
+		endpoint, err := endpointRepo.FindEndpointByID(ctx, head.EndpointID, head.ProjectID)
+		if err != nil {
+			return &EndpointError{Err: err, delay: 10 * time.Second}
+		}
+
+		delayDuration := retrystrategies.NewRetryStrategyFromMetadata(*head.Metadata).NextDuration(head.Metadata.NumTrials)
+
+		p, err := projectRepo.FetchProjectByID(ctx, endpoint.ProjectID)
+		if err != nil {
+			return &EndpointError{Err: err, delay: delayDuration}
+		}
+
+		ids := make([]string, len(deliveries))
+		for i := range deliveries {
+			ids[i] = deliveries[i].UID
+		}
+
+		if endpoint.Status == datastore.InactiveEndpointStatus {
+			err = eventDeliveryRepo.UpdateStatusOfEventDeliveries(ctx, p.UID, ids, datastore.DiscardedEventStatus)
+			if err != nil {
+				return &EndpointError{Err: err, delay: delayDuration}
+			}
+
+			log.Debugf("endpoint %s is inactive, failing to send batch %s.", endpoint.TargetURL, data.BatchID)
+			return nil
+		}
+
+		// a batch still drains after its subscription is deleted, the
+		// project's rate limit applies to it then
+		subscription, err := subRepo.FindSubscriptionByID(ctx, data.ProjectID, data.SubscriptionID)
+		if err != nil {
+			if !errors.Is(err, datastore.ErrSubscriptionNotFound) {
+				return &EndpointError{Err: err, delay: delayDuration}
+			}
+			subscription = &datastore.Subscription{}
+		}
+
+		deps := &dispatchDeps{
+			cfg:               cfg,
+			cache:             cache,
+			rateLimiter:       rateLimiter,
+			eventDeliveryRepo: eventDeliveryRepo,
+			signingKeyRepo:    signingKeyRepo,
+		}
+
+		req, err := prepareDispatch(ctx, deps, p, endpoint, subscription, data.BatchID, deliveries, delayDuration)
+		if err != nil {
+			return err
+		}
+
+		// the breaker is checked last, an allowed half-open probe has to
+		// be sent so its outcome is recorded
+		cbResult, err := breaker.Allow(ctx, endpoint.UID)
+		if err != nil {
+			log.WithError(err).Error("failed to check endpoint circuit breaker")
+			return &EndpointError{Err: err, delay: delayDuration}
+		}
+
+		fireCircuitBreakerHook(endpoint, cbResult)
+
+		if !cbResult.Allowed {
+			log.FromContext(ctx).Debugf("circuit breaker for endpoint %s is %s, rescheduling batch %s", endpoint.UID, cbResult.State, data.BatchID)
+			return &CircuitBreakerError{Err: ErrCircuitBreakerOpen, delay: cbResult.RetryAfter}
+		}
+
+		err = req.takeRateLimit(ctx, delayDuration)
+		if err != nil {
+			return err
+		}
+
+		// the batch is only marked as processing once it's ready to be sent,
+		// a batch that fails before then is retried rather than left behind
+		err = eventDeliveryRepo.UpdateStatusOfEventDeliveries(ctx, p.UID, ids, datastore.ProcessingEventStatus)
+		if err != nil {
+			return &EndpointError{Err: err, delay: delayDuration}
+		}
+
+		resp, err := req.send(ctx, endpoint, int64(cfg.MaxResponseSize), "")
+
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		invalidateOAuth2Token(ctx, cache, endpoint, statusCode)
+
+		done := err == nil && statusCode >= 200 && statusCode <= 299
+		if done {
+			log.Infof("batch %s of %d deliveries sent", data.BatchID, len(deliveries))
+			head.Status = datastore.SuccessEventStatus
+			head.Description = ""
+		} else {
+			log.Errorf("batch %s failed. Reason: %v", data.BatchID, err)
+			head.Status = datastore.RetryEventStatus
+
+			if head.Metadata.HonorRetryAfter && resp != nil && retrystrategies.ShouldHonorRetryAfter(statusCode) {
+				if retryAfter, ok := retrystrategies.ParseRetryAfter(resp.ResponseHeader, time.Now()); ok {
+					delayDuration = retryAfter
+				}
+			}
+
+			head.Metadata.LastIntervalMillis = uint64(delayDuration.Milliseconds())
+			head.Metadata.NextSendTime = time.Now().Add(delayDuration)
+		}
+
+		cbResult, err = breaker.Record(ctx, endpoint.UID, done)
+		if err != nil {
+			log.WithError(err).Error("failed to record endpoint circuit breaker result")
+		} else {
+			fireCircuitBreakerHook(endpoint, cbResult)
+		}
+
+		attempt := parseAttemptFromResponse(head, endpoint, resp, done)
+		attempt.BatchID = data.BatchID
+
+		head.Metadata.NumTrials++
+
+		if head.Metadata.NumTrials >= head.Metadata.RetryLimit && !done {
+			log.Errorf("batch %s retry limit exceeded ", data.BatchID)
+			head.Description = "Retry limit exceeded"
+			head.Status = datastore.FailureEventStatus
+
+			if endpoint.Status != datastore.PendingEndpointStatus && p.Config.DisableEndpoint {
+				endpointStatus := datastore.InactiveEndpointStatus
+
+				err := endpointRepo.UpdateEndpointStatus(ctx, p.UID, endpoint.UID, endpointStatus)
+				if err != nil {
+					log.WithError(err).Error("failed to deactivate endpoint after failed retry")
+				}
+
+				err = notifications.SendEndpointNotification(ctx, endpoint, p, endpointStatus, notificationQueue, true, resp.Error, string(resp.Body), resp.StatusCode)
+				if err != nil {
+					log.WithError(err).Error("failed to send notification")
+				}
+			}
+		}
+
+		// every member records the same attempt, and shares the batch's
+		// status and retry schedule. Members that would expire before the
+		// next retry are discarded instead.
+		status, description := head.Status, head.Description
+		retry := false
+		for i := range deliveries {
+			ed := &deliveries[i]
+			ed.Status = status
+			ed.Description = description
+			if i > 0 {
+				ed.Metadata.NumTrials = head.Metadata.NumTrials
+				ed.Metadata.NextSendTime = head.Metadata.NextSendTime
+				ed.Metadata.LastIntervalMillis = head.Metadata.LastIntervalMillis
+			}
+
+			if ed.Status == datastore.RetryEventStatus && ed.Metadata.IsExpired(ed.Metadata.NextSendTime) {
+				ed.Status = datastore.DiscardedEventStatus
+				ed.Description = expiresBeforeRetryDescription
+			}
+
+			if ed.Status == datastore.RetryEventStatus {
+				retry = true
+			}
+
+			memberAttempt := attempt
+			memberAttempt.MsgID = ed.UID
+
+			err = eventDeliveryRepo.UpdateEventDeliveryWithAttempt(ctx, p.UID, *ed, memberAttempt)
+			if err != nil {
+				log.WithError(err).Error("failed to update message ", ed.UID)
+			}
+
+			if ed.Status == datastore.FailureEventStatus {
+				ProcessDeadLetter(ctx, deadLetterRepo, ed, &memberAttempt)
+			}
+		}
+
+		if retry && head.Metadata.NumTrials < head.Metadata.RetryLimit {
+			return &EndpointError{Err: ErrBatchDeliveryAttemptFailed, delay: delayDuration}
+		}
+
+		return nil
+	}
+}
+
+func withoutDiscarded(deliveries []datastore.EventDelivery) []datastore.EventDelivery {
+	pending := make([]datastore.EventDelivery, 0, len(deliveries))
+	for i := range deliveries {
+		if deliveries[i].Status != datastore.DiscardedEventStatus {
+			pending = append(pending, deliveries[i])
+		}
+	}
+
+	return pending
+}
+
+// discardExpired discards the deliveries that have expired by t and
+// returns the rest.
+func discardExpired(ctx context.Context, eventDeliveryRepo datastore.EventDeliveryRepository, projectID string, deliveries []datastore.EventDelivery, t time.Time) ([]datastore.EventDelivery, error) {
+	var expired []string
+	live := make([]datastore.EventDelivery, 0, len(deliveries))
+	for i := range deliveries {
+		if deliveries[i].Metadata != nil && deliveries[i].Metadata.IsExpired(t) {
+			expired = append(expired, deliveries[i].UID)
+			continue
+		}
+
+		live = append(live, deliveries[i])
+	}
+
+	if len(expired) > 0 {
+		err := eventDeliveryRepo.DiscardEventDeliveries(ctx, projectID, expired, expiredDescription)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return live, nil
+}
+
+func batchPayload(deliveries []datastore.EventDelivery) (json.RawMessage, error) {
+	items := make([]json.RawMessage, len(deliveries))
+	for i := range deliveries {
+		items[i] = json.RawMessage(deliveries[i].Metadata.Raw)
+	}
+
+	return json.Marshal(items)
+}
@@ -0,0 +1,364 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/circuitbreaker"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/go-redis/redis_rate/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+)
+
+func TestScheduleBatchFlush(t *testing.T) {
+	tests := []struct {
+		name          string
+		pending       int64
+		scheduledIn   time.Duration
+		expectedDelay time.Duration
+	}{
+		{
+			name:          "should wait for the batch to fill up",
+			pending:       3,
+			expectedDelay: 30 * time.Second,
+		},
+		{
+			name:          "should flush a full batch right away",
+			pending:       10,
+			expectedDelay: 0,
+		},
+		{
+			name:          "should wait for a scheduled delivery to be due",
+			pending:       10,
+			scheduledIn:   time.Hour,
+			expectedDelay: time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			eventDeliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+			eventQueue := mocks.NewMockQueuer(ctrl)
+
+			s := &datastore.Subscription{
+				UID:         "sub-1",
+				BatchConfig: &datastore.BatchConfiguration{MaxCount: 10, MaxWait: 30},
+			}
+			ed := &datastore.EventDelivery{UID: "ed-1", ProjectID: "project-1"}
+			if tt.scheduledIn > 0 {
+				ed.Metadata = &datastore.Metadata{NextSendTime: time.Now().Add(tt.scheduledIn)}
+			}
+
+			eventDeliveryRepo.EXPECT().CountPendingBatchEventDeliveries(gomock.Any(), "project-1", "sub-1").Times(1).Return(tt.pending, nil)
+			eventQueue.EXPECT().Write(convoy.FlushEventBatchProcessor, convoy.EventQueue, gomock.Any()).Times(1).
+				DoAndReturn(func(_ convoy.TaskName, _ convoy.QueueName, job *queue.Job) error {
+					require.Equal(t, "flush:ed-1", job.ID)
+					require.InDelta(t, tt.expectedDelay, job.Delay, float64(time.Second))
+					return nil
+				})
+
+			err := scheduleBatchFlush(context.Background(), eventDeliveryRepo, eventQueue, s, ed)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestFlushEventBatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		dbFn        func(eventDeliveryRepo *mocks.MockEventDeliveryRepository, subRepo *mocks.MockSubscriptionRepository, q *mocks.MockQueuer)
+		wantErr     bool
+		wantErrType error
+	}{
+		{
+			name: "should queue every batch until no deliveries are pending",
+			dbFn: func(eventDeliveryRepo *mocks.MockEventDeliveryRepository, subRepo *mocks.MockSubscriptionRepository, q *mocks.MockQueuer) {
+				bc := datastore.BatchConfiguration{MaxCount: 10, MaxWait: 30}
+				subRepo.EXPECT().FindSubscriptionByID(gomock.Any(), "project-1", "sub-1").Times(1).
+					Return(&datastore.Subscription{UID: "sub-1", BatchConfig: &bc}, nil)
+
+				gomock.InOrder(
+					eventDeliveryRepo.EXPECT().CreateEventDeliveryBatch(gomock.Any(), "project-1", "sub-1", gomock.Any(), bc).Return(int64(10), nil),
+					eventDeliveryRepo.EXPECT().CreateEventDeliveryBatch(gomock.Any(), "project-1", "sub-1", gomock.Any(), bc).Return(int64(4), nil),
+					eventDeliveryRepo.EXPECT().CreateEventDeliveryBatch(gomock.Any(), "project-1", "sub-1", gomock.Any(), bc).Return(int64(0), nil),
+				)
+
+				q.EXPECT().Write(convoy.BatchEventProcessor, convoy.EventQueue, gomock.Any()).Times(2).Return(nil)
+			},
+		},
+		{
+			name: "should drain pending deliveries after batching is turned off",
+			dbFn: func(eventDeliveryRepo *mocks.MockEventDeliveryRepository, subRepo *mocks.MockSubscriptionRepository, q *mocks.MockQueuer) {
+				subRepo.EXPECT().FindSubscriptionByID(gomock.Any(), "project-1", "sub-1").Times(1).
+					Return(&datastore.Subscription{UID: "sub-1"}, nil)
+
+				gomock.InOrder(
+					eventDeliveryRepo.EXPECT().CreateEventDeliveryBatch(gomock.Any(), "project-1", "sub-1", gomock.Any(), defaultBatchConfig).Return(int64(2), nil),
+					eventDeliveryRepo.EXPECT().CreateEventDeliveryBatch(gomock.Any(), "project-1", "sub-1", gomock.Any(), defaultBatchConfig).Return(int64(0), nil),
+				)
+
+				q.EXPECT().Write(convoy.BatchEventProcessor, convoy.EventQueue, gomock.Any()).Times(1).Return(nil)
+			},
+		},
+		{
+			name: "should ignore deleted subscriptions",
+			dbFn: func(eventDeliveryRepo *mocks.MockEventDeliveryRepository, subRepo *mocks.MockSubscriptionRepository, q *mocks.MockQueuer) {
+				subRepo.EXPECT().FindSubscriptionByID(gomock.Any(), "project-1", "sub-1").Times(1).
+					Return(nil, datastore.ErrSubscriptionNotFound)
+			},
+		},
+		{
+			name: "should retry when the batch can't be created",
+			dbFn: func(eventDeliveryRepo *mocks.MockEventDeliveryRepository, subRepo *mocks.MockSubscriptionRepository, q *mocks.MockQueuer) {
+				subRepo.EXPECT().FindSubscriptionByID(gomock.Any(), "project-1", "sub-1").Times(1).
+					Return(&datastore.Subscription{UID: "sub-1", BatchConfig: &datastore.BatchConfiguration{MaxCount: 10}}, nil)
+
+				eventDeliveryRepo.EXPECT().CreateEventDeliveryBatch(gomock.Any(), "project-1", "sub-1", gomock.Any(), gomock.Any()).
+					Times(1).Return(int64(0), errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrType: &EndpointError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			eventDeliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+			subRepo := mocks.NewMockSubscriptionRepository(ctrl)
+			q := mocks.NewMockQueuer(ctrl)
+
+			tt.dbFn(eventDeliveryRepo, subRepo, q)
+
+			payload, err := json.Marshal(EventBatch{SubscriptionID: "sub-1", ProjectID: "project-1"})
+			require.NoError(t, err)
+
+			task := asynq.NewTask(string(convoy.FlushEventBatchProcessor), payload, asynq.Queue(string(convoy.EventQueue)))
+
+			fn := FlushEventBatch(eventDeliveryRepo, subRepo, q)
+			err = fn(context.Background(), task)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.IsType(t, tt.wantErrType, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestProcessEventBatch(t *testing.T) {
+	var gotHeader http.Header
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader, gotQuery = r.Header, r.URL.RawQuery
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	t.Setenv("CONVOY_EGRESS_ALLOW_PRIVATE_NETWORKS", "true")
+	err := config.LoadConfig("./testdata/Config/basic-convoy.json")
+	require.NoError(t, err)
+
+	newDeliveries := func(partitionKey string) []datastore.EventDelivery {
+		deliveries := make([]datastore.EventDelivery, 2)
+		for i := range deliveries {
+			deliveries[i] = datastore.EventDelivery{
+				UID:            fmt.Sprintf("ed-%d", i),
+				PartitionKey:   partitionKey,
+				ProjectID:      "project-1",
+				EndpointID:     "endpoint-1",
+				Status:         datastore.ScheduledEventStatus,
+				URLQueryParams: "source=convoy",
+				Headers: httpheader.HTTPHeader{
+					"X-Api-Key": []string{"api-key"},
+					"X-Custom":  []string{"custom"},
+				},
+				Metadata: &datastore.Metadata{
+					Raw:        `{"event": "invoice.completed"}`,
+					Strategy:   datastore.LinearStrategyProvider,
+					RetryLimit: 3,
+				},
+			}
+		}
+		return deliveries
+	}
+
+	project := &datastore.Project{
+		UID: "project-1",
+		Config: &datastore.ProjectConfig{
+			Signature: &datastore.SignatureConfiguration{
+				Header:   "X-Convoy-Signature",
+				Versions: []datastore.SignatureVersion{{UID: "abc", Hash: "SHA256", Encoding: datastore.HexEncoding}},
+			},
+			RateLimit: &datastore.DefaultRateLimitConfig,
+		},
+	}
+
+	tests := []struct {
+		name         string
+		partitionKey string
+		breaker      circuitbreaker.CircuitBreaker
+		limiterFn    func(l *mocks.MockRateLimiter)
+		dbFn         func(m *mocks.MockEventDeliveryRepository)
+		wantErrType  error
+		wantSent     bool
+	}{
+		{
+			name: "should send the headers and query params of the batch",
+			limiterFn: func(l *mocks.MockRateLimiter) {
+				l.EXPECT().ShouldAllow(gomock.Any(), srv.URL, gomock.Any(), gomock.Any()).Times(1).Return(&redis_rate.Result{Remaining: 10}, nil)
+				l.EXPECT().Allow(gomock.Any(), srv.URL, gomock.Any(), gomock.Any()).Times(1).Return(&redis_rate.Result{Remaining: 9}, nil)
+			},
+			dbFn: func(m *mocks.MockEventDeliveryRepository) {
+				m.EXPECT().UpdateStatusOfEventDeliveries(gomock.Any(), "project-1", []string{"ed-0", "ed-1"}, datastore.ProcessingEventStatus).Times(1).Return(nil)
+				m.EXPECT().UpdateEventDeliveryWithAttempt(gomock.Any(), "project-1", gomock.Any(), gomock.Any()).Times(2).Return(nil)
+			},
+			wantSent: true,
+		},
+		{
+			name: "should reschedule the batch when the endpoint's rate limit is reached",
+			limiterFn: func(l *mocks.MockRateLimiter) {
+				l.EXPECT().ShouldAllow(gomock.Any(), srv.URL, gomock.Any(), gomock.Any()).Times(1).Return(&redis_rate.Result{Remaining: 0}, nil)
+			},
+			wantErrType: &RateLimitError{},
+		},
+		{
+			name:    "should not take from the rate limit when the circuit breaker is open",
+			breaker: openCircuitBreaker{},
+			limiterFn: func(l *mocks.MockRateLimiter) {
+				l.EXPECT().ShouldAllow(gomock.Any(), srv.URL, gomock.Any(), gomock.Any()).Times(1).Return(&redis_rate.Result{Remaining: 10}, nil)
+			},
+			wantErrType: &CircuitBreakerError{},
+		},
+		{
+			name:         "should wait for an earlier delivery to the endpoint",
+			partitionKey: "endpoint-1",
+			limiterFn:    func(l *mocks.MockRateLimiter) {},
+			dbFn: func(m *mocks.MockEventDeliveryRepository) {
+				m.EXPECT().FindPendingEventDeliveryBefore(gomock.Any(), "project-1", gomock.Any()).Times(1).
+					Return(&datastore.EventDelivery{UID: "ed-earlier"}, nil)
+			},
+			wantErrType: &OrderedDeliveryError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotHeader, gotQuery = nil, ""
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			endpointRepo := mocks.NewMockEndpointRepository(ctrl)
+			eventDeliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+			projectRepo := mocks.NewMockProjectRepository(ctrl)
+			subRepo := mocks.NewMockSubscriptionRepository(ctrl)
+			signingKeyRepo := mocks.NewMockSigningKeyRepository(ctrl)
+			rateLimiter := mocks.NewMockRateLimiter(ctrl)
+
+			eventDeliveryRepo.EXPECT().FindEventDeliveriesByBatchID(gomock.Any(), "project-1", "batch-1").Times(1).Return(newDeliveries(tt.partitionKey), nil)
+			endpointRepo.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-1", "project-1").Times(1).
+				Return(&datastore.Endpoint{UID: "endpoint-1", ProjectID: "project-1", TargetURL: srv.URL, Secrets: []datastore.Secret{{Value: "secret"}}, Status: datastore.ActiveEndpointStatus}, nil)
+			projectRepo.EXPECT().FetchProjectByID(gomock.Any(), "project-1").Times(1).Return(project, nil)
+			subRepo.EXPECT().FindSubscriptionByID(gomock.Any(), "project-1", "sub-1").Times(1).Return(&datastore.Subscription{UID: "sub-1"}, nil)
+			signingKeyRepo.EXPECT().LoadActiveSigningKeys(gomock.Any(), gomock.Any()).AnyTimes().Return([]datastore.SigningKey{}, nil)
+
+			tt.limiterFn(rateLimiter)
+			if tt.dbFn != nil {
+				tt.dbFn(eventDeliveryRepo)
+			}
+
+			payload, err := json.Marshal(EventBatch{BatchID: "batch-1", SubscriptionID: "sub-1", ProjectID: "project-1"})
+			require.NoError(t, err)
+
+			task := asynq.NewTask(string(convoy.BatchEventProcessor), payload, asynq.Queue(string(convoy.EventQueue)))
+
+			breaker := tt.breaker
+			if breaker == nil {
+				breaker = circuitbreaker.NewNoopCircuitBreaker()
+			}
+
+			fn := ProcessEventBatch(endpointRepo, eventDeliveryRepo, projectRepo, mocks.NewMockDeadLetterRepository(ctrl), subRepo, signingKeyRepo, mocks.NewMockCache(ctrl), breaker, rateLimiter, mocks.NewMockQueuer(ctrl))
+			err = fn(context.Background(), task)
+			if tt.wantErrType != nil {
+				require.IsType(t, tt.wantErrType, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if !tt.wantSent {
+				require.Nil(t, gotHeader)
+				return
+			}
+
+			require.Equal(t, "source=convoy", gotQuery)
+			require.Equal(t, "api-key", gotHeader.Get("X-Api-Key"))
+			require.Equal(t, "custom", gotHeader.Get("X-Custom"))
+			require.NotEmpty(t, gotHeader.Get("X-Convoy-Signature"))
+		})
+	}
+}
+
+// openCircuitBreaker rejects every request.
+type openCircuitBreaker struct{}
+
+func (openCircuitBreaker) Allow(context.Context, string) (*circuitbreaker.Result, error) {
+	return &circuitbreaker.Result{State: circuitbreaker.StateOpen, PreviousState: circuitbreaker.StateOpen, RetryAfter: time.Minute}, nil
+}
+
+func (openCircuitBreaker) Record(context.Context, string, bool) (*circuitbreaker.Result, error) {
+	return &circuitbreaker.Result{State: circuitbreaker.StateOpen, PreviousState: circuitbreaker.StateOpen}, nil
+}
+
+func TestBatchPayload(t *testing.T) {
+	deliveries := []datastore.EventDelivery{
+		{UID: "ed-1", Metadata: &datastore.Metadata{Raw: `{"id": 1}`}},
+		{UID: "ed-2", Metadata: &datastore.Metadata{Raw: `{"id": 2}`}},
+	}
+
+	payload, err := batchPayload(deliveries)
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"id": 1}, {"id": 2}]`, string(payload))
+}
+
+func TestDiscardExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	deliveries := []datastore.EventDelivery{
+		{UID: "ed-1", Metadata: &datastore.Metadata{ExpiresAt: null.TimeFrom(now.Add(-time.Minute))}},
+		{UID: "ed-2", Metadata: &datastore.Metadata{ExpiresAt: null.TimeFrom(now.Add(time.Minute))}},
+		{UID: "ed-3", Metadata: &datastore.Metadata{}},
+	}
+
+	eventDeliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+	eventDeliveryRepo.EXPECT().DiscardEventDeliveries(gomock.Any(), "project-1", []string{"ed-1"}, expiredDescription).Times(1).Return(nil)
+
+	live, err := discardExpired(context.Background(), eventDeliveryRepo, "project-1", deliveries, now)
+	require.NoError(t, err)
+	require.Len(t, live, 2)
+	require.Equal(t, "ed-2", live[0].UID)
+	require.Equal(t, "ed-3", live[1].UID)
+}
@@ -1,7 +1,32 @@
 package task
 
-import "github.com/frain-dev/convoy/queue"
+import (
+	"context"
 
-func ProcessDeadLetters(job *queue.Job) {
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/oklog/ulid/v2"
+)
 
+// ProcessDeadLetter moves an event delivery that has exhausted its retry
+// budget into the dead letter queue along with its final attempt.
+func ProcessDeadLetter(ctx context.Context, deadLetterRepo datastore.DeadLetterRepository, ed *datastore.EventDelivery, attempt *datastore.DeliveryAttempt) {
+	deadLetter := &datastore.DeadLetter{
+		UID:             ulid.Make().String(),
+		ProjectID:       ed.ProjectID,
+		EventDeliveryID: ed.UID,
+		EventID:         ed.EventID,
+		EndpointID:      ed.EndpointID,
+		Reason:          ed.Description,
+		Attempt:         attempt,
+	}
+
+	if ed.Event != nil {
+		deadLetter.EventType = ed.Event.EventType
+	}
+
+	err := deadLetterRepo.CreateDeadLetter(ctx, deadLetter)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Errorf("failed to move event delivery %s to the dead letter queue", ed.UID)
+	}
 }
@@ -3,7 +3,6 @@ package task
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -13,13 +12,17 @@ import (
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/api/models"
 	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/eventtype"
 	"github.com/frain-dev/convoy/pkg/httpheader"
 	"github.com/frain-dev/convoy/pkg/log"
+	"github.com/frain-dev/convoy/pkg/signature"
 	"github.com/frain-dev/convoy/queue"
 	"github.com/frain-dev/convoy/util"
 	"github.com/hibiken/asynq"
 	"github.com/oklog/ulid/v2"
+	"gopkg.in/guregu/null.v4"
 )
 
 func ProcessDynamicEventCreation(endpointRepo datastore.EndpointRepository, eventRepo datastore.EventRepository, projectRepo datastore.ProjectRepository, eventDeliveryRepo datastore.EventDeliveryRepository, cache cache.Cache, eventQueue queue.Queuer, subRepo datastore.SubscriptionRepository, deviceRepo datastore.DeviceRepository) func(context.Context, *asynq.Task) error {
@@ -87,6 +90,8 @@ func ProcessDynamicEventCreation(endpointRepo datastore.EndpointRepository, even
 			IdempotencyKey:   dynamicEvent.Event.IdempotencyKey,
 			IsDuplicateEvent: isDuplicate,
 			Raw:              string(dynamicEvent.Event.Data),
+			DeliverAt:        null.TimeFromPtr(dynamicEvent.Event.DeliverAt),
+			ExpiresAt:        null.TimeFromPtr(dynamicEvent.Event.ExpiresAt),
 			CreatedAt:        time.Now(),
 			UpdatedAt:        time.Now(),
 		}
@@ -101,6 +106,12 @@ func ProcessDynamicEventCreation(endpointRepo datastore.EndpointRepository, even
 			return nil
 		}
 
+		if !matchesDynamicEventType(s, string(event.EventType)) {
+			log.FromContext(ctx).Infof("[asynq]: event type %s doesn't match the event types of subscription %s", event.EventType, s.UID)
+			indexDynamicEvent(event, eventQueue)
+			return nil
+		}
+
 		ec := &EventDeliveryConfig{project: project}
 
 		ec.subscription = s
@@ -121,15 +132,12 @@ func ProcessDynamicEventCreation(endpointRepo datastore.EndpointRepository, even
 			return &EndpointError{Err: err, delay: 10 * time.Second}
 		}
 
-		metadata := &datastore.Metadata{
-			NumTrials:       0,
-			RetryLimit:      rc.RetryCount,
-			Data:            event.Data,
-			Raw:             event.Raw,
-			IntervalSeconds: rc.Duration,
-			Strategy:        rc.Type,
-			NextSendTime:    time.Now(),
-		}
+		metadata := rc.metadata()
+		metadata.Data = event.Data
+		metadata.Raw = event.Raw
+		metadata.Payload = event.Payload
+		metadata.NextSendTime = deliveryTime(event)
+		metadata.ExpiresAt = deliveryExpiry(event, s, metadata.NextSendTime)
 
 		eventDelivery := &datastore.EventDelivery{
 			UID:            ulid.Make().String(),
@@ -141,6 +149,7 @@ func ProcessDynamicEventCreation(endpointRepo datastore.EndpointRepository, even
 			DeviceID:       s.DeviceID,
 			Headers:        headers,
 			IdempotencyKey: event.IdempotencyKey,
+			PartitionKey:   partitionKey(s.Endpoint, event.Data),
 
 			Status:           getEventDeliveryStatus(ctx, s, s.Endpoint, deviceRepo),
 			DeliveryAttempts: []datastore.DeliveryAttempt{},
@@ -175,10 +184,15 @@ func ProcessDynamicEventCreation(endpointRepo datastore.EndpointRepository, even
 			job := &queue.Job{
 				ID:      eventDelivery.UID,
 				Payload: data,
-				Delay:   1 * time.Second,
+				Delay:   deliveryDelay(eventDelivery),
 			}
 
-			if s.Type == datastore.SubscriptionTypeAPI {
+			if s.Type == datastore.SubscriptionTypeAPI && s.BatchConfig != nil {
+				err = scheduleBatchFlush(ctx, eventDeliveryRepo, eventQueue, s, eventDelivery)
+				if err != nil {
+					log.WithError(err).Errorf("[asynq]: an error occurred scheduling event delivery to be batched")
+				}
+			} else if s.Type == datastore.SubscriptionTypeAPI {
 				err = eventQueue.Write(convoy.EventProcessor, convoy.EventQueue, job)
 				if err != nil {
 					log.WithError(err).Errorf("[asynq]: an error occurred sending event delivery to be dispatched")
@@ -193,23 +207,26 @@ func ProcessDynamicEventCreation(endpointRepo datastore.EndpointRepository, even
 			}
 		}
 
-		eBytes, err := json.Marshal(event)
-		if err != nil {
-			log.Errorf("[asynq]: an error occurred marshalling event to be indexed %s", err)
-		}
+		indexDynamicEvent(event, eventQueue)
+		return nil
+	}
+}
 
-		job := &queue.Job{
-			ID:      event.UID,
-			Payload: eBytes,
-			Delay:   5 * time.Second,
-		}
+func indexDynamicEvent(event *datastore.Event, eventQueue queue.Queuer) {
+	eBytes, err := json.Marshal(event)
+	if err != nil {
+		log.Errorf("[asynq]: an error occurred marshalling event to be indexed %s", err)
+	}
 
-		err = eventQueue.Write(convoy.IndexDocument, convoy.SearchIndexQueue, job)
-		if err != nil {
-			log.Errorf("[asynq]: an error occurred sending event to be indexed %s", err)
-		}
+	job := &queue.Job{
+		ID:      event.UID,
+		Payload: eBytes,
+		Delay:   5 * time.Second,
+	}
 
-		return nil
+	err = eventQueue.Write(convoy.IndexDocument, convoy.SearchIndexQueue, job)
+	if err != nil {
+		log.Errorf("[asynq]: an error occurred sending event to be indexed %s", err)
 	}
 }
 
@@ -255,7 +272,12 @@ func findEndpoint(ctx context.Context, project *datastore.Project, endpointRepo
 			endpoint.HttpTimeout = newEndpoint.HttpTimeout
 		}
 
-		auth, err := ValidateEndpointAuthentication(newEndpoint.Authentication.Transform())
+		auth, err := models.ValidateEndpointAuthentication(newEndpoint.Authentication.Transform())
+		if err != nil {
+			return nil, err
+		}
+
+		err = encryptEndpointAuthentication(auth)
 		if err != nil {
 			return nil, err
 		}
@@ -275,6 +297,13 @@ func findEndpoint(ctx context.Context, project *datastore.Project, endpointRepo
 		}
 
 	case datastore.ErrEndpointNotFound:
+		// the url is checked like it is for endpoints created through the
+		// api, so a blocked url is never saved
+		err = validateEndpointEgress(ctx, newEndpoint.URL)
+		if err != nil {
+			return nil, err
+		}
+
 		if newEndpoint.RateLimit == 0 {
 			newEndpoint.RateLimit = convoy.RATE_LIMIT
 		}
@@ -316,7 +345,13 @@ func findEndpoint(ctx context.Context, project *datastore.Project, endpointRepo
 		}
 
 		if util.IsStringEmpty(newEndpoint.Secret) {
-			sc, err := util.GenerateSecret()
+			var sc string
+			if project.Config != nil && project.Config.GetSignatureConfig().IsStandardWebhooks() {
+				sc, err = signature.GenerateStandardSecret()
+			} else {
+				sc, err = util.GenerateSecret()
+			}
+
 			if err != nil {
 				return nil, &EndpointError{Err: err, delay: 10 * time.Second}
 			}
@@ -338,7 +373,12 @@ func findEndpoint(ctx context.Context, project *datastore.Project, endpointRepo
 			})
 		}
 
-		auth, err := ValidateEndpointAuthentication(endpoint.Authentication)
+		auth, err := models.ValidateEndpointAuthentication(endpoint.Authentication)
+		if err != nil {
+			return nil, err
+		}
+
+		err = encryptEndpointAuthentication(auth)
 		if err != nil {
 			return nil, err
 		}
@@ -358,20 +398,35 @@ func findEndpoint(ctx context.Context, project *datastore.Project, endpointRepo
 	return endpoint, nil
 }
 
-func ValidateEndpointAuthentication(auth *datastore.EndpointAuthentication) (*datastore.EndpointAuthentication, error) {
-	if auth != nil && !util.IsStringEmpty(string(auth.Type)) {
-		if err := util.Validate(auth); err != nil {
-			return nil, err
-		}
+// encryptEndpointAuthentication encrypts the endpoint's mtls client key
+// and oauth2 client secret before the endpoint is stored.
+func encryptEndpointAuthentication(auth *datastore.EndpointAuthentication) error {
+	cfg, err := config.Get()
+	if err != nil {
+		return &EndpointError{Err: err, delay: 10 * time.Second}
+	}
 
-		if auth == nil && auth.Type == datastore.APIKeyAuthentication {
-			return nil, util.NewServiceError(http.StatusBadRequest, errors.New("api key field is required"))
-		}
+	return models.EncryptEndpointAuthentication(auth, cfg.EncryptionKey)
+}
 
-		return auth, nil
+// validateEndpointEgress rejects urls the egress policy would block.
+func validateEndpointEgress(ctx context.Context, url string) error {
+	cfg, err := config.Get()
+	if err != nil {
+		return &EndpointError{Err: err, delay: 10 * time.Second}
 	}
 
-	return nil, nil
+	policy, err := egressPolicy(cfg)
+	if err != nil {
+		return &EndpointError{Err: err, delay: 10 * time.Second}
+	}
+
+	err = policy.ValidateURL(ctx, url)
+	if err != nil {
+		return &EndpointError{Err: err, delay: 10 * time.Second}
+	}
+
+	return nil
 }
 
 func findDynamicSubscription(ctx context.Context, newSubscription *models.DynamicSubscription, subRepo datastore.SubscriptionRepository, project *datastore.Project, endpoint *datastore.Endpoint) (*datastore.Subscription, error) {
@@ -422,6 +477,31 @@ func findDynamicSubscription(ctx context.Context, newSubscription *models.Dynami
 			if newSubscription.RetryConfig.RetryCount > 0 {
 				subscription.RetryConfig.RetryCount = retryConfig.RetryCount
 			}
+
+			if !util.IsStringEmpty(newSubscription.RetryConfig.MaxDuration) {
+				subscription.RetryConfig.MaxDuration = retryConfig.MaxDuration
+			}
+
+			if !util.IsStringEmpty(string(retryConfig.Jitter)) {
+				subscription.RetryConfig.Jitter = retryConfig.Jitter
+			}
+
+			if len(retryConfig.Intervals) > 0 {
+				subscription.RetryConfig.Intervals = retryConfig.Intervals
+			}
+
+			subscription.RetryConfig.HonorRetryAfter = retryConfig.HonorRetryAfter
+		}
+
+		if newSubscription.FilterConfig != nil && len(newSubscription.FilterConfig.EventTypes) > 0 {
+			err := eventtype.Validate(newSubscription.FilterConfig.EventTypes)
+			if err != nil {
+				return nil, util.NewServiceError(http.StatusBadRequest, err)
+			}
+
+			fc := subscription.GetFilterConfig()
+			fc.EventTypes = newSubscription.FilterConfig.EventTypes
+			subscription.FilterConfig = &fc
 		}
 
 		if newSubscription.RateLimitConfig != nil {
@@ -448,6 +528,15 @@ func findDynamicSubscription(ctx context.Context, newSubscription *models.Dynami
 			return nil, util.NewServiceError(http.StatusBadRequest, err)
 		}
 
+		eventTypes := []string{eventtype.All}
+		if newSubscription.FilterConfig != nil && len(newSubscription.FilterConfig.EventTypes) > 0 {
+			err := eventtype.Validate(newSubscription.FilterConfig.EventTypes)
+			if err != nil {
+				return nil, util.NewServiceError(http.StatusBadRequest, err)
+			}
+			eventTypes = newSubscription.FilterConfig.EventTypes
+		}
+
 		subscription = &datastore.Subscription{
 			UID:        ulid.Make().String(),
 			ProjectID:  project.UID,
@@ -458,6 +547,10 @@ func findDynamicSubscription(ctx context.Context, newSubscription *models.Dynami
 			RetryConfig:     retryConfig,
 			AlertConfig:     newSubscription.AlertConfig.Transform(),
 			RateLimitConfig: newSubscription.RateLimitConfig.Transform(),
+			FilterConfig: &datastore.FilterConfiguration{
+				EventTypes: eventTypes,
+				Filter:     datastore.FilterSchema{Headers: datastore.M{}, Body: datastore.M{}},
+			},
 
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
@@ -479,23 +572,7 @@ func findDynamicSubscription(ctx context.Context, newSubscription *models.Dynami
 }
 
 func getRetryConfig(cfg *models.RetryConfiguration) (*datastore.RetryConfiguration, error) {
-	if cfg == nil {
-		return nil, nil
-	}
-
-	strategyConfig := &datastore.RetryConfiguration{Type: cfg.Type, RetryCount: cfg.RetryCount}
-	if !util.IsStringEmpty(cfg.Duration) {
-		interval, err := time.ParseDuration(cfg.Duration)
-		if err != nil {
-			return nil, err
-		}
-
-		strategyConfig.Duration = uint64(interval.Seconds())
-		return strategyConfig, nil
-	}
-
-	strategyConfig.Duration = cfg.IntervalSeconds
-	return strategyConfig, nil
+	return cfg.Transform()
 }
 
 func getCustomHeaders(customHeaders map[string]string) httpheader.HTTPHeader {
@@ -511,3 +588,15 @@ func getCustomHeaders(customHeaders map[string]string) httpheader.HTTPHeader {
 
 	return headers
 }
+
+// matchesDynamicEventType reports whether eventType matches the event types
+// of a dynamic subscription, subscriptions created before dynamic events
+// set event types have none and match every event type.
+func matchesDynamicEventType(s *datastore.Subscription, eventType string) bool {
+	eventTypes := s.GetFilterConfig().EventTypes
+	if len(eventTypes) == 0 {
+		return true
+	}
+
+	return eventtype.Match(eventTypes, eventType)
+}
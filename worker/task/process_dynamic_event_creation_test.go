@@ -6,8 +6,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/net"
 
 	"github.com/frain-dev/convoy/api/models"
 
@@ -136,6 +138,112 @@ func TestProcessDynamicEventCreation(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "should_not_create_event_delivery_for_unmatched_event_type",
+			dynamicEvent: &models.DynamicEvent{
+				Endpoint: models.DynamicEndpoint{
+					URL:    "https://google.com",
+					Secret: "1234",
+					Name:   "testing",
+				},
+				Subscription: models.DynamicSubscription{
+					Name:        "test_sub",
+					AlertConfig: &models.AlertConfiguration{
+                        Count: 4,
+                        Threshold: "1h",
+                    },
+					RetryConfig: &models.RetryConfiguration{
+						Type:       datastore.DefaultRetryConfig.Type,
+						Duration:   "1m",
+						RetryCount: datastore.DefaultRetryConfig.RetryCount,
+					},
+					RateLimitConfig: &models.RateLimitConfiguration{
+                        Count: 1000,
+                        Duration: 60,
+                    },
+				},
+				Event: models.DynamicEventStub{
+					ProjectID: "project-id-1",
+					EventType: "order.created",
+					Data:      []byte(`{"name":"daniel"}`),
+					CustomHeaders: map[string]string{
+						"X-signature": "Convoy",
+					},
+				},
+			},
+			dbFn: func(args *args) {
+				mockCache, _ := args.cache.(*mocks.MockCache)
+				var p *datastore.Project
+				mockCache.EXPECT().Get(gomock.Any(), "projects:project-id-1", &p).Times(1).Return(nil)
+
+				project := &datastore.Project{
+					UID:  "project-id-1",
+					Type: datastore.OutgoingProject,
+					Config: &datastore.ProjectConfig{
+						Strategy: &datastore.StrategyConfiguration{
+							Type:       datastore.LinearStrategyProvider,
+							Duration:   10,
+							RetryCount: 3,
+						},
+					},
+				}
+
+				g, _ := args.projectRepo.(*mocks.MockProjectRepository)
+				g.EXPECT().FetchProjectByID(gomock.Any(), "project-id-1").Times(1).Return(
+					project,
+					nil,
+				)
+				mockCache.EXPECT().Set(gomock.Any(), "projects:project-id-1", project, 10*time.Minute).Times(1).Return(nil)
+
+				a, _ := args.endpointRepo.(*mocks.MockEndpointRepository)
+
+				endpoint := &datastore.Endpoint{
+					UID:    "endpoint-id-1",
+					Title:  "testing-1",
+					Status: datastore.ActiveEndpointStatus,
+					Secrets: datastore.Secrets{
+						{
+							UID:   "secret-1",
+							Value: "1234",
+						},
+					},
+				}
+				a.EXPECT().FindEndpointByTargetURL(gomock.Any(), "project-id-1", "https://google.com").Times(1).Return(endpoint, nil)
+
+				a.EXPECT().UpdateEndpoint(gomock.Any(), gomock.Any(), "project-id-1").
+					Times(1).Return(nil)
+
+				mockCache.EXPECT().Set(gomock.Any(), "endpoints:endpoint-id-1", gomock.Any(), 10*time.Minute).Times(1).Return(nil)
+
+				s, _ := args.subRepo.(*mocks.MockSubscriptionRepository)
+				subscriptions := []datastore.Subscription{
+					{
+						UID:             "sub-1",
+						Name:            "test-sub",
+						Type:            datastore.SubscriptionTypeAPI,
+						ProjectID:       "project-id-1",
+						EndpointID:      "endpoint-id-1",
+						AlertConfig:     nil,
+						RetryConfig:     nil,
+						RateLimitConfig: nil,
+						FilterConfig: &datastore.FilterConfiguration{
+							EventTypes: []string{"invoice.*", "!invoice.test"},
+						},
+					},
+				}
+
+				s.EXPECT().FindSubscriptionsByEndpointID(gomock.Any(), "project-id-1", "endpoint-id-1").Times(1).Return(subscriptions, nil)
+
+				s.EXPECT().UpdateSubscription(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+				e, _ := args.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				q, _ := args.eventQueue.(*mocks.MockQueuer)
+				q.EXPECT().Write(convoy.IndexDocument, convoy.SearchIndexQueue, gomock.Any()).Times(1).Return(nil)
+			},
+			wantErr: false,
+		},
 		{
 			name: "should_create_new_endpoint_and_subscription_for_dynamic_event",
 			dynamicEvent: &models.DynamicEvent{
@@ -227,6 +335,9 @@ func TestProcessDynamicEventCreation(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
+			err := config.LoadConfig("./testdata/Config/basic-convoy.json")
+			require.NoError(t, err)
+
 			args := provideArgs(ctrl)
 
 			if tt.dbFn != nil {
@@ -255,3 +366,45 @@ func TestProcessDynamicEventCreation(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessDynamicEventCreation_BlockedURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Setenv("CONVOY_EGRESS_ALLOW_PRIVATE_NETWORKS", "false")
+	err := config.LoadConfig("./testdata/Config/basic-convoy.json")
+	require.NoError(t, err)
+
+	args := provideArgs(ctrl)
+
+	mockCache, _ := args.cache.(*mocks.MockCache)
+	var p *datastore.Project
+	mockCache.EXPECT().Get(gomock.Any(), "projects:project-id-1", &p).Times(1).Return(nil)
+
+	project := &datastore.Project{UID: "project-id-1", Type: datastore.OutgoingProject}
+	g, _ := args.projectRepo.(*mocks.MockProjectRepository)
+	g.EXPECT().FetchProjectByID(gomock.Any(), "project-id-1").Times(1).Return(project, nil)
+	mockCache.EXPECT().Set(gomock.Any(), "projects:project-id-1", project, 10*time.Minute).Times(1).Return(nil)
+
+	// the endpoint is new, so it must be rejected before it's created
+	a, _ := args.endpointRepo.(*mocks.MockEndpointRepository)
+	a.EXPECT().FindEndpointByTargetURL(gomock.Any(), "project-id-1", "http://127.0.0.1:8080").Times(1).Return(nil, datastore.ErrEndpointNotFound)
+	a.EXPECT().CreateEndpoint(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	payload, err := json.Marshal(&models.DynamicEvent{
+		Endpoint: models.DynamicEndpoint{URL: "http://127.0.0.1:8080", Name: "testing"},
+		Event: models.DynamicEventStub{
+			ProjectID: "project-id-1",
+			EventType: "*",
+			Data:      []byte(`{"name":"daniel"}`),
+		},
+	})
+	require.NoError(t, err)
+
+	fn := ProcessDynamicEventCreation(args.endpointRepo, args.eventRepo, args.projectRepo, args.eventDeliveryRepo, args.cache, args.eventQueue, args.subRepo, args.deviceRepo)
+	err = fn(context.Background(), asynq.NewTask(string(convoy.EventProcessor), payload, asynq.Queue(string(convoy.EventQueue))))
+
+	var endpointErr *EndpointError
+	require.ErrorAs(t, err, &endpointErr)
+	require.ErrorIs(t, endpointErr.Err, net.ErrEgressBlocked)
+}
@@ -8,13 +8,16 @@ import (
 	"time"
 
 	"github.com/frain-dev/convoy"
-	"github.com/frain-dev/convoy/cache"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/compare"
+	"github.com/frain-dev/convoy/pkg/eventtype"
+	"github.com/frain-dev/convoy/pkg/expression"
 	"github.com/frain-dev/convoy/pkg/httpheader"
 	"github.com/frain-dev/convoy/pkg/log"
 	"github.com/frain-dev/convoy/queue"
 	"github.com/hibiken/asynq"
 	"github.com/oklog/ulid/v2"
+	"gopkg.in/guregu/null.v4"
 )
 
 type CreateEvent struct {
@@ -22,7 +25,10 @@ type CreateEvent struct {
 	CreateSubscription bool
 }
 
-func ProcessEventCreation(endpointRepo datastore.EndpointRepository, eventRepo datastore.EventRepository, projectRepo datastore.ProjectRepository, eventDeliveryRepo datastore.EventDeliveryRepository, cache cache.Cache, eventQueue queue.Queuer, subRepo datastore.SubscriptionRepository, deviceRepo datastore.DeviceRepository) func(context.Context, *asynq.Task) error {
+// ProcessEventCreation reads the event's project, endpoints and
+// subscriptions for every event, the worker passes it the cached
+// repositories so those reads are mostly served from the cache.
+func ProcessEventCreation(endpointRepo datastore.EndpointRepository, eventRepo datastore.EventRepository, projectRepo datastore.ProjectRepository, eventDeliveryRepo datastore.EventDeliveryRepository, eventQueue queue.Queuer, subRepo datastore.SubscriptionRepository, deviceRepo datastore.DeviceRepository) func(context.Context, *asynq.Task) error {
 	return func(ctx context.Context, t *asynq.Task) error {
 		var createEvent CreateEvent
 		var event datastore.Event
@@ -37,30 +43,17 @@ func ProcessEventCreation(endpointRepo datastore.EndpointRepository, eventRepo d
 
 		event = createEvent.Event
 
-		projectCacheKey := convoy.ProjectsCacheKey.Get(event.ProjectID).String()
-		err = cache.Get(ctx, projectCacheKey, &project)
+		project, err = projectRepo.FetchProjectByID(ctx, event.ProjectID)
 		if err != nil {
 			return &EndpointError{Err: err, delay: 10 * time.Second}
 		}
 
-		if project == nil {
-			project, err = projectRepo.FetchProjectByID(ctx, event.ProjectID)
-			if err != nil {
-				return &EndpointError{Err: err, delay: 10 * time.Second}
-			}
-
-			err = cache.Set(ctx, projectCacheKey, project, 10*time.Minute)
-			if err != nil {
-				return &EndpointError{Err: err, delay: 10 * time.Second}
-			}
-		}
-
-		subscriptions, err = findSubscriptions(ctx, endpointRepo, cache, subRepo, project, &createEvent)
+		subscriptions, err = findSubscriptions(ctx, endpointRepo, subRepo, project, &createEvent)
 		if err != nil {
 			return &EndpointError{Err: err, delay: 10 * time.Second}
 		}
 
-		_, err = eventRepo.FindEventByID(ctx, project.UID, event.UID)
+		existing, err := eventRepo.FindEventByID(ctx, project.UID, event.UID)
 		if err != nil {
 			if len(event.Endpoints) < 1 {
 				var endpointIDs []string
@@ -76,6 +69,10 @@ func ProcessEventCreation(endpointRepo datastore.EndpointRepository, eventRepo d
 			if err != nil {
 				return &EndpointError{Err: err, delay: 10 * time.Second}
 			}
+		} else if existing != nil {
+			// the event is being retried, its deliveries must point at the
+			// payload that was already stored
+			event.Payload = existing.Payload
 		}
 
 		if event.IsDuplicateEvent {
@@ -109,15 +106,12 @@ func ProcessEventCreation(endpointRepo datastore.EndpointRepository, eventRepo d
 				return &EndpointError{Err: err, delay: 10 * time.Second}
 			}
 
-			metadata := &datastore.Metadata{
-				NumTrials:       0,
-				RetryLimit:      rc.RetryCount,
-				Data:            event.Data,
-				Raw:             event.Raw,
-				IntervalSeconds: rc.Duration,
-				Strategy:        rc.Type,
-				NextSendTime:    time.Now(),
-			}
+			metadata := rc.metadata()
+			metadata.Data = event.Data
+			metadata.Raw = event.Raw
+			metadata.Payload = event.Payload
+			metadata.NextSendTime = deliveryTime(&event)
+			metadata.ExpiresAt = deliveryExpiry(&event, &s, metadata.NextSendTime)
 
 			eventDelivery := &datastore.EventDelivery{
 				UID:              ulid.Make().String(),
@@ -129,6 +123,7 @@ func ProcessEventCreation(endpointRepo datastore.EndpointRepository, eventRepo d
 				DeviceID:         s.DeviceID,
 				Headers:          headers,
 				IdempotencyKey:   event.IdempotencyKey,
+				PartitionKey:     partitionKey(s.Endpoint, event.Data),
 				URLQueryParams:   event.URLQueryParams,
 				Status:           getEventDeliveryStatus(ctx, &s, s.Endpoint, deviceRepo),
 				DeliveryAttempts: []datastore.DeliveryAttempt{},
@@ -163,10 +158,15 @@ func ProcessEventCreation(endpointRepo datastore.EndpointRepository, eventRepo d
 				job := &queue.Job{
 					ID:      eventDelivery.UID,
 					Payload: data,
-					Delay:   1 * time.Second,
+					Delay:   deliveryDelay(eventDelivery),
 				}
 
-				if s.Type == datastore.SubscriptionTypeAPI {
+				if s.Type == datastore.SubscriptionTypeAPI && s.BatchConfig != nil {
+					err = scheduleBatchFlush(ctx, eventDeliveryRepo, eventQueue, &s, eventDelivery)
+					if err != nil {
+						log.FromContext(ctx).WithError(err).Errorf("[asynq]: an error occurred scheduling event delivery to be batched")
+					}
+				} else if s.Type == datastore.SubscriptionTypeAPI {
 					err = eventQueue.Write(convoy.EventProcessor, convoy.EventQueue, job)
 					if err != nil {
 						log.FromContext(ctx).WithError(err).Errorf("[asynq]: an error occurred sending event delivery to be dispatched")
@@ -200,34 +200,19 @@ func ProcessEventCreation(endpointRepo datastore.EndpointRepository, eventRepo d
 	}
 }
 
-func findSubscriptions(ctx context.Context, endpointRepo datastore.EndpointRepository, cache cache.Cache, subRepo datastore.SubscriptionRepository, project *datastore.Project, createEvent *CreateEvent) ([]datastore.Subscription, error) {
+func findSubscriptions(ctx context.Context, endpointRepo datastore.EndpointRepository, subRepo datastore.SubscriptionRepository, project *datastore.Project, createEvent *CreateEvent) ([]datastore.Subscription, error) {
 	var subscriptions []datastore.Subscription
 	var err error
 
 	event := createEvent.Event
 	if project.Type == datastore.OutgoingProject {
+		var candidates []datastore.Subscription
 		for _, endpointID := range event.Endpoints {
-			var endpoint *datastore.Endpoint
-
-			endpointCacheKey := convoy.EndpointsCacheKey.Get(endpointID).String()
-			err = cache.Get(ctx, endpointCacheKey, &endpoint)
+			endpoint, err := endpointRepo.FindEndpointByID(ctx, endpointID, project.UID)
 			if err != nil {
 				return subscriptions, &EndpointError{Err: err, delay: 10 * time.Second}
 			}
 
-			// cache miss, load from db
-			if endpoint == nil {
-				endpoint, err = endpointRepo.FindEndpointByID(ctx, endpointID, project.UID)
-				if err != nil {
-					return subscriptions, &EndpointError{Err: err, delay: 10 * time.Second}
-				}
-
-				err = cache.Set(ctx, endpointCacheKey, endpoint, 10*time.Minute)
-				if err != nil {
-					return subscriptions, &EndpointError{Err: err, delay: 10 * time.Second}
-				}
-			}
-
 			subs, err := subRepo.FindSubscriptionsByEndpointID(ctx, project.UID, endpoint.UID)
 			if err != nil {
 				return subscriptions, &EndpointError{Err: errors.New("error fetching subscriptions for event type"), delay: 10 * time.Second}
@@ -244,15 +229,19 @@ func findSubscriptions(ctx context.Context, endpointRepo datastore.EndpointRepos
 				return subscriptions, nil
 			}
 
-			subs = matchSubscriptions(string(event.EventType), subs)
+			candidates = append(candidates, subs...)
+		}
 
-			subs, err = matchSubscriptionsUsingFilter(ctx, event, subRepo, subs)
-			if err != nil {
-				return subscriptions, &EndpointError{Err: errors.New("error fetching subscriptions for event type"), delay: 10 * time.Second}
-			}
+		// the subscriptions of every endpoint are matched together, with
+		// their event types parsed once and reused until they change
+		subs := matchSubscriptions(ctx, string(event.EventType), candidates)
 
-			subscriptions = append(subscriptions, subs...)
+		subs, err = matchSubscriptionsUsingFilter(ctx, event, subRepo, subs)
+		if err != nil {
+			return subscriptions, &EndpointError{Err: errors.New("error fetching subscriptions for event type"), delay: 10 * time.Second}
 		}
+
+		subscriptions = append(subscriptions, subs...)
 	} else if project.Type == datastore.IncomingProject {
 		subs, err := subRepo.FindSubscriptionsBySourceID(ctx, project.UID, event.SourceID)
 		if err != nil {
@@ -277,13 +266,46 @@ func matchSubscriptionsUsingFilter(ctx context.Context, e datastore.Event, subRe
 		return nil, err
 	}
 
+	input := &expression.Input{
+		Body:      payload,
+		Headers:   e.Headers,
+		EventType: string(e.EventType),
+		SourceID:  e.SourceID,
+	}
+
+	if e.Source != nil {
+		input.SourceName = e.Source.Name
+	}
+
 	for _, s := range subscriptions {
-		isBodyMatched, err := subRepo.TestSubscriptionFilter(ctx, payload, s.FilterConfig.Filter.Body.Map())
+		cf := subscriptionFilters(ctx, &s)
+
+		if s.FilterConfig.Expression != "" {
+			if cf.expression == nil {
+				continue
+			}
+
+			// an expression that fails to evaluate, e.g. because it reads
+			// a field the payload doesn't have, doesn't match
+			isMatched, err := cf.expression.Eval(input)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Debugf("subscription %s filter expression did not evaluate", s.UID)
+				continue
+			}
+
+			if isMatched {
+				matched = append(matched, s)
+			}
+
+			continue
+		}
+
+		isBodyMatched, err := subRepo.TestSubscriptionFilter(ctx, payload, cf.body)
 		if err != nil {
 			return nil, err
 		}
 
-		isHeaderMatched, err := subRepo.TestSubscriptionFilter(ctx, e.GetRawHeaders(), s.FilterConfig.Filter.Headers.Map())
+		isHeaderMatched, err := subRepo.TestSubscriptionFilter(ctx, e.GetRawHeaders(), cf.headers)
 		if err != nil {
 			return nil, err
 		}
@@ -302,19 +324,119 @@ func matchSubscriptionsUsingFilter(ctx context.Context, e datastore.Event, subRe
 	return matched, nil
 }
 
-func matchSubscriptions(eventType string, subscriptions []datastore.Subscription) []datastore.Subscription {
+// compiledFilters holds the compiled filters of the subscriptions used
+// most recently, keyed by the subscription's id.
+var compiledFilters = newFilterCache(maxCompiledFilters)
+
+type compiledFilter struct {
+	schema     string
+	eventTypes *eventtype.Matcher
+	body       interface{}
+	headers    interface{}
+	expression *expression.Program
+}
+
+// subscriptionFilters returns the subscription's filters compiled, they're
+// reused until the subscription's filter changes. A body or headers filter
+// that doesn't compile is returned as it is stored, an expression that
+// doesn't compile is left nil.
+func subscriptionFilters(ctx context.Context, s *datastore.Subscription) *compiledFilter {
+	fc := s.FilterConfig
+	cf := &compiledFilter{body: fc.Filter.Body.Map(), headers: fc.Filter.Headers.Map()}
+
+	schema, err := json.Marshal(fc)
+	if err != nil {
+		cf.eventTypes = eventtype.NewMatcher(fc.EventTypes)
+		return cf
+	}
+
+	if cached, ok := compiledFilters.Load(s.UID); ok && cached.schema == string(schema) {
+		return cached
+	}
+
+	cf.schema = string(schema)
+	cf.eventTypes = eventtype.NewMatcher(fc.EventTypes)
+
+	if fc.Expression != "" {
+		cf.expression, err = expression.Compile(fc.Expression)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Errorf("subscription %s has an invalid filter expression", s.UID)
+		}
+	} else {
+		if f, err := compare.Compile(fc.Filter.Body.Map()); err == nil {
+			cf.body = f
+		} else {
+			log.FromContext(ctx).WithError(err).Warnf("subscription %s has an invalid body filter", s.UID)
+		}
+
+		if f, err := compare.Compile(fc.Filter.Headers.Map()); err == nil {
+			cf.headers = f
+		} else {
+			log.FromContext(ctx).WithError(err).Warnf("subscription %s has an invalid headers filter", s.UID)
+		}
+	}
+
+	if s.UID != "" {
+		compiledFilters.Store(s.UID, cf)
+	}
+
+	return cf
+}
+
+// matchSubscriptions returns the subscriptions with event type patterns
+// that match eventType, the patterns are parsed once with the rest of the
+// subscription's filters.
+func matchSubscriptions(ctx context.Context, eventType string, subscriptions []datastore.Subscription) []datastore.Subscription {
 	var matched []datastore.Subscription
-	for _, sub := range subscriptions {
-		for _, ev := range sub.FilterConfig.EventTypes {
-			if ev == eventType || ev == "*" { // if this event type matches, or is *, add the subscription to matched
-				matched = append(matched, sub)
-			}
+	for i := range subscriptions {
+		if subscriptionFilters(ctx, &subscriptions[i]).eventTypes.Match(eventType) {
+			matched = append(matched, subscriptions[i])
 		}
 	}
 
 	return matched
 }
 
+// deliveryTime is when deliveries of the event are first sent, scheduled
+// events keep their deliveries until the time they were scheduled for.
+func deliveryTime(event *datastore.Event) time.Time {
+	if event.IsScheduled() {
+		return event.DeliverAt.Time
+	}
+
+	return time.Now()
+}
+
+// deliveryExpiry is when a delivery of the event to the subscription
+// expires, the subscription's ttl counts from the delivery's first send
+// and the earlier of it and the event's own expiry wins.
+func deliveryExpiry(event *datastore.Event, subscription *datastore.Subscription, sendTime time.Time) null.Time {
+	expiresAt := event.ExpiresAt
+	if subscription.TTL == 0 {
+		return expiresAt
+	}
+
+	ttlExpiry := sendTime.Add(time.Duration(subscription.TTL) * time.Second)
+	if !expiresAt.Valid || ttlExpiry.Before(expiresAt.Time) {
+		return null.TimeFrom(ttlExpiry)
+	}
+
+	return expiresAt
+}
+
+// deliveryDelay is how long a new delivery waits in the queue before it's
+// sent.
+func deliveryDelay(ed *datastore.EventDelivery) time.Duration {
+	delay := 1 * time.Second
+	if ed.Metadata != nil {
+		if untilNextSend := time.Until(ed.Metadata.NextSendTime); untilNextSend > delay {
+			delay = untilNextSend
+		}
+	}
+
+	return delay
+}
+
 func getEventDeliveryStatus(ctx context.Context, subscription *datastore.Subscription, endpoint *datastore.Endpoint, deviceRepo datastore.DeviceRepository) datastore.EventDeliveryStatus {
 	switch subscription.Type {
 	case datastore.SubscriptionTypeAPI:
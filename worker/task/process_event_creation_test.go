@@ -11,11 +11,13 @@ import (
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/pkg/searcher"
 	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/pkg/compare"
 	"github.com/frain-dev/convoy/queue"
 	"github.com/golang/mock/gomock"
 	"github.com/hibiken/asynq"
 	"github.com/oklog/ulid/v2"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
 )
 
 type args struct {
@@ -79,10 +81,6 @@ func TestProcessEventCreated(t *testing.T) {
 				},
 			},
 			dbFn: func(args *args) {
-				mockCache, _ := args.cache.(*mocks.MockCache)
-				var gr *datastore.Project
-				mockCache.EXPECT().Get(gomock.Any(), "projects:project-id-1", &gr).Times(1).Return(nil)
-
 				project := &datastore.Project{
 					UID:  "project-id-1",
 					Type: datastore.OutgoingProject,
@@ -100,15 +98,11 @@ func TestProcessEventCreated(t *testing.T) {
 					project,
 					nil,
 				)
-				mockCache.EXPECT().Set(gomock.Any(), "projects:project-id-1", project, 10*time.Minute).Times(1).Return(nil)
-
-				mockCache.EXPECT().Get(gomock.Any(), "endpoints:endpoint-id-1", gomock.Any()).Times(1).Return(nil)
 
 				a, _ := args.endpointRepo.(*mocks.MockEndpointRepository)
 
 				endpoint := &datastore.Endpoint{UID: "endpoint-id-1"}
 				a.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-id-1", gomock.Any()).Times(1).Return(endpoint, nil)
-				mockCache.EXPECT().Set(gomock.Any(), "endpoints:endpoint-id-1", endpoint, 10*time.Minute).Times(1).Return(nil)
 
 				s, _ := args.subRepo.(*mocks.MockSubscriptionRepository)
 				subscriptions := []datastore.Subscription{
@@ -159,10 +153,6 @@ func TestProcessEventCreated(t *testing.T) {
 				CreateSubscription: true,
 			},
 			dbFn: func(args *args) {
-				mockCache, _ := args.cache.(*mocks.MockCache)
-				var gr *datastore.Project
-				mockCache.EXPECT().Get(gomock.Any(), "projects:project-id-1", &gr).Times(1).Return(nil)
-
 				project := &datastore.Project{
 					UID:  "project-id-1",
 					Type: datastore.OutgoingProject,
@@ -180,15 +170,11 @@ func TestProcessEventCreated(t *testing.T) {
 					project,
 					nil,
 				)
-				mockCache.EXPECT().Set(gomock.Any(), "projects:project-id-1", project, 10*time.Minute).Times(1).Return(nil)
-
-				mockCache.EXPECT().Get(gomock.Any(), "endpoints:endpoint-id-1", gomock.Any()).Times(1).Return(nil)
 
 				a, _ := args.endpointRepo.(*mocks.MockEndpointRepository)
 
 				endpoint := &datastore.Endpoint{UID: "endpoint-id-1"}
 				a.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-id-1", gomock.Any()).Times(1).Return(endpoint, nil)
-				mockCache.EXPECT().Set(gomock.Any(), "endpoints:endpoint-id-1", endpoint, 10*time.Minute).Times(1).Return(nil)
 
 				s, _ := args.subRepo.(*mocks.MockSubscriptionRepository)
 				subscriptions := []datastore.Subscription{}
@@ -231,10 +217,6 @@ func TestProcessEventCreated(t *testing.T) {
 				},
 			},
 			dbFn: func(args *args) {
-				mockCache, _ := args.cache.(*mocks.MockCache)
-				var gr *datastore.Project
-				mockCache.EXPECT().Get(gomock.Any(), "projects:project-id-1", &gr).Times(1).Return(nil)
-
 				project := &datastore.Project{
 					UID:  "project-id-1",
 					Type: datastore.IncomingProject,
@@ -252,7 +234,6 @@ func TestProcessEventCreated(t *testing.T) {
 					project,
 					nil,
 				)
-				mockCache.EXPECT().Set(gomock.Any(), "projects:project-id-1", project, 10*time.Minute).Times(1).Return(nil)
 
 				a, _ := args.endpointRepo.(*mocks.MockEndpointRepository)
 
@@ -314,10 +295,6 @@ func TestProcessEventCreated(t *testing.T) {
 				},
 			},
 			dbFn: func(args *args) {
-				mockCache, _ := args.cache.(*mocks.MockCache)
-				var gr *datastore.Project
-				mockCache.EXPECT().Get(gomock.Any(), "projects:project-id-1", &gr).Times(1).Return(nil)
-
 				project := &datastore.Project{
 					UID:  "project-id-1",
 					Type: datastore.IncomingProject,
@@ -335,7 +312,6 @@ func TestProcessEventCreated(t *testing.T) {
 					project,
 					nil,
 				)
-				mockCache.EXPECT().Set(gomock.Any(), "projects:project-id-1", project, 10*time.Minute).Times(1).Return(nil)
 
 				s, _ := args.subRepo.(*mocks.MockSubscriptionRepository)
 				subscriptions := []datastore.Subscription{
@@ -402,10 +378,6 @@ func TestProcessEventCreated(t *testing.T) {
 				},
 			},
 			dbFn: func(args *args) {
-				mockCache, _ := args.cache.(*mocks.MockCache)
-				var gr *datastore.Project
-				mockCache.EXPECT().Get(gomock.Any(), "projects:project-id-1", &gr).Times(1).Return(nil)
-
 				project := &datastore.Project{
 					UID:  "project-id-1",
 					Type: datastore.IncomingProject,
@@ -423,7 +395,6 @@ func TestProcessEventCreated(t *testing.T) {
 					project,
 					nil,
 				)
-				mockCache.EXPECT().Set(gomock.Any(), "projects:project-id-1", project, 10*time.Minute).Times(1).Return(nil)
 
 				a, _ := args.endpointRepo.(*mocks.MockEndpointRepository)
 
@@ -479,7 +450,7 @@ func TestProcessEventCreated(t *testing.T) {
 
 			task := asynq.NewTask(string(convoy.EventProcessor), job.Payload, asynq.Queue(string(convoy.EventQueue)), asynq.ProcessIn(job.Delay))
 
-			fn := ProcessEventCreation(args.endpointRepo, args.eventRepo, args.projectRepo, args.eventDeliveryRepo, args.cache, args.eventQueue, args.subRepo, args.deviceRepo)
+			fn := ProcessEventCreation(args.endpointRepo, args.eventRepo, args.projectRepo, args.eventDeliveryRepo, args.eventQueue, args.subRepo, args.deviceRepo)
 			err = fn(context.Background(), task)
 			if tt.wantErr {
 				require.NotNil(t, err)
@@ -863,3 +834,180 @@ func TestMatchSubscriptionsUsingFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestDeliveryDelay(t *testing.T) {
+	scheduled := &datastore.Event{DeliverAt: null.TimeFrom(time.Now().Add(time.Hour))}
+	ed := &datastore.EventDelivery{Metadata: &datastore.Metadata{NextSendTime: deliveryTime(scheduled)}}
+	require.Equal(t, scheduled.DeliverAt.Time, ed.Metadata.NextSendTime)
+
+	delay := deliveryDelay(ed)
+	require.Greater(t, delay, 59*time.Minute)
+	require.LessOrEqual(t, delay, time.Hour)
+
+	// an event whose delivery time has passed is sent straight away
+	overdue := &datastore.Event{DeliverAt: null.TimeFrom(time.Now().Add(-time.Hour))}
+	ed.Metadata.NextSendTime = deliveryTime(overdue)
+	require.WithinDuration(t, time.Now(), ed.Metadata.NextSendTime, time.Second)
+	require.Equal(t, 1*time.Second, deliveryDelay(ed))
+}
+
+func TestDeliveryExpiry(t *testing.T) {
+	sendTime := time.Now()
+	eventExpiry := sendTime.Add(time.Hour)
+
+	tests := []struct {
+		name         string
+		event        *datastore.Event
+		subscription *datastore.Subscription
+		want         null.Time
+	}{
+		{
+			name:         "should_not_expire_without_ttls",
+			event:        &datastore.Event{},
+			subscription: &datastore.Subscription{},
+			want:         null.Time{},
+		},
+		{
+			name:         "should_use_event_expiry",
+			event:        &datastore.Event{ExpiresAt: null.TimeFrom(eventExpiry)},
+			subscription: &datastore.Subscription{},
+			want:         null.TimeFrom(eventExpiry),
+		},
+		{
+			name:         "should_count_subscription_ttl_from_send_time",
+			event:        &datastore.Event{},
+			subscription: &datastore.Subscription{TTL: 300},
+			want:         null.TimeFrom(sendTime.Add(5 * time.Minute)),
+		},
+		{
+			name:         "should_use_earlier_subscription_ttl",
+			event:        &datastore.Event{ExpiresAt: null.TimeFrom(eventExpiry)},
+			subscription: &datastore.Subscription{TTL: 300},
+			want:         null.TimeFrom(sendTime.Add(5 * time.Minute)),
+		},
+		{
+			name:         "should_use_earlier_event_expiry",
+			event:        &datastore.Event{ExpiresAt: null.TimeFrom(eventExpiry)},
+			subscription: &datastore.Subscription{TTL: 7200},
+			want:         null.TimeFrom(eventExpiry),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, deliveryExpiry(tt.event, tt.subscription, sendTime))
+		})
+	}
+}
+
+func TestSubscriptionFilters(t *testing.T) {
+	ctx := context.Background()
+	s := &datastore.Subscription{
+		UID: ulid.Make().String(),
+		FilterConfig: &datastore.FilterConfiguration{
+			Filter: datastore.FilterSchema{
+				Body:    datastore.M{"name": map[string]interface{}{"$prefix": "ray"}},
+				Headers: datastore.M{},
+			},
+		},
+	}
+
+	cf := subscriptionFilters(ctx, s)
+	require.IsType(t, &compare.Filter{}, cf.body)
+	require.IsType(t, &compare.Filter{}, cf.headers)
+
+	// the compiled filter is reused while the filter is unchanged
+	require.Same(t, cf, subscriptionFilters(ctx, s))
+
+	s.FilterConfig.Filter.Body = datastore.M{"name": map[string]interface{}{"$suffix": "mond"}}
+	changed := subscriptionFilters(ctx, s)
+	require.NotSame(t, cf, changed)
+
+	// filters stored before they were validated are matched as they are
+	s.FilterConfig.Filter.Body = datastore.M{"age": map[string]interface{}{"$gte": "ten"}}
+	invalid := subscriptionFilters(ctx, s)
+	require.Equal(t, s.FilterConfig.Filter.Body.Map(), invalid.body)
+
+	s.FilterConfig.Filter = datastore.FilterSchema{Body: datastore.M{}, Headers: datastore.M{}}
+	s.FilterConfig.Expression = "body.amount > 100"
+	require.NotNil(t, subscriptionFilters(ctx, s).expression)
+
+	s.FilterConfig.Expression = "amount > 100"
+	require.Nil(t, subscriptionFilters(ctx, s).expression)
+}
+
+func TestMatchSubscriptionsUsingFilter_Expression(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	subscription := func(expr string) datastore.Subscription {
+		return datastore.Subscription{
+			UID:          ulid.Make().String(),
+			FilterConfig: &datastore.FilterConfiguration{Expression: expr},
+		}
+	}
+
+	subs := []datastore.Subscription{
+		subscription(`body.amount > 100 && body.currency in ['USD', 'EUR']`),
+		subscription(`headers['x-tier'] == 'gold' && event_type == 'invoice.paid'`),
+		subscription(`source.name == 'stripe'`),
+		subscription(`body.discount > 0`),
+		subscription(`body.amount < 100`),
+	}
+
+	event := datastore.Event{
+		EventType: "invoice.paid",
+		Data:      []byte(`{"amount": 250, "currency": "EUR"}`),
+		Headers:   map[string][]string{"X-Tier": {"gold"}},
+		Source:    &datastore.Source{Name: "stripe"},
+	}
+
+	// expressions are evaluated without the repository
+	matched, err := matchSubscriptionsUsingFilter(context.Background(), event, mocks.NewMockSubscriptionRepository(ctrl), subs)
+	require.NoError(t, err)
+	require.Equal(t, subs[:3], matched)
+}
+
+func TestMatchSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	subscription := func(uid string, eventTypes ...string) datastore.Subscription {
+		return datastore.Subscription{
+			UID:          uid,
+			FilterConfig: &datastore.FilterConfiguration{EventTypes: eventTypes},
+		}
+	}
+
+	subscriptions := []datastore.Subscription{
+		subscription("all", "*"),
+		subscription("exact", "invoice.paid"),
+		subscription("invoices", "invoice.*"),
+		subscription("deletions", "*.deleted"),
+		subscription("orders", "order.**", "!order.test"),
+		subscription("twice", "invoice.paid", "invoice.*"),
+		subscription("invalid", "invoice*"),
+	}
+
+	uids := func(subs []datastore.Subscription) []string {
+		var ids []string
+		for _, s := range subs {
+			ids = append(ids, s.UID)
+		}
+		return ids
+	}
+
+	require.Equal(t, []string{"all", "exact", "invoices", "twice"}, uids(matchSubscriptions(ctx, "invoice.paid", subscriptions)))
+	require.Equal(t, []string{"all", "deletions"}, uids(matchSubscriptions(ctx, "user.deleted", subscriptions)))
+	require.Equal(t, []string{"all", "orders"}, uids(matchSubscriptions(ctx, "order.created.v1", subscriptions)))
+	require.Equal(t, []string{"all"}, uids(matchSubscriptions(ctx, "order.test", subscriptions)))
+
+	// event types stored before they were validated match exactly
+	require.Equal(t, []string{"all", "invalid"}, uids(matchSubscriptions(ctx, "invoice*", subscriptions)))
+
+	// the parsed event types are reused until they change
+	cf := subscriptionFilters(ctx, &subscriptions[1])
+	require.Same(t, cf, subscriptionFilters(ctx, &subscriptions[1]))
+
+	subscriptions[1].FilterConfig.EventTypes = []string{"invoice.created"}
+	require.NotSame(t, cf, subscriptionFilters(ctx, &subscriptions[1]))
+	require.Equal(t, []string{"all", "exact", "invoices", "twice"}, uids(matchSubscriptions(ctx, "invoice.created", subscriptions)))
+}
@@ -7,8 +7,11 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/frain-dev/convoy/pkg/url"
+	"github.com/frain-dev/convoy/pkg/httpheader"
 
+	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/circuitbreaker"
+	"github.com/frain-dev/convoy/database/hooks"
 	"github.com/frain-dev/convoy/limiter"
 	"github.com/frain-dev/convoy/pkg/signature"
 	"github.com/oklog/ulid/v2"
@@ -28,9 +31,16 @@ import (
 var (
 	ErrDeliveryAttemptFailed               = errors.New("error sending event")
 	ErrRateLimit                           = errors.New("rate limit error")
+	ErrCircuitBreakerOpen                  = errors.New("endpoint circuit breaker is open")
+	ErrDeliveryNotDue                      = errors.New("event delivery is scheduled for later")
 	defaultDelay             time.Duration = 30
 )
 
+const (
+	expiredDescription            = "Event expired before it was delivered"
+	expiresBeforeRetryDescription = "Event expires before its next retry"
+)
+
 type SignatureValues struct {
 	HMAC      string
 	Timestamp string
@@ -40,7 +50,7 @@ type EventDelivery struct {
 	ProjectID       string
 }
 
-func ProcessEventDelivery(endpointRepo datastore.EndpointRepository, eventDeliveryRepo datastore.EventDeliveryRepository, projectRepo datastore.ProjectRepository, subRepo datastore.SubscriptionRepository, notificationQueue queue.Queuer) func(context.Context, *asynq.Task) error {
+func ProcessEventDelivery(endpointRepo datastore.EndpointRepository, eventDeliveryRepo datastore.EventDeliveryRepository, projectRepo datastore.ProjectRepository, subRepo datastore.SubscriptionRepository, deadLetterRepo datastore.DeadLetterRepository, signingKeyRepo datastore.SigningKeyRepository, cache cache.Cache, breaker circuitbreaker.CircuitBreaker, rateLimiter limiter.RateLimiter, notificationQueue queue.Queuer) func(context.Context, *asynq.Task) error {
 	return func(ctx context.Context, t *asynq.Task) error {
 		var data EventDelivery
 
@@ -54,6 +64,14 @@ func ProcessEventDelivery(endpointRepo datastore.EndpointRepository, eventDelive
 			return &EndpointError{Err: err, delay: defaultDelay}
 		}
 
+		deps := &dispatchDeps{
+			cfg:               cfg,
+			cache:             cache,
+			rateLimiter:       rateLimiter,
+			eventDeliveryRepo: eventDeliveryRepo,
+			signingKeyRepo:    signingKeyRepo,
+		}
+
 		// Load message from DB and switch state to prevent concurrent processing.
 		ed, err := eventDeliveryRepo.FindEventDeliveryByID(ctx, data.ProjectID, data.EventDeliveryID)
 		if err != nil {
@@ -79,112 +97,96 @@ func ProcessEventDelivery(endpointRepo datastore.EndpointRepository, eventDelive
 
 		switch ed.Status {
 		case datastore.ProcessingEventStatus,
-			datastore.SuccessEventStatus:
+			datastore.SuccessEventStatus,
+			datastore.DiscardedEventStatus:
 			return nil
 		}
 
-		ec := &EventDeliveryConfig{subscription: subscription, project: p}
-		rlc := ec.rateLimitConfig()
-
-		rateLimiter, err := limiter.NewLimiter(cfg.Redis)
-		if err != nil {
-			log.WithError(err).Error("failed to initialise redis rate limiter")
-			return &EndpointError{Err: err, delay: delayDuration}
+		// a scheduled delivery that's picked up early, e.g. when its job
+		// was queued without the delay, is put back until it's due
+		if ed.Status == datastore.ScheduledEventStatus && ed.Metadata.NumTrials == 0 {
+			if untilNextSend := time.Until(ed.Metadata.NextSendTime); untilNextSend > 0 {
+				log.FromContext(ctx).Debugf("%s is scheduled for %s, rescheduling", ed.UID, ed.Metadata.NextSendTime)
+				return &ScheduledDeliveryError{Err: ErrDeliveryNotDue, delay: untilNextSend}
+			}
 		}
 
-		res, err := rateLimiter.ShouldAllow(ctx, endpoint.TargetURL, rlc.Count, int(rlc.Duration))
-		if err != nil {
+		if ed.Metadata.IsExpired(time.Now()) {
+			err = eventDeliveryRepo.DiscardEventDeliveries(ctx, p.UID, []string{ed.UID}, expiredDescription)
+			if err != nil {
+				return &EndpointError{Err: err, delay: delayDuration}
+			}
+
+			log.FromContext(ctx).Debugf("%s expired before it was delivered", ed.UID)
 			return nil
 		}
 
-		if res.Remaining <= 0 {
-			err := fmt.Errorf("too many events to %s, limit of %v would be reached", endpoint.TargetURL, res.Limit)
-			log.WithError(ErrRateLimit).Error(err.Error())
+		e := endpoint
+		if e.Status == datastore.InactiveEndpointStatus {
+			err = eventDeliveryRepo.UpdateStatusOfEventDelivery(ctx, p.UID, *ed, datastore.DiscardedEventStatus)
+			if err != nil {
+				return &EndpointError{Err: err, delay: delayDuration}
+			}
 
-			var delayDuration time.Duration = retrystrategies.NewRetryStrategyFromMetadata(*ed.Metadata).NextDuration(ed.Metadata.NumTrials)
-			return &RateLimitError{Err: ErrRateLimit, delay: delayDuration}
+			log.Debugf("endpoint %s is inactive, failing to send.", e.TargetURL)
+			return nil
 		}
 
-		_, err = rateLimiter.Allow(ctx, endpoint.TargetURL, rlc.Count, int(rlc.Duration))
+		// the request is checked, transformed, signed and authenticated
+		// before the delivery is marked as processing, a delivery that fails
+		// here is retried rather than being left in the processing state
+		req, err := prepareDispatch(ctx, deps, p, endpoint, subscription, "", []datastore.EventDelivery{*ed}, delayDuration)
 		if err != nil {
-			return nil
+			return err
 		}
 
-		err = eventDeliveryRepo.UpdateStatusOfEventDelivery(ctx, p.UID, *ed, datastore.ProcessingEventStatus)
+		// the breaker is checked last, an allowed half-open probe has to
+		// be sent so its outcome is recorded
+		cbResult, err := breaker.Allow(ctx, endpoint.UID)
 		if err != nil {
+			log.WithError(err).Error("failed to check endpoint circuit breaker")
 			return &EndpointError{Err: err, delay: delayDuration}
 		}
 
-		var attempt datastore.DeliveryAttempt
+		fireCircuitBreakerHook(endpoint, cbResult)
 
-		var httpDuration time.Duration
-		if util.IsStringEmpty(endpoint.HttpTimeout) {
-			httpDuration, err = time.ParseDuration(convoy.HTTP_TIMEOUT)
-			if err != nil {
-				log.WithError(err).Errorf("failed to parse endpoint duration")
-				return nil
-			}
-		} else {
-			httpDuration, err = time.ParseDuration(endpoint.HttpTimeout)
-			if err != nil {
-				log.WithError(err).Errorf("failed to parse endpoint duration")
-				return nil
-			}
+		if !cbResult.Allowed {
+			// the delivery is rescheduled without counting it as a trial
+			log.FromContext(ctx).Debugf("circuit breaker for endpoint %s is %s, rescheduling %s", endpoint.UID, cbResult.State, ed.UID)
+			return &CircuitBreakerError{Err: ErrCircuitBreakerOpen, delay: cbResult.RetryAfter}
 		}
 
-		done := true
-		dispatch, err := net.NewDispatcher(httpDuration, cfg.Server.HTTP.HttpProxy)
+		err = req.takeRateLimit(ctx, delayDuration)
 		if err != nil {
-			return &EndpointError{Err: err, delay: delayDuration}
+			return err
 		}
 
-		e := endpoint
-		if ed.Status == datastore.SuccessEventStatus {
-			log.Debugf("endpoint %s already merged with message %s\n", e.TargetURL, ed.UID)
-			return nil
-		}
-
-		if e.Status == datastore.InactiveEndpointStatus {
-			err = eventDeliveryRepo.UpdateStatusOfEventDelivery(ctx, p.UID, *ed, datastore.DiscardedEventStatus)
-			if err != nil {
-				return &EndpointError{Err: err, delay: delayDuration}
-			}
-
-			log.Debugf("endpoint %s is inactive, failing to send.", e.TargetURL)
-			return nil
-		}
-
-		sig := newSignature(endpoint, p, json.RawMessage(ed.Metadata.Raw))
-		header, err := sig.ComputeHeaderValue()
+		err = eventDeliveryRepo.UpdateStatusOfEventDelivery(ctx, p.UID, *ed, datastore.ProcessingEventStatus)
 		if err != nil {
 			return &EndpointError{Err: err, delay: delayDuration}
 		}
 
-		targetURL := e.TargetURL
-		if !util.IsStringEmpty(ed.URLQueryParams) {
-			targetURL, err = url.ConcatQueryParams(e.TargetURL, ed.URLQueryParams)
-			if err != nil {
-				log.WithError(err).Error("failed to concat url query params")
-				return &EndpointError{Err: err, delay: delayDuration}
-			}
-		}
+		var attempt datastore.DeliveryAttempt
 
+		done := true
 		attemptStatus := false
 		start := time.Now()
 
-		resp, err := dispatch.SendRequest(targetURL, string(convoy.HttpPost), sig.Payload, p.Config.Signature.Header.String(), header, int64(cfg.MaxResponseSize), ed.Headers, ed.IdempotencyKey)
+		resp, err := req.send(ctx, endpoint, int64(cfg.MaxResponseSize), ed.IdempotencyKey)
+
 		status := "-"
 		statusCode := 0
 		if resp != nil {
 			status = resp.Status
 			statusCode = resp.StatusCode
 		}
+		invalidateOAuth2Token(ctx, cache, endpoint, statusCode)
 
 		duration := time.Since(start)
 		// log request details
 		requestLogger := log.WithFields(log.Fields{
 			"status":   status,
-			"uri":      targetURL,
+			"uri":      req.targetURL,
 			"method":   convoy.HttpPost,
 			"duration": duration,
 		})
@@ -204,10 +206,23 @@ func ProcessEventDelivery(endpointRepo datastore.EndpointRepository, eventDelive
 
 			ed.Status = datastore.RetryEventStatus
 
+			if ed.Metadata.HonorRetryAfter && resp != nil && retrystrategies.ShouldHonorRetryAfter(statusCode) {
+				if retryAfter, ok := retrystrategies.ParseRetryAfter(resp.ResponseHeader, time.Now()); ok {
+					delayDuration = retryAfter
+				}
+			}
+
+			ed.Metadata.LastIntervalMillis = uint64(delayDuration.Milliseconds())
 			nextTime := time.Now().Add(delayDuration)
 			ed.Metadata.NextSendTime = nextTime
 			attempts := ed.Metadata.NumTrials + 1
 
+			if ed.Metadata.IsExpired(nextTime) {
+				// retrying would only deliver the event after it expires
+				ed.Status = datastore.DiscardedEventStatus
+				ed.Description = expiresBeforeRetryDescription
+			}
+
 			log.FromContext(ctx).Info("%s next retry time is %s (strategy = %s, delay = %d, attempts = %d/%d)\n", ed.UID, nextTime.Format(time.ANSIC), ed.Metadata.Strategy, ed.Metadata.IntervalSeconds, attempts, ed.Metadata.RetryLimit)
 		}
 
@@ -238,6 +253,13 @@ func ProcessEventDelivery(endpointRepo datastore.EndpointRepository, eventDelive
 			}
 		}
 
+		cbResult, err = breaker.Record(ctx, endpoint.UID, done)
+		if err != nil {
+			log.WithError(err).Error("failed to record endpoint circuit breaker result")
+		} else {
+			fireCircuitBreakerHook(endpoint, cbResult)
+		}
+
 		attempt = parseAttemptFromResponse(ed, endpoint, resp, attemptStatus)
 
 		ed.Metadata.NumTrials++
@@ -248,7 +270,7 @@ func ProcessEventDelivery(endpointRepo datastore.EndpointRepository, eventDelive
 					log.Errorln("an anomaly has occurred. retry limit exceeded, fan out is done but event status is not successful")
 					ed.Status = datastore.FailureEventStatus
 				}
-			} else {
+			} else if ed.Status != datastore.DiscardedEventStatus {
 				log.Errorf("%s retry limit exceeded ", ed.UID)
 				ed.Description = "Retry limit exceeded"
 				ed.Status = datastore.FailureEventStatus
@@ -276,7 +298,11 @@ func ProcessEventDelivery(endpointRepo datastore.EndpointRepository, eventDelive
 			log.WithError(err).Error("failed to update message ", ed.UID)
 		}
 
-		if !done && ed.Metadata.NumTrials < ed.Metadata.RetryLimit {
+		if ed.Status == datastore.FailureEventStatus {
+			ProcessDeadLetter(ctx, deadLetterRepo, ed, &attempt)
+		}
+
+		if ed.Status == datastore.RetryEventStatus && ed.Metadata.NumTrials < ed.Metadata.RetryLimit {
 			return &EndpointError{Err: ErrDeliveryAttemptFailed, delay: delayDuration}
 		}
 
@@ -284,6 +310,43 @@ func ProcessEventDelivery(endpointRepo datastore.EndpointRepository, eventDelive
 	}
 }
 
+func fireCircuitBreakerHook(endpoint *datastore.Endpoint, res *circuitbreaker.Result) {
+	if !res.HasTransitioned() {
+		return
+	}
+
+	var eventType datastore.HookEventType
+	switch res.State {
+	case circuitbreaker.StateOpen:
+		eventType = datastore.EndpointCircuitBreakerOpened
+	case circuitbreaker.StateHalfOpen:
+		eventType = datastore.EndpointCircuitBreakerHalfOpen
+	case circuitbreaker.StateClosed:
+		eventType = datastore.EndpointCircuitBreakerClosed
+	default:
+		return
+	}
+
+	hook, err := hooks.Get()
+	if err != nil {
+		log.WithError(err).Error("failed to get hooks, skipping circuit breaker hook")
+		return
+	}
+
+	hook.Fire(eventType, &datastore.CircuitBreakerStateChange{
+		Endpoint: &datastore.CircuitBreakerEndpoint{
+			UID:       endpoint.UID,
+			ProjectID: endpoint.ProjectID,
+			Name:      endpoint.Title,
+			URL:       endpoint.TargetURL,
+		},
+		PreviousState: string(res.PreviousState),
+		State:         string(res.State),
+		FailureRate:   res.FailureRate,
+		ChangedAt:     time.Now(),
+	})
+}
+
 func newSignature(endpoint *datastore.Endpoint, g *datastore.Project, data json.RawMessage) *signature.Signature {
 	s := &signature.Signature{Advanced: endpoint.AdvancedSignatures, Payload: data}
 
@@ -305,6 +368,59 @@ func newSignature(endpoint *datastore.Endpoint, g *datastore.Project, data json.
 	return s
 }
 
+// signRequest returns the signature header and its value for the project's
+// signature scheme. Standard Webhooks also sends the message id and the
+// timestamp, so these are added to a copy of headers.
+func signRequest(sig *signature.Signature, p *datastore.Project, msgID string, headers httpheader.HTTPHeader) (string, string, httpheader.HTTPHeader, error) {
+	if !p.Config.GetSignatureConfig().IsStandardWebhooks() {
+		value, err := sig.ComputeHeaderValue()
+		return p.Config.Signature.Header.String(), value, headers, err
+	}
+
+	sh, err := sig.ComputeStandardHeaders(msgID)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	h := httpheader.HTTPHeader{
+		signature.StandardIDHeader:        []string{sh.ID},
+		signature.StandardTimestampHeader: []string{sh.Timestamp},
+	}
+	h.MergeHeaders(headers)
+
+	return signature.StandardSignatureHeader, sh.Signature, h, nil
+}
+
+// addJWSHeader adds a detached JWS of the payload made with the project's
+// newest signing key to a copy of headers. Projects without signing keys
+// only use the shared secret signatures.
+func addJWSHeader(ctx context.Context, signingKeyRepo datastore.SigningKeyRepository, p *datastore.Project, encryptionKey string, payload []byte, headers httpheader.HTTPHeader) (httpheader.HTTPHeader, error) {
+	keys, err := signingKeyRepo.LoadActiveSigningKeys(ctx, p.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return headers, nil
+	}
+
+	key := keys[0]
+	privateKey, err := util.Decrypt(key.PrivateKey, encryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt signing key %s: %v", key.UID, err)
+	}
+
+	jws, err := signature.SignDetachedJWS(string(key.Algorithm), key.UID, privateKey, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	h := httpheader.HTTPHeader{signature.JWSSignatureHeader: []string{jws}}
+	h.MergeHeaders(headers)
+
+	return h, nil
+}
+
 func parseAttemptFromResponse(m *datastore.EventDelivery, e *datastore.Endpoint, resp *net.Response, attemptStatus bool) datastore.DeliveryAttempt {
 	responseHeader := util.ConvertDefaultHeaderToCustomHeader(&resp.ResponseHeader)
 	requestHeader := util.ConvertDefaultHeaderToCustomHeader(&resp.RequestHeader)
@@ -336,9 +452,13 @@ type EventDeliveryConfig struct {
 }
 
 type RetryConfig struct {
-	Type       datastore.StrategyProvider
-	Duration   uint64
-	RetryCount uint64
+	Type            datastore.StrategyProvider
+	Duration        uint64
+	RetryCount      uint64
+	MaxDuration     uint64
+	Jitter          datastore.JitterType
+	Intervals       []uint64
+	HonorRetryAfter bool
 }
 
 type RateLimitConfig struct {
@@ -353,15 +473,50 @@ func (ec *EventDeliveryConfig) retryConfig() (*RetryConfig, error) {
 		rc.Duration = ec.subscription.RetryConfig.Duration
 		rc.RetryCount = ec.subscription.RetryConfig.RetryCount
 		rc.Type = ec.subscription.RetryConfig.Type
+		rc.MaxDuration = ec.subscription.RetryConfig.MaxDuration
+		rc.Jitter = ec.subscription.RetryConfig.Jitter
+		rc.Intervals = toUint64s(ec.subscription.RetryConfig.Intervals)
+		rc.HonorRetryAfter = ec.subscription.RetryConfig.HonorRetryAfter
 	} else {
 		rc.Duration = ec.project.Config.Strategy.Duration
 		rc.RetryCount = ec.project.Config.Strategy.RetryCount
 		rc.Type = ec.project.Config.Strategy.Type
+		rc.MaxDuration = ec.project.Config.Strategy.MaxDuration
+		rc.Jitter = ec.project.Config.Strategy.Jitter
+		rc.Intervals = toUint64s(ec.project.Config.Strategy.Intervals)
+		rc.HonorRetryAfter = ec.project.Config.Strategy.HonorRetryAfter
 	}
 
 	return rc, nil
 }
 
+func (rc *RetryConfig) metadata() *datastore.Metadata {
+	return &datastore.Metadata{
+		RetryLimit:         rc.RetryCount,
+		IntervalSeconds:    rc.Duration,
+		Strategy:           rc.Type,
+		MaxIntervalSeconds: rc.MaxDuration,
+		Jitter:             rc.Jitter,
+		Intervals:          rc.Intervals,
+		HonorRetryAfter:    rc.HonorRetryAfter,
+	}
+}
+
+func toUint64s(values []int64) []uint64 {
+	if len(values) == 0 {
+		return nil
+	}
+
+	u := make([]uint64, 0, len(values))
+	for _, v := range values {
+		if v > 0 {
+			u = append(u, uint64(v))
+		}
+	}
+
+	return u
+}
+
 func (ec *EventDeliveryConfig) rateLimitConfig() *RateLimitConfig {
 	rlc := &RateLimitConfig{}
 
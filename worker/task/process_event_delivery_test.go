@@ -2,14 +2,22 @@ package task
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/auth/realm_chain"
+	"github.com/frain-dev/convoy/circuitbreaker"
+	"github.com/frain-dev/convoy/database/hooks"
 	"github.com/frain-dev/convoy/datastore"
+	nooplimiter "github.com/frain-dev/convoy/limiter/noop"
+	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/frain-dev/convoy/pkg/signature"
 	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/util"
 	"github.com/hibiken/asynq"
 	"github.com/jarcoal/httpmock"
 
@@ -26,6 +34,7 @@ func TestProcessEventDelivery(t *testing.T) {
 		expectedError error
 		msg           *datastore.EventDelivery
 		dbFn          func(*mocks.MockEndpointRepository, *mocks.MockProjectRepository, *mocks.MockEventDeliveryRepository, *mocks.MockSubscriptionRepository, *mocks.MockQueuer)
+		dlFn          func(*mocks.MockDeadLetterRepository)
 		nFn           func() func()
 	}{
 		{
@@ -89,10 +98,6 @@ func TestProcessEventDelivery(t *testing.T) {
 					}, nil).Times(1)
 
 				m.EXPECT().UpdateStatusOfEventDelivery(gomock.Any(), gomock.Any(), gomock.Any(), datastore.DiscardedEventStatus).Times(1).Return(nil)
-
-				m.EXPECT().
-					UpdateStatusOfEventDelivery(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).Times(1)
 			},
 		},
 		{
@@ -244,6 +249,11 @@ func TestProcessEventDelivery(t *testing.T) {
 					UpdateEventDeliveryWithAttempt(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 			},
+			dlFn: func(d *mocks.MockDeadLetterRepository) {
+				d.EXPECT().
+					CreateDeadLetter(gomock.Any(), gomock.Any()).
+					Return(nil).Times(1)
+			},
 			nFn: func() func() {
 				httpmock.Activate()
 
@@ -326,6 +336,11 @@ func TestProcessEventDelivery(t *testing.T) {
 					UpdateEventDeliveryWithAttempt(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 			},
+			dlFn: func(d *mocks.MockDeadLetterRepository) {
+				d.EXPECT().
+					CreateDeadLetter(gomock.Any(), gomock.Any()).
+					Return(nil).Times(1)
+			},
 			nFn: func() func() {
 				httpmock.Activate()
 
@@ -408,6 +423,11 @@ func TestProcessEventDelivery(t *testing.T) {
 					UpdateEventDeliveryWithAttempt(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 			},
+			dlFn: func(d *mocks.MockDeadLetterRepository) {
+				d.EXPECT().
+					CreateDeadLetter(gomock.Any(), gomock.Any()).
+					Return(nil).Times(1)
+			},
 			nFn: func() func() {
 				httpmock.Activate()
 
@@ -573,6 +593,11 @@ func TestProcessEventDelivery(t *testing.T) {
 					UpdateEventDeliveryWithAttempt(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
 			},
+			dlFn: func(d *mocks.MockDeadLetterRepository) {
+				d.EXPECT().
+					CreateDeadLetter(gomock.Any(), gomock.Any()).
+					Return(nil).Times(1)
+			},
 			nFn: func() func() {
 				httpmock.Activate()
 
@@ -660,6 +685,11 @@ func TestProcessEventDelivery(t *testing.T) {
 					Write(convoy.NotificationProcessor, convoy.DefaultQueue, gomock.Any()).
 					Return(nil).Times(1)
 			},
+			dlFn: func(d *mocks.MockDeadLetterRepository) {
+				d.EXPECT().
+					CreateDeadLetter(gomock.Any(), gomock.Any()).
+					Return(nil).Times(1)
+			},
 			nFn: func() func() {
 				httpmock.Activate()
 
@@ -773,6 +803,9 @@ func TestProcessEventDelivery(t *testing.T) {
 			userRepo := mocks.NewMockUserRepository(ctrl)
 			cache := mocks.NewMockCache(ctrl)
 			subRepo := mocks.NewMockSubscriptionRepository(ctrl)
+			deadLetterRepo := mocks.NewMockDeadLetterRepository(ctrl)
+			signingKeyRepo := mocks.NewMockSigningKeyRepository(ctrl)
+			signingKeyRepo.EXPECT().LoadActiveSigningKeys(gomock.Any(), gomock.Any()).AnyTimes().Return([]datastore.SigningKey{}, nil)
 			q := mocks.NewMockQueuer(ctrl)
 
 			err := config.LoadConfig(tc.cfgPath)
@@ -799,7 +832,11 @@ func TestProcessEventDelivery(t *testing.T) {
 				tc.dbFn(endpointRepo, projectRepo, msgRepo, subRepo, q)
 			}
 
-			processFn := ProcessEventDelivery(endpointRepo, msgRepo, projectRepo, subRepo, q)
+			if tc.dlFn != nil {
+				tc.dlFn(deadLetterRepo)
+			}
+
+			processFn := ProcessEventDelivery(endpointRepo, msgRepo, projectRepo, subRepo, deadLetterRepo, signingKeyRepo, mocks.NewMockCache(ctrl), circuitbreaker.NewNoopCircuitBreaker(), nooplimiter.NewNoopLimiter(), q)
 
 			payload := EventDelivery{
 				EventDeliveryID: tc.msg.UID,
@@ -825,6 +862,79 @@ func TestProcessEventDelivery(t *testing.T) {
 	}
 }
 
+func TestProcessEventDelivery_NotDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	err := config.LoadConfig("./testdata/Config/basic-convoy.json")
+	assert.NoError(t, err)
+
+	endpointRepo := mocks.NewMockEndpointRepository(ctrl)
+	msgRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+	projectRepo := mocks.NewMockProjectRepository(ctrl)
+	subRepo := mocks.NewMockSubscriptionRepository(ctrl)
+
+	nextSendTime := time.Now().Add(time.Hour)
+	msgRepo.EXPECT().FindEventDeliveryByID(gomock.Any(), "project-1", "ed-1").Times(1).
+		Return(&datastore.EventDelivery{
+			UID:        "ed-1",
+			ProjectID:  "project-1",
+			EndpointID: "endpoint-1",
+			Status:     datastore.ScheduledEventStatus,
+			Metadata: &datastore.Metadata{
+				Strategy:     datastore.LinearStrategyProvider,
+				RetryLimit:   3,
+				NextSendTime: nextSendTime,
+			},
+		}, nil)
+	endpointRepo.EXPECT().FindEndpointByID(gomock.Any(), "endpoint-1", "project-1").Times(1).
+		Return(&datastore.Endpoint{UID: "endpoint-1", ProjectID: "project-1", Status: datastore.ActiveEndpointStatus}, nil)
+	subRepo.EXPECT().FindSubscriptionByID(gomock.Any(), "project-1", gomock.Any()).Times(1).Return(&datastore.Subscription{}, nil)
+	projectRepo.EXPECT().FetchProjectByID(gomock.Any(), "project-1").Times(1).Return(&datastore.Project{UID: "project-1"}, nil)
+
+	processFn := ProcessEventDelivery(endpointRepo, msgRepo, projectRepo, subRepo, mocks.NewMockDeadLetterRepository(ctrl), mocks.NewMockSigningKeyRepository(ctrl), mocks.NewMockCache(ctrl), circuitbreaker.NewNoopCircuitBreaker(), nooplimiter.NewNoopLimiter(), mocks.NewMockQueuer(ctrl))
+
+	data, err := json.Marshal(EventDelivery{EventDeliveryID: "ed-1", ProjectID: "project-1"})
+	assert.NoError(t, err)
+
+	err = processFn(context.Background(), asynq.NewTask(string(convoy.EventProcessor), data, asynq.Queue(string(convoy.EventQueue))))
+
+	// picking the delivery up early isn't a failed attempt
+	var scheduledErr *ScheduledDeliveryError
+	assert.ErrorAs(t, err, &scheduledErr)
+	assert.Equal(t, ErrDeliveryNotDue, scheduledErr.Err)
+	assert.InDelta(t, time.Until(nextSendTime), GetRetryDelay(0, err, nil), float64(time.Second))
+}
+
+func TestFireCircuitBreakerHook(t *testing.T) {
+	var payload []byte
+	hooks.Init().RegisterHook(datastore.EndpointCircuitBreakerOpened, func(data interface{}) {
+		payload, _ = json.Marshal(data)
+	})
+
+	endpoint := &datastore.Endpoint{
+		UID:       "endpoint-1",
+		ProjectID: "project-1",
+		Title:     "billing",
+		TargetURL: "https://example.com/webhook",
+		Secrets:   []datastore.Secret{{Value: "endpoint-secret"}},
+		Authentication: &datastore.EndpointAuthentication{
+			Type:   datastore.APIKeyAuthentication,
+			ApiKey: &datastore.ApiKey{HeaderName: "X-Api-Key", HeaderValue: "api-key-value"},
+		},
+	}
+
+	fireCircuitBreakerHook(endpoint, &circuitbreaker.Result{State: circuitbreaker.StateOpen, PreviousState: circuitbreaker.StateClosed})
+
+	var change datastore.CircuitBreakerStateChange
+	assert.NoError(t, json.Unmarshal(payload, &change))
+	assert.Equal(t, &datastore.CircuitBreakerEndpoint{UID: "endpoint-1", ProjectID: "project-1", Name: "billing", URL: "https://example.com/webhook"}, change.Endpoint)
+
+	// the meta event doesn't carry the endpoint's secrets or credentials
+	assert.NotContains(t, string(payload), "endpoint-secret")
+	assert.NotContains(t, string(payload), "api-key-value")
+}
+
 func TestProcessEventDeliveryConfig(t *testing.T) {
 	tt := []struct {
 		name                string
@@ -917,3 +1027,86 @@ func TestProcessEventDeliveryConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestSignRequest(t *testing.T) {
+	endpoint := &datastore.Endpoint{
+		Secrets: []datastore.Secret{{Value: "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw"}},
+	}
+
+	t.Run("should sign with the convoy scheme", func(t *testing.T) {
+		p := &datastore.Project{Config: &datastore.ProjectConfig{Signature: datastore.GetDefaultSignatureConfig()}}
+		headers := httpheader.HTTPHeader{"X-Custom": []string{"value"}}
+
+		sigHeader, value, h, err := signRequest(newSignature(endpoint, p, json.RawMessage(`{"a": 1}`)), p, "msg-1", headers)
+		assert.NoError(t, err)
+		assert.Equal(t, "X-Convoy-Signature", sigHeader)
+		assert.NotEmpty(t, value)
+		assert.Equal(t, headers, h)
+	})
+
+	t.Run("should sign with the standard webhooks scheme", func(t *testing.T) {
+		sc := datastore.GetDefaultSignatureConfig()
+		sc.Scheme = datastore.StandardWebhooksSignatureScheme
+		p := &datastore.Project{Config: &datastore.ProjectConfig{Signature: sc}}
+		headers := httpheader.HTTPHeader{"X-Custom": []string{"value"}}
+
+		sigHeader, value, h, err := signRequest(newSignature(endpoint, p, json.RawMessage(`{"a": 1}`)), p, "msg-1", headers)
+		assert.NoError(t, err)
+		assert.Equal(t, signature.StandardSignatureHeader, sigHeader)
+		assert.True(t, strings.HasPrefix(value, "v1,"))
+		assert.Equal(t, []string{"msg-1"}, h[signature.StandardIDHeader])
+		assert.NotEmpty(t, h[signature.StandardTimestampHeader])
+		assert.Equal(t, []string{"value"}, h["X-Custom"])
+
+		// the delivery's own headers are left untouched
+		assert.Len(t, headers, 1)
+	})
+}
+
+func TestAddJWSHeader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := &datastore.Project{UID: "project-1"}
+	payload := []byte(`{"a": 1}`)
+	headers := httpheader.HTTPHeader{"X-Custom": []string{"value"}}
+	signingKeyRepo := mocks.NewMockSigningKeyRepository(ctrl)
+
+	t.Run("should not sign without signing keys", func(t *testing.T) {
+		signingKeyRepo.EXPECT().LoadActiveSigningKeys(gomock.Any(), "project-1").Times(1).Return([]datastore.SigningKey{}, nil)
+
+		h, err := addJWSHeader(context.Background(), signingKeyRepo, p, "encryption-key", payload, headers)
+		assert.NoError(t, err)
+		assert.Equal(t, headers, h)
+	})
+
+	t.Run("should sign with the newest signing key", func(t *testing.T) {
+		var keys []datastore.SigningKey
+		for _, uid := range []string{"key-2", "key-1"} {
+			privateKey, publicKey, err := signature.GenerateKeyPair(signature.EdDSAAlgorithm)
+			assert.NoError(t, err)
+
+			encrypted, err := util.Encrypt(privateKey, "encryption-key")
+			assert.NoError(t, err)
+
+			keys = append(keys, datastore.SigningKey{
+				UID:        uid,
+				Algorithm:  datastore.Ed25519SigningKeyAlgorithm,
+				PublicKey:  base64.StdEncoding.EncodeToString(publicKey),
+				PrivateKey: encrypted,
+			})
+		}
+		signingKeyRepo.EXPECT().LoadActiveSigningKeys(gomock.Any(), "project-1").Times(1).Return(keys, nil)
+
+		h, err := addJWSHeader(context.Background(), signingKeyRepo, p, "encryption-key", payload, headers)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"value"}, h["X-Custom"])
+
+		newest, err := keys[0].JWK()
+		assert.NoError(t, err)
+
+		jws := h[signature.JWSSignatureHeader][0]
+		assert.NoError(t, signature.VerifyDetachedJWS(jws, payload, signature.JWKS{Keys: []signature.JWK{*newest}}))
+		assert.Len(t, headers, 1)
+	})
+}
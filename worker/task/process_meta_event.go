@@ -123,7 +123,13 @@ func sendUrlRequest(project *datastore.Project, metaEvent *datastore.MetaEvent)
 		return nil, err
 	}
 
-	dispatch, err := net.NewDispatcher(httpDuration, cfg.Server.HTTP.HttpProxy)
+	policy, err := egressPolicy(cfg)
+	if err != nil {
+		log.WithError(err).Error("failed to load egress policy")
+		return nil, err
+	}
+
+	dispatch, err := net.NewDispatcher(httpDuration, cfg.Server.HTTP.HttpProxy, policy)
 	if err != nil {
 		log.WithError(err).Error("error occurred while creating http client")
 		return nil, err
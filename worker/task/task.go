@@ -35,6 +35,49 @@ func (e *RateLimitError) Delay() time.Duration {
 func (e *RateLimitError) RateLimit() {
 }
 
+type CircuitBreakerError struct {
+	delay time.Duration
+	Err   error
+}
+
+func (e *CircuitBreakerError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CircuitBreakerError) Delay() time.Duration {
+	return e.delay
+}
+
+// OrderedDeliveryError is returned when a delivery has to wait for an
+// earlier delivery to the same endpoint partition.
+type OrderedDeliveryError struct {
+	delay time.Duration
+	Err   error
+}
+
+func (e *OrderedDeliveryError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *OrderedDeliveryError) Delay() time.Duration {
+	return e.delay
+}
+
+// ScheduledDeliveryError is returned when a scheduled delivery is picked
+// up before it's due.
+type ScheduledDeliveryError struct {
+	delay time.Duration
+	Err   error
+}
+
+func (e *ScheduledDeliveryError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ScheduledDeliveryError) Delay() time.Duration {
+	return e.delay
+}
+
 func GetRetryDelay(n int, err error, t *asynq.Task) time.Duration {
 	if endpointError, ok := err.(*EndpointError); ok {
 		return endpointError.Delay()
@@ -42,5 +85,14 @@ func GetRetryDelay(n int, err error, t *asynq.Task) time.Duration {
 	if rateLimitError, ok := err.(*RateLimitError); ok {
 		return rateLimitError.Delay()
 	}
+	if circuitBreakerError, ok := err.(*CircuitBreakerError); ok {
+		return circuitBreakerError.Delay()
+	}
+	if orderedDeliveryError, ok := err.(*OrderedDeliveryError); ok {
+		return orderedDeliveryError.Delay()
+	}
+	if scheduledDeliveryError, ok := err.(*ScheduledDeliveryError); ok {
+		return scheduledDeliveryError.Delay()
+	}
 	return defaultDelay
 }
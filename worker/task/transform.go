@@ -0,0 +1,38 @@
+package task
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/frain-dev/convoy/pkg/transform"
+)
+
+// transformDelivery runs the subscription's transform function, if it has
+// one, on the delivery's payload, headers and the path of targetURL.
+func transformDelivery(subscription *datastore.Subscription, ed *datastore.EventDelivery, targetURL string) (json.RawMessage, httpheader.HTTPHeader, string, error) {
+	payload := json.RawMessage(ed.Metadata.Raw)
+	if subscription == nil || !subscription.Function.Valid {
+		return payload, ed.Headers, targetURL, nil
+	}
+
+	u, err := url.Parse(targetURL)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	res, err := transform.Transform(subscription.Function.String, &transform.Request{
+		Body:    payload,
+		Headers: ed.Headers,
+		Path:    u.Path,
+	})
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	u.Path = res.Path
+	u.RawPath = ""
+
+	return res.Body, res.Headers, u.String(), nil
+}
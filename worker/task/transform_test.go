@@ -0,0 +1,47 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+)
+
+func TestTransformDelivery(t *testing.T) {
+	ed := &datastore.EventDelivery{
+		Headers:  httpheader.HTTPHeader{"X-Source": []string{"billing"}},
+		Metadata: &datastore.Metadata{Raw: `{"event":"invoice.paid","amount":2000}`},
+	}
+
+	t.Run("should_send_delivery_as_is_without_function", func(t *testing.T) {
+		payload, headers, targetURL, err := transformDelivery(&datastore.Subscription{}, ed, "https://example.com/hooks?a=b")
+		require.NoError(t, err)
+		require.JSONEq(t, ed.Metadata.Raw, string(payload))
+		require.Equal(t, ed.Headers, headers)
+		require.Equal(t, "https://example.com/hooks?a=b", targetURL)
+	})
+
+	t.Run("should_transform_delivery", func(t *testing.T) {
+		sub := &datastore.Subscription{Function: null.StringFrom(`function transform(req) {
+			req.body = { amount: req.body.amount / 100 };
+			req.headers["X-Tenant"] = "acme";
+			req.path = req.path + "/invoices";
+			return req;
+		}`)}
+
+		payload, headers, targetURL, err := transformDelivery(sub, ed, "https://example.com/hooks?a=b")
+		require.NoError(t, err)
+		require.JSONEq(t, `{"amount":20}`, string(payload))
+		require.Equal(t, httpheader.HTTPHeader{"X-Source": []string{"billing"}, "X-Tenant": []string{"acme"}}, headers)
+		require.Equal(t, "https://example.com/hooks/invoices?a=b", targetURL)
+	})
+
+	t.Run("should_error_for_failing_function", func(t *testing.T) {
+		sub := &datastore.Subscription{Function: null.StringFrom(`function transform(req) { throw new Error("boom"); }`)}
+
+		_, _, _, err := transformDelivery(sub, ed, "https://example.com/hooks")
+		require.EqualError(t, err, "transform function failed: Error: boom")
+	})
+}